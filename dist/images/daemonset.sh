@@ -72,6 +72,7 @@ OVN_DISABLE_OVN_IFACE_ID_VER="false"
 OVN_MULTI_NETWORK_ENABLE=
 OVN_NETWORK_SEGMENTATION_ENABLE=
 OVN_ROUTE_ADVERTISEMENTS_ENABLE=
+OVN_PRECONFIGURED_UDN_ADDRESSES_ENABLE=
 OVN_ADVERTISE_DEFAULT_NETWORK=
 OVN_V4_JOIN_SUBNET=""
 OVN_V6_JOIN_SUBNET=""
@@ -104,6 +105,7 @@ IN_UPGRADE=
 # northd-backoff-interval, in ms
 OVN_NORTHD_BACKOFF_INTERVAL=
 OVN_OBSERV_ENABLE="false"
+OVN_POD_NETWORK_ADMISSION_ENABLE="false"
 
 # Parse parameters given as arguments to this script.
 while [ "$1" != "" ]; do
@@ -275,6 +277,9 @@ while [ "$1" != "" ]; do
   --route-advertisements-enable)
     OVN_ROUTE_ADVERTISEMENTS_ENABLE=$VALUE
     ;;
+  --preconfigured-udn-addresses-enable)
+    OVN_PRECONFIGURED_UDN_ADDRESSES_ENABLE=$VALUE
+    ;;
   --advertise-default-network)
     OVN_ADVERTISE_DEFAULT_NETWORK=$VALUE
     ;;
@@ -368,6 +373,9 @@ while [ "$1" != "" ]; do
   --enable-observ)
     OVN_OBSERV_ENABLE=$VALUE
     ;;
+  --enable-pod-network-admission)
+    OVN_POD_NETWORK_ADMISSION_ENABLE=$VALUE
+    ;;
   --no-hostsubnet-label)
     OVN_NOHOSTSUBNET_LABEL=$VALUE
     ;;
@@ -466,6 +474,8 @@ ovn_network_segmentation_enable=${OVN_NETWORK_SEGMENTATION_ENABLE}
 echo "ovn_network_segmentation_enable: ${ovn_network_segmentation_enable}"
 ovn_route_advertisements_enable=${OVN_ROUTE_ADVERTISEMENTS_ENABLE}
 echo "ovn_route_advertisements_enable: ${ovn_route_advertisements_enable}"
+ovn_preconfigured_udn_addresses_enable=${OVN_PRECONFIGURED_UDN_ADDRESSES_ENABLE}
+echo "ovn_preconfigured_udn_addresses_enable: ${ovn_preconfigured_udn_addresses_enable}"
 ovn_advertise_default_network=${OVN_ADVERTISE_DEFAULT_NETWORK}
 echo "ovn_advertise_default_network: ${ovn_advertise_default_network}"
 ovn_hybrid_overlay_net_cidr=${OVN_HYBRID_OVERLAY_NET_CIDR}
@@ -571,6 +581,9 @@ echo "ovn_enable_dnsnameresolver: ${ovn_enable_dnsnameresolver}"
 ovn_observ_enable=${OVN_OBSERV_ENABLE}
 echo "ovn_observ_enable: ${ovn_observ_enable}"
 
+ovn_pod_network_admission_enable=${OVN_POD_NETWORK_ADMISSION_ENABLE}
+echo "ovn_pod_network_admission_enable: ${ovn_pod_network_admission_enable}"
+
 ovn_nohostsubnet_label=${OVN_NOHOSTSUBNET_LABEL}
 echo "ovn_nohostsubnet_label: ${ovn_nohostsubnet_label}"
 
@@ -756,6 +769,7 @@ ovn_image=${ovnkube_image} \
   ovn_multi_network_enable=${ovn_multi_network_enable} \
   ovn_network_segmentation_enable=${ovn_network_segmentation_enable} \
   ovn_route_advertisements_enable=${ovn_route_advertisements_enable} \
+  ovn_preconfigured_udn_addresses_enable=${ovn_preconfigured_udn_addresses_enable} \
   ovn_egress_service_enable=${ovn_egress_service_enable} \
   ovn_ssl_en=${ovn_ssl_en} \
   ovn_master_count=${ovn_master_count} \
@@ -804,6 +818,7 @@ ovn_image=${ovnkube_image} \
   ovn_multi_network_enable=${ovn_multi_network_enable} \
   ovn_network_segmentation_enable=${ovn_network_segmentation_enable} \
   ovn_route_advertisements_enable=${ovn_route_advertisements_enable} \
+  ovn_preconfigured_udn_addresses_enable=${ovn_preconfigured_udn_addresses_enable} \
   ovn_egress_service_enable=${ovn_egress_service_enable} \
   ovn_ssl_en=${ovn_ssl_en} \
   ovn_master_count=${ovn_master_count} \
@@ -883,6 +898,7 @@ ovn_image=${ovnkube_image} \
   ovn_multi_network_enable=${ovn_multi_network_enable} \
   ovn_network_segmentation_enable=${ovn_network_segmentation_enable} \
   ovn_route_advertisements_enable=${ovn_route_advertisements_enable} \
+  ovn_preconfigured_udn_addresses_enable=${ovn_preconfigured_udn_addresses_enable} \
   ovn_egress_service_enable=${ovn_egress_service_enable} \
   ovn_ssl_en=${ovn_ssl_en} \
   ovn_remote_probe_interval=${ovn_remote_probe_interval} \
@@ -949,6 +965,7 @@ ovn_image=${ovnkube_image} \
   ovn_multi_network_enable=${ovn_multi_network_enable} \
   ovn_network_segmentation_enable=${ovn_network_segmentation_enable} \
   ovn_route_advertisements_enable=${ovn_route_advertisements_enable} \
+  ovn_preconfigured_udn_addresses_enable=${ovn_preconfigured_udn_addresses_enable} \
   ovn_ssl_en=${ovn_ssl_en} \
   ovn_remote_probe_interval=${ovn_remote_probe_interval} \
   ovn_monitor_all=${ovn_monitor_all} \
@@ -1009,6 +1026,7 @@ ovn_image=${ovnkube_image} \
   webhook_cert=$(cat "${path_prefix}.crt" | base64 -w0) \
   ovn_enable_multi_node_zone=${ovn_enable_multi_node_zone} \
   ovn_hybrid_overlay_enable=${ovn_hybrid_overlay_enable} \
+  ovn_pod_network_admission_enable=${ovn_pod_network_admission_enable} \
   jinjanate ../templates/ovnkube-identity.yaml.j2 -o ${output_dir}/ovnkube-identity.yaml
 
 if ${enable_ipsec}; then
@@ -1050,6 +1068,7 @@ ovn_route_advertisements_enable=${ovn_route_advertisements_enable} \
 ovn_network_segmentation_enable=${ovn_network_segmentation_enable} \
 ovn_enable_dnsnameresolver=${ovn_enable_dnsnameresolver} \
 ovn_route_advertisements_enable=${ovn_route_advertisements_enable} \
+ovn_preconfigured_udn_addresses_enable=${ovn_preconfigured_udn_addresses_enable} \
   jinjanate ../templates/rbac-ovnkube-master.yaml.j2 -o ${output_dir}/rbac-ovnkube-master.yaml
 
 cp ../templates/rbac-ovnkube-identity.yaml.j2 ${output_dir}/rbac-ovnkube-identity.yaml
@@ -1063,5 +1082,6 @@ cp ../templates/k8s.ovn.org_adminpolicybasedexternalroutes.yaml.j2 ${output_dir}
 cp ../templates/k8s.ovn.org_userdefinednetworks.yaml.j2 ${output_dir}/k8s.ovn.org_userdefinednetworks.yaml
 cp ../templates/k8s.ovn.org_clusteruserdefinednetworks.yaml.j2 ${output_dir}/k8s.ovn.org_clusteruserdefinednetworks.yaml
 cp ../templates/k8s.ovn.org_routeadvertisements.yaml.j2 ${output_dir}/k8s.ovn.org_routeadvertisements.yaml
+cp ../templates/k8s.ovn.org_peerclusters.yaml.j2 ${output_dir}/k8s.ovn.org_peerclusters.yaml
 
 exit 0
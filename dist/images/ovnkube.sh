@@ -97,6 +97,7 @@ fi
 # OVN_ENABLE_SVC_TEMPLATE_SUPPORT - enable svc template support
 # OVN_ENABLE_DNSNAMERESOLVER - enable dns name resolver support
 # OVN_OBSERV_ENABLE - enable observability for ovnkube
+# OVN_POD_NETWORK_ADMISSION_ENABLE - validate the network annotations of pods in ovnkube-identity
 
 # The argument to the command is the operation to be performed
 # ovn-master ovn-controller ovn-node display display_env ovn_debug
@@ -271,6 +272,8 @@ ovn_multi_network_enable=${OVN_MULTI_NETWORK_ENABLE:-false}
 ovn_network_segmentation_enable=${OVN_NETWORK_SEGMENTATION_ENABLE:=false}
 #OVN_NROUTE_ADVERTISEMENTS_ENABLE - enable route advertisements for ovn-kubernetes
 ovn_route_advertisements_enable=${OVN_ROUTE_ADVERTISEMENTS_ENABLE:=false}
+#OVN_PRECONFIGURED_UDN_ADDRESSES_ENABLE - honor the IPs and MAC requested for primary user defined network pods
+ovn_preconfigured_udn_addresses_enable=${OVN_PRECONFIGURED_UDN_ADDRESSES_ENABLE:=false}
 ovn_acl_logging_rate_limit=${OVN_ACL_LOGGING_RATE_LIMIT:-"20"}
 ovn_netflow_targets=${OVN_NETFLOW_TARGETS:-}
 ovn_sflow_targets=${OVN_SFLOW_TARGETS:-}
@@ -315,6 +318,9 @@ ovn_enable_svc_template_support=${OVN_ENABLE_SVC_TEMPLATE_SUPPORT:-true}
 ovn_enable_dnsnameresolver=${OVN_ENABLE_DNSNAMERESOLVER:-false}
 # OVN_OBSERV_ENABLE - enable observability for ovnkube
 ovn_observ_enable=${OVN_OBSERV_ENABLE:-false}
+# OVN_POD_NETWORK_ADMISSION_ENABLE - validate the network annotations of pods against the
+# allowances of the ovnkube-pod-network-allowances ConfigMap
+ovn_pod_network_admission_enable=${OVN_POD_NETWORK_ADMISSION_ENABLE:-false}
 # OVN_NOHOSTSUBNET_LABEL - node label indicating nodes managing their own network
 ovn_nohostsubnet_label=${OVN_NOHOSTSUBNET_LABEL:-""}
 # OVN_DISABLE_REQUESTEDCHASSIS - disable requested-chassis option during pod creation
@@ -1078,6 +1084,11 @@ ovnkube-identity() {
       ovnkube_enable_hybrid_overlay_flag="--enable-hybrid-overlay"
     fi
 
+    ovnkube_pod_network_allowances_flag=
+    if [[ ${ovn_pod_network_admission_enable} == "true" ]]; then
+      ovnkube_pod_network_allowances_flag="--pod-network-allowances=/etc/ovnkube-identity/pod-network-allowances/allowances.json"
+    fi
+
     # extra-allowed-user:
     #   ovnkube-master service account - required for compact mode
     #   ovnkube-cluster-manager service account - required for multi-homing
@@ -1085,6 +1096,7 @@ ovnkube-identity() {
     --webhook-cert-dir="/etc/webhook-cert" \
     ${ovnkube_enable_interconnect_flag} \
     ${ovnkube_enable_hybrid_overlay_flag} \
+    ${ovnkube_pod_network_allowances_flag} \
     --extra-allowed-user="system:serviceaccount:ovn-kubernetes:ovnkube-cluster-manager" \
     --extra-allowed-user="system:serviceaccount:ovn-kubernetes:ovnkube-master" \
     --loglevel="${ovnkube_loglevel}"
@@ -1229,6 +1241,12 @@ ovn-master() {
   fi
   echo "route_advertisements_enabled_flag=${route_advertisements_enabled_flag}"
 
+  preconfigured_udn_addresses_enabled_flag=
+  if [[ ${ovn_preconfigured_udn_addresses_enable} == "true" ]]; then
+	  preconfigured_udn_addresses_enabled_flag="--enable-preconfigured-udn-addresses"
+  fi
+  echo "preconfigured_udn_addresses_enabled_flag=${preconfigured_udn_addresses_enabled_flag}"
+
   egressservice_enabled_flag=
   if [[ ${ovn_egressservice_enable} == "true" ]]; then
 	  egressservice_enabled_flag="--enable-egress-service"
@@ -1330,6 +1348,7 @@ ovn-master() {
     ${multi_network_enabled_flag} \
     ${network_segmentation_enabled_flag} \
     ${route_advertisements_enabled_flag} \
+    ${preconfigured_udn_addresses_enabled_flag} \
     ${ovn_acl_logging_rate_limit_flag} \
     ${ovn_enable_svc_template_support_flag} \
     ${ovn_observ_enable_flag} \
@@ -1524,6 +1543,12 @@ ovnkube-controller() {
   fi
   echo "route_advertisements_enabled_flag=${route_advertisements_enabled_flag}"
 
+  preconfigured_udn_addresses_enabled_flag=
+  if [[ ${ovn_preconfigured_udn_addresses_enable} == "true" ]]; then
+	  preconfigured_udn_addresses_enabled_flag="--enable-preconfigured-udn-addresses"
+  fi
+  echo "preconfigured_udn_addresses_enabled_flag=${preconfigured_udn_addresses_enabled_flag}"
+
   egressservice_enabled_flag=
   if [[ ${ovn_egressservice_enable} == "true" ]]; then
 	  egressservice_enabled_flag="--enable-egress-service"
@@ -1626,6 +1651,7 @@ ovnkube-controller() {
     ${multi_network_enabled_flag} \
     ${network_segmentation_enabled_flag} \
     ${route_advertisements_enabled_flag} \
+    ${preconfigured_udn_addresses_enabled_flag} \
     ${ovn_acl_logging_rate_limit_flag} \
     ${ovn_dbs} \
     ${ovn_enable_svc_template_support_flag} \
@@ -1814,6 +1840,12 @@ ovnkube-controller-with-node() {
   fi
   echo "route_advertisements_enabled_flag=${route_advertisements_enabled_flag}"
 
+  preconfigured_udn_addresses_enabled_flag=
+  if [[ ${ovn_preconfigured_udn_addresses_enable} == "true" ]]; then
+	  preconfigured_udn_addresses_enabled_flag="--enable-preconfigured-udn-addresses"
+  fi
+  echo "preconfigured_udn_addresses_enabled_flag=${preconfigured_udn_addresses_enabled_flag}"
+
   egressservice_enabled_flag=
   if [[ ${ovn_egressservice_enable} == "true" ]]; then
 	  egressservice_enabled_flag="--enable-egress-service"
@@ -2057,6 +2089,7 @@ ovnkube-controller-with-node() {
     ${multi_network_enabled_flag} \
     ${network_segmentation_enabled_flag} \
     ${route_advertisements_enabled_flag} \
+    ${preconfigured_udn_addresses_enabled_flag} \
     ${netflow_targets} \
     ${ofctrl_wait_before_clear} \
     ${ovn_acl_logging_rate_limit_flag} \
@@ -2228,6 +2261,12 @@ ovn-cluster-manager() {
   fi
   echo "route_advertisements_enabled_flag=${route_advertisements_enabled_flag}"
 
+  preconfigured_udn_addresses_enabled_flag=
+  if [[ ${ovn_preconfigured_udn_addresses_enable} == "true" ]]; then
+	  preconfigured_udn_addresses_enabled_flag="--enable-preconfigured-udn-addresses"
+  fi
+  echo "preconfigured_udn_addresses_enabled_flag=${preconfigured_udn_addresses_enabled_flag}"
+
   persistent_ips_enabled_flag=
   if [[ ${ovn_enable_persistent_ips} == "true" ]]; then
 	  persistent_ips_enabled_flag="--enable-persistent-ips"
@@ -2284,6 +2323,7 @@ ovn-cluster-manager() {
     ${multi_network_enabled_flag} \
     ${network_segmentation_enabled_flag} \
     ${route_advertisements_enabled_flag} \
+    ${preconfigured_udn_addresses_enabled_flag} \
     ${persistent_ips_enabled_flag} \
     ${ovnkube_enable_interconnect_flag} \
     ${ovnkube_enable_multi_external_gateway_flag} \
@@ -20,12 +20,7 @@ func main() {
 
 	p := cni.NewCNIPlugin("")
 	c.Action = func(_ *cli.Context) error {
-		skel.PluginMain(
-			p.CmdAdd,
-			p.CmdCheck,
-			p.CmdDel,
-			version.All,
-			bv.BuildString("ovn-k8s-cni-overlay"))
+		skel.PluginMainFuncs(cniFuncs(p), version.All, bv.BuildString("ovn-k8s-cni-overlay"))
 		return nil
 	}
 
@@ -36,5 +31,17 @@ func main() {
 			e = &types.Error{Code: 100, Msg: err.Error()}
 		}
 		e.Print()
+		os.Exit(1)
+	}
+}
+
+// cniFuncs returns the plugin callbacks of the CNI commands
+func cniFuncs(p *cni.Plugin) skel.CNIFuncs {
+	return skel.CNIFuncs{
+		Add:    p.CmdAdd,
+		Del:    p.CmdDel,
+		Check:  p.CmdCheck,
+		GC:     p.CmdGC,
+		Status: p.CmdStatus,
 	}
 }
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	"github.com/containernetworking/cni/pkg/version"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
+)
+
+const (
+	gcConfig = `{"cniVersion": "1.1.0", "name": "ovn-kubernetes", "type": "ovn-k8s-cni-overlay",` +
+		`"cni.dev/valid-attachments": [{"containerID": "sandbox1", "ifname": "eth0"}]}`
+	statusConfig = `{"cniVersion": "1.1.0", "name": "ovn-kubernetes", "type": "ovn-k8s-cni-overlay"}`
+	oldConfig    = `{"cniVersion": "1.0.0", "name": "ovn-kubernetes", "type": "ovn-k8s-cni-overlay"}`
+)
+
+// startFakeCNIServer serves CNI requests on a unix socket, recording them and
+// failing them with the given status
+func startFakeCNIServer(t *testing.T, socketPath string, status *int) *[]cni.Request {
+	t.Helper()
+	var requests []cni.Request
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", socketPath, err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(_ http.ResponseWriter, _ *http.Request) {})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var req cni.Request
+		b, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &req); err != nil {
+			t.Errorf("failed to unmarshal CNI request: %v", err)
+		}
+		requests = append(requests, req)
+		w.WriteHeader(*status)
+	})
+	server := &http.Server{Handler: mux}
+	go func() {
+		_ = server.Serve(l)
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+	return &requests
+}
+
+// runPlugin runs the plugin through skel as the runtime would
+func runPlugin(t *testing.T, p *cni.Plugin, command, conf string) *types.Error {
+	t.Helper()
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatalf("failed to create stdin file: %v", err)
+	}
+	if _, err := stdin.WriteString(conf); err != nil {
+		t.Fatalf("failed to write stdin file: %v", err)
+	}
+	if _, err := stdin.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("failed to rewind stdin file: %v", err)
+	}
+	oldStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() {
+		os.Stdin = oldStdin
+		stdin.Close()
+	}()
+	t.Setenv("CNI_COMMAND", command)
+	t.Setenv("CNI_PATH", "/opt/cni/bin")
+	return skel.PluginMainFuncsWithError(cniFuncs(p), version.All, "ovn-k8s-cni-overlay")
+}
+
+func TestCNI11Commands(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "ovn-cni-server.sock")
+	status := http.StatusOK
+	requests := startFakeCNIServer(t, socketPath, &status)
+	p := cni.NewCNIPlugin(socketPath)
+
+	if err := runPlugin(t, p, "GC", gcConfig); err != nil {
+		t.Fatalf("GC failed: %v", err)
+	}
+	if len(*requests) != 1 || (*requests)[0].Env["CNI_COMMAND"] != "GC" || string((*requests)[0].Config) != gcConfig {
+		t.Fatalf("unexpected GC requests %+v", *requests)
+	}
+
+	if err := runPlugin(t, p, "STATUS", statusConfig); err != nil {
+		t.Fatalf("STATUS failed: %v", err)
+	}
+	if len(*requests) != 2 || (*requests)[1].Env["CNI_COMMAND"] != "STATUS" {
+		t.Fatalf("unexpected STATUS requests %+v", *requests)
+	}
+
+	status = http.StatusBadRequest
+	if err := runPlugin(t, p, "STATUS", statusConfig); err == nil || err.Code != 50 {
+		t.Fatalf("expected STATUS to fail with the plugin not available code, got %v", err)
+	}
+
+	// GC and STATUS are not part of the CNI versions before 1.1.0
+	for _, command := range []string{"GC", "STATUS"} {
+		if err := runPlugin(t, p, command, oldConfig); err == nil || err.Code != types.ErrIncompatibleCNIVersion {
+			t.Fatalf("expected %s to fail with an incompatible version, got %v", command, err)
+		}
+	}
+	if len(*requests) != 3 {
+		t.Fatalf("unexpected requests %+v", *requests)
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "ovn-cni-server.sock")
+	status := http.StatusOK
+	requests := startFakeCNIServer(t, socketPath, &status)
+	p := cni.NewCNIPlugin(socketPath)
+	t.Setenv("CNI_CONTAINERID", "sandbox1")
+	t.Setenv("CNI_NETNS", "/var/run/netns/sandbox1")
+	t.Setenv("CNI_IFNAME", "eth0")
+
+	testcases := []struct {
+		desc      string
+		conf      string
+		forwarded bool
+	}{
+		{
+			desc: "check disabled",
+			conf: statusConfig,
+		},
+		{
+			desc:      "check enabled",
+			conf:      `{"cniVersion": "1.1.0", "name": "ovn-kubernetes", "type": "ovn-k8s-cni-overlay", "enableCheck": true}`,
+			forwarded: true,
+		},
+		{
+			desc:      "NAD configuration",
+			conf:      `{"cniVersion": "1.1.0", "name": "tenant", "type": "ovn-k8s-cni-overlay", "netAttachDefName": "ns1/tenant"}`,
+			forwarded: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			*requests = nil
+			if err := runPlugin(t, p, "CHECK", tc.conf); err != nil {
+				t.Fatalf("CHECK failed: %v", err)
+			}
+			if forwarded := len(*requests) > 0; forwarded != tc.forwarded {
+				t.Fatalf("expected CHECK forwarded %t, got requests %+v", tc.forwarded, *requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/policysim"
+)
+
+// PolicySimulationCommand evaluates the ACLs of a NB database file for a flow
+var PolicySimulationCommand = cli.Command{
+	Name: "policy-simulation",
+	Usage: "Evaluate whether a flow is allowed by the network policies, admin network policies, " +
+		"egress firewalls and UDN isolation ACLs of a NB database file",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "nb-db",
+			Usage:    "path of the NB database file, e.g. a copy of /etc/ovn/ovnnb_db.db",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "src-pod",
+			Usage: "source pod, as namespace/name",
+		},
+		&cli.StringFlag{
+			Name:  "src-ip",
+			Usage: "source IP, the IP of the source pod by default",
+		},
+		&cli.StringFlag{
+			Name:  "dst-pod",
+			Usage: "destination pod, as namespace/name",
+		},
+		&cli.StringFlag{
+			Name:  "dst-ip",
+			Usage: "destination IP, the IP of the destination pod by default",
+		},
+		&cli.StringFlag{
+			Name:  "protocol",
+			Usage: "protocol of the flow: tcp, udp, sctp or icmp",
+			Value: policysim.ProtocolTCP,
+		},
+		&cli.IntFlag{
+			Name:  "src-port",
+			Usage: "source port of the flow",
+		},
+		&cli.IntFlag{
+			Name:  "dst-port",
+			Usage: "destination port of the flow",
+		},
+		&cli.StringFlag{
+			Name:  "network",
+			Usage: "network of the pods, their primary network by default",
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "print the result as JSON",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		for _, flag := range []string{"src-port", "dst-port"} {
+			if port := ctx.Int(flag); port < 0 || port > math.MaxUint16 {
+				return fmt.Errorf("invalid %s %d, expected a port between 0 and %d", flag, port, math.MaxUint16)
+			}
+		}
+		snapshot, err := policysim.LoadSnapshotFile(ctx.String("nb-db"))
+		if err != nil {
+			return err
+		}
+		result, err := snapshot.Simulate(&policysim.Flow{
+			SrcPod:   ctx.String("src-pod"),
+			SrcIP:    ctx.String("src-ip"),
+			DstPod:   ctx.String("dst-pod"),
+			DstIP:    ctx.String("dst-ip"),
+			Protocol: ctx.String("protocol"),
+			SrcPort:  ctx.Int("src-port"),
+			DstPort:  ctx.Int("dst-port"),
+			Network:  ctx.String("network"),
+		})
+		if err != nil {
+			return err
+		}
+		for _, warning := range result.Warnings {
+			klog.Warning(warning)
+		}
+		if ctx.Bool("json") {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(result)
+		}
+		printPolicySimulationResult(os.Stdout, result)
+		return nil
+	},
+}
+
+func printPolicySimulationResult(w io.Writer, result *policysim.Result) {
+	verdict := "dropped"
+	if result.Allowed {
+		verdict = "allowed"
+	}
+	fmt.Fprintf(w, "Flow from %s to %s is %s\n", result.SrcIP, result.DstIP, verdict)
+	if result.ACL != nil {
+		fmt.Fprintf(w, "Deciding ACL: %s\n", describeACL(result.ACL))
+	} else {
+		fmt.Fprintln(w, "Deciding ACL: none, allowed by default")
+	}
+	for _, stage := range result.Stages {
+		fmt.Fprintf(w, "Stage %s of port %s on switch %s:\n", stage.Stage, stage.Port, stage.Switch)
+		for _, acl := range stage.Passed {
+			fmt.Fprintf(w, "  passed by %s\n", describeACL(acl))
+		}
+		switch {
+		case stage.ACL != nil:
+			fmt.Fprintf(w, "  %s\n", describeACL(stage.ACL))
+		default:
+			fmt.Fprintln(w, "  no matching ACL")
+		}
+	}
+}
+
+func describeACL(acl *policysim.ACLResult) string {
+	owner := acl.Owner
+	if owner == "" {
+		owner = "action " + acl.Action
+	}
+	return fmt.Sprintf("%s (ACL %s, tier %d, priority %d, match %q)", owner, acl.UUID, acl.Tier, acl.Priority, acl.Match)
+}
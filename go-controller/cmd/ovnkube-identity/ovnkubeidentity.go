@@ -56,6 +56,7 @@ type config struct {
 	leaseNamespace             string
 	enableInterconnect         bool
 	enableHybridOverlay        bool
+	enablePodInterfaceHotplug  bool
 	disableWebhook             bool
 	disableApprover            bool
 	waitForKAPIDuration        time.Duration
@@ -65,6 +66,8 @@ type config struct {
 	csrAcceptanceConditions    []csrapprover.CSRAcceptanceCondition
 	podAdmissionConditionFile  string
 	podAdmissionConditions     []ovnwebhook.PodAdmissionConditionOption
+	podNetworkAllowanceFile    string
+	podNetworkAllowances       []ovnwebhook.PodNetworkAllowance
 }
 
 var cliCfg config
@@ -149,6 +152,11 @@ func main() {
 			return err
 		}
 
+		cliCfg.podNetworkAllowances, err = ovnwebhook.InitPodNetworkAllowances(cliCfg.podNetworkAllowanceFile)
+		if err != nil {
+			return err
+		}
+
 		runWg := &sync.WaitGroup{}
 
 		ctx, cancel := context.WithCancel(c.Context)
@@ -249,6 +257,12 @@ func main() {
 			Destination: &cliCfg.enableHybridOverlay,
 			Value:       false,
 		},
+		&cli.BoolFlag{
+			Name:        "enable-pod-interface-hotplug",
+			Usage:       "Configure to allow ovnkube-node to update the network status of pods when hot-plugging their interfaces",
+			Destination: &cliCfg.enablePodInterfaceHotplug,
+			Value:       false,
+		},
 		&cli.StringSliceFlag{
 			Name:        "extra-allowed-user",
 			Usage:       "Configure extra user that is allowed to modify annotations protected by the webhook, can be used multiple times",
@@ -276,6 +290,12 @@ func main() {
 			Usage:       "Configure additional pod validate admission conditions",
 			Destination: &cliCfg.podAdmissionConditionFile,
 		},
+		&cli.StringFlag{
+			Name: "pod-network-allowances",
+			Usage: "Configure the namespace allowances the network annotations of pods are validated against. " +
+				"When set, the /pod-network webhook endpoint is served",
+			Destination: &cliCfg.podNetworkAllowanceFile,
+		},
 	}
 	ctx := context.Background()
 
@@ -356,7 +376,7 @@ func runWebhook(ctx context.Context, restCfg *rest.Config) error {
 		podWebhook := admission.WithCustomValidator(
 			scheme.Scheme,
 			&corev1.Pod{},
-			ovnwebhook.NewPodAdmissionWebhook(nodeLister, cliCfg.podAdmissionConditions, cliCfg.extraAllowedUsers.Value()...),
+			ovnwebhook.NewPodAdmissionWebhook(nodeLister, cliCfg.podAdmissionConditions, cliCfg.enablePodInterfaceHotplug, cliCfg.extraAllowedUsers.Value()...),
 		).WithRecoverPanic(true)
 		podHandler, err := admission.StandaloneWebhook(
 			podWebhook,
@@ -371,6 +391,25 @@ func runWebhook(ctx context.Context, restCfg *rest.Config) error {
 		webhookMux.Handle("/pod", podHandler)
 	}
 
+	if cliCfg.podNetworkAllowanceFile != "" {
+		podNetworkWebhook := admission.WithCustomValidator(
+			scheme.Scheme,
+			&corev1.Pod{},
+			ovnwebhook.NewPodNetworkAdmissionWebhook(cliCfg.podNetworkAllowances),
+		).WithRecoverPanic(true)
+		podNetworkHandler, err := admission.StandaloneWebhook(
+			podNetworkWebhook,
+			admission.StandaloneOptions{
+				Logger:      logger.WithName("pod.network-allowance"),
+				MetricsPath: "pod.network-allowance",
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to setup the pod network admission webhook: %w", err)
+		}
+		webhookMux.Handle("/pod-network", podNetworkHandler)
+	}
+
 	cfg := &tls.Config{
 		NextProtos: []string{"h2"},
 		MinVersion: tls.VersionTLS10,
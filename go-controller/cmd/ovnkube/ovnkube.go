@@ -31,7 +31,9 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	ovnnode "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/aclaudit"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/policysim"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
@@ -499,6 +501,8 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 				return
 			}
 
+			metrics.RegisterDebugHandler(policysim.DebugPath, policysim.NewHandler(libovsdbOvnNBClient))
+
 			libovsdbOvnSBClient, err := libovsdb.NewSBClient(ctx.Done())
 			if err != nil {
 				controllerErr = fmt.Errorf("failed to initialize libovsdb SB client: %w", err)
@@ -574,6 +578,20 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 			// record delay until ready
 			metrics.MetricNodeReadyDuration.Set(time.Since(startTime).Seconds())
 
+			if config.Logging.ACLAuditExportDir != "" || config.Logging.ACLAuditExportSyslogAddress != "" {
+				exporter, err := aclaudit.NewExporter(runMode.identity, watchFactory)
+				if err != nil {
+					nodeControllerManager.Stop()
+					nodeErr = fmt.Errorf("failed to create ACL audit exporter: %w", err)
+					return
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					exporter.Run(ctx.Done())
+				}()
+			}
+
 			<-ctx.Done()
 			nodeControllerManager.Stop()
 		}()
@@ -596,6 +614,9 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 		}
 		metrics.RegisterOvnMetrics(ovnClientset.KubeClient, runMode.identity,
 			ovsClient, metricsScrapeInterval, ctx.Done())
+		if runMode.node && config.Metrics.EnablePolicyRuleHitMetrics && config.OVNKubernetesFeature.EnableObservability {
+			metrics.RegisterPolicyRuleHitMetrics(metricsScrapeInterval, ctx.Done())
+		}
 		metrics.StartOVNMetricsServer(config.Metrics.OVNMetricsBindAddress,
 			config.Metrics.NodeServerCert, config.Metrics.NodeServerPrivKey, ctx.Done(), wg)
 	}
@@ -17,6 +17,7 @@ const (
 	multicastClusterOwnerType           = "MulticastCluster"
 	netpolNodeOwnerType                 = "NetpolNode"
 	netpolNamespaceOwnerType            = "NetpolNamespace"
+	netpolSharedRuleOwnerType           = "NetpolSharedRule"
 	udnIsolationOwnerType               = "UDNIsolation"
 
 	// nbdb constants: see also github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb
@@ -26,6 +27,9 @@ const (
 	aclActionDrop           = "drop"
 	aclActionReject         = "reject"
 	aclActionPass           = "pass"
+
+	// libovsdb util constants: see also github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util
+	aclAuditWouldDrop = "would-drop"
 )
 
 type NetworkEvent interface {
@@ -34,11 +38,16 @@ type NetworkEvent interface {
 
 type ACLEvent struct {
 	NetworkEvent
+	// Action is the ACL action, or "would-drop" for the ACLs of policies in audit mode
+	// letting through the traffic they would drop
 	Action    string
 	Actor     string
 	Name      string
 	Namespace string
 	Direction string
+	// RuleIndex is the index of the ingress or egress rule of the policy the ACL was created for,
+	// it is empty for ACLs not created for a specific policy rule
+	RuleIndex string
 }
 
 func (e *ACLEvent) String() string {
@@ -50,6 +59,8 @@ func (e *ACLEvent) String() string {
 		action = "Dropped"
 	case aclActionPass:
 		action = "Delegated to network policy"
+	case aclAuditWouldDrop:
+		action = "Would be dropped"
 	default:
 		action = "Action " + e.Action
 	}
@@ -73,6 +84,8 @@ func (e *ACLEvent) String() string {
 		}
 	case netpolNamespaceOwnerType:
 		msg = fmt.Sprintf("network policies isolation in namespace %s, direction %s", e.Namespace, e.Direction)
+	case netpolSharedRuleOwnerType:
+		msg = fmt.Sprintf("network policy rule %s shared by multiple network policies, direction %s", e.Name, e.Direction)
 	case egressFirewallOwnerType:
 		msg = fmt.Sprintf("egress firewall in namespace %s", e.Namespace)
 	case udnIsolationOwnerType:
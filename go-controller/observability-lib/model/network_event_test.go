@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 )
 
@@ -16,6 +17,7 @@ var mapping = map[string]string{
 	multicastClusterOwnerType:           libovsdbops.MulticastClusterOwnerType,
 	netpolNodeOwnerType:                 libovsdbops.NetpolNodeOwnerType,
 	netpolNamespaceOwnerType:            libovsdbops.NetpolNamespaceOwnerType,
+	netpolSharedRuleOwnerType:           libovsdbops.NetpolSharedRuleOwnerType,
 	udnIsolationOwnerType:               libovsdbops.UDNIsolationOwnerType,
 	aclActionAllow:                      nbdb.ACLActionAllow,
 	aclActionAllowRelated:               nbdb.ACLActionAllowRelated,
@@ -23,6 +25,7 @@ var mapping = map[string]string{
 	aclActionDrop:                       nbdb.ACLActionDrop,
 	aclActionPass:                       nbdb.ACLActionPass,
 	aclActionReject:                     nbdb.ACLActionReject,
+	aclAuditWouldDrop:                   libovsdbutil.ACLAuditWouldDrop,
 }
 
 // Protects from potential future renaming in ovn/ovs constants, since all constants are duplicated here
@@ -12,6 +12,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/ovsdb"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -158,7 +159,7 @@ func (d *SampleDecoder) DecodeCookieIDs(obsDomainID, obsPointID uint32) (model.N
 	var event model.NetworkEvent
 	switch o := dbObj.(type) {
 	case *nbdb.ACL:
-		event, err = newACLEvent(o)
+		event, err = NewACLEvent(o)
 		if err != nil {
 			return nil, fmt.Errorf("failed to build ACL network event: %w", err)
 		}
@@ -169,12 +170,16 @@ func (d *SampleDecoder) DecodeCookieIDs(obsDomainID, obsPointID uint32) (model.N
 	return event, nil
 }
 
-func newACLEvent(o *nbdb.ACL) (*model.ACLEvent, error) {
+// NewACLEvent returns the network event of the ACL, describing its action and the object it was created for
+func NewACLEvent(o *nbdb.ACL) (*model.ACLEvent, error) {
 	actor := o.ExternalIDs[libovsdbops.OwnerTypeKey.String()]
 	event := model.ACLEvent{
 		Action: o.Action,
 		Actor:  actor,
 	}
+	if libovsdbutil.IsAuditACL(o) {
+		event.Action = libovsdbutil.ACLAuditWouldDrop
+	}
 	switch actor {
 	case libovsdbops.NetworkPolicyOwnerType:
 		objName := o.ExternalIDs[libovsdbops.ObjectNameKey.String()]
@@ -186,9 +191,14 @@ func newACLEvent(o *nbdb.ACL) (*model.ACLEvent, error) {
 			return nil, fmt.Errorf("expected format namespace:name for Object Name, but found: %s", objName)
 		}
 		event.Direction = o.ExternalIDs[libovsdbops.PolicyDirectionKey.String()]
+		event.RuleIndex = o.ExternalIDs[libovsdbops.GressIdxKey.String()]
 	case libovsdbops.AdminNetworkPolicyOwnerType, libovsdbops.BaselineAdminNetworkPolicyOwnerType:
 		event.Name = o.ExternalIDs[libovsdbops.ObjectNameKey.String()]
 		event.Direction = o.ExternalIDs[libovsdbops.PolicyDirectionKey.String()]
+		event.RuleIndex = o.ExternalIDs[libovsdbops.GressIdxKey.String()]
+	case libovsdbops.NetpolSharedRuleOwnerType:
+		event.Name = o.ExternalIDs[libovsdbops.ObjectNameKey.String()]
+		event.Direction = o.ExternalIDs[libovsdbops.PolicyDirectionKey.String()]
 	case libovsdbops.MulticastNamespaceOwnerType, libovsdbops.NetpolNamespaceOwnerType:
 		event.Namespace = o.ExternalIDs[libovsdbops.ObjectNameKey.String()]
 		event.Direction = o.ExternalIDs[libovsdbops.PolicyDirectionKey.String()]
@@ -250,6 +260,10 @@ func (d *SampleDecoder) AddCollector(collectorID, groupID int, ownerName string)
 		return fmt.Errorf("requested collector with id=%v already exists "+
 			"with the external_ids=%+v, local_group_id=%v", collectorID, collectors[0].ExternalIDs["owner"], getGroupID(collectors[0].LocalGroupID))
 	}
+	if len(collectors) > 0 {
+		// collector left over by a previous run of the same owner
+		return nil
+	}
 
 	// find br-int UUID to attach collector
 	bridges := []*ovsdb.Bridge{}
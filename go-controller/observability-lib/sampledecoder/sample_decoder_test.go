@@ -9,10 +9,11 @@ import (
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
 func TestCreateOrUpdateACL(t *testing.T) {
-	event, err := newACLEvent(&nbdb.ACL{
+	event, err := NewACLEvent(&nbdb.ACL{
 		Action: nbdb.ACLActionAllow,
 		ExternalIDs: map[string]string{
 			libovsdbops.OwnerTypeKey.String():       libovsdbops.NetworkPolicyOwnerType,
@@ -23,18 +24,20 @@ func TestCreateOrUpdateACL(t *testing.T) {
 	require.ErrorContains(t, err, "expected format namespace:name for Object Name, but found: foo")
 	assert.Nil(t, event)
 
-	event, err = newACLEvent(&nbdb.ACL{
+	event, err = NewACLEvent(&nbdb.ACL{
 		Action: nbdb.ACLActionAllow,
 		ExternalIDs: map[string]string{
 			libovsdbops.OwnerTypeKey.String():       libovsdbops.NetworkPolicyOwnerType,
 			libovsdbops.ObjectNameKey.String():      "bar:foo",
 			libovsdbops.PolicyDirectionKey.String(): string(libovsdbutil.ACLIngress),
+			libovsdbops.GressIdxKey.String():        "1",
 		},
 	})
 	require.NoError(t, err)
 	assert.Equal(t, "Allowed by network policy foo in namespace bar, direction Ingress", event.String())
+	assert.Equal(t, "1", event.RuleIndex)
 
-	event, err = newACLEvent(&nbdb.ACL{
+	event, err = NewACLEvent(&nbdb.ACL{
 		Action: nbdb.ACLActionAllow,
 		ExternalIDs: map[string]string{
 			libovsdbops.OwnerTypeKey.String():       libovsdbops.AdminNetworkPolicyOwnerType,
@@ -45,7 +48,7 @@ func TestCreateOrUpdateACL(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Allowed by admin network policy foo, direction Ingress", event.String())
 
-	event, err = newACLEvent(&nbdb.ACL{
+	event, err = NewACLEvent(&nbdb.ACL{
 		Action: nbdb.ACLActionAllow,
 		ExternalIDs: map[string]string{
 			libovsdbops.OwnerTypeKey.String():  libovsdbops.EgressFirewallOwnerType,
@@ -56,7 +59,7 @@ func TestCreateOrUpdateACL(t *testing.T) {
 	assert.Equal(t, "Allowed by egress firewall in namespace foo", event.String())
 	assert.Equal(t, "Egress", event.Direction)
 
-	event, err = newACLEvent(&nbdb.ACL{
+	event, err = NewACLEvent(&nbdb.ACL{
 		Action: nbdb.ACLActionAllow,
 		ExternalIDs: map[string]string{
 			libovsdbops.OwnerTypeKey.String(): libovsdbops.NetpolNodeOwnerType,
@@ -65,4 +68,28 @@ func TestCreateOrUpdateACL(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Allowed by default allow from local node policy, direction Ingress", event.String())
 	assert.Equal(t, "Ingress", event.Direction)
+
+	event, err = NewACLEvent(&nbdb.ACL{
+		Action: nbdb.ACLActionAllow,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():       libovsdbops.NetpolNamespaceOwnerType,
+			libovsdbops.ObjectNameKey.String():      "foo",
+			libovsdbops.PolicyDirectionKey.String(): string(libovsdbutil.ACLIngress),
+			types.ACLAuditExternalID:                libovsdbutil.ACLAuditWouldDrop,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Would be dropped by network policies isolation in namespace foo, direction Ingress", event.String())
+
+	event, err = NewACLEvent(&nbdb.ACL{
+		Action: nbdb.ACLActionAllowRelated,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():       libovsdbops.NetpolSharedRuleOwnerType,
+			libovsdbops.ObjectNameKey.String():      "a123",
+			libovsdbops.PolicyDirectionKey.String(): string(libovsdbutil.ACLEgress),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Allowed by network policy rule a123 shared by multiple network policies, direction Egress", event.String())
+	assert.Empty(t, event.Namespace)
 }
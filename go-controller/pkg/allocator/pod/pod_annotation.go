@@ -1,14 +1,17 @@
 package pod
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"sync"
 
 	ipamclaimsapi "github.com/k8snetworkplumbingwg/ipamclaims/pkg/crd/ipamclaims/v1alpha1"
 	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 
 	corev1 "k8s.io/api/core/v1"
 	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/id"
@@ -20,27 +23,46 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
+// ErrStaticAddressConflict is returned when the IPs or MAC requested for a pod
+// on its primary UDN are already in use
+var ErrStaticAddressConflict = errors.New("requested address conflict")
+
+// ErrUnsupportedStaticAddressTopology is returned when the IPs or MAC requested
+// for a pod on its primary UDN can't be honored with the network topology
+var ErrUnsupportedStaticAddressTopology = errors.New("requested addresses not supported on network topology")
+
 // PodAnnotationAllocator is a utility to handle allocation of the PodAnnotation to Pods.
 type PodAnnotationAllocator struct {
 	podLister listers.PodLister
 	kube      kube.InterfaceOVN
+	// macs detects conflicts with the MACs requested for pods, only on
+	// networks honoring them
+	macs *macRegistry
 
 	netInfo              util.NetInfo
 	ipamClaimsReconciler persistentips.PersistentAllocations
 }
 
+// NewPodAnnotationAllocator builds a new PodAnnotationAllocator. The pod
+// indexer, which has to include the util.PodMACIndex, is only used on networks
+// honoring the MACs requested for pods.
 func NewPodAnnotationAllocator(
 	netInfo util.NetInfo,
 	podLister listers.PodLister,
+	podIndexer cache.Indexer,
 	kube kube.InterfaceOVN,
 	claimsReconciler persistentips.PersistentAllocations,
 ) *PodAnnotationAllocator {
-	return &PodAnnotationAllocator{
+	allocator := &PodAnnotationAllocator{
 		podLister:            podLister,
 		kube:                 kube,
 		netInfo:              netInfo,
 		ipamClaimsReconciler: claimsReconciler,
 	}
+	if podIndexer != nil && allowsStaticAddressRequests(netInfo) {
+		allocator.macs = newMACRegistry(netInfo, podIndexer)
+	}
+	return allocator
 }
 
 // AllocatePodAnnotation allocates the PodAnnotation which includes IPs, a mac
@@ -66,6 +88,7 @@ func (allocator *PodAnnotationAllocator) AllocatePodAnnotation(
 	return allocatePodAnnotation(
 		allocator.podLister,
 		allocator.kube,
+		allocator.macs,
 		ipAllocator,
 		allocator.netInfo,
 		node,
@@ -80,6 +103,7 @@ func (allocator *PodAnnotationAllocator) AllocatePodAnnotation(
 func allocatePodAnnotation(
 	podLister listers.PodLister,
 	kube kube.Interface,
+	macs *macRegistry,
 	ipAllocator subnet.NamedAllocator,
 	netInfo util.NetInfo,
 	node *corev1.Node,
@@ -95,6 +119,16 @@ func allocatePodAnnotation(
 	// no id allocation
 	var idAllocator id.NamedAllocator
 
+	releaseMAC, err := reserveStaticMACRequest(macs, netInfo, pod, network, reallocateIP)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err != nil {
+			releaseMAC()
+		}
+	}()
+
 	allocateToPodWithRollback := func(pod *corev1.Pod) (*corev1.Pod, func(), error) {
 		var rollback func()
 		pod, podAnnotation, rollback, err = allocatePodAnnotationWithRollback(
@@ -149,6 +183,7 @@ func (allocator *PodAnnotationAllocator) AllocatePodAnnotationWithTunnelID(
 	return allocatePodAnnotationWithTunnelID(
 		allocator.podLister,
 		allocator.kube,
+		allocator.macs,
 		ipAllocator,
 		idAllocator,
 		allocator.netInfo,
@@ -161,9 +196,35 @@ func (allocator *PodAnnotationAllocator) AllocatePodAnnotationWithTunnelID(
 	)
 }
 
+// RemovePodAnnotation removes the PodAnnotation of the given NAD from the pod
+// once the pod was hot-unplugged from it. The IPs and tunnel ID of the
+// PodAnnotation have to be released by the caller.
+func (allocator *PodAnnotationAllocator) RemovePodAnnotation(pod *corev1.Pod, nadName string) error {
+	removePodAnnotation := func(pod *corev1.Pod) (*corev1.Pod, func(), error) {
+		podAnnotation, _ := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+		if podAnnotation == nil {
+			return nil, nil, nil
+		}
+		var err error
+		pod.Annotations, err = util.DeletePodAnnotation(pod.Annotations, nadName)
+		if err != nil {
+			return nil, nil, err
+		}
+		return pod, nil, nil
+	}
+
+	return util.UpdatePodWithRetryOrRollback(
+		allocator.podLister,
+		allocator.kube,
+		pod,
+		removePodAnnotation,
+	)
+}
+
 func allocatePodAnnotationWithTunnelID(
 	podLister listers.PodLister,
 	kube kube.Interface,
+	macs *macRegistry,
 	ipAllocator subnet.NamedAllocator,
 	idAllocator id.NamedAllocator,
 	netInfo util.NetInfo,
@@ -177,6 +238,16 @@ func allocatePodAnnotationWithTunnelID(
 	podAnnotation *util.PodAnnotation,
 	err error) {
 
+	releaseMAC, err := reserveStaticMACRequest(macs, netInfo, pod, network, reallocateIP)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err != nil {
+			releaseMAC()
+		}
+	}()
+
 	allocateToPodWithRollback := func(pod *corev1.Pod) (*corev1.Pod, func(), error) {
 		var rollback func()
 		pod, podAnnotation, rollback, err = allocatePodAnnotationWithRollback(
@@ -309,6 +380,10 @@ func allocatePodAnnotationWithRollback(
 	hasIPAM := util.DoesNetworkRequireIPAM(netInfo)
 	hasIPRequest := network != nil && len(network.IPRequest) > 0
 	hasStaticIPRequest := hasIPRequest && !reallocateIP
+	// primary UDN static IP requests are honored with IPAM, as long as the
+	// requested IPs are not allocated to something else
+	allowsStaticIPRequest := allowsStaticAddressRequests(netInfo)
+	honorStaticIPRequest := hasIPAM && hasStaticIPRequest && allowsStaticIPRequest && len(tentative.IPs) == 0
 
 	var ipamClaim *ipamclaimsapi.IPAMClaim
 	hasPersistentIPs := netInfo.AllowsPersistentIPs() && hasIPAM && claimsReconciler != nil
@@ -330,7 +405,11 @@ func allocatePodAnnotationWithRollback(
 		}
 		hasIPAMClaim = ipamClaim != nil && len(ipamClaim.Status.IPs) > 0
 	}
-	if hasIPAM && hasStaticIPRequest {
+	if hasIPAM && hasStaticIPRequest && isUnsupportedStaticAddressTopology(netInfo) {
+		err = unsupportedStaticAddressTopologyError(netInfo, podDesc)
+		return
+	}
+	if hasIPAM && hasStaticIPRequest && !allowsStaticIPRequest {
 		// for now we can't tell apart already allocated IPs from IPs excluded
 		// from allocation so we can't really honor static IP requests when
 		// there is IPAM as we don't really know if the requested IP should not
@@ -350,6 +429,12 @@ func allocatePodAnnotationWithRollback(
 			if err != nil {
 				return
 			}
+			if honorStaticIPRequest {
+				if err = validateStaticIPRequest(netInfo, tentative.IPs); err != nil {
+					err = fmt.Errorf("invalid IP request for %s: %w", podDesc, err)
+					return
+				}
+			}
 		} else if hasIPAMClaim {
 			tentative.IPs, err = util.ParseIPNets(ipamClaim.Status.IPs)
 			if err != nil {
@@ -360,7 +445,15 @@ func allocatePodAnnotationWithRollback(
 
 	if hasIPAM {
 		if len(tentative.IPs) > 0 {
-			if err = ipAllocator.AllocateIPs(tentative.IPs); err != nil && !ip.IsErrAllocated(err) {
+			err = ipAllocator.AllocateIPs(tentative.IPs)
+			if honorStaticIPRequest && ip.IsErrAllocated(err) {
+				// the pod has no IPs yet, so the requested ones are in use
+				// by some other pod or reserved
+				err = fmt.Errorf("%w: requested IPs %v for %s are already allocated",
+					ErrStaticAddressConflict, util.StringSlice(tentative.IPs), podDesc)
+				return
+			}
+			if err != nil && !ip.IsErrAllocated(err) {
 				err = fmt.Errorf("failed to ensure requested or annotated IPs %v for %s: %w",
 					util.StringSlice(tentative.IPs), podDesc, err)
 				if !reallocateOnNonStaticIPRequest {
@@ -428,3 +521,178 @@ func allocatePodAnnotationWithRollback(
 
 	return
 }
+
+// allowsStaticAddressRequests returns whether the IPs and MAC requested for
+// pods are honored on the given network along with IPAM
+func allowsStaticAddressRequests(netInfo util.NetInfo) bool {
+	return util.IsPreconfiguredUDNAddressesEnabled() && netInfo.IsPrimaryNetwork() &&
+		netInfo.TopologyType() == types.Layer2Topology
+}
+
+// validateStaticIPRequest ensures the IPs requested for a pod include a single
+// IP of each of the network subnets, none of them excluded
+func validateStaticIPRequest(netInfo util.NetInfo, ips []*net.IPNet) error {
+	subnets := netInfo.Subnets()
+	if len(ips) != len(subnets) {
+		return fmt.Errorf("requested %d IPs but network %s has %d subnets", len(ips), netInfo.GetNetworkName(), len(subnets))
+	}
+	for _, ipNet := range ips {
+		found := false
+		for _, subnet := range subnets {
+			if subnet.CIDR.Contains(ipNet.IP) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("requested IP %s is not within the subnets of network %s", ipNet.IP, netInfo.GetNetworkName())
+		}
+		for _, excluded := range netInfo.ExcludeSubnets() {
+			if excluded.Contains(ipNet.IP) {
+				return fmt.Errorf("requested IP %s is excluded from network %s", ipNet.IP, netInfo.GetNetworkName())
+			}
+		}
+	}
+	return nil
+}
+
+// isUnsupportedStaticAddressTopology returns whether the IPs and MAC requested
+// for pods can't be honored on the given primary UDN because of its topology
+func isUnsupportedStaticAddressTopology(netInfo util.NetInfo) bool {
+	return util.IsPreconfiguredUDNAddressesEnabled() && netInfo.IsPrimaryNetwork() &&
+		netInfo.TopologyType() != types.Layer2Topology
+}
+
+func unsupportedStaticAddressTopologyError(netInfo util.NetInfo, podDesc string) error {
+	return fmt.Errorf("%w: cannot honor the addresses requested for pod %s on %s network %s, only %s networks support them",
+		ErrUnsupportedStaticAddressTopology, podDesc, netInfo.TopologyType(), netInfo.GetNetworkName(), types.Layer2Topology)
+}
+
+// reserveStaticMACRequest reserves the MAC requested for a pod that has not
+// been allocated yet on its primary UDN. Returns a function to release the
+// reservation if the allocation fails.
+func reserveStaticMACRequest(macs *macRegistry, netInfo util.NetInfo, pod *corev1.Pod, network *nadapi.NetworkSelectionElement,
+	reallocate bool) (func(), error) {
+	noop := func() {}
+	if network == nil || network.MacRequest == "" || reallocate {
+		return noop, nil
+	}
+	nadName := util.GetNADName(network.Namespace, network.Name)
+	if podAnnotation, _ := util.UnmarshalPodAnnotation(pod.Annotations, nadName); podAnnotation != nil {
+		return noop, nil
+	}
+	podDesc := fmt.Sprintf("%s/%s/%s", nadName, pod.Namespace, pod.Name)
+	if isUnsupportedStaticAddressTopology(netInfo) {
+		return nil, unsupportedStaticAddressTopologyError(netInfo, podDesc)
+	}
+	if macs == nil {
+		return noop, nil
+	}
+	mac, err := net.ParseMAC(network.MacRequest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAC request %q for pod %s: %w", network.MacRequest, podDesc, err)
+	}
+	return macs.reserve(pod, mac)
+}
+
+// macRegistry detects conflicts between the MACs requested for pods and the
+// MACs of the other pods of a network. The MACs annotated on pods are looked
+// up through the util.PodMACIndex of the pod informer cache, while the
+// requested MACs being allocated are kept track of until the cache catches up
+// with them.
+type macRegistry struct {
+	netInfo    util.NetInfo
+	podIndexer cache.Indexer
+
+	lock sync.Mutex
+	// pending maps the requested MACs being allocated to their pods
+	pending map[string]*corev1.Pod
+}
+
+func newMACRegistry(netInfo util.NetInfo, podIndexer cache.Indexer) *macRegistry {
+	return &macRegistry{
+		netInfo:    netInfo,
+		podIndexer: podIndexer,
+		pending:    map[string]*corev1.Pod{},
+	}
+}
+
+// reserve reserves the given MAC for the pod, failing with
+// ErrStaticAddressConflict if it is used by some other pod of the network.
+// Returns a function to release the reservation.
+func (r *macRegistry) reserve(pod *corev1.Pod, mac net.HardwareAddr) (func(), error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.prune()
+
+	owners, err := r.indexedPods(mac)
+	if err != nil {
+		return nil, err
+	}
+	if pending := r.pending[mac.String()]; pending != nil {
+		owners = append(owners, pending)
+	}
+	for _, other := range owners {
+		if other.UID == pod.UID || util.PodCompleted(other) {
+			continue
+		}
+		return nil, fmt.Errorf("%w: requested MAC %s for pod %s/%s is used by pod %s/%s",
+			ErrStaticAddressConflict, mac, pod.Namespace, pod.Name, other.Namespace, other.Name)
+	}
+
+	r.pending[mac.String()] = pod
+	return func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if pending := r.pending[mac.String()]; pending != nil && pending.UID == pod.UID {
+			delete(r.pending, mac.String())
+		}
+	}, nil
+}
+
+// indexedPods returns the pods of the cache annotated with the given MAC on
+// the network
+func (r *macRegistry) indexedPods(mac net.HardwareAddr) ([]*corev1.Pod, error) {
+	var pods []*corev1.Pod
+	for _, nadName := range r.netInfo.GetNADs() {
+		objs, err := r.podIndexer.ByIndex(util.PodMACIndex, util.PodMACIndexKey(nadName, mac))
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up pods with MAC %s: %w", mac, err)
+		}
+		for _, obj := range objs {
+			pods = append(pods, obj.(*corev1.Pod))
+		}
+	}
+	return pods, nil
+}
+
+// prune forgets the pending MACs of pods that are gone or that the cache
+// already knows as annotated on the network
+func (r *macRegistry) prune() {
+	for mac, pending := range r.pending {
+		obj, exists, err := r.podIndexer.GetByKey(pending.Namespace + "/" + pending.Name)
+		if err != nil {
+			continue
+		}
+		if !exists {
+			delete(r.pending, mac)
+			continue
+		}
+		cached := obj.(*corev1.Pod)
+		if cached.UID != pending.UID || util.PodCompleted(cached) {
+			delete(r.pending, mac)
+			continue
+		}
+		nadNames, err := util.UnmarshalPodAnnotationAllNetworks(cached.Annotations)
+		if err != nil {
+			continue
+		}
+		for nadName := range nadNames {
+			if r.netInfo.HasNAD(nadName) {
+				delete(r.pending, mac)
+				break
+			}
+		}
+	}
+}
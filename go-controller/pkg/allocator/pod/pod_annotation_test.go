@@ -14,6 +14,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/id"
 	ipam "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
@@ -85,6 +87,14 @@ func (c *persistentIPsStub) FindIPAMClaim(claimName string, namespace string) (*
 	return &ipamClaim, nil
 }
 
+func (c *persistentIPsStub) EnsureStatefulSetIPAMClaim(_ *corev1.Pod, _ string) error {
+	return nil
+}
+
+func (c *persistentIPsStub) ReleaseStatefulSetIPAMClaim(_ *corev1.Pod, _ string) error {
+	return nil
+}
+
 func ipamClaimKey(namespace string, claimName string) string {
 	return fmt.Sprintf("%s/%s", namespace, claimName)
 }
@@ -128,6 +138,7 @@ func Test_allocatePodAnnotationWithRollback(t *testing.T) {
 		isSingleStackIPv4         bool
 		isSingleStackIPv6         bool
 		multiNetworkDisabled      bool
+		preconfiguredUDNAddresses bool
 	}{
 		{
 			// on secondary L2 networks with no IPAM, we expect to generate a
@@ -205,6 +216,92 @@ func Test_allocatePodAnnotationWithRollback(t *testing.T) {
 			wantUpdatedPod: true,
 			wantErr:        true,
 		},
+		{
+			// on primary UDNs with IPAM, honor static IP requests when
+			// preconfigured addresses are enabled
+			name:                      "expect requested static IP, primary UDN, IPAM",
+			ipam:                      true,
+			idAllocation:              true,
+			preconfiguredUDNAddresses: true,
+			args: args{
+				network: &nadapi.NetworkSelectionElement{
+					IPRequest: []string{"192.168.0.4/24", "2001:db8::4/64"},
+				},
+				ipAllocator: &ipAllocatorStub{
+					nextIPs: ovntest.MustParseIPNets("192.168.0.3/24", "2001:db8::3/64"),
+				},
+				idAllocator: &idAllocatorStub{
+					nextID: 100,
+				},
+			},
+			wantUpdatedPod: true,
+			wantPodAnnotation: &util.PodAnnotation{
+				IPs:            ovntest.MustParseIPNets("192.168.0.4/24", "2001:db8::4/64"),
+				MAC:            util.IPAddrToHWAddr(ovntest.MustParseIPNets("192.168.0.4/24")[0].IP),
+				Gateways:       []net.IP{ovntest.MustParseIP("192.168.0.1").To4(), ovntest.MustParseIP("2001:db8::1")},
+				GatewayIPv6LLA: util.HWAddrToIPv6LLA(util.IPAddrToHWAddr(ovntest.MustParseIP("100.65.0.4"))),
+				Routes: []util.PodRoute{
+					{
+						Dest: &net.IPNet{
+							IP:   ovntest.MustParseIP("100.65.0.0").To4(),
+							Mask: net.CIDRMask(16, 32),
+						},
+						NextHop: ovntest.MustParseIP("192.168.0.1").To4(),
+					},
+					{
+						Dest:    ovntest.MustParseIPNet("fd99::/64"),
+						NextHop: ovntest.MustParseIP("2001:db8::1"),
+					},
+				},
+				Role:     types.NetworkRolePrimary,
+				TunnelID: 100,
+			},
+			wantRelasedIDOnRollback:   true,
+			wantReleasedIPsOnRollback: ovntest.MustParseIPNets("192.168.0.4/24", "2001:db8::4/64"),
+			role:                      types.NetworkRolePrimary,
+		},
+		{
+			// on primary UDNs with IPAM, expect error if the requested static
+			// IP is already allocated
+			name:                      "expect error, requested static IP already allocated, primary UDN, IPAM",
+			ipam:                      true,
+			idAllocation:              true,
+			preconfiguredUDNAddresses: true,
+			args: args{
+				network: &nadapi.NetworkSelectionElement{
+					IPRequest: []string{"192.168.0.4/24", "2001:db8::4/64"},
+				},
+				ipAllocator: &ipAllocatorStub{
+					allocateIPsError: ipam.ErrAllocated,
+				},
+				idAllocator: &idAllocatorStub{
+					nextID: 100,
+				},
+			},
+			wantReleaseID: true,
+			wantErr:       true,
+			role:          types.NetworkRolePrimary,
+		},
+		{
+			// on primary UDNs with IPAM, expect error if the requested static
+			// IP is not within the network subnets
+			name:                      "expect error, requested static IP out of subnet, primary UDN, IPAM",
+			ipam:                      true,
+			idAllocation:              true,
+			preconfiguredUDNAddresses: true,
+			args: args{
+				network: &nadapi.NetworkSelectionElement{
+					IPRequest: []string{"10.0.0.4/24", "2001:db8::4/64"},
+				},
+				ipAllocator: &ipAllocatorStub{},
+				idAllocator: &idAllocatorStub{
+					nextID: 100,
+				},
+			},
+			wantReleaseID: true,
+			wantErr:       true,
+			role:          types.NetworkRolePrimary,
+		},
 		{
 			// on networks with IPAM, expect a normal IP, MAC and gateway
 			// allocation
@@ -777,6 +874,7 @@ func Test_allocatePodAnnotationWithRollback(t *testing.T) {
 			config.OVNKubernetesFeature.EnableInterconnect = tt.idAllocation
 			config.OVNKubernetesFeature.EnableMultiNetwork = !tt.multiNetworkDisabled
 			config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+			config.OVNKubernetesFeature.EnablePreconfiguredUDNAddresses = tt.preconfiguredUDNAddresses
 			config.IPv4Mode = true
 			if tt.isSingleStackIPv6 {
 				config.IPv4Mode = false
@@ -917,3 +1015,132 @@ func Test_allocatePodAnnotationWithRollback(t *testing.T) {
 		})
 	}
 }
+
+func newPrimaryNetInfo(t *testing.T, topology, subnets string) util.NetInfo {
+	netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+		Topology: topology,
+		NetConf: cnitypes.NetConf{
+			Name: "primary",
+		},
+		NADName: util.GetNADName("namespace", "primary"),
+		Subnets: subnets,
+		Role:    types.NetworkRolePrimary,
+	})
+	if err != nil {
+		t.Fatalf("failed to create NetInfo: %v", err)
+	}
+	mutableNetInfo := util.NewMutableNetInfo(netInfo)
+	mutableNetInfo.AddNADs(util.GetNADName("namespace", "primary"))
+	return mutableNetInfo
+}
+
+func Test_macRegistry(t *testing.T) {
+	g := gomega.NewWithT(t)
+	config.OVNKubernetesFeature.EnableMultiNetwork = true
+	config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+	config.OVNKubernetesFeature.EnablePreconfiguredUDNAddresses = true
+	config.IPv4Mode = true
+	netInfo := newPrimaryNetInfo(t, types.Layer2Topology, "192.168.0.0/24")
+	nadName := util.GetNADName("namespace", "primary")
+
+	newPod := func(name string, mac net.HardwareAddr) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "namespace",
+				UID:       k8stypes.UID(name),
+			},
+		}
+		if mac != nil {
+			var err error
+			pod.Annotations, err = util.MarshalPodAnnotation(nil, &util.PodAnnotation{
+				IPs: ovntest.MustParseIPNets("192.168.0.3/24"),
+				MAC: mac,
+			}, nadName)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+		return pod
+	}
+	usedMAC := ovntest.MustParseMAC("0a:58:c0:a8:00:03")
+	requestedMAC := ovntest.MustParseMAC("02:00:00:00:00:01")
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{util.PodMACIndex: util.PodMACIndexFunc})
+	running := newPod("running", usedMAC)
+	first := newPod("first", nil)
+	second := newPod("second", nil)
+	for _, pod := range []*corev1.Pod{running, first, second} {
+		g.Expect(indexer.Add(pod)).To(gomega.Succeed())
+	}
+	macs := newMACRegistry(netInfo, indexer)
+
+	_, err := macs.reserve(first, usedMAC)
+	g.Expect(err).To(gomega.MatchError(ErrStaticAddressConflict), "MAC annotated on a running pod")
+
+	release, err := macs.reserve(first, requestedMAC)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	_, err = macs.reserve(second, requestedMAC)
+	g.Expect(err).To(gomega.MatchError(ErrStaticAddressConflict), "MAC being allocated to another pod")
+
+	release()
+	release, err = macs.reserve(second, requestedMAC)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "MAC released by the other pod")
+
+	// once the cache knows about the allocation, the index takes over
+	g.Expect(indexer.Update(newPod("second", requestedMAC))).To(gomega.Succeed())
+	_, err = macs.reserve(first, usedMAC)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(macs.pending).To(gomega.BeEmpty())
+	_, err = macs.reserve(first, requestedMAC)
+	g.Expect(err).To(gomega.MatchError(ErrStaticAddressConflict), "MAC annotated on the other pod")
+	release()
+
+	completed := newPod("second", requestedMAC)
+	completed.Status.Phase = corev1.PodSucceeded
+	g.Expect(indexer.Update(completed)).To(gomega.Succeed())
+	_, err = macs.reserve(first, requestedMAC)
+	g.Expect(err).NotTo(gomega.HaveOccurred(), "MAC of a completed pod")
+}
+
+func Test_staticAddressRequestUnsupportedTopology(t *testing.T) {
+	g := gomega.NewWithT(t)
+	config.OVNKubernetesFeature.EnableMultiNetwork = true
+	config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+	config.OVNKubernetesFeature.EnablePreconfiguredUDNAddresses = true
+	config.OVNKubernetesFeature.EnableInterconnect = false
+	config.IPv4Mode = true
+	config.IPv6Mode = false
+	netInfo := newPrimaryNetInfo(t, types.Layer3Topology, "10.1.0.0/16/24")
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "namespace",
+		},
+	}
+	network := &nadapi.NetworkSelectionElement{
+		Name:       "primary",
+		Namespace:  "namespace",
+		MacRequest: "02:00:00:00:00:01",
+	}
+	_, err := reserveStaticMACRequest(nil, netInfo, pod, network, false)
+	g.Expect(err).To(gomega.MatchError(ErrUnsupportedStaticAddressTopology))
+
+	network = &nadapi.NetworkSelectionElement{
+		Name:      "primary",
+		Namespace: "namespace",
+		IPRequest: []string{"10.1.1.3/24"},
+	}
+	_, _, rollback, err := allocatePodAnnotationWithRollback(
+		&ipAllocatorStub{},
+		nil,
+		netInfo,
+		&corev1.Node{},
+		pod,
+		network,
+		nil,
+		false,
+		types.NetworkRolePrimary,
+	)
+	rollback()
+	g.Expect(err).To(gomega.MatchError(ErrUnsupportedStaticAddressTopology))
+}
@@ -241,6 +241,7 @@ func (ncc *networkClusterController) init() error {
 		podAllocationAnnotator = annotationalloc.NewPodAnnotationAllocator(
 			ncc.GetNetInfo(),
 			ncc.watchFactory.PodCoreInformer().Lister(),
+			ncc.watchFactory.PodCoreInformer().Informer().GetIndexer(),
 			ncc.kube,
 			ipamClaimsReconciler,
 		)
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadutils "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/utils"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -19,7 +20,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/id"
 	ipallocator "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip/subnet"
-	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/pod"
+	podallocator "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/pod"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/persistentips"
@@ -40,7 +41,7 @@ type PodAllocator struct {
 	idAllocator id.Allocator
 
 	// An utility to allocate the PodAnnotation to pods
-	podAnnotationAllocator *pod.PodAnnotationAllocator
+	podAnnotationAllocator *podallocator.PodAnnotationAllocator
 
 	ipamClaimsReconciler persistentips.PersistentAllocations
 
@@ -60,7 +61,7 @@ type PodAllocator struct {
 // NewPodAllocator builds a new PodAllocator
 func NewPodAllocator(
 	netInfo util.NetInfo,
-	podAnnotationAllocator *pod.PodAnnotationAllocator,
+	podAnnotationAllocator *podallocator.PodAnnotationAllocator,
 	ipAllocator subnet.Allocator,
 	claimsReconciler persistentips.PersistentAllocations,
 	networkManager networkmanager.Interface,
@@ -212,9 +213,15 @@ func (a *PodAllocator) reconcile(old, new *corev1.Pod, releaseFromAllocator bool
 		return fmt.Errorf("failed to get NAD to network mapping: %w", err)
 	}
 
+	// release the NADs a running pod was hot-unplugged from, which might be
+	// all the NADs of this network
+	if new != nil && !util.PodCompleted(pod) {
+		if err = a.releaseHotUnpluggedNADs(pod, releaseFromAllocator); err != nil {
+			return err
+		}
+	}
+
 	// nothing to do if not on this network
-	// Note: we are not considering a hotplug scenario where we would have to
-	// release IPs if the pod was unplugged from the network
 	if !onNetwork {
 		return nil
 	}
@@ -315,6 +322,12 @@ func (a *PodAllocator) releasePodOnNAD(pod *corev1.Pod, nad string, network *net
 		klog.V(5).Infof("Released IPs %v", util.StringSlice(podAnnotation.IPs))
 	}
 
+	if podDeleted && a.isStatefulSetIPAMClaim(pod, network) {
+		if err := a.ipamClaimsReconciler.ReleaseStatefulSetIPAMClaim(pod, network.IPAMClaimReference); err != nil {
+			return fmt.Errorf("failed to release IPAMClaim of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+	}
+
 	if podDeleted {
 		a.deleteReleasedPod(nad, string(pod.UID))
 	} else {
@@ -324,6 +337,51 @@ func (a *PodAllocator) releasePodOnNAD(pod *corev1.Pod, nad string, network *net
 	return nil
 }
 
+// releaseHotUnpluggedNADs releases the IPs and tunnel IDs allocated to a
+// running pod on the NADs it was hot-unplugged from, and removes their
+// PodAnnotation. The resources are only released once the node reported the
+// interface unplugged by removing it from the pod network-status annotation,
+// so that they are not handed out to another pod while still in use.
+func (a *PodAllocator) releaseHotUnpluggedNADs(pod *corev1.Pod, releaseFromAllocator bool) error {
+	nadNames, err := util.GetPodHotUnpluggedNADs(pod, a.netInfo)
+	if err != nil {
+		return err
+	}
+	if len(nadNames) == 0 {
+		return nil
+	}
+	if _, ok := pod.Annotations[nettypes.NetworkStatusAnnot]; !ok {
+		// the node can't report the unplug, wait for the pod to be deleted
+		return nil
+	}
+	statuses, err := nadutils.GetNetworkStatus(pod)
+	if err != nil {
+		return fmt.Errorf("failed to get the network status of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	pluggedNADs := sets.New[string]()
+	for _, status := range statuses {
+		pluggedNADs.Insert(status.Name)
+	}
+	for _, nadName := range nadNames {
+		if pluggedNADs.Has(nadName) {
+			// the node did not unplug the interface yet, the pod update
+			// removing it from the network-status triggers the release
+			klog.V(5).Infof("Waiting for pod %s/%s to be unplugged from NAD %s", pod.Namespace, pod.Name, nadName)
+			continue
+		}
+		klog.Infof("Releasing pod %s/%s hot-unplugged from NAD %s", pod.Namespace, pod.Name, nadName)
+		if err = a.releasePodOnNAD(pod, nadName, nil, false, releaseFromAllocator); err != nil {
+			return err
+		}
+		if err = a.podAnnotationAllocator.RemovePodAnnotation(pod, nadName); err != nil {
+			return fmt.Errorf("failed to remove the annotation of pod %s/%s for NAD %s: %w", pod.Namespace, pod.Name, nadName, err)
+		}
+		// the pod might be plugged to the NAD again
+		a.deleteReleasedPod(nadName, string(pod.UID))
+	}
+	return nil
+}
+
 func (a *PodAllocator) allocatePodOnNAD(pod *corev1.Pod, nad string, network *nettypes.NetworkSelectionElement) error {
 	var ipAllocator subnet.NamedAllocator
 	if util.DoesNetworkRequireIPAM(a.netInfo) {
@@ -348,6 +406,14 @@ func (a *PodAllocator) allocatePodOnNAD(pod *corev1.Pod, nad string, network *ne
 		return nil
 	}
 
+	if a.isStatefulSetIPAMClaim(pod, network) {
+		// the IPAMClaim might not be in the informer cache yet once created,
+		// in which case the allocation below fails and is retried
+		if err := a.ipamClaimsReconciler.EnsureStatefulSetIPAMClaim(pod, network.IPAMClaimReference); err != nil {
+			return fmt.Errorf("failed to ensure IPAMClaim of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+	}
+
 	node, err := a.nodeLister.Get(pod.Spec.NodeName)
 	if err != nil {
 		return fmt.Errorf("failed to get node %q: %w", pod.Spec.NodeName, err)
@@ -364,7 +430,8 @@ func (a *PodAllocator) allocatePodOnNAD(pod *corev1.Pod, nad string, network *ne
 	)
 
 	if err != nil {
-		if errors.Is(err, ipallocator.ErrFull) {
+		if errors.Is(err, ipallocator.ErrFull) || errors.Is(err, podallocator.ErrStaticAddressConflict) ||
+			errors.Is(err, podallocator.ErrUnsupportedStaticAddressTopology) {
 			a.recordPodErrorEvent(pod, err)
 		}
 		return err
@@ -384,6 +451,18 @@ func (a *PodAllocator) allocatePodOnNAD(pod *corev1.Pod, nad string, network *ne
 	return err
 }
 
+// isStatefulSetIPAMClaim returns whether the IPAMClaim referenced by the pod
+// on the given network is managed on behalf of its StatefulSet
+func (a *PodAllocator) isStatefulSetIPAMClaim(pod *corev1.Pod, network *nettypes.NetworkSelectionElement) bool {
+	if a.ipamClaimsReconciler == nil || !a.netInfo.IsPrimaryNetwork() || network == nil || network.IPAMClaimReference == "" {
+		return false
+	}
+	if _, explicit := pod.Annotations[util.OvnUDNIPAMClaimName]; explicit {
+		return false
+	}
+	return network.IPAMClaimReference == util.GetStatefulSetPodIPAMClaimName(pod)
+}
+
 func (a *PodAllocator) addReleasedPod(nad, uid string) {
 	a.releasedPodsMutex.Lock()
 	defer a.releasedPodsMutex.Unlock()
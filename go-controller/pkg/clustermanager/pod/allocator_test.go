@@ -609,6 +609,7 @@ func TestPodAllocator_reconcileForNAD(t *testing.T) {
 			podAnnotationAllocator := pod.NewPodAnnotationAllocator(
 				netInfo,
 				podListerMock,
+				nil,
 				kubeMock,
 				ipamClaimsReconciler,
 			)
@@ -717,3 +718,82 @@ func generateIPAMClaimsListerAndTeardownFunc(stopChannel <-chan struct{}, ipamCl
 		informerFactory.Shutdown()
 	}
 }
+
+func TestPodAllocator_releaseHotUnpluggedNADs(t *testing.T) {
+	tests := []struct {
+		name            string
+		networkStatus   []nadapi.NetworkStatus
+		expectIPRelease bool
+	}{
+		{
+			name: "Pod without network status",
+		},
+		{
+			name: "Pod interface not unplugged yet",
+			networkStatus: []nadapi.NetworkStatus{
+				{Name: "ovn-kubernetes", Interface: "eth0", Default: true},
+				{Name: "namespace/nad", Interface: "net1"},
+			},
+		},
+		{
+			name: "Pod interface unplugged",
+			networkStatus: []nadapi.NetworkStatus{
+				{Name: "ovn-kubernetes", Interface: "eth0", Default: true},
+			},
+			expectIPRelease: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			ipallocator := &ipAllocatorStub{}
+			podListerMock := &v1mocks.PodLister{}
+			podNamespaceLister := &v1mocks.PodNamespaceLister{}
+			kubeMock := &kubemocks.InterfaceOVN{}
+			podListerMock.On("Pods", mock.AnythingOfType("string")).Return(podNamespaceLister)
+
+			var annotationRemoved bool
+			kubeMock.On("UpdatePodStatus", mock.AnythingOfType(fmt.Sprintf("%T", &corev1.Pod{}))).Run(
+				func(mock.Arguments) {
+					annotationRemoved = true
+				},
+			).Return(nil)
+
+			config.IPv4Mode = true
+			netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+				Topology: types.Layer2Topology,
+				Subnets:  "10.1.130.0/24",
+			})
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			mutableNetInfo := util.NewMutableNetInfo(netInfo)
+			mutableNetInfo.AddNADs("namespace/nad")
+			netInfo = mutableNetInfo
+
+			unpluggedPod := testPod{scheduled: true}.getPod(t)
+			unpluggedPod.Status.Phase = corev1.PodRunning
+			unpluggedPod.Annotations, err = util.MarshalPodAnnotation(unpluggedPod.Annotations, &util.PodAnnotation{
+				IPs: ovntest.MustParseIPNets("10.1.130.4/24"),
+				MAC: util.IPAddrToHWAddr(ovntest.MustParseIPNets("10.1.130.4/24")[0].IP),
+			}, "namespace/nad")
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			if tt.networkStatus != nil {
+				bytes, err := json.Marshal(tt.networkStatus)
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				unpluggedPod.Annotations[nadapi.NetworkStatusAnnot] = string(bytes)
+			}
+			podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(unpluggedPod, nil)
+
+			a := &PodAllocator{
+				netInfo:                netInfo,
+				ipAllocator:            ipallocator,
+				idAllocator:            &idAllocatorStub{},
+				podAnnotationAllocator: pod.NewPodAnnotationAllocator(netInfo, podListerMock, nil, kubeMock, nil),
+				releasedPods:           map[string]sets.Set[string]{},
+			}
+
+			g.Expect(a.releaseHotUnpluggedNADs(unpluggedPod, true)).To(gomega.Succeed())
+			g.Expect(ipallocator.released).To(gomega.Equal(tt.expectIPRelease))
+			g.Expect(annotationRemoved).To(gomega.Equal(tt.expectIPRelease))
+		})
+	}
+}
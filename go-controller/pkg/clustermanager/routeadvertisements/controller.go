@@ -243,7 +243,8 @@ func (c *Controller) ReconcileNetwork(_ string, old, new util.NetInfo) {
 // - If EgressIP advertisements are enabled, the generated FRRConfiguration will
 // announce from the node the EgressIPs allocated to it on the matching target
 // VRFs. Selected EgressIP are those that serve the same namespaces as the
-// selected networks. Target VRF `auto` is not supported for EgressIPs.
+// selected networks. With target VRF `auto`, the EgressIPs are announced from
+// the VRF of the network serving their namespaces.
 //
 // - If pod network advertisements are enabled, the generated FRRConfiguration
 // will import the target VRFs on the selected networks as required.
@@ -334,9 +335,6 @@ func (c *Controller) generateFRRConfigurations(ra *ratypes.RouteAdvertisements)
 	}
 
 	advertisements := sets.New(ra.Spec.Advertisements...)
-	if advertisements.Has(ratypes.EgressIP) && ra.Spec.TargetVRF == "auto" {
-		return nil, nil, fmt.Errorf("%w: advertising EgressIP not supported with TargetVRF set to 'auto'", errConfig)
-	}
 
 	// if we are matching on the well known default network label, create an
 	// internal nad for it if it doesn't exist
@@ -573,9 +571,15 @@ func (c *Controller) generateFRRConfigurations(ra *ratypes.RouteAdvertisements)
 			}
 			generated = append(generated, new)
 		}
-		// check that we matched all the selected networks on 'auto'
-		if ra.Spec.TargetVRF == "auto" && !matchedNetworks.HasAll(selectedNetworks.networks...) {
-			return nil, nil, fmt.Errorf("%w: selected FRRConfigurations for node %q don't match all selected networks with target VRF 'auto'", errConfig, nodeName)
+		// check that we matched all the selected networks on 'auto' that have
+		// something to advertise from this node: if only EgressIPs are
+		// advertised, a network might not have any assigned to this node
+		if ra.Spec.TargetVRF == "auto" {
+			for _, network := range selectedNetworks.networks {
+				if len(selectedNetworks.hostNetworkSubnets[network]) > 0 && !matchedNetworks.Has(network) {
+					return nil, nil, fmt.Errorf("%w: selected FRRConfigurations for node %q don't match all selected networks with target VRF 'auto'", errConfig, nodeName)
+				}
+			}
 		}
 	}
 
@@ -0,0 +1,79 @@
+package status_manager
+
+import (
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	peerclusterapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1"
+	peerclusterapply "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/applyconfiguration/peercluster/v1"
+	peerclusterclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/clientset/versioned"
+	peerclusterlisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/listers/peercluster/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+type peerClusterManager struct {
+	lister peerclusterlisters.PeerClusterLister
+	client peerclusterclientset.Interface
+}
+
+func newPeerClusterManager(lister peerclusterlisters.PeerClusterLister, client peerclusterclientset.Interface) *peerClusterManager {
+	return &peerClusterManager{
+		lister: lister,
+		client: client,
+	}
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives https://github.com/dominikh/go-tools/issues/1440
+func (m *peerClusterManager) get(_, name string) (*peerclusterapi.PeerCluster, error) {
+	return m.lister.Get(name)
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *peerClusterManager) getMessages(peerCluster *peerclusterapi.PeerCluster) []string {
+	return peerCluster.Status.Messages
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *peerClusterManager) updateStatus(peerCluster *peerclusterapi.PeerCluster, applyOpts *metav1.ApplyOptions,
+	applyEmptyOrFailed bool) error {
+	if peerCluster == nil {
+		return nil
+	}
+	newStatus := peerclusterapi.ConnectedStatus
+	for _, message := range peerCluster.Status.Messages {
+		if strings.Contains(message, types.PeerClusterErrorMsg) || strings.Contains(message, types.PeerClusterUnreachableMsg) {
+			newStatus = peerclusterapi.DegradedStatus
+			break
+		}
+	}
+	if applyEmptyOrFailed && newStatus != peerclusterapi.DegradedStatus {
+		newStatus = ""
+	}
+
+	if peerCluster.Status.Status == newStatus {
+		// already set to the same value
+		return nil
+	}
+
+	applyStatus := peerclusterapply.PeerClusterStatus()
+
+	if newStatus != "" {
+		applyStatus.WithStatus(newStatus)
+	}
+
+	applyObj := peerclusterapply.PeerCluster(peerCluster.Name).
+		WithStatus(applyStatus)
+
+	_, err := m.client.K8sV1().PeerClusters().ApplyStatus(context.TODO(), applyObj, *applyOpts)
+	return err
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *peerClusterManager) cleanupStatus(peerCluster *peerclusterapi.PeerCluster, applyOpts *metav1.ApplyOptions) error {
+	applyObj := peerclusterapply.PeerCluster(peerCluster.Name).
+		WithStatus(peerclusterapply.PeerClusterStatus())
+	_, err := m.client.K8sV1().PeerClusters().ApplyStatus(context.TODO(), applyObj, *applyOpts)
+	return err
+}
@@ -20,6 +20,7 @@ import (
 	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
 	egressfirewallapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
 	egressqosapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1"
+	peerclusterapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -203,6 +204,16 @@ func NewStatusManager(wf *factory.WatchFactory, ovnClient *util.OVNClusterManage
 		)
 		sm.typedManagers["egressqoses"] = egressQoSManager
 	}
+	if util.IsPeerClustersEnabled() {
+		peerClusterManager := newStatusManager[peerclusterapi.PeerCluster](
+			"peerclusters_statusmanager",
+			wf.PeerClusterInformer().Informer(),
+			wf.PeerClusterInformer().Lister().List,
+			newPeerClusterManager(wf.PeerClusterInformer().Lister(), ovnClient.PeerClusterClient),
+			sm.withZonesRLock,
+		)
+		sm.typedManagers["peerclusters"] = peerClusterManager
+	}
 	return sm
 }
 
@@ -11,7 +11,7 @@ import (
 	v1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 
@@ -26,13 +26,11 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/vswitchd"
 )
 
-var (
-	minRsrc           = resource.MustParse("1k")
-	maxRsrc           = resource.MustParse("1P")
-	BandwidthNotFound = &notFoundError{}
-)
+var BandwidthNotFound = &notFoundError{}
 
 type direction int
 
@@ -54,34 +52,20 @@ func (*notFoundError) Error() string {
 	return "not found"
 }
 
-func validateBandwidthIsReasonable(rsrc *resource.Quantity) error {
-	if rsrc.Value() < minRsrc.Value() {
-		return fmt.Errorf("resource is unreasonably small (< 1kbit)")
-	}
-	if rsrc.Value() > maxRsrc.Value() {
-		return fmt.Errorf("resoruce is unreasonably large (> 1Pbit)")
-	}
-	return nil
-}
-
 func extractPodBandwidth(podAnnotations map[string]string, dir direction) (int64, error) {
-	annotation := "kubernetes.io/ingress-bandwidth"
+	annotation := util.PodIngressBandwidthAnnotation
 	if dir == Egress {
-		annotation = "kubernetes.io/egress-bandwidth"
+		annotation = util.PodEgressBandwidthAnnotation
 	}
 
-	str, found := podAnnotations[annotation]
-	if !found {
-		return 0, BandwidthNotFound
-	}
-	bwVal, err := resource.ParseQuantity(str)
+	bw, found, err := util.ParsePodBandwidthAnnotation(podAnnotations, annotation)
 	if err != nil {
 		return 0, err
 	}
-	if err := validateBandwidthIsReasonable(&bwVal); err != nil {
-		return 0, err
+	if !found {
+		return 0, BandwidthNotFound
 	}
-	return bwVal.Value(), nil
+	return bw, nil
 }
 
 func (pr *PodRequest) String() string {
@@ -158,10 +142,12 @@ func (pr *PodRequest) cmdAddWithGetCNIResultFunc(
 	if util.IsNetworkSegmentationSupportEnabled() {
 		annotCondFn = primaryUDN.WaitForPrimaryAnnotationFn(podName, namespace, annotCondFn)
 	}
+	annotationStart := time.Now()
 	pod, annotations, podNADAnnotation, err := GetPodWithAnnotations(pr.ctx, clientset, namespace, podName, pr.nadName, annotCondFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pod annotation: %v", err)
 	}
+	observePhase(pr.ctx, phasePodAnnotation, pr.netName, annotationStart)
 	if err = pr.checkOrUpdatePodUID(pod); err != nil {
 		return nil, err
 	}
@@ -301,11 +287,39 @@ func (pr *PodRequest) cmdDel(clientset *ClientSet) (*Response, error) {
 	return response, nil
 }
 
-func (pr *PodRequest) cmdCheck() error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
-	return nil
+func (pr *PodRequest) cmdCheck(clientset *ClientSet) error {
+	if !config.CNI.EnableCheck {
+		// noop...CMD check is not considered useful, and has a considerable performance impact
+		// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
+		// after CNI ADD before it finishes bringing the container up
+		return nil
+	}
+	if config.UnprivilegedMode || config.OvnKubeNode.Mode == types.NodeModeDPUHost || pr.CNIConf.DeviceID != "" {
+		// only veth interfaces plugged by ovnkube-node itself are checked
+		return nil
+	}
+
+	namespace := pr.PodNamespace
+	podName := pr.PodName
+	if namespace == "" || podName == "" {
+		return fmt.Errorf("required CNI variable missing")
+	}
+
+	pod, err := clientset.getPod(namespace, podName)
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s/%s: %v", namespace, podName, err)
+	}
+	podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, pr.nadName)
+	if err != nil {
+		return fmt.Errorf("failed to get pod annotation for NAD %s: %v", pr.nadName, err)
+	}
+	podInterfaceInfo, err := pr.buildPodInterfaceInfo(pod.Annotations, podAnnotation, "")
+	if err != nil {
+		return err
+	}
+	podInterfaceInfo.SkipIPConfig = kubevirt.IsPodLiveMigratable(pod)
+
+	return podRequestInterfaceOps.CheckInterface(pr, podInterfaceInfo)
 }
 
 // HandlePodRequest is the callback for all the requests
@@ -331,7 +345,7 @@ func HandlePodRequest(
 	case CNIDel:
 		response, err = request.cmdDel(clientset)
 	case CNICheck:
-		err = request.cmdCheck()
+		err = request.cmdCheck(clientset)
 	default:
 	}
 
@@ -347,6 +361,9 @@ func HandlePodRequest(
 
 	klog.Infof("%s %s finished CNI request %+v, result %q, err %v",
 		request, request.Command, request, string(resultForLogging), err)
+	if request.Command == CNIAdd {
+		logRequestTrace(request)
+	}
 
 	if err != nil {
 		// Prefix errors with request info for easier failure debugging
@@ -355,6 +372,91 @@ func HandlePodRequest(
 	return result, nil
 }
 
+// HandleGCRequest is the callback for GC requests coming to the cniserver.
+// validAttachments are the attachments of the NAD nadName the runtime still
+// knows about; the OVS ports, bandwidth settings and conntrack entries of any
+// other attachment of that NAD plugged by ovnkube-node are removed.
+func HandleGCRequest(nadName string, validAttachments sets.Set[gcAttachment], ovsClient client.Client) error {
+	if config.UnprivilegedMode || config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		// pod interfaces are not plugged into OVS by ovnkube-node on this host
+		return nil
+	}
+
+	ifaces, err := ovs.FindInterfacesWithPredicate(ovsClient, func(iface *vswitchd.Interface) bool {
+		return iface.ExternalIDs["sandbox"] != ""
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pod interfaces in OVS: %v", err)
+	}
+
+	staleIfaces, inUseIPs := getStaleInterfaces(ifaces, nadName, validAttachments)
+	klog.Infof("%s starting CNI request for NAD %s, %d valid attachments, %d stale sandboxes",
+		CNIGC, nadName, validAttachments.Len(), len(staleIfaces))
+	var errs []error
+	for sandboxID, ifaces := range staleIfaces {
+		if err := deleteStaleSandbox(sandboxID, ifaces, inUseIPs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
+// getStaleInterfaces returns the pod OVS interfaces of the NAD nadName that
+// don't belong to any of the valid attachments, by sandbox, along with the IPs
+// of the interfaces that are kept
+func getStaleInterfaces(ifaces []*vswitchd.Interface, nadName string, validAttachments sets.Set[gcAttachment]) (map[string][]*vswitchd.Interface, sets.Set[string]) {
+	validSandboxes := sets.New[string]()
+	for attachment := range validAttachments {
+		validSandboxes.Insert(attachment.ContainerID)
+	}
+	staleIfaces := map[string][]*vswitchd.Interface{}
+	inUseIPs := sets.New[string]()
+	for _, iface := range ifaces {
+		if !isGCNetworkInterface(iface, nadName) || isValidAttachmentInterface(iface, validAttachments, validSandboxes) {
+			inUseIPs.Insert(podInterfaceIPs(iface)...)
+			continue
+		}
+		sandboxID := iface.ExternalIDs["sandbox"]
+		staleIfaces[sandboxID] = append(staleIfaces[sandboxID], iface)
+	}
+	return staleIfaces, inUseIPs
+}
+
+// isGCNetworkInterface returns whether a pod OVS interface belongs to the
+// attachments of the NAD a GC request is for. The primary UDN interface is
+// set up along the default network one, so it belongs to the default network
+// attachment.
+func isGCNetworkInterface(iface *vswitchd.Interface, nadName string) bool {
+	isPrimaryUDN := iface.ExternalIDs[types.NetworkRoleExternalID] == types.NetworkRolePrimary
+	if nadName == types.DefaultNetworkName {
+		return iface.ExternalIDs[types.NADExternalID] == "" || isPrimaryUDN
+	}
+	return iface.ExternalIDs[types.NADExternalID] == nadName && !isPrimaryUDN
+}
+
+// isValidAttachmentInterface returns whether a pod OVS interface belongs to
+// one of the valid attachments of a GC request. Interfaces plugged without
+// their attachment interface name recorded are matched on the sandbox only.
+func isValidAttachmentInterface(iface *vswitchd.Interface, validAttachments sets.Set[gcAttachment], validSandboxes sets.Set[string]) bool {
+	sandboxID := iface.ExternalIDs["sandbox"]
+	ifName, ok := iface.ExternalIDs[cniIfNameExternalID]
+	if !ok {
+		return validSandboxes.Has(sandboxID)
+	}
+	return validAttachments.Has(gcAttachment{ContainerID: sandboxID, IfName: ifName})
+}
+
+// podInterfaceIPs returns the IPs recorded on a pod OVS interface
+func podInterfaceIPs(iface *vswitchd.Interface) []string {
+	var ips []string
+	for _, ipStr := range strings.Split(iface.ExternalIDs["ip_addresses"], ",") {
+		if ip, _, err := net.ParseCIDR(ipStr); err == nil {
+			ips = append(ips, ip.String())
+		}
+	}
+	return ips
+}
+
 // getCNIResult get result from pod interface info.
 // PodInfoGetter is used to check if sandbox is still valid for the current
 // instance of the pod in the apiserver, see checkCancelSandbox for more info.
@@ -397,6 +499,14 @@ func getCNIResult(pr *PodRequest, getter PodInfoGetter, podInterfaceInfo *PodInt
 	}, nil
 }
 
+// cniIfName returns the interface name of the CNI attachment the request is for
+func (pr *PodRequest) cniIfName() string {
+	if pr.attachmentIfName != "" {
+		return pr.attachmentIfName
+	}
+	return pr.IfName
+}
+
 func (pr *PodRequest) buildPrimaryUDNPodRequest(
 	pod *corev1.Pod,
 	primaryUDN *udn.UserDefinedPrimaryNetwork,
@@ -409,6 +519,8 @@ func (pr *PodRequest) buildPrimaryUDNPodRequest(
 		SandboxID:    pr.SandboxID,
 		Netns:        pr.Netns,
 		IfName:       primaryUDN.InterfaceName(),
+		// the primary UDN interface is part of the default network attachment
+		attachmentIfName: pr.IfName,
 		CNIConf: &ovncnitypes.NetConf{
 			// primary UDN MTU will be taken from config.Default.MTU
 			// if not specified at the NAD
@@ -13,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/ovn-org/libovsdb/client"
@@ -20,6 +21,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
 	v1nadmocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
 	v1mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/k8s.io/client-go/listers/core/v1"
@@ -34,6 +36,7 @@ import (
 
 type podRequestInterfaceOpsStub struct {
 	unconfiguredInterfaces []*PodInterfaceInfo
+	checkedInterfaces      []*PodInterfaceInfo
 }
 
 func (stub *podRequestInterfaceOpsStub) ConfigureInterface(*PodRequest, PodInfoGetter, *PodInterfaceInfo) ([]*current.Interface, error) {
@@ -43,6 +46,10 @@ func (stub *podRequestInterfaceOpsStub) UnconfigureInterface(_ *PodRequest, ifIn
 	stub.unconfiguredInterfaces = append(stub.unconfiguredInterfaces, ifInfo)
 	return nil
 }
+func (stub *podRequestInterfaceOpsStub) CheckInterface(_ *PodRequest, ifInfo *PodInterfaceInfo) error {
+	stub.checkedInterfaces = append(stub.checkedInterfaces, ifInfo)
+	return nil
+}
 
 var _ = Describe("Network Segmentation", func() {
 	var (
@@ -140,6 +147,22 @@ var _ = Describe("Network Segmentation", func() {
 			Expect(pr.cmdDel(clientSet)).NotTo(BeNil())
 			Expect(prInterfaceOpsStub.unconfiguredInterfaces).To(HaveLen(1))
 		})
+		It("should not check the pod interface at cmdCheck unless enabled", func() {
+			prInterfaceOpsStub.checkedInterfaces = nil
+			Expect(pr.cmdCheck(clientSet)).To(Succeed())
+			Expect(prInterfaceOpsStub.checkedInterfaces).To(BeEmpty())
+		})
+		It("should check the pod interface against the annotation at cmdCheck when enabled", func() {
+			config.CNI.EnableCheck = true
+			config.OvnKubeNode.Mode = ovntypes.NodeModeFull
+			DeferCleanup(func() { config.CNI.EnableCheck = false })
+			prInterfaceOpsStub.checkedInterfaces = nil
+			podNamespaceLister.On("Get", pr.PodName).Return(pod, nil)
+			Expect(pr.cmdCheck(clientSet)).To(Succeed())
+			Expect(prInterfaceOpsStub.checkedInterfaces).To(HaveLen(1))
+			Expect(prInterfaceOpsStub.checkedInterfaces[0].MAC.String()).To(Equal("0a:58:fd:98:00:01"))
+			Expect(prInterfaceOpsStub.checkedInterfaces[0].IPs).To(ConsistOf(ovntest.MustParseIPNet("100.10.10.3/24")))
+		})
 
 	})
 	Context("with network segmentation fg enabled and annotation with role field", func() {
@@ -387,3 +410,64 @@ func newOVSClientWithExternalIDs(externalIDs map[string]string) (client.Client,
 	})
 	return ovsClient, err
 }
+
+var _ = Describe("GC stale interfaces", func() {
+	newPodInterface := func(name, sandboxID, ifName, nadName, role, ip string) *vswitchd.Interface {
+		iface := &vswitchd.Interface{
+			Name: name,
+			ExternalIDs: map[string]string{
+				"sandbox":      sandboxID,
+				"ip_addresses": ip,
+			},
+		}
+		if ifName != "" {
+			iface.ExternalIDs[cniIfNameExternalID] = ifName
+		}
+		if nadName != "" {
+			iface.ExternalIDs[ovntypes.NADExternalID] = nadName
+			iface.ExternalIDs[ovntypes.NetworkRoleExternalID] = role
+		}
+		return iface
+	}
+
+	var (
+		validDefault    = newPodInterface("valid-default", "valid", "eth0", "", "", "10.128.1.3/24")
+		validPrimaryUDN = newPodInterface("valid-udn", "valid", "eth0", "ns1/udn", ovntypes.NetworkRolePrimary, "10.20.1.3/24")
+		validSecondary  = newPodInterface("valid-net1", "valid", "net1", "ns1/sec", ovntypes.NetworkRoleSecondary, "10.30.1.3/24")
+		staleDefault    = newPodInterface("stale-default", "stale", "eth0", "", "", "10.128.1.4/24")
+		stalePrimaryUDN = newPodInterface("stale-udn", "stale", "eth0", "ns1/udn", ovntypes.NetworkRolePrimary, "10.20.1.4/24")
+		staleSecondary  = newPodInterface("stale-net1", "stale", "net1", "ns1/sec", ovntypes.NetworkRoleSecondary, "10.30.1.4/24")
+		// valid sandbox, but the attachment of the interface is gone
+		staleSecondary2 = newPodInterface("valid-net2", "valid", "net2", "ns1/sec", ovntypes.NetworkRoleSecondary, "10.30.1.5/24")
+		// plugged without the attachment interface name recorded
+		legacyDefault = newPodInterface("legacy-default", "valid", "", "", "", "10.128.1.5/24")
+		ifaces        = []*vswitchd.Interface{validDefault, validPrimaryUDN, validSecondary, staleDefault,
+			stalePrimaryUDN, staleSecondary, staleSecondary2, legacyDefault}
+	)
+
+	DescribeTable("only selects the interfaces of the GC'd network missing from the valid attachments",
+		func(nadName string, validAttachments []gcAttachment, expectedStale map[string][]*vswitchd.Interface, expectedInUseIPs []string) {
+			staleIfaces, inUseIPs := getStaleInterfaces(ifaces, nadName, sets.New(validAttachments...))
+			Expect(staleIfaces).To(Equal(expectedStale))
+			Expect(sets.List(inUseIPs)).To(Equal(expectedInUseIPs))
+		},
+		Entry("default network",
+			ovntypes.DefaultNetworkName,
+			[]gcAttachment{{ContainerID: "valid", IfName: "eth0"}},
+			map[string][]*vswitchd.Interface{"stale": {staleDefault, stalePrimaryUDN}},
+			[]string{"10.128.1.3", "10.128.1.5", "10.20.1.3", "10.30.1.3", "10.30.1.4", "10.30.1.5"},
+		),
+		Entry("secondary network",
+			"ns1/sec",
+			[]gcAttachment{{ContainerID: "valid", IfName: "net1"}},
+			map[string][]*vswitchd.Interface{"stale": {staleSecondary}, "valid": {staleSecondary2}},
+			[]string{"10.128.1.3", "10.128.1.4", "10.128.1.5", "10.20.1.3", "10.20.1.4", "10.30.1.3"},
+		),
+		Entry("network with no valid attachments",
+			"ns1/other",
+			nil,
+			map[string][]*vswitchd.Interface{},
+			[]string{"10.128.1.3", "10.128.1.4", "10.128.1.5", "10.20.1.3", "10.20.1.4", "10.30.1.3", "10.30.1.4", "10.30.1.5"},
+		),
+	)
+})
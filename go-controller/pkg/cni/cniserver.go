@@ -12,6 +12,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
@@ -79,6 +80,7 @@ func NewCNIServer(
 			KubeAPITokenFile: config.Kubernetes.TokenFile,
 		},
 		handlePodRequestFunc: HandlePodRequest,
+		handleGCRequestFunc:  HandleGCRequest,
 		networkManager:       networkManager,
 		ovsClient:            ovsClient,
 	}
@@ -106,6 +108,12 @@ func NewCNIServer(
 	return s, nil
 }
 
+// SetReady marks ovnkube-node as done starting up, after which STATUS
+// requests report the plugin as ready to handle pod sandboxes
+func (s *Server) SetReady() {
+	s.ready.Store(true)
+}
+
 // Split the "CNI_ARGS" environment variable's value into a map.  CNI_ARGS
 // contains arbitrary key/value pairs separated by ';' and is for runtime or
 // plugin specific uses.  Kubernetes passes the pod namespace and name in
@@ -209,6 +217,7 @@ func cniRequestToPodRequest(cr *Request) (*PodRequest, error) {
 	req.timestamp = time.Now()
 	// Match the Kubelet default CRI operation timeout of 2m
 	req.ctx, req.cancel = context.WithTimeout(context.Background(), 2*time.Minute)
+	req.ctx = withRequestTrace(req.ctx)
 	return req, nil
 }
 
@@ -220,6 +229,16 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 	if err := json.Unmarshal(b, &cr); err != nil {
 		return nil, err
 	}
+
+	// GC and STATUS are not scoped to a pod so they are not turned into a
+	// PodRequest
+	switch command(cr.Env["CNI_COMMAND"]) {
+	case CNIStatus:
+		return nil, s.handleCNIStatus()
+	case CNIGC:
+		return nil, s.handleCNIGC(&cr)
+	}
+
 	req, err := cniRequestToPodRequest(&cr)
 	if err != nil {
 		return nil, err
@@ -234,6 +253,34 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 	return result, nil
 }
 
+func (s *Server) handleCNIStatus() error {
+	if !s.ready.Load() {
+		return fmt.Errorf("ovnkube-node has not finished starting up")
+	}
+	return nil
+}
+
+func (s *Server) handleCNIGC(cr *Request) error {
+	conf, err := config.ReadCNIConfig(cr.Config)
+	if err != nil {
+		return fmt.Errorf("broken stdin args: %v", err)
+	}
+	var gcConf gcConfig
+	if err := json.Unmarshal(cr.Config, &gcConf); err != nil {
+		return fmt.Errorf("broken stdin args: %v", err)
+	}
+	// the GC request only covers the attachments of the network it is
+	// configured for, the default network configuration has no NAD name
+	nadName := types.DefaultNetworkName
+	if conf.Name != types.DefaultNetworkName && conf.NADName != "" {
+		nadName = conf.NADName
+	}
+	if err := s.handleGCRequestFunc(nadName, sets.New(gcConf.ValidAttachments...), s.ovsClient); err != nil {
+		return fmt.Errorf("%s failed: %v", CNIGC, err)
+	}
+	return nil
+}
+
 func (s *Server) handleCNIMetrics(w http.ResponseWriter, r *http.Request) {
 	var cm CNIRequestMetrics
 
@@ -20,6 +20,7 @@ import (
 	cni020 "github.com/containernetworking/cni/pkg/types/020"
 	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/fake"
 	utiltesting "k8s.io/client-go/util/testing"
 
@@ -27,6 +28,7 @@ import (
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
@@ -73,6 +75,8 @@ const (
 	cniConfig    string = "{\"cniVersion\": \"1.0.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"}"
 	cniConfig_40 string = "{\"cniVersion\": \"1.0.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"}"
 	nodeName     string = "mynode"
+	cniConfigGC  string = "{\"cniVersion\": \"1.1.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"," +
+		"\"cni.dev/valid-attachments\": [{\"containerID\": \"" + sandboxID + "\",\"ifname\": \"eth0\"},{\"containerID\": \"other-sandbox\",\"ifname\": \"eth0\"}]}"
 )
 
 func TestCNIServer(t *testing.T) {
@@ -103,8 +107,15 @@ func TestCNIServer(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error creating CNI server: %v", err)
 	}
-	// override request handler
+	// override request handlers
 	s.handlePodRequestFunc = serverHandleCNI
+	var gcNADName string
+	var gcValidAttachments sets.Set[gcAttachment]
+	s.handleGCRequestFunc = func(nadName string, validAttachments sets.Set[gcAttachment], _ client.Client) error {
+		gcNADName = nadName
+		gcValidAttachments = validAttachments
+		return nil
+	}
 	if err := s.Start(tmpDir); err != nil {
 		t.Fatalf("error starting CNI server: %v", err)
 	}
@@ -214,6 +225,29 @@ func TestCNIServer(t *testing.T) {
 			},
 			result: nil,
 		},
+		// GC request, not scoped to a pod
+		{
+			name: "GC",
+			request: &Request{
+				Env: map[string]string{
+					"CNI_COMMAND": string(CNIGC),
+				},
+				Config: []byte(cniConfigGC),
+			},
+			result: nil,
+		},
+		// STATUS request before ovnkube-node is ready
+		{
+			name: "STATUS",
+			request: &Request{
+				Env: map[string]string{
+					"CNI_COMMAND": string(CNIStatus),
+				},
+				Config: []byte(cniConfig),
+			},
+			result:      nil,
+			errorPrefix: "ovnkube-node has not finished starting up",
+		},
 		// Missing CNI_ARGS
 		{
 			name: "ARGS1",
@@ -282,4 +316,20 @@ func TestCNIServer(t *testing.T) {
 			}
 		}
 	}
+
+	if gcNADName != ovntypes.DefaultNetworkName {
+		t.Fatalf("[GC] unexpected NAD %s", gcNADName)
+	}
+	if !gcValidAttachments.Equal(sets.New(gcAttachment{ContainerID: sandboxID, IfName: "eth0"},
+		gcAttachment{ContainerID: "other-sandbox", IfName: "eth0"})) {
+		t.Fatalf("[GC] unexpected valid attachments %v", gcValidAttachments.UnsortedList())
+	}
+
+	s.SetReady()
+	if _, code := clientDoCNI(t, client, &Request{
+		Env:    map[string]string{"CNI_COMMAND": string(CNIStatus)},
+		Config: []byte(cniConfig),
+	}); code != http.StatusOK {
+		t.Fatalf("[STATUS] expected status %v once ready but got %v", http.StatusOK, code)
+	}
 }
@@ -326,10 +326,78 @@ func (p *Plugin) CmdDel(args *skel.CmdArgs) error {
 	return err
 }
 
+// errPluginNotAvailable is the CNI 1.1 well known error code returned by
+// STATUS when the plugin can't handle ADD requests
+const errPluginNotAvailable uint = 50
+
+// CmdGC is the callback for 'gc' cni calls, removing leftovers of the
+// sandboxes missing from the valid attachments in the config
+func (p *Plugin) CmdGC(args *skel.CmdArgs) error {
+	var err error
+
+	startTime := time.Now()
+	defer func() {
+		p.postMetrics(startTime, CNIGC, err)
+	}()
+
+	conf, err := config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return fmt.Errorf("invalid stdin args %v", err)
+	}
+	setupLogging(conf)
+
+	req := newCNIRequest(args, nadapi.DeviceInfo{})
+	if _, err = p.doCNI("http://dummy/", req); err != nil {
+		klog.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// CmdStatus is the callback for 'status' cni calls, reporting whether
+// ovnkube-node is ready to handle pod sandboxes
+func (p *Plugin) CmdStatus(args *skel.CmdArgs) error {
+	var err error
+
+	startTime := time.Now()
+	defer func() {
+		p.postMetrics(startTime, CNIStatus, err)
+	}()
+
+	req := newCNIRequest(args, nadapi.DeviceInfo{})
+	if _, err = p.doCNI("http://dummy/", req); err != nil {
+		return types.NewError(errPluginNotAvailable, "ovn-kubernetes is not ready", err.Error())
+	}
+	return nil
+}
+
 // CmdCheck is the callback for 'checking' container's networking is as expected.
-func (p *Plugin) CmdCheck(_ *skel.CmdArgs) error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
+// The request is forwarded to ovnkube-node which only does the actual checks
+// when it is configured to.
+func (p *Plugin) CmdCheck(args *skel.CmdArgs) error {
+	startTime := time.Now()
+
+	conf, err := config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return fmt.Errorf("invalid stdin args %v", err)
+	}
+	setupLogging(conf)
+
+	// the default network configuration tells whether ovnkube-node does the
+	// checks, don't reach it at all when it doesn't; NAD configurations
+	// don't carry the flag so they are always forwarded
+	if conf.NADName == "" && !conf.EnableCheck {
+		return nil
+	}
+
+	defer func() {
+		p.postMetrics(startTime, CNICheck, err)
+	}()
+
+	req := newCNIRequest(args, nadapi.DeviceInfo{})
+	if _, err = p.doCNI("http://dummy/", req); err != nil {
+		klog.Error(err.Error())
+		return err
+	}
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -20,11 +21,14 @@ import (
 	"github.com/safchain/ethtool"
 	"github.com/vishvananda/netlink"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/knftables"
 
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/vswitchd"
 )
 
 type CNIPluginLibOps interface {
@@ -172,7 +176,7 @@ func moveIfToNetns(ifname string, netns ns.NetNS) error {
 	return nil
 }
 
-func setupNetwork(link netlink.Link, ifInfo *PodInterfaceInfo) error {
+func setupNetwork(ctx context.Context, link netlink.Link, ifInfo *PodInterfaceInfo) error {
 	// make sure link is up
 	if link.Attrs().Flags&net.FlagUp == 0 {
 		if err := util.GetNetLinkOps().LinkSetUp(link); err != nil {
@@ -192,6 +196,7 @@ func setupNetwork(link netlink.Link, ifInfo *PodInterfaceInfo) error {
 			return fmt.Errorf("failed to add IP addr %s to %s: %v", ip, link.Attrs().Name, err)
 		}
 	}
+	routeStart := time.Now()
 	for _, gw := range ifInfo.Gateways {
 		if err := cniPluginLibOps.AddRoute(nil, gw, link, ifInfo.RoutableMTU); err != nil {
 			return fmt.Errorf("failed to add gateway route to link '%s': %v", link.Attrs().Name, err)
@@ -202,11 +207,86 @@ func setupNetwork(link netlink.Link, ifInfo *PodInterfaceInfo) error {
 			return fmt.Errorf("failed to add pod route %v via %v: %v", route.Dest, route.NextHop, err)
 		}
 	}
+	observePhase(ctx, phaseRouteSetup, ifInfo.NetName, routeStart)
 
 	return nil
 }
 
-func setupInterface(netns ns.NetNS, containerID, ifName string, ifInfo *PodInterfaceInfo) (*current.Interface, *current.Interface, error) {
+// checkNetwork verifies that the container interface has the MAC, IPs and
+// routes that setupNetwork configured on it
+func checkNetwork(ifName string, ifInfo *PodInterfaceInfo) error {
+	link, err := util.GetNetLinkOps().LinkByName(ifName)
+	if err != nil {
+		return fmt.Errorf("failed to lookup container interface %s: %v", ifName, err)
+	}
+	if link.Attrs().Flags&net.FlagUp == 0 {
+		return fmt.Errorf("container interface %s is down", ifName)
+	}
+	if mac := link.Attrs().HardwareAddr.String(); mac != ifInfo.MAC.String() {
+		return fmt.Errorf("container interface %s has MAC %s, expected %s", ifName, mac, ifInfo.MAC)
+	}
+
+	if ifInfo.SkipIPConfig {
+		return nil
+	}
+
+	addrs, err := util.GetNetLinkOps().AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list IP addresses of container interface %s: %v", ifName, err)
+	}
+	for _, ip := range ifInfo.IPs {
+		found := false
+		for _, addr := range addrs {
+			if addr.IPNet != nil && addr.IPNet.String() == ip.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("container interface %s is missing IP address %s", ifName, ip)
+		}
+	}
+
+	routes, err := util.GetNetLinkOps().RouteList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list routes of container interface %s: %v", ifName, err)
+	}
+	hasRoute := func(dst *net.IPNet, gw net.IP) bool {
+		for _, route := range routes {
+			if !route.Gw.Equal(gw) {
+				continue
+			}
+			if dst == nil {
+				// default routes may be reported with a nil or a zero destination
+				if route.Dst == nil {
+					return true
+				}
+				if ones, _ := route.Dst.Mask.Size(); ones == 0 && route.Dst.IP.IsUnspecified() {
+					return true
+				}
+				continue
+			}
+			if route.Dst != nil && route.Dst.String() == dst.String() {
+				return true
+			}
+		}
+		return false
+	}
+	for _, gw := range ifInfo.Gateways {
+		if !hasRoute(nil, gw) {
+			return fmt.Errorf("container interface %s is missing the default route via %s", ifName, gw)
+		}
+	}
+	for _, route := range ifInfo.Routes {
+		if !hasRoute(route.Dest, route.NextHop) {
+			return fmt.Errorf("container interface %s is missing route %s via %s", ifName, route.Dest, route.NextHop)
+		}
+	}
+
+	return nil
+}
+
+func setupInterface(ctx context.Context, netns ns.NetNS, containerID, ifName string, ifInfo *PodInterfaceInfo) (*current.Interface, *current.Interface, error) {
 	hostIface := &current.Interface{}
 	contIface := &current.Interface{}
 	ifnameSuffix := ""
@@ -234,7 +314,7 @@ func setupInterface(netns ns.NetNS, containerID, ifName string, ifInfo *PodInter
 			return fmt.Errorf("failed to lookup %s: %v", contIface.Name, err)
 		}
 
-		err = setupNetwork(link, ifInfo)
+		err = setupNetwork(ctx, link, ifInfo)
 		if err != nil {
 			return err
 		}
@@ -285,6 +365,90 @@ func setupInterface(netns ns.NetNS, containerID, ifName string, ifInfo *PodInter
 	return hostIface, contIface, nil
 }
 
+// netnsDirs are the directories where container runtimes pin the network
+// namespaces of pod sandboxes
+var netnsDirs = []string{"/var/run/netns", "/run/netns"}
+
+// getNetNSPathByID returns the path of the pinned network namespace that has
+// the given id in the host network namespace
+func getNetNSPathByID(nsID int) (string, error) {
+	for _, dir := range netnsDirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to read network namespaces from %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			f, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			id, err := netlink.GetNetNsIdByFd(int(f.Fd()))
+			f.Close()
+			if err == nil && id == nsID {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("failed to find network namespace with id %d", nsID)
+}
+
+// UpdatePodInterfaceMTU changes the MTU of the veth pair that connects a
+// running pod to OVS, given the name of the host side interface. The MTU is
+// lowered on the container side first and raised on the host side first, so
+// that the host never receives frames bigger than it can forward. Interfaces
+// other than veths, like VF representors, are left untouched.
+func UpdatePodInterfaceMTU(hostIfaceName string, mtu int) error {
+	hostLink, err := util.GetNetLinkOps().LinkByName(hostIfaceName)
+	if err != nil {
+		return fmt.Errorf("failed to lookup %s: %w", hostIfaceName, err)
+	}
+	if _, ok := hostLink.(*netlink.Veth); !ok {
+		klog.V(5).Infof("Skipping MTU update of non veth interface %s", hostIfaceName)
+		return nil
+	}
+	if hostLink.Attrs().MTU == mtu {
+		return nil
+	}
+
+	netnsPath, err := getNetNSPathByID(hostLink.Attrs().NetNsID)
+	if err != nil {
+		return fmt.Errorf("failed to find the network namespace of the peer of %s: %w", hostIfaceName, err)
+	}
+	setContainerMTU := func() error {
+		return ns.WithNetNSPath(netnsPath, func(ns.NetNS) error {
+			link, err := util.GetNetLinkOps().LinkByIndex(hostLink.Attrs().ParentIndex)
+			if err != nil {
+				return fmt.Errorf("failed to lookup peer of %s in %s: %w", hostIfaceName, netnsPath, err)
+			}
+			if err = util.GetNetLinkOps().LinkSetMTU(link, mtu); err != nil {
+				return fmt.Errorf("failed to set MTU %d on %s in %s: %w", mtu, link.Attrs().Name, netnsPath, err)
+			}
+			return nil
+		})
+	}
+	setHostMTU := func() error {
+		if err := util.GetNetLinkOps().LinkSetMTU(hostLink, mtu); err != nil {
+			return fmt.Errorf("failed to set MTU %d on %s: %w", mtu, hostIfaceName, err)
+		}
+		return nil
+	}
+
+	steps := []func() error{setHostMTU, setContainerMTU}
+	if mtu < hostLink.Attrs().MTU {
+		steps = []func() error{setContainerMTU, setHostMTU}
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // generate a unique interface name for the temporary netdev that will be moved to pod namespace
 func generateIfName(containerID string) string {
 	randomId := util.GenerateId(5) // random ID with 5 chars
@@ -293,7 +457,7 @@ func generateIfName(containerID string) string {
 }
 
 // Setup sriov interface in the pod
-func setupSriovInterface(netns ns.NetNS, containerID, ifName string, ifInfo *PodInterfaceInfo, deviceID string, isVFIO bool) (*current.Interface, *current.Interface, error) {
+func setupSriovInterface(ctx context.Context, netns ns.NetNS, containerID, ifName string, ifInfo *PodInterfaceInfo, deviceID string, isVFIO bool) (*current.Interface, *current.Interface, error) {
 	hostIface := &current.Interface{}
 	contIface := &current.Interface{}
 	netdevice := ifInfo.NetdevName
@@ -338,7 +502,7 @@ func setupSriovInterface(netns ns.NetNS, containerID, ifName string, ifInfo *Pod
 					return err
 				}
 
-				err = setupNetwork(link, ifInfo)
+				err = setupNetwork(ctx, link, ifInfo)
 				if err != nil {
 					return err
 				}
@@ -430,7 +594,7 @@ func getPfEncapIP(deviceID string) (string, error) {
 
 // ConfigureOVS performs OVS configurations in order to set up Pod networking
 func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
-	ifInfo *PodInterfaceInfo, sandboxID, deviceID string, getter PodInfoGetter) error {
+	ifInfo *PodInterfaceInfo, sandboxID, ifName, deviceID string, getter PodInfoGetter) error {
 
 	ifaceID := util.GetIfaceId(namespace, podName)
 	if ifInfo.NetName != types.DefaultNetworkName {
@@ -450,6 +614,8 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 	klog.Infof("ConfigureOVS: namespace: %s, podName: %s, hostIfaceName: %s, network: %s, NAD %s, SandboxID: %q, PCI device ID: %s, UID: %q, MAC: %s, IPs: %v",
 		namespace, podName, hostIfaceName, ifInfo.NetName, ifInfo.NADName, sandboxID, deviceID, initialPodUID, ifInfo.MAC, ipStrs)
 
+	ovsPortStart := time.Now()
+
 	// Find and remove any existing OVS port with this iface-id. Pods can
 	// have multiple sandboxes if some are waiting for garbage collection,
 	// but only the latest one should have the iface-id set.
@@ -494,6 +660,11 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 		fmt.Sprintf("external_ids:iface-id-ver=%s", initialPodUID),
 		fmt.Sprintf("external_ids:sandbox=%s", sandboxID),
 	}
+	// record the interface of the CNI attachment so that a GC request can
+	// tell the attachments of a sandbox apart
+	if ifName != "" {
+		ovsArgs = append(ovsArgs, fmt.Sprintf("external_ids:%s=%s", cniIfNameExternalID, ifName))
+	}
 
 	// In case of multi-vtep, host has multipe NICs and each NIC has a VTEP interface, the mapping
 	// of VTEP IP to NIC is stored in Open_vSwitch table's `external_ids:ovn-pf-encap-ip-mapping`,
@@ -540,6 +711,9 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 	if ifInfo.NetName != types.DefaultNetworkName {
 		ovsArgs = append(ovsArgs, fmt.Sprintf("external_ids:%s=%s", types.NetworkExternalID, ifInfo.NetName))
 		ovsArgs = append(ovsArgs, fmt.Sprintf("external_ids:%s=%s", types.NADExternalID, ifInfo.NADName))
+		if ifInfo.Role != "" {
+			ovsArgs = append(ovsArgs, fmt.Sprintf("external_ids:%s=%s", types.NetworkRoleExternalID, ifInfo.Role))
+		}
 	} else {
 		ovsArgs = append(ovsArgs, []string{"--", "--if-exists", "remove", "interface", hostIfaceName, "external_ids", types.NetworkExternalID}...)
 		ovsArgs = append(ovsArgs, []string{"--", "--if-exists", "remove", "interface", hostIfaceName, "external_ids", types.NADExternalID}...)
@@ -568,6 +742,9 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 		}
 	}
 
+	observePhase(ctx, phaseOVSPort, ifInfo.NetName, ovsPortStart)
+
+	ovnInstalledStart := time.Now()
 	if err := waitForPodInterface(ctx, ifInfo, hostIfaceName, ifaceID, getter,
 		namespace, podName, initialPodUID); err != nil {
 		// Ensure the error shows up in node logs, rather than just
@@ -575,12 +752,48 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 		klog.Warningf("[%s/%s %s] pod uid %s: %v", namespace, podName, sandboxID, initialPodUID, err)
 		return err
 	}
+	observePhase(ctx, phaseOVNInstalled, ifInfo.NetName, ovnInstalledStart)
+	return nil
+}
+
+// checkOVSPort verifies that the sandbox OVS port is owned by the pod and that
+// ovn-controller has bound its logical switch port
+func checkOVSPort(pr *PodRequest, ifInfo *PodInterfaceInfo) error {
+	ifaceID := util.GetIfaceId(pr.PodNamespace, pr.PodName)
+	condString := []string{"external-ids:sandbox=" + pr.SandboxID}
+	if ifInfo.NetName != types.DefaultNetworkName {
+		ifaceID = util.GetSecondaryNetworkIfaceId(pr.PodNamespace, pr.PodName, ifInfo.NADName)
+		condString = append(condString, fmt.Sprintf("external_ids:%s=%s", types.NADExternalID, ifInfo.NADName))
+	} else {
+		condString = append(condString, fmt.Sprintf("external_ids:%s{=}[]", types.NADExternalID))
+	}
+
+	ovsIfNames, err := ovsFind("Interface", "name", condString...)
+	if err != nil {
+		return fmt.Errorf("failed to find the OVS interface of sandbox %s: %v", pr.SandboxID, err)
+	}
+	if len(ovsIfNames) != 1 {
+		return fmt.Errorf("expected one OVS interface for sandbox %s NAD %s, found %d", pr.SandboxID, ifInfo.NADName, len(ovsIfNames))
+	}
+	ovsIfName := ovsIfNames[0]
+
+	output, err := ovsGetMultiOutput("Interface", ovsIfName, []string{"external-ids:iface-id", "external-ids:ovn-installed"})
+	if err != nil {
+		return fmt.Errorf("failed to get external IDs of OVS interface %s: %v", ovsIfName, err)
+	}
+	if output[0] != ifaceID {
+		return fmt.Errorf("OVS interface %s has iface-id %q, expected %q", ovsIfName, output[0], ifaceID)
+	}
+	if len(output) < 2 || output[1] != "true" {
+		return fmt.Errorf("logical switch port %s is not bound to OVS interface %s (ovn-installed is not set)", ifaceID, ovsIfName)
+	}
 	return nil
 }
 
 type PodRequestInterfaceOps interface {
 	ConfigureInterface(pr *PodRequest, getter PodInfoGetter, ifInfo *PodInterfaceInfo) ([]*current.Interface, error)
 	UnconfigureInterface(pr *PodRequest, ifInfo *PodInterfaceInfo) error
+	CheckInterface(pr *PodRequest, ifInfo *PodInterfaceInfo) error
 }
 
 type defaultPodRequestInterfaceOps struct{}
@@ -598,9 +811,10 @@ func (*defaultPodRequestInterfaceOps) ConfigureInterface(pr *PodRequest, getter
 	var hostIface, contIface *current.Interface
 
 	klog.V(5).Infof("CNI Conf %v", pr.CNIConf)
+	setupStart := time.Now()
 	if pr.CNIConf.DeviceID != "" {
 		// SR-IOV Case
-		hostIface, contIface, err = setupSriovInterface(netns, pr.SandboxID, pr.IfName, ifInfo, pr.CNIConf.DeviceID, pr.IsVFIO)
+		hostIface, contIface, err = setupSriovInterface(pr.ctx, netns, pr.SandboxID, pr.IfName, ifInfo, pr.CNIConf.DeviceID, pr.IsVFIO)
 	} else {
 		if ifInfo.IsDPUHostMode {
 			return nil, fmt.Errorf("unexpected configuration, pod request on dpu host. " +
@@ -608,11 +822,12 @@ func (*defaultPodRequestInterfaceOps) ConfigureInterface(pr *PodRequest, getter
 		}
 
 		// General case
-		hostIface, contIface, err = setupInterface(netns, pr.SandboxID, pr.IfName, ifInfo)
+		hostIface, contIface, err = setupInterface(pr.ctx, netns, pr.SandboxID, pr.IfName, ifInfo)
 	}
 	if err != nil {
 		return nil, err
 	}
+	observePhase(pr.ctx, phaseInterfaceSetup, ifInfo.NetName, setupStart, phaseRouteSetup)
 
 	// OCP HACK: block access to MCS/metadata; https://github.com/openshift/ovn-kubernetes/pull/19
 	var wg sync.WaitGroup
@@ -625,7 +840,7 @@ func (*defaultPodRequestInterfaceOps) ConfigureInterface(pr *PodRequest, getter
 	// END OCP HACK
 
 	if !ifInfo.IsDPUHostMode {
-		err = ConfigureOVS(pr.ctx, pr.PodNamespace, pr.PodName, hostIface.Name, ifInfo, pr.SandboxID, pr.CNIConf.DeviceID, getter)
+		err = ConfigureOVS(pr.ctx, pr.PodNamespace, pr.PodName, hostIface.Name, ifInfo, pr.SandboxID, pr.cniIfName(), pr.CNIConf.DeviceID, getter)
 		if err != nil {
 			pr.deletePort(hostIface.Name, pr.PodNamespace, pr.PodName)
 			return nil, err
@@ -650,6 +865,7 @@ func (*defaultPodRequestInterfaceOps) ConfigureInterface(pr *PodRequest, getter
 		}
 	}
 	if haveV6 && !pr.IsVFIO {
+		settleStart := time.Now()
 		err = netns.Do(func(_ ns.NetNS) error {
 			// deny IPv6 neighbor solicitations
 			dadSysctlIface := fmt.Sprintf("/proc/sys/net/ipv6/conf/%s/dad_transmits", contIface.Name)
@@ -673,11 +889,31 @@ func (*defaultPodRequestInterfaceOps) ConfigureInterface(pr *PodRequest, getter
 		if err != nil {
 			klog.Warningf("Failed to settle addresses: %q", err)
 		}
+		observePhase(pr.ctx, phaseAddressSettle, ifInfo.NetName, settleStart)
 	}
 
 	return []*current.Interface{hostIface, contIface}, nil
 }
 
+// CheckInterface verifies that the container interface, its OVS port and its
+// OVN binding are still as configured by ConfigureInterface
+func (*defaultPodRequestInterfaceOps) CheckInterface(pr *PodRequest, ifInfo *PodInterfaceInfo) error {
+	netns, err := ns.GetNS(pr.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %q: %v", pr.Netns, err)
+	}
+	defer netns.Close()
+
+	err = netns.Do(func(_ ns.NetNS) error {
+		return checkNetwork(pr.IfName, ifInfo)
+	})
+	if err != nil {
+		return err
+	}
+
+	return checkOVSPort(pr, ifInfo)
+}
+
 func (*defaultPodRequestInterfaceOps) UnconfigureInterface(pr *PodRequest, ifInfo *PodInterfaceInfo) error {
 	podDesc := fmt.Sprintf("for pod %s/%s NAD %s", pr.PodNamespace, pr.PodName, pr.nadName)
 	klog.V(5).Infof("Tear down interface (%+v) %s", *pr, podDesc)
@@ -824,6 +1060,48 @@ func (pr *PodRequest) deletePodConntrack() {
 	}
 }
 
+// deleteStaleSandbox tears down the OVS ports of a sandbox the runtime no
+// longer knows about, along with its bandwidth settings and conntrack entries.
+// Conntrack entries of IPs that were already given to another sandbox are
+// left alone.
+func deleteStaleSandbox(sandboxID string, ifaces []*vswitchd.Interface, inUseIPs sets.Set[string]) error {
+	var ports, representors []string
+	for _, iface := range ifaces {
+		if iface.ExternalIDs["vf-netdev-name"] != "" {
+			representors = append(representors, iface.Name)
+		} else {
+			ports = append(ports, iface.Name)
+		}
+	}
+	klog.Infof("Removing stale sandbox %s OVS ports: %s", sandboxID, strings.Join(append(ports, representors...), ","))
+
+	// The pod is gone so namespace and name are unknown, the port names are
+	// logged instead.
+	pr := &PodRequest{SandboxID: sandboxID, CNIConf: &ovncnitypes.NetConf{}}
+	pr.deletePorts(ports, "", sandboxID)
+	// the actual device ID is not recorded in OVS, any value makes deletePort
+	// leave the representor links on the host
+	pr.CNIConf.DeviceID = sandboxID
+	pr.deletePorts(representors, "", sandboxID)
+
+	if err := clearPodBandwidthForPorts(append(ports, representors...), sandboxID); err != nil {
+		return fmt.Errorf("failed to clear bandwidth of stale sandbox %s: %v", sandboxID, err)
+	}
+
+	for _, iface := range ifaces {
+		for _, ip := range podInterfaceIPs(iface) {
+			if inUseIPs.Has(ip) {
+				continue
+			}
+			err := util.DeleteConntrack(ip, 0, "", netlink.ConntrackReplyAnyIP, nil)
+			if err != nil {
+				klog.Errorf("Failed to delete Conntrack Entry for %s: %v", ip, err)
+			}
+		}
+	}
+	return nil
+}
+
 func (pr *PodRequest) deletePort(ifaceName, podNamespace, podName string) {
 	podDesc := fmt.Sprintf("%s/%s", podNamespace, podName)
 
@@ -441,7 +441,7 @@ func TestSetupNetwork(t *testing.T) {
 			ovntest.ProcessMockFnList(&mockLink.Mock, tc.linkMockHelper)
 			ovntest.ProcessMockFnList(&mockCNIPlugin.Mock, tc.cniPluginMockHelper)
 
-			err := setupNetwork(tc.inpLink, tc.inpPodIfaceInfo)
+			err := setupNetwork(context.Background(), tc.inpLink, tc.inpPodIfaceInfo)
 			t.Log(err)
 			if tc.errMatch != nil {
 				assert.Contains(t, err.Error(), tc.errMatch.Error())
@@ -455,6 +455,128 @@ func TestSetupNetwork(t *testing.T) {
 	}
 }
 
+func TestCheckNetwork(t *testing.T) {
+	mockNetLinkOps := new(util_mocks.NetLinkOps)
+	mockLink := new(netlink_mocks.Link)
+	// below sets the `netLinkOps` in util/net_linux.go to a mock instance for purpose of unit tests execution
+	util.SetNetLinkOpMockInst(mockNetLinkOps)
+
+	ifInfo := &PodInterfaceInfo{
+		PodAnnotation: util.PodAnnotation{
+			IPs:      ovntest.MustParseIPNets("192.168.0.5/24"),
+			MAC:      ovntest.MustParseMAC("0A:58:FD:98:00:01"),
+			Gateways: ovntest.MustParseIPs("192.168.0.1"),
+			Routes: []util.PodRoute{
+				{
+					Dest:    ovntest.MustParseIPNet("192.168.1.0/24"),
+					NextHop: net.ParseIP("192.168.1.1"),
+				},
+			},
+		},
+	}
+	upLinkAttrs := &netlink.LinkAttrs{Name: "eth0", Flags: net.FlagUp, HardwareAddr: ovntest.MustParseMAC("0A:58:FD:98:00:01")}
+	addrs := []netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.0.5/24")}}
+	routes := []netlink.Route{
+		{Gw: net.ParseIP("192.168.0.1")},
+		{Dst: ovntest.MustParseIPNet("192.168.1.0/24"), Gw: net.ParseIP("192.168.1.1")},
+	}
+
+	tests := []struct {
+		desc                 string
+		inpPodIfaceInfo      *PodInterfaceInfo
+		errMatch             error
+		netLinkOpsMockHelper []ovntest.TestifyMockHelper
+		linkMockHelper       []ovntest.TestifyMockHelper
+	}{
+		{
+			desc:            "test code path when the container interface is missing",
+			inpPodIfaceInfo: ifInfo,
+			errMatch:        fmt.Errorf("failed to lookup container interface eth0"),
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
+			},
+		},
+		{
+			desc:            "test code path when the container interface has another MAC",
+			inpPodIfaceInfo: ifInfo,
+			errMatch:        fmt.Errorf("container interface eth0 has MAC 0a:58:fd:98:00:02, expected 0a:58:fd:98:00:01"),
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+			},
+			linkMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{
+					&netlink.LinkAttrs{Name: "eth0", Flags: net.FlagUp, HardwareAddr: ovntest.MustParseMAC("0A:58:FD:98:00:02")}}, CallTimes: 2},
+			},
+		},
+		{
+			desc:            "test code path when the container interface is missing an IP",
+			inpPodIfaceInfo: ifInfo,
+			errMatch:        fmt.Errorf("container interface eth0 is missing IP address 192.168.0.5/24"),
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{}, nil}},
+			},
+			linkMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{upLinkAttrs}, CallTimes: 2},
+			},
+		},
+		{
+			desc:            "test code path when the container interface is missing a route",
+			inpPodIfaceInfo: ifInfo,
+			errMatch:        fmt.Errorf("container interface eth0 is missing route 192.168.1.0/24 via 192.168.1.1"),
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{addrs, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{routes[:1], nil}},
+			},
+			linkMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{upLinkAttrs}, CallTimes: 2},
+			},
+		},
+		{
+			desc:            "test success path",
+			inpPodIfaceInfo: ifInfo,
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{addrs, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{routes, nil}},
+			},
+			linkMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{upLinkAttrs}, CallTimes: 2},
+			},
+		},
+		{
+			desc: "test skip ip config",
+			inpPodIfaceInfo: &PodInterfaceInfo{
+				SkipIPConfig:  true,
+				PodAnnotation: ifInfo.PodAnnotation,
+			},
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+			},
+			linkMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{upLinkAttrs}, CallTimes: 2},
+			},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.netLinkOpsMockHelper)
+			ovntest.ProcessMockFnList(&mockLink.Mock, tc.linkMockHelper)
+
+			err := checkNetwork("eth0", tc.inpPodIfaceInfo)
+			t.Log(err)
+			if tc.errMatch != nil {
+				assert.Contains(t, err.Error(), tc.errMatch.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			mockNetLinkOps.AssertExpectations(t)
+			mockLink.AssertExpectations(t)
+		})
+	}
+}
+
 func TestSetupInterface(t *testing.T) {
 	mockNetLinkOps := new(util_mocks.NetLinkOps)
 	mockCNIPlugin := new(mocks.CNIPluginLibOps)
@@ -520,7 +642,7 @@ func TestSetupInterface(t *testing.T) {
 			ovntest.ProcessMockFnList(&mockCNIPlugin.Mock, tc.cniPluginMockHelper)
 			ovntest.ProcessMockFnList(&mockNS.Mock, tc.nsMockHelper)
 
-			hostIface, contIface, err := setupInterface(tc.inpNetNS, tc.inpContID, tc.inpIfaceName, tc.inpPodIfaceInfo)
+			hostIface, contIface, err := setupInterface(context.Background(), tc.inpNetNS, tc.inpContID, tc.inpIfaceName, tc.inpPodIfaceInfo)
 			t.Log(hostIface, contIface, err)
 			if tc.errExp {
 				require.Error(t, err)
@@ -1172,7 +1294,7 @@ func TestSetupSriovInterface(t *testing.T) {
 			runner = tc.runnerInstance
 
 			netNsDoError = nil
-			hostIface, contIface, err := setupSriovInterface(tc.inpNetNS, tc.inpContID, tc.inpIfaceName, tc.inpPodIfaceInfo, tc.inpPCIAddrs, false)
+			hostIface, contIface, err := setupSriovInterface(context.Background(), tc.inpNetNS, tc.inpContID, tc.inpIfaceName, tc.inpPodIfaceInfo, tc.inpPCIAddrs, false)
 			t.Log(hostIface, contIface, err)
 			if err == nil {
 				err = netNsDoError
@@ -1447,7 +1569,7 @@ func TestConfigureOVS(t *testing.T) {
 					"add-port br-int %s other_config:transient=true "+
 					"-- set interface %s external_ids:attached_mac=%s "+
 					"external_ids:iface-id=%s external_ids:iface-id-ver=%s "+
-					"external_ids:sandbox=%s ",
+					"external_ids:sandbox=%s external_ids:cni-ifname=eth0 ",
 				tc.vfRep, tc.vfRep, "", genIfaceID(tc.podNs, tc.podName), tc.ifInfo.PodUID, sandboxID)
 			if tc.pfEncapIp != "" {
 				ovsAddPortCmd += fmt.Sprintf("external_ids:encap-ip=%s ", tc.pfEncapIp)
@@ -1504,7 +1626,7 @@ func TestConfigureOVS(t *testing.T) {
 			fakeClient := fake.NewSimpleClientset(&corev1.PodList{Items: []corev1.Pod{pod}})
 			clientset := NewClientSet(fakeClient, &podLister)
 			err = ConfigureOVS(ctx, tc.podNs, tc.podName, tc.vfRep,
-				tc.ifInfo, sandboxID, vfPciAddress, clientset)
+				tc.ifInfo, sandboxID, "eth0", vfPciAddress, clientset)
 			if tc.errMatch != nil {
 				assert.Contains(t, err.Error(), tc.errMatch.Error())
 			} else {
@@ -1639,7 +1761,7 @@ func TestConfigureOVS_getPfEncapIpWithError(t *testing.T) {
 			var podLister v1mocks.PodLister
 			podLister.On("Pods", mock.AnythingOfType("string")).Return(&podNamespaceLister)
 			err = ConfigureOVS(ctx, tc.podNs, tc.podName, tc.vfRep,
-				tc.ifInfo, sandboxID, vfPciAddress, nil)
+				tc.ifInfo, sandboxID, "eth0", vfPciAddress, nil)
 			if tc.errMatch != nil {
 				assert.Contains(t, err.Error(), tc.errMatch.Error())
 			} else {
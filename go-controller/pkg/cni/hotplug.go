@@ -0,0 +1,111 @@
+package cni
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/libovsdb/client"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// PodSandbox identifies the sandbox of a running pod
+type PodSandbox struct {
+	// ID of the sandbox container
+	ID string
+	// Netns is the path of the sandbox network namespace
+	Netns string
+}
+
+// GetPodSandbox finds the sandbox of a running pod through the OVS port of
+// its default network interface
+func GetPodSandbox(pod *corev1.Pod) (*PodSandbox, error) {
+	ifaceID := util.GetIfaceId(pod.Namespace, pod.Name)
+	names, err := ovsFind("Interface", "name", "external-ids:iface-id="+ifaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the OVS interface with iface-id %s: %w", ifaceID, err)
+	}
+	if len(names) != 1 {
+		return nil, fmt.Errorf("found %d OVS interfaces with iface-id %s", len(names), ifaceID)
+	}
+	sandboxID, err := ovsGet("Interface", names[0], "external_ids", "sandbox")
+	if err != nil || sandboxID == "" {
+		return nil, fmt.Errorf("failed to get the sandbox of OVS interface %s: %v", names[0], err)
+	}
+	link, err := util.GetNetLinkOps().LinkByName(names[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup %s: %w", names[0], err)
+	}
+	netns, err := getNetNSPathByID(link.Attrs().NetNsID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the network namespace of the peer of %s: %w", names[0], err)
+	}
+	return &PodSandbox{ID: sandboxID, Netns: netns}, nil
+}
+
+// newHotplugPodRequest builds a request for the interface ifName of a running
+// pod on a NAD of a secondary network, as the container runtime would for the
+// pod sandbox
+func newHotplugPodRequest(cmd command, pod *corev1.Pod, sandbox *PodSandbox, ifName string, netInfo util.NetInfo, nadName string) *PodRequest {
+	req := &PodRequest{
+		Command:      cmd,
+		PodNamespace: pod.Namespace,
+		PodName:      pod.Name,
+		PodUID:       string(pod.UID),
+		SandboxID:    sandbox.ID,
+		Netns:        sandbox.Netns,
+		IfName:       ifName,
+		CNIConf: &ovncnitypes.NetConf{
+			NetConf:             cnitypes.NetConf{Name: netInfo.GetNetworkName()},
+			Topology:            netInfo.TopologyType(),
+			NADName:             nadName,
+			MTU:                 netInfo.MTU(),
+			PhysicalNetworkName: netInfo.PhysicalNetworkName(),
+		},
+		timestamp: time.Now(),
+		netName:   netInfo.GetNetworkName(),
+		nadName:   nadName,
+	}
+	// Match the Kubelet default CRI operation timeout of 2m
+	req.ctx, req.cancel = context.WithTimeout(context.Background(), 2*time.Minute)
+	req.ctx = withRequestTrace(req.ctx)
+	return req
+}
+
+// HotplugPodInterface plugs the interface ifName of a running pod to a NAD of
+// a secondary network, through the same path as a CNI ADD request, once the
+// pod is annotated for the NAD.
+func HotplugPodInterface(clientset *ClientSet, networkManager networkmanager.Interface, ovsClient client.Client,
+	pod *corev1.Pod, sandbox *PodSandbox, ifName string, netInfo util.NetInfo, nadName string) (*current.Result, error) {
+	req := newHotplugPodRequest(CNIAdd, pod, sandbox, ifName, netInfo, nadName)
+	defer req.cancel()
+	klog.Infof("%s hot-plugging interface %s", req, ifName)
+	response, err := req.cmdAdd(nil, clientset, networkManager, ovsClient)
+	logRequestTrace(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed to hot-plug interface %s: %w", req, ifName, err)
+	}
+	return response.Result, nil
+}
+
+// HotUnplugPodInterface unplugs the interface ifName of a running pod from a
+// NAD of a secondary network, through the same path as a CNI DEL request.
+func HotUnplugPodInterface(clientset *ClientSet, pod *corev1.Pod, sandbox *PodSandbox, ifName string,
+	netInfo util.NetInfo, nadName string) error {
+	req := newHotplugPodRequest(CNIDel, pod, sandbox, ifName, netInfo, nadName)
+	defer req.cancel()
+	klog.Infof("%s hot-unplugging interface %s", req, ifName)
+	if _, err := req.cmdDel(clientset); err != nil {
+		return fmt.Errorf("%s failed to hot-unplug interface %s: %w", req, ifName, err)
+	}
+	return nil
+}
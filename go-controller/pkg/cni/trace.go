@@ -0,0 +1,100 @@
+package cni
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+)
+
+// cniPhase is a step of a CNI ADD request whose latency is tracked
+type cniPhase string
+
+const (
+	// phasePodAnnotation is the wait for the controller to annotate the pod
+	phasePodAnnotation cniPhase = "pod_annotation"
+	// phaseInterfaceSetup is the creation of the pod interface and its
+	// addresses, without its routes
+	phaseInterfaceSetup cniPhase = "interface_setup"
+	// phaseRouteSetup is the creation of the default and other routes of
+	// the pod interface
+	phaseRouteSetup cniPhase = "route_setup"
+	// phaseOVSPort is the creation of the OVS port and its bandwidth settings
+	phaseOVSPort cniPhase = "ovs_port"
+	// phaseOVNInstalled is the wait for ovn-controller to set ovn-installed
+	// on the OVS port
+	phaseOVNInstalled cniPhase = "ovn_installed"
+	// phaseAddressSettle is the wait for the IPv6 addresses of the pod to be
+	// usable
+	phaseAddressSettle cniPhase = "address_settle"
+)
+
+type requestTraceKey struct{}
+
+// requestTrace collects the phases of a CNI request. Phases nested in other
+// phases are excluded from the duration of the enclosing phase.
+type requestTrace struct {
+	sync.Mutex
+	spans []traceSpan
+}
+
+type traceSpan struct {
+	phase    cniPhase
+	network  string
+	start    time.Time
+	duration time.Duration
+}
+
+// withRequestTrace returns a context carrying a new trace of the request
+func withRequestTrace(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestTraceKey{}, &requestTrace{})
+}
+
+// observePhase records the time spent in phase for network since start, in
+// the per phase histogram and in the trace of the request if any. The time
+// spent since start in the given nested phases of the same network, which are
+// observed on their own, is not accounted to phase.
+func observePhase(ctx context.Context, phase cniPhase, network string, start time.Time, nested ...cniPhase) {
+	duration := time.Since(start)
+	trace, ok := ctx.Value(requestTraceKey{}).(*requestTrace)
+	if ok {
+		trace.Lock()
+		for _, span := range trace.spans {
+			if span.network == network && !span.start.Before(start) && slices.Contains(nested, span.phase) {
+				duration -= span.duration
+			}
+		}
+		trace.spans = append(trace.spans, traceSpan{phase: phase, network: network, start: start, duration: duration})
+		trace.Unlock()
+	}
+	metrics.MetricCNIRequestPhaseDuration.WithLabelValues(string(phase), network).Observe(duration.Seconds())
+}
+
+// logRequestTrace logs the phases traced for the request if request tracing
+// is enabled, keyed by the pod UID so that they can be correlated with the
+// controller that annotated the pod
+func logRequestTrace(pr *PodRequest) {
+	if !config.CNI.EnableRequestTracing {
+		return
+	}
+	trace, ok := pr.ctx.Value(requestTraceKey{}).(*requestTrace)
+	if !ok {
+		return
+	}
+	trace.Lock()
+	defer trace.Unlock()
+	spans := make([]string, 0, len(trace.spans))
+	for _, span := range trace.spans {
+		spans = append(spans, fmt.Sprintf("%s[%s]:+%v/%v",
+			span.phase, span.network, span.start.Sub(pr.timestamp), span.duration))
+	}
+	klog.Infof("%s %s CNI request trace: pod uid %q, total %v, phases %s",
+		pr, pr.Command, pr.PodUID, time.Since(pr.timestamp), strings.Join(spans, " "))
+}
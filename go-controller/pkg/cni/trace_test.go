@@ -0,0 +1,47 @@
+package cni
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+func TestObservePhase(t *testing.T) {
+	if err := config.PrepareTestConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := withRequestTrace(context.Background())
+	observePhase(ctx, phasePodAnnotation, "default", time.Now())
+	observePhase(ctx, phaseOVNInstalled, "tenantblue", time.Now())
+	trace, ok := ctx.Value(requestTraceKey{}).(*requestTrace)
+	if !ok {
+		t.Fatalf("expected a request trace")
+	}
+	if len(trace.spans) != 2 || trace.spans[0].phase != phasePodAnnotation ||
+		trace.spans[1].phase != phaseOVNInstalled || trace.spans[1].network != "tenantblue" {
+		t.Errorf("unexpected request trace spans %+v", trace.spans)
+	}
+}
+
+func TestObservePhaseExcludesNestedPhases(t *testing.T) {
+	if err := config.PrepareTestConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := withRequestTrace(context.Background())
+	// a nested phase of another network is not excluded
+	observePhase(ctx, phaseRouteSetup, "tenantblue", time.Now().Add(-time.Hour))
+	observePhase(ctx, phaseRouteSetup, "default", time.Now().Add(-time.Hour))
+	observePhase(ctx, phaseInterfaceSetup, "default", time.Now().Add(-2*time.Hour), phaseRouteSetup)
+	trace := ctx.Value(requestTraceKey{}).(*requestTrace)
+	if len(trace.spans) != 3 {
+		t.Fatalf("unexpected request trace spans %+v", trace.spans)
+	}
+	// interface setup took 2 hours, one of them spent in the route setup
+	if duration := trace.spans[2].duration; duration < 59*time.Minute || duration > 61*time.Minute {
+		t.Errorf("expected %s to exclude %s, got %v", phaseInterfaceSetup, phaseRouteSetup, duration)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	current "github.com/containernetworking/cni/pkg/types/100"
 	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
@@ -77,6 +79,12 @@ const CNIDel command = "DEL"
 // CNICheck is the command representing check operation on a pod
 const CNICheck command = "CHECK"
 
+// CNIGC is the command representing garbage collection of stale pod sandboxes
+const CNIGC command = "GC"
+
+// CNIStatus is the command representing a check of the plugin readiness
+const CNIStatus command = "STATUS"
+
 // Request sent to the Server by the OVN CNI plugin
 type Request struct {
 	// CNI environment variables, like CNI_COMMAND and CNI_NETNS
@@ -87,6 +95,22 @@ type Request struct {
 	nadapi.DeviceInfo
 }
 
+// gcAttachment is a pod sandbox attachment the runtime still knows about,
+// as passed in a GC request
+type gcAttachment struct {
+	ContainerID string `json:"containerID"`
+	IfName      string `json:"ifname"`
+}
+
+// gcConfig holds the fields of the CNI configuration specific to a GC request
+type gcConfig struct {
+	ValidAttachments []gcAttachment `json:"cni.dev/valid-attachments,omitempty"`
+}
+
+// cniIfNameExternalID is the pod OVS interface external ID recording the
+// interface name of the CNI attachment the OVS interface was plugged for
+const cniIfNameExternalID = "cni-ifname"
+
 // CNIRequestMetrics info to report from CNI shim to CNI server
 type CNIRequestMetrics struct {
 	Command     command `json:"command"`
@@ -147,6 +171,9 @@ type PodRequest struct {
 	Netns string
 	// Interface name to be configured
 	IfName string
+	// Interface name of the CNI attachment when it differs from IfName, as
+	// for the primary UDN interface set up along the default network one
+	attachmentIfName string
 	// CNI conf obtained from stdin conf
 	CNIConf *types.NetConf
 	// Timestamp when the request was started
@@ -171,6 +198,7 @@ type PodRequest struct {
 }
 
 type podRequestFunc func(request *PodRequest, clientset *ClientSet, kubeAuth *KubeAPIAuth, networkManager networkmanager.Interface, ovsClient client.Client) ([]byte, error)
+type gcRequestFunc func(nadName string, validAttachments sets.Set[gcAttachment], ovsClient client.Client) error
 type getCNIResultFunc func(request *PodRequest, getter PodInfoGetter, podInterfaceInfo *PodInterfaceInfo) (*current.Result, error)
 
 type PodInfoGetter interface {
@@ -195,8 +223,12 @@ func NewClientSet(kclient kubernetes.Interface, podLister corev1listers.PodListe
 type Server struct {
 	http.Server
 	handlePodRequestFunc podRequestFunc
+	handleGCRequestFunc  gcRequestFunc
 	clientSet            *ClientSet
 	kubeAuth             *KubeAPIAuth
 	networkManager       networkmanager.Interface
 	ovsClient            client.Client
+	// ready is set once ovnkube-node has finished starting up; until then
+	// STATUS requests report the plugin as not available
+	ready atomic.Bool
 }
@@ -73,6 +73,9 @@ type NetConf struct {
 	// LogFileMaxAge represents the maximum number
 	// of days to retain old log files
 	LogFileMaxAge int `json:"logfile-maxage"`
+	// EnableCheck is set on the default network configuration when
+	// ovnkube-node verifies the pod networking on CNI CHECK
+	EnableCheck bool `json:"enableCheck,omitempty"`
 	// Runtime arguments passed by the NPWG implementation (e.g. multus)
 	RuntimeConfig struct {
 		// see https://github.com/k8snetworkplumbingwg/device-info-spec
@@ -10,6 +10,10 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
+// PrimaryNetworkInterfaceName is the name of the pod interface attached to
+// the primary UDN
+const PrimaryNetworkInterfaceName = "ovn-udn1"
+
 // wait on a certain pod annotation related condition
 type podAnnotWaitCond = func(map[string]string, string) (*util.PodAnnotation, bool)
 
@@ -26,7 +30,7 @@ func NewPrimaryNetwork(networkManager networkmanager.Interface) *UserDefinedPrim
 }
 
 func (p *UserDefinedPrimaryNetwork) InterfaceName() string {
-	return "ovn-udn1"
+	return PrimaryNetworkInterfaceName
 }
 
 func (p *UserDefinedPrimaryNetwork) NetworkDevice() string {
@@ -108,13 +108,18 @@ func PodAnnotation2PodInfo(podAnnotation map[string]string, podNADAnnotation *ut
 			return nil, err
 		}
 	}
-	ingress, err := extractPodBandwidth(podAnnotation, Ingress)
-	if err != nil && !errors.Is(err, BandwidthNotFound) {
-		return nil, err
-	}
-	egress, err := extractPodBandwidth(podAnnotation, Egress)
-	if err != nil && !errors.Is(err, BandwidthNotFound) {
-		return nil, err
+	// with pod bandwidth QoS, ovnkube-controller limits the bandwidth on the
+	// logical switch port so the OVS interface must not be policed as well
+	var ingress, egress int64
+	if !config.OVNKubernetesFeature.EnablePodBandwidthQoS {
+		ingress, err = extractPodBandwidth(podAnnotation, Ingress)
+		if err != nil && !errors.Is(err, BandwidthNotFound) {
+			return nil, err
+		}
+		egress, err = extractPodBandwidth(podAnnotation, Egress)
+		if err != nil && !errors.Is(err, BandwidthNotFound) {
+			return nil, err
+		}
 	}
 
 	podInterfaceInfo := &PodInterfaceInfo{
@@ -12,10 +12,15 @@ import (
 	"github.com/containernetworking/cni/pkg/types"
 	"github.com/containernetworking/cni/pkg/version"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
+// cniConfVersions are the versions the CNI config file can be written with
+var cniConfVersions = sets.New("0.4.0", "1.0.0", "1.1.0")
+
 var ErrorAttachDefNotOvnManaged = errors.New("net-attach-def not managed by OVN")
 var ErrorChainingNotSupported = errors.New("CNI plugin chaining is not supported")
 
@@ -25,7 +30,7 @@ var ErrorChainingNotSupported = errors.New("CNI plugin chaining is not supported
 func WriteCNIConfig() error {
 	netConf := &ovncnitypes.NetConf{
 		NetConf: types.NetConf{
-			CNIVersion: "0.4.0",
+			CNIVersion: CNI.ConfVersion,
 			Name:       "ovn-kubernetes",
 			Type:       CNI.Plugin,
 		},
@@ -34,6 +39,7 @@ func WriteCNIConfig() error {
 		LogFileMaxSize:    Logging.LogFileMaxSize,
 		LogFileMaxBackups: Logging.LogFileMaxBackups,
 		LogFileMaxAge:     Logging.LogFileMaxAge,
+		EnableCheck:       CNI.EnableCheck,
 	}
 
 	newBytes, err := json.Marshal(netConf)
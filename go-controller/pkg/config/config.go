@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	kexec "k8s.io/utils/exec"
@@ -87,6 +88,8 @@ var (
 		LogFileMaxBackups:   5,
 		LogFileMaxAge:       5, //days
 		ACLLoggingRateLimit: 20,
+
+		ACLAuditExportRateLimit: 20,
 	}
 
 	// Monitoring holds monitoring-related parsed config file parameters and command-line overrides
@@ -106,8 +109,9 @@ var (
 
 	// CNI holds CNI-related parsed config file parameters and command-line overrides
 	CNI = CNIConfig{
-		ConfDir: "/etc/cni/net.d",
-		Plugin:  "ovn-k8s-cni-overlay",
+		ConfDir:     "/etc/cni/net.d",
+		Plugin:      "ovn-k8s-cni-overlay",
+		ConfVersion: "0.4.0",
 	}
 
 	// Kubernetes holds Kubernetes-related parsed config file parameters and command-line overrides
@@ -314,6 +318,12 @@ type LoggingConfig struct {
 	LogFileMaxAge int `gcfg:"logfile-maxage"`
 	// Logging rate-limiting meter
 	ACLLoggingRateLimit int `gcfg:"acl-logging-rate-limit"`
+	// ACLAuditExportDir is the directory ovnkube-node writes the per namespace ACL audit logs to
+	ACLAuditExportDir string `gcfg:"acl-audit-export-dir"`
+	// ACLAuditExportSyslogAddress is the syslog server ovnkube-node forwards the ACL audit logs to
+	ACLAuditExportSyslogAddress string `gcfg:"acl-audit-export-syslog-address"`
+	// ACLAuditExportRateLimit is the default number of ACL audit log entries exported per second for a namespace
+	ACLAuditExportRateLimit int `gcfg:"acl-audit-export-rate-limit"`
 }
 
 // MonitoringConfig holds monitoring-related parsed config file parameters and command-line overrides
@@ -355,6 +365,15 @@ type CNIConfig struct {
 	ConfDir string `gcfg:"conf-dir"`
 	// Plugin specifies the name of the CNI plugin
 	Plugin string `gcfg:"plugin"`
+	// EnableCheck makes CNI CHECK verify the pod interface, its OVS port and
+	// its OVN binding against the pod annotation instead of being a no-op
+	EnableCheck bool `gcfg:"enable-check"`
+	// EnableRequestTracing logs the per phase timings of every CNI ADD
+	// request keyed by the pod UID
+	EnableRequestTracing bool `gcfg:"enable-request-tracing"`
+	// ConfVersion is the cniVersion of the CNI config file. Container runtimes
+	// only send GC and STATUS requests with 1.1.0, which requires libcni v1.2.0
+	ConfVersion string `gcfg:"conf-version"`
 }
 
 // KubernetesConfig holds Kubernetes-related parsed config file parameters and command-line overrides
@@ -404,6 +423,9 @@ type MetricsConfig struct {
 	// configuration duration and optionally, its application to all nodes
 	EnableConfigDuration bool `gcfg:"enable-config-duration"`
 	EnableScaleMetrics   bool `gcfg:"enable-scale-metrics"`
+	// EnablePolicyRuleHitMetrics holds the boolean flag to enable exporting per rule new connection hit
+	// counters of network policies and admin network policies, built from the flows of sampled ACLs
+	EnablePolicyRuleHitMetrics bool `gcfg:"enable-policy-rule-hit-metrics"`
 }
 
 // OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
@@ -421,12 +443,31 @@ type OVNKubernetesFeatureConfig struct {
 	EnableMultiNetwork              bool `gcfg:"enable-multi-network"`
 	EnableNetworkSegmentation       bool `gcfg:"enable-network-segmentation"`
 	EnableRouteAdvertisements       bool `gcfg:"enable-route-advertisements"`
+	// Layer2 primary network gateway routers load balance egress traffic
+	// across the gateway routers of all nodes, using BFD to detect failures
+	EnableLayer2GatewayECMP bool `gcfg:"enable-layer2-gateway-ecmp"`
+	// Program the pod bandwidth annotations as OVN QoS rules on the pod's
+	// logical switch port instead of policing the OVS interface
+	EnablePodBandwidthQoS bool `gcfg:"enable-pod-bandwidth-qos"`
+	// Honor the IPs and MAC requested in the default network selection
+	// element of pods for their primary user defined network
+	EnablePreconfiguredUDNAddresses bool `gcfg:"enable-preconfigured-udn-addresses"`
+	// Plug and unplug secondary network interfaces of running pods when
+	// their k8s.v1.cni.cncf.io/networks annotation changes
+	EnablePodInterfaceHotplug bool `gcfg:"enable-pod-interface-hotplug"`
+	// Implement identical network policy gress rules of different policies with
+	// shared port groups and ACLs, instead of per-policy ACLs
+	EnableNetworkPolicyRuleSharing bool `gcfg:"enable-network-policy-rule-sharing"`
+	// Enforce the network policies selecting host network pods with nftables
+	// rules on the nodes labeled with k8s.ovn.org/host-network-policy=true
+	EnableHostNetworkPolicy bool `gcfg:"enable-host-network-policy"`
 	// This feature requires a kernel fix https://github.com/torvalds/linux/commit/7f3287db654395f9c5ddd246325ff7889f550286
 	// to work on a kind cluster. Flag allows to disable it for current CI, will be turned on when github runners have this fix.
 	DisableUDNHostIsolation      bool `gcfg:"disable-udn-host-isolation"`
 	EnableMultiNetworkPolicy     bool `gcfg:"enable-multi-networkpolicy"`
 	EnableStatelessNetPol        bool `gcfg:"enable-stateless-netpol"`
 	EnableInterconnect           bool `gcfg:"enable-interconnect"`
+	EnablePeerClusters           bool `gcfg:"enable-peer-clusters"`
 	EnableMultiExternalGateway   bool `gcfg:"enable-multi-external-gateway"`
 	EnablePersistentIPs          bool `gcfg:"enable-persistent-ips"`
 	EnableDNSNameResolver        bool `gcfg:"enable-dns-name-resolver"`
@@ -946,6 +987,25 @@ var CommonFlags = []cli.Flag{
 		Destination: &cliConfig.Logging.ACLLoggingRateLimit,
 		Value:       20,
 	},
+	&cli.StringFlag{
+		Name: "acl-audit-export-dir",
+		Usage: "Directory ovnkube-node writes the ACL audit logs of the namespaces with ACL logging enabled to, " +
+			"as JSON lines in one file per namespace. Requires enable-observability and enable-interconnect",
+		Destination: &cliConfig.Logging.ACLAuditExportDir,
+	},
+	&cli.StringFlag{
+		Name: "acl-audit-export-syslog-address",
+		Usage: "Syslog server ovnkube-node forwards the ACL audit logs of the namespaces with ACL logging enabled to, " +
+			"in the network:address format, e.g. udp:127.0.0.1:514 or unix:/dev/log. Requires enable-observability and enable-interconnect",
+		Destination: &cliConfig.Logging.ACLAuditExportSyslogAddress,
+	},
+	&cli.IntFlag{
+		Name: "acl-audit-export-rate-limit",
+		Usage: "The largest number of ACL audit log entries per second exported for a namespace, " +
+			"unless set by its k8s.ovn.org/acl-logging annotation (default 20)",
+		Destination: &cliConfig.Logging.ACLAuditExportRateLimit,
+		Value:       Logging.ACLAuditExportRateLimit,
+	},
 	&cli.StringFlag{
 		Name:        "zone",
 		Usage:       "zone name to which ovnkube-node/ovnkube-controller belongs to",
@@ -1024,6 +1084,25 @@ var CNIFlags = []cli.Flag{
 		Destination: &cliConfig.CNI.Plugin,
 		Value:       CNI.Plugin,
 	},
+	&cli.BoolFlag{
+		Name:        "cni-enable-check",
+		Usage:       "verify the pod interface, its OVS port and its OVN binding on CNI CHECK requests instead of ignoring them",
+		Destination: &cliConfig.CNI.EnableCheck,
+		Value:       CNI.EnableCheck,
+	},
+	&cli.BoolFlag{
+		Name:        "cni-enable-request-tracing",
+		Usage:       "log the time spent in each phase of a CNI ADD request, keyed by the pod UID",
+		Destination: &cliConfig.CNI.EnableRequestTracing,
+		Value:       CNI.EnableRequestTracing,
+	},
+	&cli.StringFlag{
+		Name: "cni-conf-version",
+		Usage: "the cniVersion of the CNI config file: 0.4.0, 1.0.0 or 1.1.0. The container runtime only sends " +
+			"GC and STATUS requests with 1.1.0, which requires a runtime built with libcni v1.2.0 or newer",
+		Destination: &cliConfig.CNI.ConfVersion,
+		Value:       CNI.ConfVersion,
+	},
 }
 
 // OVNK8sFeatureFlags capture OVN-Kubernetes feature related options
@@ -1093,6 +1172,49 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableRouteAdvertisements,
 		Value:       OVNKubernetesFeature.EnableRouteAdvertisements,
 	},
+	&cli.BoolFlag{
+		Name: "enable-layer2-gateway-ecmp",
+		Usage: "Configure the gateway routers of layer2 primary user defined networks to egress through the " +
+			"gateway routers of the other nodes when their uplink fails, using ECMP routes monitored with BFD.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableLayer2GatewayECMP,
+		Value:       OVNKubernetesFeature.EnableLayer2GatewayECMP,
+	},
+	&cli.BoolFlag{
+		Name: "enable-pod-bandwidth-qos",
+		Usage: "Configure ovnkube-controller to enforce the kubernetes.io/ingress-bandwidth and " +
+			"kubernetes.io/egress-bandwidth pod annotations with OVN QoS rules on the pod's logical switch port, " +
+			"on the default and user defined networks, instead of OVS interface policing in the CNI.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnablePodBandwidthQoS,
+		Value:       OVNKubernetesFeature.EnablePodBandwidthQoS,
+	},
+	&cli.BoolFlag{
+		Name: "enable-preconfigured-udn-addresses",
+		Usage: "Configure to honor the ips and mac requested in the v1.multus-cni.io/default-network " +
+			"annotation of pods on their primary user defined network, instead of the default network.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnablePreconfiguredUDNAddresses,
+		Value:       OVNKubernetesFeature.EnablePreconfiguredUDNAddresses,
+	},
+	&cli.BoolFlag{
+		Name: "enable-pod-interface-hotplug",
+		Usage: "Configure to plug and unplug the secondary network interfaces of running pods when " +
+			"their k8s.v1.cni.cncf.io/networks annotation changes. Requires multi-network to be enabled.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnablePodInterfaceHotplug,
+		Value:       OVNKubernetesFeature.EnablePodInterfaceHotplug,
+	},
+	&cli.BoolFlag{
+		Name: "enable-network-policy-rule-sharing",
+		Usage: "Configure to implement identical ingress or egress rules of different network policies " +
+			"with shared port groups and ACLs, to reduce the number of logical flows on large clusters.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableNetworkPolicyRuleSharing,
+		Value:       OVNKubernetesFeature.EnableNetworkPolicyRuleSharing,
+	},
+	&cli.BoolFlag{
+		Name: "enable-host-network-policy",
+		Usage: "Configure to enforce the network policies selecting host network pods with nftables rules " +
+			"on the nodes labeled with k8s.ovn.org/host-network-policy=true.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableHostNetworkPolicy,
+		Value:       OVNKubernetesFeature.EnableHostNetworkPolicy,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-stateless-netpol",
 		Usage:       "Configure to use stateless network policy feature with ovn-kubernetes.",
@@ -1105,6 +1227,12 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableInterconnect,
 		Value:       OVNKubernetesFeature.EnableInterconnect,
 	},
+	&cli.BoolFlag{
+		Name:        "enable-peer-clusters",
+		Usage:       "Configure to use PeerCluster CRD feature to connect pods with the pods of remote interconnected clusters.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnablePeerClusters,
+		Value:       OVNKubernetesFeature.EnablePeerClusters,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-egress-service",
 		Usage:       "Configure to use EgressService CRD feature with ovn-kubernetes.",
@@ -1310,6 +1438,11 @@ var MetricsFlags = []cli.Flag{
 		Usage:       "Enables metrics related to scaling",
 		Destination: &cliConfig.Metrics.EnableScaleMetrics,
 	},
+	&cli.BoolFlag{
+		Name:        "metrics-enable-policy-rule-hit-metrics",
+		Usage:       "Enables per rule new connection hit counters of network policies and admin network policies, requires observability to be enabled",
+		Destination: &cliConfig.Metrics.EnablePolicyRuleHitMetrics,
+	},
 }
 
 // OvnNBFlags capture OVN northbound database options
@@ -2309,6 +2442,10 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 	if err = overrideFields(&CNI, &cliConfig.CNI, &savedCNI); err != nil {
 		return "", err
 	}
+	if !cniConfVersions.Has(CNI.ConfVersion) {
+		return "", fmt.Errorf("unsupported CNI config version %q, supported versions are %v",
+			CNI.ConfVersion, sets.List(cniConfVersions))
+	}
 
 	// Logging setup
 	if err = overrideFields(&Logging, &cfg.Logging, &savedLogging); err != nil {
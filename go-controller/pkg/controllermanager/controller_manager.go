@@ -19,6 +19,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
@@ -63,6 +64,11 @@ type ControllerManager struct {
 
 	// eIPController programs OVN to support EgressIP
 	eIPController *ovn.EgressIPController
+
+	// ovsClient is the client of the local OVS database, used to report the
+	// status of the tunnels towards peer cluster nodes. It is nil if the OVS
+	// database is not available.
+	ovsClient libovsdbclient.Client
 }
 
 func (cm *ControllerManager) NewNetworkController(nInfo util.NetInfo) (networkmanager.NetworkController, error) {
@@ -210,6 +216,7 @@ func NewControllerManager(ovnClient *util.OVNClientset, wf *factory.WatchFactory
 			APBRouteClient:       ovnClient.AdminPolicyRouteClient,
 			EgressQoSClient:      ovnClient.EgressQoSClient,
 			IPAMClaimsClient:     ovnClient.IPAMClaimsClient,
+			PeerClusterClient:    ovnClient.PeerClusterClient,
 		},
 		stopChan:         stopCh,
 		watchFactory:     wf,
@@ -238,6 +245,17 @@ func NewControllerManager(ovnClient *util.OVNClientset, wf *factory.WatchFactory
 		cm.routeImportManager = routeimport.New(config.Default.Zone, cm.nbClient)
 	}
 
+	if util.IsPeerClustersEnabled() {
+		// the OVS database is only available when the zone controller runs
+		// on the node of a single node zone
+		cm.ovsClient, err = libovsdb.NewOVSClient(stopCh)
+		if err != nil {
+			klog.Warningf("Failed to connect to the OVS database, the reachability of peer cluster nodes "+
+				"will not be reported: %v", err)
+			cm.ovsClient = nil
+		}
+	}
+
 	return cm, nil
 }
 
@@ -317,7 +335,8 @@ func (cm *ControllerManager) initDefaultNetworkController(observManager *observa
 	if err != nil {
 		return fmt.Errorf("failed to create common network controller info: %w", err)
 	}
-	defaultController, err := ovn.NewDefaultNetworkController(cnci, observManager, cm.networkManager.Interface(), cm.routeImportManager, cm.eIPController, cm.portCache)
+	defaultController, err := ovn.NewDefaultNetworkController(cnci, observManager, cm.networkManager.Interface(), cm.routeImportManager, cm.eIPController,
+		cm.portCache, cm.ovsClient)
 	if err != nil {
 		return err
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iprulemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/mtumanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/vrfmanager"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -50,6 +51,8 @@ type NodeControllerManager struct {
 	ruleManager *iprulemanager.Controller
 	// ovs client that allows to read ovs info
 	ovsClient client.Client
+	// mtu manager that rolls out network MTU changes to existing pods
+	mtuManager *mtumanager.Controller
 }
 
 // NewNetworkController create secondary node network controllers for the given NetInfo
@@ -60,7 +63,7 @@ func (ncm *NodeControllerManager) NewNetworkController(nInfo util.NetInfo) (netw
 		// Pass a shallow clone of the watch factory, this allows multiplexing
 		// informers for secondary networks.
 		return node.NewSecondaryNodeNetworkController(ncm.newCommonNetworkControllerInfo(ncm.watchFactory.(*factory.WatchFactory).ShallowClone()),
-			nInfo, ncm.vrfManager, ncm.ruleManager, ncm.defaultNodeNetworkController.Gateway)
+			nInfo, ncm.vrfManager, ncm.ruleManager, ncm.mtuManager, ncm.defaultNodeNetworkController.Gateway)
 	}
 	return nil, fmt.Errorf("topology type %s not supported", topoType)
 }
@@ -94,7 +97,8 @@ func (ncm *NodeControllerManager) newCommonNetworkControllerInfo(wf factory.Node
 // (1) dpu mode is enabled when secondary networks feature is enabled
 // (2) primary user defined networks is enabled (all modes)
 func isNetworkManagerRequiredForNode() bool {
-	return (config.OVNKubernetesFeature.EnableMultiNetwork && config.OvnKubeNode.Mode == ovntypes.NodeModeDPU) ||
+	return (config.OVNKubernetesFeature.EnableMultiNetwork &&
+		(config.OvnKubeNode.Mode == ovntypes.NodeModeDPU || config.OVNKubernetesFeature.EnablePodInterfaceHotplug)) ||
 		util.IsNetworkSegmentationSupportEnabled() ||
 		util.IsRouteAdvertisementsEnabled()
 }
@@ -117,6 +121,7 @@ func NewNodeControllerManager(ovnClient *util.OVNClientset, wf factory.NodeWatch
 	// need to configure OVS interfaces for Pods on secondary networks in the DPU mode
 	// need to start NAD controller on node side for programming gateway pieces for UDNs
 	// need to start NAD controller on node side for VRF awareness with BGP
+	// need to start NAD controller on node side to hot-plug pod interfaces
 	var err error
 	ncm.networkManager = networkmanager.Default()
 	if isNetworkManagerRequiredForNode() {
@@ -129,6 +134,9 @@ func NewNodeControllerManager(ovnClient *util.OVNClientset, wf factory.NodeWatch
 		ncm.vrfManager = vrfmanager.NewController(ncm.routeManager)
 		ncm.ruleManager = iprulemanager.NewController(config.IPv4Mode, config.IPv6Mode)
 	}
+	if config.OvnKubeNode.Mode == ovntypes.NodeModeFull && ovsClient != nil {
+		ncm.mtuManager = mtumanager.NewController(name, ncm.Kube, ovsClient)
+	}
 	return ncm, nil
 }
 
@@ -185,6 +193,16 @@ func (ncm *NodeControllerManager) Start(ctx context.Context) (err error) {
 		ncm.routeManager.Run(ncm.stopChan, 2*time.Minute)
 	}()
 
+	// The default network MTU might have changed since the management port was
+	// configured, in which case the change needs to be rolled out to existing
+	// pods as well.
+	oldDefaultNetworkMTU := getManagementPortMTU()
+	if ncm.mtuManager != nil {
+		if err := ncm.mtuManager.StartUpdate(ovntypes.DefaultNetworkName, oldDefaultNetworkMTU, config.Default.MTU); err != nil {
+			klog.Errorf("Failed to start MTU update for the default network: %v", err)
+		}
+	}
+
 	err = ncm.initDefaultNodeNetworkController(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to init default node network controller: %v", err)
@@ -202,6 +220,12 @@ func (ncm *NodeControllerManager) Start(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to start default node network controller: %v", err)
 	}
 
+	if ncm.mtuManager != nil {
+		if err := ncm.mtuManager.FinishUpdate(ovntypes.DefaultNetworkName, oldDefaultNetworkMTU, config.Default.MTU); err != nil {
+			klog.Errorf("Failed to finish MTU update for the default network: %v", err)
+		}
+	}
+
 	if ncm.vrfManager != nil {
 		// Let's create VRF manager that will manage VRFs for all UDNs
 		err = ncm.vrfManager.Run(ncm.stopChan, ncm.wg)
@@ -328,6 +352,21 @@ func (ncm *NodeControllerManager) checkForStaleOVSRepresentorInterfaces() {
 	}
 }
 
+// getManagementPortMTU returns the MTU the default network management port
+// is configured with, which is the MTU the default network had the last time
+// it was configured on this node. Returns the currently configured MTU if the
+// management port does not exist yet.
+func getManagementPortMTU() int {
+	link, err := util.GetNetLinkOps().LinkByName(ovntypes.K8sMgmtIntfName)
+	if err != nil {
+		if !util.GetNetLinkOps().IsLinkNotFoundError(err) {
+			klog.Warningf("Failed to get management port %s: %v", ovntypes.K8sMgmtIntfName, err)
+		}
+		return config.Default.MTU
+	}
+	return link.Attrs().MTU
+}
+
 // checkForStaleOVSInternalPorts checks for OVS internal ports without any ofport assigned,
 // they are stale ports that must be deleted
 func checkForStaleOVSInternalPorts() {
@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PeerCluster describes a remote ovn-kubernetes cluster whose pods are
+// reachable from the pods of this cluster directly over Geneve tunnels, through
+// the zone interconnect transit switch of the default network.
+// The peer cluster is expected to describe the nodes of this cluster likewise.
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=peerclusters,scope=Cluster,shortName=pc,singular=peercluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Last Update",type="date",JSONPath=`.status.lastTransitionTime`
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=`.status.status`
+type PeerCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:Required
+	// +required
+	Spec PeerClusterSpec `json:"spec"`
+	// +optional
+	Status PeerClusterStatus `json:"status,omitempty"`
+}
+
+// PeerClusterSpec defines the desired state of PeerCluster
+type PeerClusterSpec struct {
+	// Nodes of the peer cluster.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=5000
+	// +listType=map
+	// +listMapKey=name
+	// +required
+	Nodes []PeerClusterNode `json:"nodes"`
+}
+
+// PeerClusterNode describes how to reach the pods of a node of a peer cluster.
+type PeerClusterNode struct {
+	// Name of the node in the peer cluster.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Name string `json:"name"`
+	// ChassisID is the OVN chassis ID of the node.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ChassisID string `json:"chassisID"`
+	// TunnelEndpoint is the IP address where the Geneve tunnels towards the
+	// node are terminated.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="isIP(self)",message="tunnelEndpoint must be a valid IP address"
+	// +required
+	TunnelEndpoint string `json:"tunnelEndpoint"`
+	// TunnelKey is the tunnel key of the node port on the transit switch,
+	// which is the node ID in the peer cluster. It must be unique among the
+	// nodes of all the peered clusters.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32767
+	// +required
+	TunnelKey int32 `json:"tunnelKey"`
+	// TransitSwitchIPs are the addresses, with prefix length, of the node
+	// port on the transit switch. They must be unique among the nodes of all
+	// the peered clusters.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	// +required
+	TransitSwitchIPs []CIDR `json:"transitSwitchIPs"`
+	// PodSubnets are the default network pod subnets of the node. They must
+	// not overlap with the cluster subnets or with the pod subnets of other
+	// peered clusters.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	// +required
+	PodSubnets []CIDR `json:"podSubnets"`
+}
+
+// +kubebuilder:validation:XValidation:rule="isCIDR(self)", message="CIDR is invalid"
+// +kubebuilder:validation:MaxLength=43
+type CIDR string
+
+// PeerClusterList contains a list of PeerClusters
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PeerClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PeerCluster `json:"items"`
+}
+
+// PeerClusterStatus contains the observed status of the PeerCluster.
+type PeerClusterStatus struct {
+	// Captures the time when the last change was applied.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// An array of Human-readable messages indicating details about the
+	// connectivity of each zone towards the peer cluster nodes.
+	// +patchStrategy=merge
+	// +listType=set
+	// +optional
+	Messages []string `json:"messages,omitempty"`
+	// A concise indication of whether the peer cluster nodes are reachable
+	// from all the zones.
+	// +optional
+	Status StatusType `json:"status,omitempty"`
+	// Conditions report, for each zone, whether the tunnels towards the peer
+	// cluster nodes are up. The condition type is PeerNodesReachable-<zone>.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// StatusType defines the types of status used in the Status field. The value
+// determines if the peer cluster nodes are reachable or not.
+type StatusType string
+
+const (
+	ConnectedStatus StatusType = "Connected"
+	DegradedStatus  StatusType = "Degraded"
+)
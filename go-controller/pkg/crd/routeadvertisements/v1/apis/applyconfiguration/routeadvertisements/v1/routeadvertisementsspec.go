@@ -31,6 +31,7 @@ type RouteAdvertisementsSpecApplyConfiguration struct {
 	NodeSelector             *metav1.LabelSelectorApplyConfiguration   `json:"nodeSelector,omitempty"`
 	FRRConfigurationSelector *metav1.LabelSelectorApplyConfiguration   `json:"frrConfigurationSelector,omitempty"`
 	Advertisements           []routeadvertisementsv1.AdvertisementType `json:"advertisements,omitempty"`
+	RouteImportPolicy        *RouteImportPolicyApplyConfiguration      `json:"routeImportPolicy,omitempty"`
 }
 
 // RouteAdvertisementsSpecApplyConfiguration constructs a declarative configuration of the RouteAdvertisementsSpec type for use with
@@ -80,3 +81,11 @@ func (b *RouteAdvertisementsSpecApplyConfiguration) WithAdvertisements(values ..
 	}
 	return b
 }
+
+// WithRouteImportPolicy sets the RouteImportPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RouteImportPolicy field is set to the value of the last call.
+func (b *RouteAdvertisementsSpecApplyConfiguration) WithRouteImportPolicy(value *RouteImportPolicyApplyConfiguration) *RouteAdvertisementsSpecApplyConfiguration {
+	b.RouteImportPolicy = value
+	return b
+}
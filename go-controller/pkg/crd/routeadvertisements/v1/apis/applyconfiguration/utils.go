@@ -37,6 +37,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &routeadvertisementsv1.RouteAdvertisementsSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RouteAdvertisementsStatus"):
 		return &routeadvertisementsv1.RouteAdvertisementsStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RouteImportPolicy"):
+		return &routeadvertisementsv1.RouteImportPolicyApplyConfiguration{}
 
 	}
 	return nil
@@ -54,8 +54,44 @@ type RouteAdvertisementsSpec struct {
 	// +kubebuilder:validation:MaxItems=2
 	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, x == y))"
 	Advertisements []AdvertisementType `json:"advertisements,omitempty"`
+
+	// routeImportPolicy determines which of the routes learned over BGP for
+	// the selected networks are imported into them. If not set, all the
+	// learned routes are imported.
+	// +kubebuilder:validation:Optional
+	RouteImportPolicy *RouteImportPolicy `json:"routeImportPolicy,omitempty"`
+}
+
+// RouteImportPolicy determines which of the routes learned over BGP are
+// imported into a network.
+type RouteImportPolicy struct {
+	// allowedPrefixes, if set, limits the imported routes to those with a
+	// destination contained in any of the given CIDRs.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	AllowedPrefixes []CIDR `json:"allowedPrefixes,omitempty"`
+
+	// deniedPrefixes prevents importing routes with a destination contained in
+	// any of the given CIDRs. Takes precedence over allowedPrefixes.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	DeniedPrefixes []CIDR `json:"deniedPrefixes,omitempty"`
+
+	// maxPrefixes limits the number of different destinations imported into
+	// the network. Routes to destinations exceeding the limit are rejected.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPrefixes *int32 `json:"maxPrefixes,omitempty"`
+
+	// ignoreDefaultRoute prevents importing default routes.
+	// +kubebuilder:validation:Optional
+	IgnoreDefaultRoute bool `json:"ignoreDefaultRoute,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="isCIDR(self)", message="CIDR is invalid"
+// +kubebuilder:validation:MaxLength=43
+type CIDR string
+
 // AdvertisementType determines the type of advertisement.
 // +kubebuilder:validation:Enum=PodNetwork;EgressIP
 type AdvertisementType string
@@ -104,6 +104,11 @@ func (in *RouteAdvertisementsSpec) DeepCopyInto(out *RouteAdvertisementsSpec) {
 		*out = make([]AdvertisementType, len(*in))
 		copy(*out, *in)
 	}
+	if in.RouteImportPolicy != nil {
+		in, out := &in.RouteImportPolicy, &out.RouteImportPolicy
+		*out = new(RouteImportPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -139,3 +144,34 @@ func (in *RouteAdvertisementsStatus) DeepCopy() *RouteAdvertisementsStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteImportPolicy) DeepCopyInto(out *RouteImportPolicy) {
+	*out = *in
+	if in.AllowedPrefixes != nil {
+		in, out := &in.AllowedPrefixes, &out.AllowedPrefixes
+		*out = make([]CIDR, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedPrefixes != nil {
+		in, out := &in.DeniedPrefixes, &out.DeniedPrefixes
+		*out = make([]CIDR, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxPrefixes != nil {
+		in, out := &in.MaxPrefixes, &out.MaxPrefixes
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteImportPolicy.
+func (in *RouteImportPolicy) DeepCopy() *RouteImportPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteImportPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
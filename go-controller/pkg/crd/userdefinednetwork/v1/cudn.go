@@ -33,7 +33,7 @@ type ClusterUserDefinedNetworkSpec struct {
 	// +kubebuilder:validation:XValidation:rule="has(self.topology) && self.topology == 'Layer3' ? has(self.layer3): !has(self.layer3)", message="spec.layer3 is required when topology is Layer3 and forbidden otherwise"
 	// +kubebuilder:validation:XValidation:rule="has(self.topology) && self.topology == 'Layer2' ? has(self.layer2): !has(self.layer2)", message="spec.layer2 is required when topology is Layer2 and forbidden otherwise"
 	// +kubebuilder:validation:XValidation:rule="has(self.topology) && self.topology == 'Localnet' ? has(self.localnet): !has(self.localnet)", message="spec.localnet is required when topology is Localnet and forbidden otherwise"
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="Network spec is immutable"
+	// +kubebuilder:validation:XValidation:rule="self.topology == oldSelf.topology && has(self.localnet) == has(oldSelf.localnet) && (!has(self.localnet) || self.localnet == oldSelf.localnet)", message="Network spec is immutable, except for the layer3 and layer2 MTU"
 	// +required
 	Network NetworkSpec `json:"network"`
 }
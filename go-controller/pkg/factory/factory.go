@@ -47,6 +47,7 @@ import (
 	certificatesinformers "k8s.io/client-go/informers/certificates/v1"
 	v1coreinformers "k8s.io/client-go/informers/core/v1"
 	discoveryinformers "k8s.io/client-go/informers/discovery/v1"
+	netinformers "k8s.io/client-go/informers/networking/v1"
 	"k8s.io/client-go/kubernetes"
 	listers "k8s.io/client-go/listers/core/v1"
 	discoverylisters "k8s.io/client-go/listers/discovery/v1"
@@ -81,6 +82,10 @@ import (
 	egressservicescheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned/scheme"
 	egressserviceinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/informers/externalversions"
 	egressserviceinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/informers/externalversions/egressservice/v1"
+	peerclusterapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1"
+	peerclusterscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/clientset/versioned/scheme"
+	peerclusterinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/informers/externalversions"
+	peerclusterinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/informers/externalversions/peercluster/v1"
 	routeadvertisementsapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1"
 	routeadvertisementsscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1/apis/clientset/versioned/scheme"
 	routeadvertisementsinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1/apis/informers/externalversions"
@@ -117,6 +122,7 @@ type WatchFactory struct {
 	nadFactory           nadinformerfactory.SharedInformerFactory
 	udnFactory           userdefinednetworkapiinformerfactory.SharedInformerFactory
 	raFactory            routeadvertisementsinformerfactory.SharedInformerFactory
+	peerClusterFactory   peerclusterinformerfactory.SharedInformerFactory
 	frrFactory           frrinformerfactory.SharedInformerFactory
 	informers            map[reflect.Type]*informer
 
@@ -144,6 +150,7 @@ func (wf *WatchFactory) ShallowClone() *WatchFactory {
 		nadFactory:           wf.nadFactory,
 		udnFactory:           wf.udnFactory,
 		raFactory:            wf.raFactory,
+		peerClusterFactory:   wf.peerClusterFactory,
 		frrFactory:           wf.frrFactory,
 		informers:            wf.informers,
 		stopChan:             wf.stopChan,
@@ -335,6 +342,9 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 	if err := routeadvertisementsapi.AddToScheme(routeadvertisementsscheme.Scheme); err != nil {
 		return nil, err
 	}
+	if err := peerclusterapi.AddToScheme(peerclusterscheme.Scheme); err != nil {
+		return nil, err
+	}
 
 	if err := nadapi.AddToScheme(nadscheme.Scheme); err != nil {
 		return nil, err
@@ -378,6 +388,9 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 	if err != nil {
 		return nil, err
 	}
+	if err = addPodMACIndexer(wf.iFactory.Core().V1().Pods().Informer()); err != nil {
+		return nil, err
+	}
 	wf.informers[ServiceType], err = newQueuedInformer(eventQueueSize, ServiceType, wf.iFactory.Core().V1().Services().Informer(),
 		wf.stopChan, minNumEventQueues)
 	if err != nil {
@@ -501,6 +514,12 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 		wf.raFactory.K8s().V1().RouteAdvertisements().Informer()
 	}
 
+	if util.IsPeerClustersEnabled() {
+		wf.peerClusterFactory = peerclusterinformerfactory.NewSharedInformerFactory(ovnClientset.PeerClusterClient, resyncInterval)
+		// make sure shared informer is created for a factory, so on wf.peerClusterFactory.Start() it is initialized and caches are synced.
+		wf.peerClusterFactory.K8s().V1().PeerClusters().Informer()
+	}
+
 	return wf, nil
 }
 
@@ -635,6 +654,15 @@ func (wf *WatchFactory) Start() error {
 		}
 	}
 
+	if wf.peerClusterFactory != nil {
+		wf.peerClusterFactory.Start(wf.stopChan)
+		for oType, synced := range waitForCacheSyncWithTimeout(wf.peerClusterFactory, wf.stopChan) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", oType)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -683,6 +711,9 @@ func (wf *WatchFactory) Stop() {
 	if wf.frrFactory != nil {
 		wf.frrFactory.Shutdown()
 	}
+	if wf.peerClusterFactory != nil {
+		wf.peerClusterFactory.Shutdown()
+	}
 }
 
 // NewNodeWatchFactory initializes a watch factory with significantly fewer
@@ -717,6 +748,9 @@ func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (
 	if err := routeadvertisementsapi.AddToScheme(routeadvertisementsscheme.Scheme); err != nil {
 		return nil, err
 	}
+	if err := peerclusterapi.AddToScheme(peerclusterscheme.Scheme); err != nil {
+		return nil, err
+	}
 
 	var err error
 	wf.informers[PodType], err = newQueuedInformer(eventQueueSize, PodType, wf.iFactory.Core().V1().Pods().Informer(), wf.stopChan,
@@ -824,8 +858,10 @@ func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (
 
 	// need to configure OVS interfaces for Pods on secondary networks in the DPU mode
 	// need to know what is the primary network for a namespace on the CNI side, which
-	// needs the NAD factory whenever the UDN feature is used.
-	if config.OVNKubernetesFeature.EnableMultiNetwork && (config.OVNKubernetesFeature.EnableNetworkSegmentation || config.OvnKubeNode.Mode == types.NodeModeDPU) {
+	// needs the NAD factory whenever the UDN feature is used. Hot-plugging pod
+	// interfaces needs to know the networks of the NADs requested by pods.
+	if config.OVNKubernetesFeature.EnableMultiNetwork && (config.OVNKubernetesFeature.EnableNetworkSegmentation ||
+		config.OvnKubeNode.Mode == types.NodeModeDPU || config.OVNKubernetesFeature.EnablePodInterfaceHotplug) {
 		wf.nadFactory = nadinformerfactory.NewSharedInformerFactory(ovnClientset.NetworkAttchDefClient, resyncInterval)
 		wf.informers[NetworkAttachmentDefinitionType], err = newQueuedInformer(eventQueueSize,
 			NetworkAttachmentDefinitionType, wf.nadFactory.K8sCniCncfIo().V1().NetworkAttachmentDefinitions().Informer(),
@@ -852,9 +888,24 @@ func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableHostNetworkPolicy {
+		// host network policies need the network policies to find the policies of the host network pods, the pods
+		// of all nodes are only watched by the nodes enforcing them, see NewClusterPodInformerFactory.
+		// make sure shared informers are created for the factories, so on Start() they are initialized and caches are synced.
+		wf.iFactory.Networking().V1().NetworkPolicies().Informer()
+	}
+
 	return wf, nil
 }
 
+// NewClusterPodInformerFactory returns an informer factory for the pods of all nodes, trimmed like the pods
+// of the watch factories. The node watch factory only watches the local pods, this factory is meant to be
+// started by the node controllers that need the pods of other nodes, only for as long as they need them.
+func NewClusterPodInformerFactory(kubeClient kubernetes.Interface) informerfactory.SharedInformerFactory {
+	return informerfactory.NewSharedInformerFactoryWithOptions(kubeClient, resyncInterval,
+		informerfactory.WithTransform(informerObjectTrim))
+}
+
 // NewClusterManagerWatchFactory initializes a watch factory with significantly fewer
 // informers to save memory + bandwidth. It is to be used by the cluster manager only
 // mode process.
@@ -898,6 +949,9 @@ func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset
 	if err := routeadvertisementsapi.AddToScheme(routeadvertisementsscheme.Scheme); err != nil {
 		return nil, err
 	}
+	if err := peerclusterapi.AddToScheme(peerclusterscheme.Scheme); err != nil {
+		return nil, err
+	}
 	if err := frrapi.AddToScheme(frrscheme.Scheme); err != nil {
 		return nil, err
 	}
@@ -990,6 +1044,9 @@ func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset
 			if err != nil {
 				return nil, err
 			}
+			if err = addPodMACIndexer(wf.iFactory.Core().V1().Pods().Informer()); err != nil {
+				return nil, err
+			}
 
 			if config.OVNKubernetesFeature.EnablePersistentIPs {
 				wf.ipamClaimsFactory = ipamclaimsfactory.NewSharedInformerFactory(ovnClientset.IPAMClaimsClient, resyncInterval)
@@ -1059,6 +1116,12 @@ func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset
 		wf.frrFactory.Api().V1beta1().FRRConfigurations().Informer()
 	}
 
+	if util.IsPeerClustersEnabled() {
+		wf.peerClusterFactory = peerclusterinformerfactory.NewSharedInformerFactory(ovnClientset.PeerClusterClient, resyncInterval)
+		// make sure shared informer is created for a factory, so on wf.peerClusterFactory.Start() it is initialized and caches are synced.
+		wf.peerClusterFactory.K8s().V1().PeerClusters().Informer()
+	}
+
 	return wf, nil
 }
 
@@ -1680,6 +1743,10 @@ func (wf *WatchFactory) PodCoreInformer() v1coreinformers.PodInformer {
 	return wf.iFactory.Core().V1().Pods()
 }
 
+func (wf *WatchFactory) NetworkPolicyCoreInformer() netinformers.NetworkPolicyInformer {
+	return wf.iFactory.Networking().V1().NetworkPolicies()
+}
+
 func (wf *WatchFactory) NamespaceInformer() v1coreinformers.NamespaceInformer {
 	return wf.iFactory.Core().V1().Namespaces()
 }
@@ -1760,6 +1827,10 @@ func (wf *WatchFactory) FRRConfigurationsInformer() frrinformer.FRRConfiguration
 	return wf.frrFactory.Api().V1beta1().FRRConfigurations()
 }
 
+func (wf *WatchFactory) PeerClusterInformer() peerclusterinformer.PeerClusterInformer {
+	return wf.peerClusterFactory.K8s().V1().PeerClusters()
+}
+
 // withServiceNameAndNoHeadlessServiceSelector returns a LabelSelector (added to the
 // watcher for EndpointSlices) that will only choose EndpointSlices with a non-empty
 // "kubernetes.io/service-name" label and without "service.kubernetes.io/headless"
@@ -1791,6 +1862,15 @@ func withServiceNameAndNoHeadlessServiceSelector() func(options *metav1.ListOpti
 	}
 }
 
+// addPodMACIndexer indexes pods by their annotated MACs, used to detect
+// conflicts with the MACs requested for primary UDN pods
+func addPodMACIndexer(podInformer cache.SharedIndexInformer) error {
+	if !util.IsPreconfiguredUDNAddressesEnabled() {
+		return nil
+	}
+	return podInformer.AddIndexers(cache.Indexers{util.PodMACIndex: util.PodMACIndexFunc})
+}
+
 // noHeadlessServiceSelector returns a LabelSelector (added to the
 // watcher for EndpointSlices) that will only choose EndpointSlices without "service.kubernetes.io/headless"
 // label.
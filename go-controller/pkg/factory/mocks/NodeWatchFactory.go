@@ -20,6 +20,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	networkingv1 "k8s.io/client-go/informers/networking/v1"
+
 	routeadvertisementsv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1/apis/informers/externalversions/routeadvertisements/v1"
 
 	userdefinednetworkv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/userdefinednetwork/v1/apis/informers/externalversions/userdefinednetwork/v1"
@@ -662,6 +664,26 @@ func (_m *NodeWatchFactory) NamespaceInformer() informerscorev1.NamespaceInforme
 	return r0
 }
 
+// NetworkPolicyCoreInformer provides a mock function with given fields:
+func (_m *NodeWatchFactory) NetworkPolicyCoreInformer() networkingv1.NetworkPolicyInformer {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NetworkPolicyCoreInformer")
+	}
+
+	var r0 networkingv1.NetworkPolicyInformer
+	if rf, ok := ret.Get(0).(func() networkingv1.NetworkPolicyInformer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(networkingv1.NetworkPolicyInformer)
+		}
+	}
+
+	return r0
+}
+
 // NodeCoreInformer provides a mock function with given fields:
 func (_m *NodeWatchFactory) NodeCoreInformer() informerscorev1.NodeInformer {
 	ret := _m.Called()
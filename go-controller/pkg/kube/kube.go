@@ -10,6 +10,7 @@ import (
 	ocpcloudnetworkapi "github.com/openshift/api/cloudnetwork/v1"
 	ocpcloudnetworkclientset "github.com/openshift/client-go/cloudnetwork/clientset/versioned"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -29,6 +30,7 @@ import (
 	egressipclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned"
 	egressqosclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned"
 	egressserviceclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned"
+	peerclusterclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/clientset/versioned"
 )
 
 // InterfaceOVN represents the exported methods for dealing with getting/setting
@@ -46,6 +48,8 @@ type InterfaceOVN interface {
 	DeleteCloudPrivateIPConfig(name string) error
 	UpdateEgressServiceStatus(namespace, name, host string) error
 	UpdateIPAMClaimIPs(updatedIPAMClaim *ipamclaimsapi.IPAMClaim) error
+	CreateIPAMClaim(ipamClaim *ipamclaimsapi.IPAMClaim) (*ipamclaimsapi.IPAMClaim, error)
+	DeleteIPAMClaim(namespace, name string) error
 }
 
 // Interface represents the exported methods for dealing with getting/setting
@@ -67,6 +71,7 @@ type Interface interface {
 	GetPods(namespace string, opts metav1.ListOptions) ([]*corev1.Pod, error)
 	GetPod(namespace, name string) (*corev1.Pod, error)
 	GetNode(name string) (*corev1.Node, error)
+	GetStatefulSet(namespace, name string) (*appsv1.StatefulSet, error)
 	Events() kv1core.EventInterface
 }
 
@@ -89,6 +94,7 @@ type KubeOVN struct {
 	EgressQoSClient      egressqosclientset.Interface
 	IPAMClaimsClient     ipamclaimssclientset.Interface
 	NADClient            nadclientset.Interface
+	PeerClusterClient    peerclusterclientset.Interface
 }
 
 // SetAnnotationsOnPod takes the pod object and map of key/value string pairs to set as annotations
@@ -364,6 +370,11 @@ func (k *Kube) GetPod(namespace, name string) (*corev1.Pod, error) {
 	return k.KClient.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
 }
 
+// GetStatefulSet obtains the StatefulSet from kubernetes apiserver, given the name and namespace
+func (k *Kube) GetStatefulSet(namespace, name string) (*appsv1.StatefulSet, error) {
+	return k.KClient.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
 // GetNodes returns the list of all Node objects from kubernetes
 func (k *Kube) GetNodes() ([]*corev1.Node, error) {
 	list := []*corev1.Node{}
@@ -469,6 +480,14 @@ func (k *KubeOVN) UpdateIPAMClaimIPs(updatedIPAMClaim *ipamclaimsapi.IPAMClaim)
 	return err
 }
 
+func (k *KubeOVN) CreateIPAMClaim(ipamClaim *ipamclaimsapi.IPAMClaim) (*ipamclaimsapi.IPAMClaim, error) {
+	return k.IPAMClaimsClient.K8sV1alpha1().IPAMClaims(ipamClaim.Namespace).Create(context.TODO(), ipamClaim, metav1.CreateOptions{})
+}
+
+func (k *KubeOVN) DeleteIPAMClaim(namespace, name string) error {
+	return k.IPAMClaimsClient.K8sV1alpha1().IPAMClaims(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
 // SetAnnotationsOnNAD takes a NAD namespace and name and a map of key/value string pairs to set as annotations
 func (k *KubeOVN) SetAnnotationsOnNAD(namespace, name string, annotations map[string]string, fieldManager string) error {
 	var err error
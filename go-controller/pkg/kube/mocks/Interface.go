@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -217,6 +218,36 @@ func (_m *Interface) GetPods(namespace string, opts metav1.ListOptions) ([]*core
 	return r0, r1
 }
 
+// GetStatefulSet provides a mock function with given fields: namespace, name
+func (_m *Interface) GetStatefulSet(namespace string, name string) (*appsv1.StatefulSet, error) {
+	ret := _m.Called(namespace, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatefulSet")
+	}
+
+	var r0 *appsv1.StatefulSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*appsv1.StatefulSet, error)); ok {
+		return rf(namespace, name)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *appsv1.StatefulSet); ok {
+		r0 = rf(namespace, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*appsv1.StatefulSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(namespace, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PatchNode provides a mock function with given fields: old, new
 func (_m *Interface) PatchNode(old *corev1.Node, new *corev1.Node) error {
 	ret := _m.Called(old, new)
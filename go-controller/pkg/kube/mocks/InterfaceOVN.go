@@ -4,6 +4,7 @@ package mocks
 
 import (
 	egressfirewallv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	apicorev1 "k8s.io/api/core/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
@@ -53,6 +54,36 @@ func (_m *InterfaceOVN) CreateCloudPrivateIPConfig(cloudPrivateIPConfig *v1.Clou
 	return r0, r1
 }
 
+// CreateIPAMClaim provides a mock function with given fields: ipamClaim
+func (_m *InterfaceOVN) CreateIPAMClaim(ipamClaim *v1alpha1.IPAMClaim) (*v1alpha1.IPAMClaim, error) {
+	ret := _m.Called(ipamClaim)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIPAMClaim")
+	}
+
+	var r0 *v1alpha1.IPAMClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v1alpha1.IPAMClaim) (*v1alpha1.IPAMClaim, error)); ok {
+		return rf(ipamClaim)
+	}
+	if rf, ok := ret.Get(0).(func(*v1alpha1.IPAMClaim) *v1alpha1.IPAMClaim); ok {
+		r0 = rf(ipamClaim)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.IPAMClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v1alpha1.IPAMClaim) error); ok {
+		r1 = rf(ipamClaim)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteCloudPrivateIPConfig provides a mock function with given fields: name
 func (_m *InterfaceOVN) DeleteCloudPrivateIPConfig(name string) error {
 	ret := _m.Called(name)
@@ -71,6 +102,24 @@ func (_m *InterfaceOVN) DeleteCloudPrivateIPConfig(name string) error {
 	return r0
 }
 
+// DeleteIPAMClaim provides a mock function with given fields: namespace, name
+func (_m *InterfaceOVN) DeleteIPAMClaim(namespace string, name string) error {
+	ret := _m.Called(namespace, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIPAMClaim")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(namespace, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Events provides a mock function with given fields:
 func (_m *InterfaceOVN) Events() corev1.EventInterface {
 	ret := _m.Called()
@@ -361,6 +410,36 @@ func (_m *InterfaceOVN) GetPods(namespace string, opts metav1.ListOptions) ([]*a
 	return r0, r1
 }
 
+// GetStatefulSet provides a mock function with given fields: namespace, name
+func (_m *InterfaceOVN) GetStatefulSet(namespace string, name string) (*appsv1.StatefulSet, error) {
+	ret := _m.Called(namespace, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatefulSet")
+	}
+
+	var r0 *appsv1.StatefulSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*appsv1.StatefulSet, error)); ok {
+		return rf(namespace, name)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *appsv1.StatefulSet); ok {
+		r0 = rf(namespace, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*appsv1.StatefulSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(namespace, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PatchEgressIP provides a mock function with given fields: name, patchData
 func (_m *InterfaceOVN) PatchEgressIP(name string, patchData []byte) error {
 	ret := _m.Called(name, patchData)
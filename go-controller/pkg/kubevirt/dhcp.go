@@ -1,12 +1,14 @@
 package kubevirt
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	utilnet "k8s.io/utils/net"
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
@@ -20,8 +22,33 @@ import (
 
 const (
 	dhcpLeaseTime = 3500
+
+	// DHCPOptionsAnnotation allows VM owners to configure additional DHCP
+	// options per network. KubeVirt propagates it from the VMI to the
+	// virt-launcher pod. The value is a JSON map keyed by NAD name, or
+	// "default" for the cluster default network, for example:
+	//	{"ns1/tenantblue": {"ntpServers": ["10.0.0.10"], "domainSearch": ["example.com"],
+	//	 "staticRoutes": [{"destination": "10.10.0.0/16", "nextHop": "192.168.0.254"}]}}
+	DHCPOptionsAnnotation = "k8s.ovn.org/dhcp-options"
 )
 
+// DHCPOptionsOverrides are the DHCP options that VM owners can configure on
+// top of the ones composed by ovn-kubernetes.
+type DHCPOptionsOverrides struct {
+	// NTPServers are IPv4 NTP server addresses handed over with DHCPv4
+	NTPServers []string `json:"ntpServers,omitempty"`
+	// DomainSearch is the domain search list handed over with both DHCPv4
+	// and DHCPv6
+	DomainSearch []string `json:"domainSearch,omitempty"`
+	// StaticRoutes are IPv4 classless static routes handed over with DHCPv4
+	StaticRoutes []DHCPStaticRoute `json:"staticRoutes,omitempty"`
+}
+
+type DHCPStaticRoute struct {
+	Destination string `json:"destination"`
+	NextHop     string `json:"nextHop"`
+}
+
 type DHCPConfigsOpt = func(*dhcpConfigs)
 
 type dhcpConfigs struct {
@@ -71,16 +98,116 @@ func WithIPv6DNSServer(dnsServer string) func(*dhcpConfigs) {
 	}
 }
 
-func EnsureDHCPOptionsForMigratablePod(controllerName string, nbClient libovsdbclient.Client, watchFactory *factory.WatchFactory, pod *corev1.Pod, ips []*net.IPNet, lsp *nbdb.LogicalSwitchPort) error {
+// WithDHCPOptionsOverrides merges the options configured by the VM owner.
+// Since clients ignore the router option when classless static routes are
+// handed over, a default route through the router is added unless the owner
+// configured one.
+func WithDHCPOptionsOverrides(overrides *DHCPOptionsOverrides) func(*dhcpConfigs) {
+	return func(configs *dhcpConfigs) {
+		if overrides == nil {
+			return
+		}
+		if configs.V4 != nil {
+			if len(overrides.NTPServers) > 0 {
+				configs.V4.Options["ntp_server"] = composeDHCPAddressList(overrides.NTPServers)
+			}
+			if len(overrides.DomainSearch) > 0 {
+				configs.V4.Options["domain_search_list"] = fmt.Sprintf("%q", strings.Join(overrides.DomainSearch, ","))
+			}
+			if len(overrides.StaticRoutes) > 0 {
+				configs.V4.Options["classless_static_route"] = composeClasslessStaticRoutes(overrides.StaticRoutes, configs.V4.Options["router"])
+			}
+		}
+		if configs.V6 != nil && len(overrides.DomainSearch) > 0 {
+			configs.V6.Options["domain_search"] = fmt.Sprintf("%q", strings.Join(overrides.DomainSearch, ","))
+		}
+	}
+}
+
+func composeDHCPAddressList(addresses []string) string {
+	if len(addresses) == 1 {
+		return addresses[0]
+	}
+	return fmt.Sprintf("{%s}", strings.Join(addresses, ", "))
+}
+
+func composeClasslessStaticRoutes(routes []DHCPStaticRoute, router string) string {
+	entries := make([]string, 0, len(routes)+1)
+	hasDefaultRoute := false
+	for _, route := range routes {
+		_, destination, _ := net.ParseCIDR(route.Destination)
+		if destination != nil && destination.IP.IsUnspecified() {
+			if ones, _ := destination.Mask.Size(); ones == 0 {
+				hasDefaultRoute = true
+			}
+		}
+		entries = append(entries, fmt.Sprintf("%s,%s", route.Destination, route.NextHop))
+	}
+	if !hasDefaultRoute && router != "" {
+		entries = append(entries, fmt.Sprintf("0.0.0.0/0,%s", router))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(entries, ", "))
+}
+
+func (overrides *DHCPOptionsOverrides) validate() error {
+	if overrides == nil {
+		return nil
+	}
+	for _, ntpServer := range overrides.NTPServers {
+		if !utilnet.IsIPv4String(ntpServer) {
+			return fmt.Errorf("invalid NTP server %q, only IPv4 addresses are supported", ntpServer)
+		}
+	}
+	for _, domain := range overrides.DomainSearch {
+		if errs := validation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+			return fmt.Errorf("invalid search domain %q: %s", domain, strings.Join(errs, ", "))
+		}
+	}
+	for _, route := range overrides.StaticRoutes {
+		if !utilnet.IsIPv4CIDRString(route.Destination) || !utilnet.IsIPv4String(route.NextHop) {
+			return fmt.Errorf("invalid static route %s via %s, only IPv4 routes are supported",
+				route.Destination, route.NextHop)
+		}
+	}
+	return nil
+}
+
+// ParseDHCPOptionsAnnotation returns the DHCP options configured by the VM
+// owner for the given NAD, or nil if there are none. The options of every
+// network are validated, so that a malformed annotation is reported whatever
+// the network being configured.
+func ParseDHCPOptionsAnnotation(pod *corev1.Pod, nadName string) (*DHCPOptionsOverrides, error) {
+	annotation, ok := pod.Annotations[DHCPOptionsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	overridesByNAD := map[string]*DHCPOptionsOverrides{}
+	if err := json.Unmarshal([]byte(annotation), &overridesByNAD); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation: %w", DHCPOptionsAnnotation, err)
+	}
+	for name, overrides := range overridesByNAD {
+		if err := overrides.validate(); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation options for %s: %w", DHCPOptionsAnnotation, name, err)
+		}
+	}
+	return overridesByNAD[nadName], nil
+}
+
+// EnsureDHCPOptionsForMigratablePod configures the DHCP options of a live
+// migratable pod on the default network, the given options are applied after
+// the ones composed by ovn-kubernetes.
+func EnsureDHCPOptionsForMigratablePod(controllerName string, nbClient libovsdbclient.Client, watchFactory *factory.WatchFactory, pod *corev1.Pod, ips []*net.IPNet, lsp *nbdb.LogicalSwitchPort, opts ...DHCPConfigsOpt) error {
 	dnsServerIPv4, dnsServerIPv6, err := RetrieveDNSServiceClusterIPs(watchFactory)
 	if err != nil {
 		return fmt.Errorf("failed retrieving dns service cluster ip: %v", err)
 	}
 
 	return EnsureDHCPOptionsForLSP(controllerName, nbClient, pod, ips, lsp,
-		WithIPv4Router(ARPProxyIPv4),
-		WithIPv4DNSServer(dnsServerIPv4),
-		WithIPv6DNSServer(dnsServerIPv6),
+		append([]DHCPConfigsOpt{
+			WithIPv4Router(ARPProxyIPv4),
+			WithIPv4DNSServer(dnsServerIPv4),
+			WithIPv6DNSServer(dnsServerIPv6),
+		}, opts...)...,
 	)
 }
 
@@ -157,6 +284,9 @@ func ComposeDHCPv4Options(cidr, controllerName string, vmKey ktypes.NamespacedNa
 	return composeDHCPOptions(controllerName, vmKey, dhcpOptions)
 }
 
+// ComposeDHCPv6Options composes the DHCPv6 options. dhcpv6_stateless is not
+// set, so OVN leases the IPv6 address of the logical switch port, the one at
+// the pod annotation.
 func ComposeDHCPv6Options(cidr, controllerName string, vmKey ktypes.NamespacedName) *nbdb.DHCPOptions {
 	serverMAC := util.IPAddrToHWAddr(net.ParseIP(ARPProxyIPv6)).String()
 	dhcpOptions := &nbdb.DHCPOptions{
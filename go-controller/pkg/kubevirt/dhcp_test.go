@@ -3,6 +3,8 @@ package kubevirt
 import (
 	"net"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
@@ -189,6 +191,59 @@ var _ = Describe("Kubevirt", func() {
 				},
 			},
 		}),
+		Entry("Dual stack with VM owner options", dhcpTest{
+			cidrs:          []string{"192.168.25.0/24", "2002:0:0:1234::/64"},
+			controllerName: "defaultController",
+			namespace:      "namespace1",
+			vmName:         "foo1",
+			opts: []DHCPConfigsOpt{
+				WithIPv4Router("192.168.25.1"),
+				WithDHCPOptionsOverrides(&DHCPOptionsOverrides{
+					NTPServers:   []string{"10.0.0.10", "10.0.0.11"},
+					DomainSearch: []string{"example.com", "corp.example.com"},
+					StaticRoutes: []DHCPStaticRoute{{Destination: "10.10.0.0/16", NextHop: "192.168.25.254"}},
+				}),
+			},
+			expectedDHCPConfigs: dhcpConfigs{
+				V4: &nbdb.DHCPOptions{
+					Cidr: "192.168.25.0/24",
+					ExternalIDs: map[string]string{
+						"k8s.ovn.org/owner-controller": "defaultController",
+						"k8s.ovn.org/owner-type":       "VirtualMachine",
+						"k8s.ovn.org/name":             "namespace1/foo1",
+						"k8s.ovn.org/cidr":             "192.168.25.0/24",
+						"k8s.ovn.org/id":               "defaultController:VirtualMachine:namespace1/foo1:192.168.25.0/24",
+						"k8s.ovn.org/zone":             "local",
+					},
+					Options: map[string]string{
+						"lease_time":             "3500",
+						"server_id":              ARPProxyIPv4,
+						"server_mac":             ARPProxyMAC,
+						"hostname":               `"foo1"`,
+						"router":                 "192.168.25.1",
+						"ntp_server":             "{10.0.0.10, 10.0.0.11}",
+						"domain_search_list":     `"example.com,corp.example.com"`,
+						"classless_static_route": "{10.10.0.0/16,192.168.25.254, 0.0.0.0/0,192.168.25.1}",
+					},
+				},
+				V6: &nbdb.DHCPOptions{
+					Cidr: "2002:0:0:1234::/64",
+					ExternalIDs: map[string]string{
+						"k8s.ovn.org/owner-controller": "defaultController",
+						"k8s.ovn.org/owner-type":       "VirtualMachine",
+						"k8s.ovn.org/name":             "namespace1/foo1",
+						"k8s.ovn.org/cidr":             "2002.0.0.1234../64",
+						"k8s.ovn.org/id":               "defaultController:VirtualMachine:namespace1/foo1:2002.0.0.1234../64",
+						"k8s.ovn.org/zone":             "local",
+					},
+					Options: map[string]string{
+						"server_id":     "0a:58:6d:6d:c1:50",
+						"fqdn":          `"foo1"`,
+						"domain_search": `"example.com,corp.example.com"`,
+					},
+				},
+			},
+		}),
 	)
 
 	DescribeTable("composing dhcp options should fail", func(t dhcpTest) {
@@ -209,4 +264,34 @@ var _ = Describe("Kubevirt", func() {
 		}),
 	)
 
+	DescribeTable("parsing the VM owner dhcp options", func(annotation string, expectedOverrides *DHCPOptionsOverrides, expectedError string) {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+		if annotation != "" {
+			pod.Annotations[DHCPOptionsAnnotation] = annotation
+		}
+		overrides, err := ParseDHCPOptionsAnnotation(pod, "ns1/tenantblue")
+		if expectedError != "" {
+			Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(overrides).To(Equal(expectedOverrides))
+	},
+		Entry("returns nil without annotation", "", nil, ""),
+		Entry("returns nil for other networks", `{"default": {"ntpServers": ["10.0.0.10"]}}`, nil, ""),
+		Entry("returns the network options",
+			`{"ns1/tenantblue": {"ntpServers": ["10.0.0.10"], "domainSearch": ["example.com"], "staticRoutes": [{"destination": "10.10.0.0/16", "nextHop": "192.168.0.254"}]}}`,
+			&DHCPOptionsOverrides{
+				NTPServers:   []string{"10.0.0.10"},
+				DomainSearch: []string{"example.com"},
+				StaticRoutes: []DHCPStaticRoute{{Destination: "10.10.0.0/16", NextHop: "192.168.0.254"}},
+			}, ""),
+		Entry("fails with invalid json", `{"ns1/tenantblue": [`, nil, "failed to unmarshal"),
+		Entry("fails with IPv6 NTP servers", `{"ns1/tenantblue": {"ntpServers": ["fd00::10"]}}`, nil, "invalid NTP server"),
+		Entry("fails with invalid search domains", `{"ns1/tenantblue": {"domainSearch": ["Not A Domain"]}}`, nil, "invalid search domain"),
+		Entry("fails with invalid options of other networks",
+			`{"ns1/tenantblue": {"ntpServers": ["10.0.0.10"]}, "default": {"ntpServers": ["fd00::10"]}}`, nil, "invalid NTP server"),
+		Entry("fails with IPv6 static routes", `{"ns1/tenantblue": {"staticRoutes": [{"destination": "fd00::/64", "nextHop": "fd00::1"}]}}`, nil, "invalid static route"),
+	)
+
 })
@@ -204,12 +204,12 @@ func ZoneContainsPodSubnet(lsManager *logicalswitchmanager.LogicalSwitchManager,
 
 // nodeContainsPodSubnet will return true if the node subnet annotation
 // contains the subnets from the argument
-func nodeContainsPodSubnet(watchFactory *factory.WatchFactory, nodeName string, podAnnotation *util.PodAnnotation, nadName string) (bool, error) {
+func nodeContainsPodSubnet(watchFactory *factory.WatchFactory, nodeName string, podAnnotation *util.PodAnnotation, networkName string) (bool, error) {
 	node, err := watchFactory.GetNode(nodeName)
 	if err != nil {
 		return false, err
 	}
-	nodeHostSubNets, err := util.ParseNodeHostSubnetAnnotation(node, nadName)
+	nodeHostSubNets, err := util.ParseNodeHostSubnetAnnotation(node, networkName)
 	if err != nil {
 		return false, err
 	}
@@ -235,7 +235,7 @@ func ExtractVMNameFromPod(pod *corev1.Pod) *ktypes.NamespacedName {
 
 // CleanUpLiveMigratablePod remove routing and DHCP ovn related resources
 // when all the pods for the same VM as `pod` argument are completed.
-func CleanUpLiveMigratablePod(nbClient libovsdbclient.Client, watchFactory *factory.WatchFactory, pod *corev1.Pod) error {
+func CleanUpLiveMigratablePod(nbClient libovsdbclient.Client, watchFactory *factory.WatchFactory, netInfo util.NetInfo, pod *corev1.Pod) error {
 	if !IsPodLiveMigratable(pod) {
 		return nil
 	}
@@ -253,19 +253,20 @@ func CleanUpLiveMigratablePod(nbClient libovsdbclient.Client, watchFactory *fact
 	if err := DeleteDHCPOptions(nbClient, pod); err != nil {
 		return err
 	}
-	if err := DeleteRoutingForMigratedPod(nbClient, pod); err != nil {
+	if err := DeleteRoutingForMigratedPod(nbClient, netInfo, pod); err != nil {
 		return err
 	}
 	return nil
 }
 
-func SyncVirtualMachines(nbClient libovsdbclient.Client, vms map[ktypes.NamespacedName]bool) error {
-	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nbClient, ovntypes.OVNClusterRouter, func(item *nbdb.LogicalRouterStaticRoute) bool {
+func SyncVirtualMachines(nbClient libovsdbclient.Client, netInfo util.NetInfo, vms map[ktypes.NamespacedName]bool) error {
+	clusterRouter := netInfo.GetNetworkScopedClusterRouterName()
+	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nbClient, clusterRouter, func(item *nbdb.LogicalRouterStaticRoute) bool {
 		return ownsItAndIsOrphanOrWrongZone(item.ExternalIDs, vms)
 	}); err != nil {
 		return fmt.Errorf("failed deleting stale vm static routes: %v", err)
 	}
-	if err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nbClient, ovntypes.OVNClusterRouter, func(item *nbdb.LogicalRouterPolicy) bool {
+	if err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nbClient, clusterRouter, func(item *nbdb.LogicalRouterPolicy) bool {
 		return ownsItAndIsOrphanOrWrongZone(item.ExternalIDs, vms)
 	}); err != nil {
 		return fmt.Errorf("failed deleting stale vm policies: %v", err)
@@ -348,7 +349,7 @@ func AllocateSyncMigratablePodIPsOnZone(watchFactory *factory.WatchFactory, lsMa
 // assigned to that node has not yet been re-assigned to a different node. For
 // convenience, the host subnets might not provided in which case they might be
 // parsed and returned if used.
-func ZoneContainsPodSubnetOrUntracked(watchFactory *factory.WatchFactory, lsManager *logicalswitchmanager.LogicalSwitchManager, hostSubnets []*net.IPNet, annotation *util.PodAnnotation) ([]*net.IPNet, bool, error) {
+func ZoneContainsPodSubnetOrUntracked(watchFactory *factory.WatchFactory, lsManager *logicalswitchmanager.LogicalSwitchManager, netInfo util.NetInfo, hostSubnets []*net.IPNet, annotation *util.PodAnnotation) ([]*net.IPNet, bool, error) {
 	_, local := ZoneContainsPodSubnet(lsManager, annotation.IPs)
 	if local {
 		return nil, true, nil
@@ -358,7 +359,7 @@ func ZoneContainsPodSubnetOrUntracked(watchFactory *factory.WatchFactory, lsMana
 		if err != nil {
 			return nil, false, err
 		}
-		hostSubnets, err = util.ParseNodesHostSubnetAnnotation(nodes, ovntypes.DefaultNetworkName)
+		hostSubnets, err = util.ParseNodesHostSubnetAnnotation(nodes, netInfo.GetNetworkName())
 		if err != nil {
 			return nil, false, err
 		}
@@ -374,6 +375,17 @@ func IsPodOwnedByVirtualMachine(pod *corev1.Pod) bool {
 	return ExtractVMNameFromPod(pod) != nil
 }
 
+// IsPodLiveMigratableWithPointToPointRouting determines whether a given pod is
+// eligible for live migration on a layer3 network, where each node owns a
+// subnet and the VM keeps its IPs on other nodes thanks to point to point
+// routes. That is the case of the default network and of layer3 primary user
+// defined networks.
+func IsPodLiveMigratableWithPointToPointRouting(pod *corev1.Pod, netInfo util.NetInfo) bool {
+	return IsPodLiveMigratable(pod) &&
+		netInfo.TopologyType() == ovntypes.Layer3Topology &&
+		(netInfo.IsDefault() || netInfo.IsPrimaryNetwork())
+}
+
 // IsPodAllowedForMigration determines whether a given pod is eligible for live migration
 func IsPodAllowedForMigration(pod *corev1.Pod, netInfo util.NetInfo) bool {
 	return IsPodOwnedByVirtualMachine(pod) &&
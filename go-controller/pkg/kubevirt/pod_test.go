@@ -11,8 +11,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
 
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -161,8 +165,46 @@ var _ = Describe("Kubevirt Pod", func() {
 			},
 		),
 	)
+
+	DescribeTable("IsPodLiveMigratableWithPointToPointRouting", func(pod corev1.Pod, netConf *ovncnitypes.NetConf, expected bool) {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+		netInfo, err := util.NewNetInfo(netConf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(IsPodLiveMigratableWithPointToPointRouting(&pod, netInfo)).To(Equal(expected))
+	},
+		Entry("is true for live migratable pods on the default network",
+			liveMigratableKubevirtPod(), &ovncnitypes.NetConf{NetConf: cnitypes.NetConf{Name: types.DefaultNetworkName}}, true),
+		Entry("is true for live migratable pods on layer3 primary user defined networks",
+			liveMigratableKubevirtPod(), udnNetConf(types.Layer3Topology, types.NetworkRolePrimary), true),
+		Entry("is false for live migratable pods on layer3 secondary networks",
+			liveMigratableKubevirtPod(), udnNetConf(types.Layer3Topology, types.NetworkRoleSecondary), false),
+		Entry("is false for live migratable pods on layer2 primary user defined networks",
+			liveMigratableKubevirtPod(), udnNetConf(types.Layer2Topology, types.NetworkRolePrimary), false),
+		Entry("is false for non live migratable pods",
+			runningKubevirtPod(t0), udnNetConf(types.Layer3Topology, types.NetworkRolePrimary), false),
+	)
 })
 
+func liveMigratableKubevirtPod() corev1.Pod {
+	return newKubevirtPod(corev1.PodRunning, map[string]string{kubevirtv1.AllowPodBridgeNetworkLiveMigrationAnnotation: ""}, 0)
+}
+
+func udnNetConf(topology, role string) *ovncnitypes.NetConf {
+	subnets := "10.128.0.0/16"
+	if topology == types.Layer3Topology {
+		subnets = "10.128.0.0/16/24"
+	}
+	return &ovncnitypes.NetConf{
+		NetConf:  cnitypes.NetConf{Name: "tenantblue"},
+		Topology: topology,
+		Role:     role,
+		Subnets:  subnets,
+		NADName:  "default/tenantblue",
+	}
+}
+
 func completedKubevirtPod(creationOffset time.Duration) corev1.Pod {
 	return newKubevirtPod(corev1.PodSucceeded, nil, creationOffset)
 }
@@ -20,7 +20,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
-func DeleteRoutingForMigratedPodWithZone(nbClient libovsdbclient.Client, pod *corev1.Pod, zone string) error {
+func DeleteRoutingForMigratedPodWithZone(nbClient libovsdbclient.Client, netInfo util.NetInfo, pod *corev1.Pod, zone string) error {
 	vm := ExtractVMNameFromPod(pod)
 	predicate := func(itemExternalIDs map[string]string) bool {
 		containsZone := true
@@ -32,24 +32,27 @@ func DeleteRoutingForMigratedPodWithZone(nbClient libovsdbclient.Client, pod *co
 	routePredicate := func(item *nbdb.LogicalRouterStaticRoute) bool {
 		return predicate(item.ExternalIDs)
 	}
-	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nbClient, types.OVNClusterRouter, routePredicate); err != nil {
+	clusterRouter := netInfo.GetNetworkScopedClusterRouterName()
+	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nbClient, clusterRouter, routePredicate); err != nil {
 		return fmt.Errorf("failed deleting pod routing when deleting the LR static routes: %v", err)
 	}
 	policyPredicate := func(item *nbdb.LogicalRouterPolicy) bool {
 		return predicate(item.ExternalIDs)
 	}
-	if err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nbClient, types.OVNClusterRouter, policyPredicate); err != nil {
+	if err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(nbClient, clusterRouter, policyPredicate); err != nil {
 		return fmt.Errorf("failed deleting pod routing when deleting the LR policies: %v", err)
 	}
 	return nil
 }
 
-func DeleteRoutingForMigratedPod(nbClient libovsdbclient.Client, pod *corev1.Pod) error {
-	return DeleteRoutingForMigratedPodWithZone(nbClient, pod, "")
+func DeleteRoutingForMigratedPod(nbClient libovsdbclient.Client, netInfo util.NetInfo, pod *corev1.Pod) error {
+	return DeleteRoutingForMigratedPodWithZone(nbClient, netInfo, pod, "")
 }
 
-// EnsureLocalZonePodAddressesToNodeRoute will add static routes and policies to ovn_cluster_route logical router
+// EnsureLocalZonePodAddressesToNodeRoute will add static routes and policies to the network's cluster router
 // to ensure VM traffic work as expected after live migration if the pod is running at the local/global zone.
+// The network has to be a layer3 one where each node owns a subnet: the default network or a primary user
+// defined network.
 //
 // NOTE: IC with multiple nodes per zone is not supported
 //
@@ -66,7 +69,7 @@ func DeleteRoutingForMigratedPod(nbClient libovsdbclient.Client, pod *corev1.Pod
 // Both:
 //   - static route with VM ip as dst-ip prefix and output port the LRP pointing to the VM's node switch
 func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory, nbClient libovsdbclient.Client,
-	lsManager *logicalswitchmanager.LogicalSwitchManager, pod *corev1.Pod, nadName string, clusterSubnets []config.CIDRNetworkEntry) error {
+	lsManager *logicalswitchmanager.LogicalSwitchManager, netInfo util.NetInfo, pod *corev1.Pod, nadName string) error {
 	vmReady, err := virtualMachineReady(watchFactory, pod)
 	if err != nil {
 		return err
@@ -79,28 +82,31 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 		return fmt.Errorf("failed reading local pod annotation: %v", err)
 	}
 
-	nodeOwningSubnet, _ := ZoneContainsPodSubnet(lsManager, podAnnotation.IPs)
-	vmRunningAtNodeOwningSubnet := nodeOwningSubnet == pod.Spec.NodeName
+	switchOwningSubnet, _ := ZoneContainsPodSubnet(lsManager, podAnnotation.IPs)
+	vmRunningAtNodeOwningSubnet := switchOwningSubnet == netInfo.GetNetworkScopedSwitchName(pod.Spec.NodeName)
 	if vmRunningAtNodeOwningSubnet {
 		// Point to point routing is no longer needed if vm
 		// is running at the node that owns the subnet
-		if err := DeleteRoutingForMigratedPod(nbClient, pod); err != nil {
+		if err := DeleteRoutingForMigratedPod(nbClient, netInfo, pod); err != nil {
 			return fmt.Errorf("failed configuring pod routing when deleting stale static routes or policies for pod %s/%s: %v", pod.Namespace, pod.Name, err)
 		}
 		return nil
 	}
 
+	clusterRouter := netInfo.GetNetworkScopedClusterRouterName()
+	gwRouter := netInfo.GetNetworkScopedGWRouterName(pod.Spec.NodeName)
+
 	// For interconnect at static route with a cluster-wide src-ip address is
 	// needed to route egress n/s traffic
 	if config.OVNKubernetesFeature.EnableInterconnect {
 		// NOTE: EIP & ESVC use same route and if this is already present thanks to those features,
 		// this will be a no-op
-		if err := libovsdbutil.CreateDefaultRouteToExternal(nbClient, types.OVNClusterRouter, types.GWRouterPrefix+pod.Spec.NodeName, clusterSubnets); err != nil {
+		if err := libovsdbutil.CreateDefaultRouteToExternal(nbClient, clusterRouter, gwRouter, netInfo.Subnets()); err != nil {
 			return err
 		}
 	}
 
-	lrpName := types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + pod.Spec.NodeName
+	lrpName := types.GWRouterToJoinSwitchPrefix + gwRouter
 	lrpAddresses, err := libovsdbutil.GetLRPAddrs(nbClient, lrpName)
 	if err != nil {
 		return fmt.Errorf("failed configuring pod routing when reading LRP %s addresses: %v", lrpName, err)
@@ -130,7 +136,7 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 					NamespaceExternalIDsKey:      pod.Namespace,
 				},
 			}
-			if err := libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicate(nbClient, types.OVNClusterRouter, &egressPolicy, func(item *nbdb.LogicalRouterPolicy) bool {
+			if err := libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicate(nbClient, clusterRouter, &egressPolicy, func(item *nbdb.LogicalRouterPolicy) bool {
 				return item.Priority == egressPolicy.Priority && item.Match == egressPolicy.Match && item.Action == egressPolicy.Action
 			}); err != nil {
 				return fmt.Errorf("failed adding point to point policy for pod %s/%s : %v", pod.Namespace, pod.Name, err)
@@ -138,7 +144,7 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 		}
 		// Add a route for reroute ingress traffic to the VM port since
 		// the subnet is alien to ovn_cluster_router
-		outputPort := types.RouterToSwitchPrefix + pod.Spec.NodeName
+		outputPort := types.RouterToSwitchPrefix + netInfo.GetNetworkScopedSwitchName(pod.Spec.NodeName)
 		ingressRoute := nbdb.LogicalRouterStaticRoute{
 			IPPrefix:   podAddress,
 			Nexthop:    podAddress,
@@ -150,7 +156,7 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 				NamespaceExternalIDsKey:      pod.Namespace,
 			},
 		}
-		if err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(nbClient, types.OVNClusterRouter, &ingressRoute, func(item *nbdb.LogicalRouterStaticRoute) bool {
+		if err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(nbClient, clusterRouter, &ingressRoute, func(item *nbdb.LogicalRouterStaticRoute) bool {
 			matches := item.IPPrefix == ingressRoute.IPPrefix && item.Policy != nil && *item.Policy == *ingressRoute.Policy
 			return matches
 		}); err != nil {
@@ -165,7 +171,7 @@ func EnsureLocalZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 // port of the node where the pod is running:
 //   - A dst-ip with live migrated pod ip as prefix and nexthop the pod's
 //     current node transit switch port.
-func EnsureRemoteZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory, nbClient libovsdbclient.Client, pod *corev1.Pod, nadName string) error {
+func EnsureRemoteZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory, nbClient libovsdbclient.Client, netInfo util.NetInfo, pod *corev1.Pod, nadName string) error {
 	vmReady, err := virtualMachineReady(watchFactory, pod)
 	if err != nil {
 		return err
@@ -184,21 +190,21 @@ func EnsureRemoteZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 		return fmt.Errorf("failed reading remote pod annotation: %v", err)
 	}
 
-	vmRunningAtNodeOwningSubnet, err := nodeContainsPodSubnet(watchFactory, pod.Spec.NodeName, podAnnotation, nadName)
+	vmRunningAtNodeOwningSubnet, err := nodeContainsPodSubnet(watchFactory, pod.Spec.NodeName, podAnnotation, netInfo.GetNetworkName())
 	if err != nil {
 		return err
 	}
 	if vmRunningAtNodeOwningSubnet {
 		// Point to point routing is no longer needed if vm
 		// is running at the node with VM's subnet
-		if err := DeleteRoutingForMigratedPod(nbClient, pod); err != nil {
+		if err := DeleteRoutingForMigratedPod(nbClient, netInfo, pod); err != nil {
 			return err
 		}
 		return nil
 	} else {
 		// Since we are at remote zone we should not have local zone point to
 		// to point routing
-		if err := DeleteRoutingForMigratedPodWithZone(nbClient, pod, OvnLocalZone); err != nil {
+		if err := DeleteRoutingForMigratedPodWithZone(nbClient, netInfo, pod, OvnLocalZone); err != nil {
 			return err
 		}
 	}
@@ -227,7 +233,7 @@ func EnsureRemoteZonePodAddressesToNodeRoute(watchFactory *factory.WatchFactory,
 				NamespaceExternalIDsKey:      pod.Namespace,
 			},
 		}
-		if err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(nbClient, types.OVNClusterRouter, &route, func(item *nbdb.LogicalRouterStaticRoute) bool {
+		if err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(nbClient, netInfo.GetNetworkScopedClusterRouterName(), &route, func(item *nbdb.LogicalRouterStaticRoute) bool {
 			matches := item.IPPrefix == route.IPPrefix && item.Policy != nil && *item.Policy == *route.Policy
 			return matches
 		}); err != nil {
@@ -0,0 +1,177 @@
+package kubevirt
+
+import (
+	"fmt"
+	"net"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	logicalswitchmanager "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Kubevirt Router", func() {
+	const (
+		nadName       = "default/tenantblue"
+		clusterRouter = "tenantblue_" + types.OVNClusterRouter
+		node1         = "node1"
+		node2         = "node2"
+		vmIP          = "10.128.1.5"
+	)
+
+	var (
+		netInfo util.NetInfo
+		wf      *factory.WatchFactory
+		pod     *corev1.Pod
+	)
+
+	newNode := func(name, subnet, transitSwitchPortAddr string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Annotations: map[string]string{
+					"k8s.ovn.org/node-subnets":                    fmt.Sprintf(`{"tenantblue":"%s"}`, subnet),
+					"k8s.ovn.org/node-transit-switch-port-ifaddr": fmt.Sprintf(`{"ipv4":"%s"}`, transitSwitchPortAddr),
+				},
+			},
+		}
+	}
+
+	vmExternalIDs := func(zone string) map[string]string {
+		return map[string]string{
+			OvnZoneExternalIDKey:         zone,
+			VirtualMachineExternalIDsKey: vmName,
+			NamespaceExternalIDsKey:      corev1.NamespaceDefault,
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+
+		var err error
+		netInfo, err = util.NewNetInfo(udnNetConf(types.Layer3Topology, types.NetworkRolePrimary))
+		Expect(err).NotTo(HaveOccurred())
+
+		// the VM got its IP at node1 and has been migrated to node2
+		migratedPod := runningKubevirtPod(0)
+		migratedPod.Spec.NodeName = node2
+		migratedPod.Labels[kubevirtv1.NodeNameLabel] = node2
+		migratedPod.Annotations = map[string]string{
+			kubevirtv1.AllowPodBridgeNetworkLiveMigrationAnnotation: "",
+			util.OvnPodAnnotationName: fmt.Sprintf(`{"%s":{"ip_addresses":["%s/24"],"mac_address":"0a:58:0a:80:01:05","role":"primary"}}`,
+				nadName, vmIP),
+		}
+		pod = &migratedPod
+
+		fakeClient := util.GetOVNClientset(
+			newNode(node1, "10.128.1.0/24", "100.88.0.2/16"),
+			newNode(node2, "10.128.2.0/24", "100.88.0.3/16"),
+			pod,
+		).GetOVNKubeControllerClientset()
+		wf, err = factory.NewOVNKubeControllerWatchFactory(fakeClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		DeferCleanup(wf.Shutdown)
+		Eventually(func() error {
+			_, err := wf.GetPod(pod.Namespace, pod.Name)
+			return err
+		}).Should(Succeed())
+	})
+
+	It("routes a VM migrated to a local node of a primary user defined network", func() {
+		nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{
+				&nbdb.LogicalRouterPort{
+					UUID:     "rtoj-UUID",
+					Name:     types.GWRouterToJoinSwitchPrefix + netInfo.GetNetworkScopedGWRouterName(node2),
+					Networks: []string{"100.65.0.3/16"},
+				},
+				&nbdb.LogicalRouter{UUID: "gw-router-UUID", Name: netInfo.GetNetworkScopedGWRouterName(node2), Ports: []string{"rtoj-UUID"}},
+				&nbdb.LogicalRouter{UUID: "cluster-router-UUID", Name: clusterRouter},
+			},
+		}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(cleanup.Cleanup)
+
+		lsManager := logicalswitchmanager.NewLogicalSwitchManager()
+		Expect(lsManager.AddOrUpdateSwitch(netInfo.GetNetworkScopedSwitchName(node1),
+			[]*net.IPNet{ovntest.MustParseIPNet("10.128.1.0/24")})).To(Succeed())
+
+		Expect(EnsureLocalZonePodAddressesToNodeRoute(wf, nbClient, lsManager, netInfo, pod, nadName)).To(Succeed())
+
+		outputPort := types.RouterToSwitchPrefix + netInfo.GetNetworkScopedSwitchName(node2)
+		Eventually(nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+			&nbdb.LogicalRouterPort{
+				UUID:     "rtoj-UUID",
+				Name:     types.GWRouterToJoinSwitchPrefix + netInfo.GetNetworkScopedGWRouterName(node2),
+				Networks: []string{"100.65.0.3/16"},
+			},
+			&nbdb.LogicalRouter{UUID: "gw-router-UUID", Name: netInfo.GetNetworkScopedGWRouterName(node2), Ports: []string{"rtoj-UUID"}},
+			&nbdb.LogicalRouterPolicy{
+				UUID:        "policy-UUID",
+				Match:       "ip4.src == " + vmIP,
+				Action:      nbdb.LogicalRouterPolicyActionReroute,
+				Nexthops:    []string{"100.65.0.3"},
+				Priority:    types.EgressLiveMigrationReroutePriority,
+				ExternalIDs: vmExternalIDs(OvnLocalZone),
+			},
+			&nbdb.LogicalRouterStaticRoute{
+				UUID:        "route-UUID",
+				IPPrefix:    vmIP,
+				Nexthop:     vmIP,
+				Policy:      &nbdb.LogicalRouterStaticRoutePolicyDstIP,
+				OutputPort:  &outputPort,
+				ExternalIDs: vmExternalIDs(OvnLocalZone),
+			},
+			&nbdb.LogicalRouter{
+				UUID:         "cluster-router-UUID",
+				Name:         clusterRouter,
+				Policies:     []string{"policy-UUID"},
+				StaticRoutes: []string{"route-UUID"},
+			},
+		}))
+	})
+
+	It("routes a VM migrated to a remote node of a primary user defined network", func() {
+		nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{
+				&nbdb.LogicalRouter{UUID: "cluster-router-UUID", Name: clusterRouter},
+			},
+		}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(cleanup.Cleanup)
+
+		Expect(EnsureRemoteZonePodAddressesToNodeRoute(wf, nbClient, netInfo, pod, nadName)).To(Succeed())
+
+		expectedRoute := &nbdb.LogicalRouterStaticRoute{
+			UUID:        "route-UUID",
+			IPPrefix:    vmIP,
+			Nexthop:     "100.88.0.3",
+			Policy:      &nbdb.LogicalRouterStaticRoutePolicyDstIP,
+			ExternalIDs: vmExternalIDs(OvnRemoteZone),
+		}
+		Eventually(nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+			expectedRoute,
+			&nbdb.LogicalRouter{UUID: "cluster-router-UUID", Name: clusterRouter, StaticRoutes: []string{"route-UUID"}},
+		}))
+
+		Expect(DeleteRoutingForMigratedPod(nbClient, netInfo, pod)).To(Succeed())
+		Eventually(nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+			&nbdb.LogicalRouter{UUID: "cluster-router-UUID", Name: clusterRouter},
+		}))
+	})
+})
@@ -33,6 +33,7 @@ const (
 	MulticastClusterOwnerType   ownerType = "MulticastCluster"
 	NetpolNodeOwnerType         ownerType = "NetpolNode"
 	NetpolNamespaceOwnerType    ownerType = "NetpolNamespace"
+	NetpolSharedRuleOwnerType   ownerType = "NetpolSharedRule"
 	VirtualMachineOwnerType     ownerType = "VirtualMachine"
 	UDNEnabledServiceOwnerType  ownerType = "UDNEnabledService"
 	// NetworkPolicyPortIndexOwnerType is the old version of NetworkPolicyOwnerType, kept for sync only
@@ -41,6 +42,8 @@ const (
 	ClusterOwnerType ownerType = "Cluster"
 	// UDNIsolationOwnerType means the object is needed to implement UserDefinedNetwork isolation
 	UDNIsolationOwnerType ownerType = "UDNIsolation"
+	// PodBandwidthOwnerType means the object limits the bandwidth of a pod's logical switch port
+	PodBandwidthOwnerType ownerType = "PodBandwidth"
 
 	// owner extra IDs, make sure to define only 1 ExternalIDKey for every string value
 	PriorityKey           ExternalIDKey = "priority"
@@ -224,6 +227,7 @@ var ACLNetworkPolicyPortIndex = newObjectIDsType(acl, NetworkPolicyPortIndexOwne
 // Therefore unique id for a given gressPolicy is protocol name + IPBlock idx
 // (protocol will be "None" if no port policy is defined, and empty policy and all
 // selector-based peers ACLs will have idx=-1)
+// Stateless policies also have ACLs for the reply traffic, their protocol name has the "-reply" suffix.
 var ACLNetworkPolicy = newObjectIDsType(acl, NetworkPolicyOwnerType, []ExternalIDKey{
 	// policy namespace+name
 	ObjectNameKey,
@@ -235,6 +239,18 @@ var ACLNetworkPolicy = newObjectIDsType(acl, NetworkPolicyOwnerType, []ExternalI
 	IpBlockIndexKey,
 })
 
+// ACLNetpolSharedRule define a unique index for every ACL of a gress rule shared by network policies.
+// Shared rules are identified by the hash of the normalized rule, the rest of the index is the same as
+// for ACLNetworkPolicy.
+var ACLNetpolSharedRule = newObjectIDsType(acl, NetpolSharedRuleOwnerType, []ExternalIDKey{
+	// normalized gress rule hash
+	ObjectNameKey,
+	// egress or ingress
+	PolicyDirectionKey,
+	PortPolicyProtocolKey,
+	IpBlockIndexKey,
+})
+
 var ACLNetpolNamespace = newObjectIDsType(acl, NetpolNamespaceOwnerType, []ExternalIDKey{
 	// namespace
 	ObjectNameKey,
@@ -287,6 +303,12 @@ var PortGroupNetworkPolicy = newObjectIDsType(portGroup, NetworkPolicyOwnerType,
 	ObjectNameKey,
 })
 
+// every gress rule shared by network policies has a port group with the local pods of all these policies.
+var PortGroupNetpolSharedRule = newObjectIDsType(portGroup, NetpolSharedRuleOwnerType, []ExternalIDKey{
+	// normalized gress rule hash
+	ObjectNameKey,
+})
+
 var PortGroupAdminNetworkPolicy = newObjectIDsType(portGroup, AdminNetworkPolicyOwnerType, []ExternalIDKey{
 	// ANP name
 	ObjectNameKey,
@@ -336,6 +358,13 @@ var QoSEgressQoS = newObjectIDsType(qos, EgressQoSOwnerType, []ExternalIDKey{
 	ObjectNameKey,
 })
 
+var QoSPodBandwidth = newObjectIDsType(qos, PodBandwidthOwnerType, []ExternalIDKey{
+	// logical switch port name of the pod
+	ObjectNameKey,
+	// ingress or egress, as seen from the pod
+	PolicyDirectionKey,
+})
+
 var QoSRuleEgressIP = newObjectIDsType(qos, EgressIPOwnerType, []ExternalIDKey{
 	// the priority of the QoSRule
 	PriorityKey,
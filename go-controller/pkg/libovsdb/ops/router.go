@@ -880,6 +880,22 @@ func DeleteBFDs(nbClient libovsdbclient.Client, bfds ...*nbdb.BFD) error {
 	return m.Delete(opModels...)
 }
 
+type bfdPredicate func(*nbdb.BFD) bool
+
+// DeleteBFDsWithPredicate looks up BFDs from the cache based on a given
+// predicate and deletes them
+func DeleteBFDsWithPredicate(nbClient libovsdbclient.Client, p bfdPredicate) error {
+	opModel := operationModel{
+		Model:          &nbdb.BFD{},
+		ModelPredicate: p,
+		ErrNotFound:    false,
+		BulkOp:         true,
+	}
+
+	m := newModelClient(nbClient)
+	return m.Delete(opModel)
+}
+
 func LookupBFD(nbClient libovsdbclient.Client, bfd *nbdb.BFD) (*nbdb.BFD, error) {
 	found := []*nbdb.BFD{}
 	opModel := operationModel{
@@ -212,7 +212,7 @@ func getACLSampleFeature(acl *nbdb.ACL) SampleFeature {
 		return AdminNetworkPolicySample
 	case MulticastNamespaceOwnerType, MulticastClusterOwnerType:
 		return MulticastSample
-	case NetpolNodeOwnerType, NetworkPolicyOwnerType, NetpolNamespaceOwnerType:
+	case NetpolNodeOwnerType, NetworkPolicyOwnerType, NetpolNamespaceOwnerType, NetpolSharedRuleOwnerType:
 		return NetworkPolicySample
 	case EgressFirewallOwnerType:
 		return EgressFirewallSample
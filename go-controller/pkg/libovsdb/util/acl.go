@@ -74,6 +74,9 @@ func GetACLName(dbIDs *libovsdbops.DbObjectIDs) string {
 	case t.IsSameType(libovsdbops.ACLNetworkPolicy):
 		aclName = "NP:" + dbIDs.GetObjectID(libovsdbops.ObjectNameKey) + ":" + dbIDs.GetObjectID(libovsdbops.PolicyDirectionKey) +
 			":" + dbIDs.GetObjectID(libovsdbops.GressIdxKey)
+	case t.IsSameType(libovsdbops.ACLNetpolSharedRule):
+		// shared rules don't belong to a namespace, they are named after the hash of the rule
+		aclName = "NP:shared:" + dbIDs.GetObjectID(libovsdbops.ObjectNameKey) + ":" + dbIDs.GetObjectID(libovsdbops.PolicyDirectionKey)
 	case t.IsSameType(libovsdbops.ACLNetpolNamespace):
 		aclName = "NP:" + dbIDs.GetObjectID(libovsdbops.ObjectNameKey) + ":" + dbIDs.GetObjectID(libovsdbops.PolicyDirectionKey)
 	case t.IsSameType(libovsdbops.ACLEgressFirewall):
@@ -127,6 +130,40 @@ func BuildACL(dbIDs *libovsdbops.DbObjectIDs, priority int, match, action string
 	return ACL
 }
 
+// ACLAuditWouldDrop is the value of the types.ACLAuditExternalID external ID of
+// the ACLs that let through, in audit mode, the traffic they would drop.
+const ACLAuditWouldDrop = "would-drop"
+
+// SetACLAudit puts a drop or reject ACL in audit mode: the ACL is given the
+// provided action instead (allow, or pass to let the lower tiers decide) and is
+// tagged as would-drop in its name and external IDs, so that both the ACL logs
+// and the observability samples report the flows the policy would deny.
+// The flows are always logged, with the deny severity when set.
+func SetACLAudit(acl *nbdb.ACL, action string, logLevels *ACLLoggingLevels) {
+	if acl.Action != nbdb.ACLActionDrop && acl.Action != nbdb.ACLActionReject {
+		return
+	}
+	acl.Action = action
+	externalIDs := make(map[string]string, len(acl.ExternalIDs)+1)
+	for key, value := range acl.ExternalIDs {
+		externalIDs[key] = value
+	}
+	externalIDs[types.ACLAuditExternalID] = ACLAuditWouldDrop
+	acl.ExternalIDs = externalIDs
+	if acl.Name != nil {
+		name := fmt.Sprintf("%.63s", ACLAuditWouldDrop+":"+*acl.Name)
+		acl.Name = &name
+	}
+	log, severity := getACLLogSeverity(acl, logLevels)
+	libovsdbops.SetACLLogging(acl, severity, log)
+}
+
+// IsAuditACL returns whether the ACL lets through, in audit mode, the traffic it
+// would drop
+func IsAuditACL(acl *nbdb.ACL) bool {
+	return acl.ExternalIDs[types.ACLAuditExternalID] == ACLAuditWouldDrop
+}
+
 func BuildANPACL(dbIDs *libovsdbops.DbObjectIDs, priority int, match, action string, aclT ACLPipelineType, logLevels *ACLLoggingLevels) *nbdb.ACL {
 	anpACL := BuildACL(dbIDs, priority, match, action, logLevels, aclT)
 	anpACL.Tier = GetACLTier(dbIDs)
@@ -183,6 +220,19 @@ func getLogSeverity(action string, aclLogging *ACLLoggingLevels) (log bool, seve
 	return log, severity
 }
 
+// getACLLogSeverity returns the log settings of the given ACL, logging the
+// flows of the ACLs in audit mode as the ones they would drop
+func getACLLogSeverity(acl *nbdb.ACL, aclLogging *ACLLoggingLevels) (log bool, severity string) {
+	if !IsAuditACL(acl) {
+		return getLogSeverity(acl.Action, aclLogging)
+	}
+	_, severity = getLogSeverity(nbdb.ACLActionDrop, aclLogging)
+	if severity == "" {
+		severity = nbdb.ACLSeverityNotice
+	}
+	return true, severity
+}
+
 // UpdateACLLoggingWithPredicate finds all ACLs based on a given predicate, updates log settings,
 // then transacts these changes with a single transaction.
 func UpdateACLLoggingWithPredicate(nbClient libovsdbclient.Client, p func(*nbdb.ACL) bool, aclLogging *ACLLoggingLevels) error {
@@ -198,7 +248,7 @@ func UpdateACLLogging(nbClient libovsdbclient.Client, ACLs []*nbdb.ACL, aclLoggi
 		return nil
 	}
 	for i := range ACLs {
-		log, severity := getLogSeverity(ACLs[i].Action, aclLogging)
+		log, severity := getACLLogSeverity(ACLs[i], aclLogging)
 		libovsdbops.SetACLLogging(ACLs[i], severity, log)
 	}
 	ops, err := libovsdbops.UpdateACLsLoggingOps(nbClient, nil, ACLs...)
@@ -226,6 +276,9 @@ const (
 	// NamedPortL4MatchSuffix is used to create ACL for gressPolicy that
 	// has named port policies.
 	NamedPortL4MatchSuffix = "-namedPort"
+	// ReplyL4MatchSuffix is used to create the ACLs that let through the reply
+	// traffic of a stateless gressPolicy.
+	ReplyL4MatchSuffix = "-reply"
 )
 
 // convertK8sProtocolToOVNProtocol returns the OVN syntax-specific protocol value for a v1.Protocol K8s type
@@ -269,7 +322,8 @@ type gressRulePortsForL4ACLMatch struct {
 	portRange []string // list of provided port ranges in OVN ACL format
 }
 
-func getProtocolPortsMap(rulePorts []*NetworkPolicyPort) map[string]*gressRulePortsForL4ACLMatch {
+// getProtocolPortsMap matches the given port field, dst for the rule traffic or src for its reply traffic
+func getProtocolPortsMap(rulePorts []*NetworkPolicyPort, portField string) map[string]*gressRulePortsForL4ACLMatch {
 	gressProtoPortsMap := make(map[string]*gressRulePortsForL4ACLMatch)
 	for _, pp := range rulePorts {
 		gpp, ok := gressProtoPortsMap[pp.Protocol]
@@ -278,7 +332,7 @@ func getProtocolPortsMap(rulePorts []*NetworkPolicyPort) map[string]*gressRulePo
 			gressProtoPortsMap[pp.Protocol] = gpp
 		}
 		if pp.EndPort != 0 && pp.EndPort != pp.Port {
-			gpp.portRange = append(gpp.portRange, fmt.Sprintf("%d<=%s.dst<=%d", pp.Port, pp.Protocol, pp.EndPort))
+			gpp.portRange = append(gpp.portRange, fmt.Sprintf("%d<=%s.%s<=%d", pp.Port, pp.Protocol, portField, pp.EndPort))
 		} else if pp.Port != 0 {
 			gpp.portList = append(gpp.portList, fmt.Sprintf("%d", pp.Port))
 		}
@@ -286,15 +340,15 @@ func getProtocolPortsMap(rulePorts []*NetworkPolicyPort) map[string]*gressRulePo
 	return gressProtoPortsMap
 }
 
-func getL4Match(protocol string, ports *gressRulePortsForL4ACLMatch) string {
+func getL4Match(protocol string, ports *gressRulePortsForL4ACLMatch, portField string) string {
 	allL4Matches := []string{}
 	if len(ports.portList) > 0 {
 		// if there is just one port, then don't use `{}`
-		template := "%s.dst==%s"
+		template := "%s.%s==%s"
 		if len(ports.portList) > 1 {
-			template = "%s.dst=={%s}"
+			template = "%s.%s=={%s}"
 		}
-		allL4Matches = append(allL4Matches, fmt.Sprintf(template, protocol, strings.Join(ports.portList, ",")))
+		allL4Matches = append(allL4Matches, fmt.Sprintf(template, protocol, portField, strings.Join(ports.portList, ",")))
 	}
 	allL4Matches = append(allL4Matches, ports.portRange...)
 	l4Match := protocol
@@ -313,15 +367,25 @@ func getL4Match(protocol string, ports *gressRulePortsForL4ACLMatch) string {
 // It returns a map that has protocol as the key and the l4Match as the value
 // If len(rulePorts)==0; it returns map["None"] = "None" which means there is no L4 match
 func GetL4MatchesFromNetworkPolicyPorts(rulePorts []*NetworkPolicyPort) map[string]string {
+	return getL4MatchesFromNetworkPolicyPorts(rulePorts, "dst")
+}
+
+// GetL4ReplyMatchesFromNetworkPolicyPorts returns the same l4Matches as GetL4MatchesFromNetworkPolicyPorts
+// for the reply traffic, that is sent from the given ports
+func GetL4ReplyMatchesFromNetworkPolicyPorts(rulePorts []*NetworkPolicyPort) map[string]string {
+	return getL4MatchesFromNetworkPolicyPorts(rulePorts, "src")
+}
+
+func getL4MatchesFromNetworkPolicyPorts(rulePorts []*NetworkPolicyPort, portField string) map[string]string {
 	l4Matches := make(map[string]string)
-	gressProtoPortsMap := getProtocolPortsMap(rulePorts)
+	gressProtoPortsMap := getProtocolPortsMap(rulePorts, portField)
 	if len(gressProtoPortsMap) == 0 {
 		gressProtoPortsMap[UnspecifiedL4Protocol] = nil
 	}
 	for protocol, ports := range gressProtoPortsMap {
 		l4Match := UnspecifiedL4Match
 		if ports != nil {
-			l4Match = getL4Match(protocol, ports)
+			l4Match = getL4Match(protocol, ports, portField)
 		}
 		l4Matches[protocol] = l4Match
 	}
@@ -1,11 +1,16 @@
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
 func TestConvertK8sProtocolToOVNProtocol(t *testing.T) {
@@ -160,15 +165,19 @@ func TestGetL4Match(t *testing.T) {
 	}
 
 	for _, tc := range testcases {
-		protocolPortsMap := getProtocolPortsMap(tc.portPolices)
+		protocolPortsMap := getProtocolPortsMap(tc.portPolices, "dst")
 		if tc.expected == "" {
 			assert.Empty(t, protocolPortsMap)
 			continue
 		}
 		assert.Len(t, protocolPortsMap, 1)
 		assert.Contains(t, protocolPortsMap, tc.protocol)
-		l4Match := getL4Match(tc.protocol, protocolPortsMap[tc.protocol])
+		l4Match := getL4Match(tc.protocol, protocolPortsMap[tc.protocol], "dst")
 		assert.Equal(t, tc.expected, l4Match)
+		// the reply traffic is sent from the same ports
+		replyPortsMap := getProtocolPortsMap(tc.portPolices, "src")
+		replyL4Match := getL4Match(tc.protocol, replyPortsMap[tc.protocol], "src")
+		assert.Equal(t, strings.ReplaceAll(tc.expected, ".dst", ".src"), replyL4Match)
 	}
 }
 
@@ -299,3 +308,97 @@ func TestGetL3L4MatchesFromNamedPorts(t *testing.T) {
 		}
 	}
 }
+
+func TestGetACLName(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		dbIDs    *libovsdbops.DbObjectIDs
+		expected string
+	}{
+		{
+			desc: "network policy rule",
+			dbIDs: libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetworkPolicy, "default-network-controller",
+				map[libovsdbops.ExternalIDKey]string{
+					libovsdbops.ObjectNameKey:         "namespace:policy",
+					libovsdbops.PolicyDirectionKey:    string(ACLIngress),
+					libovsdbops.GressIdxKey:           "0",
+					libovsdbops.PortPolicyProtocolKey: "None",
+					libovsdbops.IpBlockIndexKey:       "-1",
+				}),
+			expected: "NP:namespace:policy:Ingress:0",
+		},
+		{
+			desc: "network policy rule shared by policies",
+			dbIDs: libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetpolSharedRule, "default-network-controller",
+				map[libovsdbops.ExternalIDKey]string{
+					libovsdbops.ObjectNameKey:         "a10739487386528939713",
+					libovsdbops.PolicyDirectionKey:    string(ACLEgress),
+					libovsdbops.PortPolicyProtocolKey: "None",
+					libovsdbops.IpBlockIndexKey:       "-1",
+				}),
+			expected: "NP:shared:a10739487386528939713:Egress",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetACLName(tc.dbIDs))
+		})
+	}
+}
+
+func TestSetACLAudit(t *testing.T) {
+	dbIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetpolNamespace, "default-network-controller",
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.ObjectNameKey:      "foo",
+			libovsdbops.PolicyDirectionKey: string(ACLIngress),
+			libovsdbops.TypeKey:            "defaultDeny",
+		})
+	testcases := []struct {
+		desc             string
+		action           string
+		logLevels        *ACLLoggingLevels
+		expectedAction   string
+		expectedSeverity string
+		expectedAudit    bool
+	}{
+		{
+			desc:             "drop ACL logs with the deny severity",
+			action:           nbdb.ACLActionDrop,
+			logLevels:        &ACLLoggingLevels{Allow: nbdb.ACLSeverityInfo, Deny: nbdb.ACLSeverityAlert},
+			expectedAction:   nbdb.ACLActionAllow,
+			expectedSeverity: nbdb.ACLSeverityAlert,
+			expectedAudit:    true,
+		},
+		{
+			desc:             "drop ACL without deny severity logs with notice severity",
+			action:           nbdb.ACLActionDrop,
+			logLevels:        nil,
+			expectedAction:   nbdb.ACLActionAllow,
+			expectedSeverity: nbdb.ACLSeverityNotice,
+			expectedAudit:    true,
+		},
+		{
+			desc:             "allow ACL is left unchanged",
+			action:           nbdb.ACLActionAllow,
+			logLevels:        &ACLLoggingLevels{Allow: nbdb.ACLSeverityInfo, Deny: nbdb.ACLSeverityAlert},
+			expectedAction:   nbdb.ACLActionAllow,
+			expectedSeverity: nbdb.ACLSeverityInfo,
+			expectedAudit:    false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			acl := BuildACL(dbIDs, types.DefaultDenyPriority, "outport == @foo", tc.action, tc.logLevels, LportIngress)
+			SetACLAudit(acl, nbdb.ACLActionAllow, tc.logLevels)
+			assert.Equal(t, tc.expectedAction, acl.Action)
+			assert.Equal(t, tc.expectedAudit, IsAuditACL(acl))
+			assert.True(t, acl.Log)
+			assert.Equal(t, tc.expectedSeverity, *acl.Severity)
+			if tc.expectedAudit {
+				assert.Equal(t, "would-drop:NP:foo:Ingress", *acl.Name)
+				// the external IDs of the dbIDs are not modified
+				assert.NotContains(t, dbIDs.GetExternalIDs(), types.ACLAuditExternalID)
+			}
+		})
+	}
+}
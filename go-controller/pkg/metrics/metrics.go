@@ -494,6 +494,15 @@ func writePlainText(statusCode int, text string, w http.ResponseWriter) {
 	fmt.Fprintln(w, text)
 }
 
+// debugHandlers serves the handlers registered with RegisterDebugHandler
+var debugHandlers = http.NewServeMux()
+
+// RegisterDebugHandler registers a handler for a path under /debug/ovnkube/, served by the metrics
+// server along with pprof when it is enabled
+func RegisterDebugHandler(path string, handler http.Handler) {
+	debugHandlers.Handle(path, handler)
+}
+
 // StartMetricsServer runs the prometheus listener so that OVN K8s metrics can be collected
 // It puts the endpoint behind TLS if certFile and keyFile are defined.
 func StartMetricsServer(bindAddress string, enablePprof bool, certFile string, keyFile string,
@@ -510,6 +519,8 @@ func StartMetricsServer(bindAddress string, enablePprof bool, certFile string, k
 
 		// Allow changes to log level at runtime
 		mux.HandleFunc("/debug/flags/v", stringFlagPutHandler(klogSetter))
+
+		mux.Handle("/debug/ovnkube/", debugHandlers)
 	}
 
 	startMetricsServer(bindAddress, certFile, keyFile, mux, stopChan, wg)
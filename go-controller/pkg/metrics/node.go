@@ -21,6 +21,18 @@ var MetricCNIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOp
 	[]string{"command", "err"},
 )
 
+// MetricCNIRequestPhaseDuration is a prometheus metric that tracks the duration
+// of the phases of CNI ADD requests per network
+var MetricCNIRequestPhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "cni_request_phase_duration_seconds",
+	Help:      "The duration of the phases of CNI ADD requests.",
+	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15)},
+	//labels
+	[]string{"phase", "network"},
+)
+
 var MetricNodeReadyDuration = prometheus.NewGauge(prometheus.GaugeOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemNode,
@@ -52,6 +64,7 @@ func RegisterNodeMetrics(stopChan <-chan struct{}) {
 	registerNodeMetricsOnce.Do(func() {
 		// ovnkube-node metrics
 		prometheus.MustRegister(MetricCNIRequestDuration)
+		prometheus.MustRegister(MetricCNIRequestPhaseDuration)
 		prometheus.MustRegister(MetricNodeReadyDuration)
 		prometheus.MustRegister(metricOvnNodePortEnabled)
 		prometheus.MustRegister(prometheus.NewGaugeFunc(
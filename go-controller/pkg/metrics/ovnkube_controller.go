@@ -271,6 +271,26 @@ var metricEgressFirewallCount = prometheus.NewGauge(prometheus.GaugeOpts{
 	Help:      "The number of egress firewall policies",
 })
 
+var metricRouteImportImportedPrefixes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "route_import_imported_prefixes",
+	Help:      "The number of prefixes learned over BGP imported into a network"},
+	[]string{
+		"network",
+	},
+)
+
+var metricRouteImportRejectedPrefixes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "route_import_rejected_prefixes",
+	Help:      "The number of prefixes learned over BGP rejected by the route import policy of a network"},
+	[]string{
+		"network",
+	},
+)
+
 /** AdminNetworkPolicyMetrics Begin**/
 var metricANPCount = prometheus.NewGauge(prometheus.GaugeOpts{
 	Namespace: MetricOvnkubeNamespace,
@@ -444,6 +464,8 @@ func RegisterOVNKubeControllerFunctional(stopChan <-chan struct{}) {
 	prometheus.MustRegister(metricEgressRoutingViaHost)
 	prometheus.MustRegister(metricANPCount)
 	prometheus.MustRegister(metricBANPCount)
+	prometheus.MustRegister(metricRouteImportImportedPrefixes)
+	prometheus.MustRegister(metricRouteImportRejectedPrefixes)
 	if err := prometheus.Register(MetricResourceRetryFailuresCount); err != nil {
 		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
 			panic(err)
@@ -601,6 +623,19 @@ func UpdateEgressFirewallRuleCount(count float64) {
 	metricEgressFirewallRuleCount.Add(count)
 }
 
+// UpdateRouteImportPrefixes records the number of prefixes learned over BGP
+// that were imported into and rejected for a network.
+func UpdateRouteImportPrefixes(network string, imported, rejected int) {
+	metricRouteImportImportedPrefixes.WithLabelValues(network).Set(float64(imported))
+	metricRouteImportRejectedPrefixes.WithLabelValues(network).Set(float64(rejected))
+}
+
+// DeleteRouteImportPrefixes removes the route import metrics of a network.
+func DeleteRouteImportPrefixes(network string) {
+	metricRouteImportImportedPrefixes.DeleteLabelValues(network)
+	metricRouteImportRejectedPrefixes.DeleteLabelValues(network)
+}
+
 // RecordEgressRoutingViaHost records the egress gateway mode of the cluster
 // The values are:
 // 0: If it is shared gateway mode
@@ -0,0 +1,289 @@
+//go:build linux
+// +build linux
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/sampledecoder"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var policyRuleHitLabels = []string{
+	"kind",
+	"namespace",
+	"name",
+	"direction",
+	"rule_index",
+	"action",
+}
+
+// Descriptors used by the policyRuleHitCollector below. The values are read from the OVS flow
+// counters, so they go back to zero when the flows of a rule are reinstalled.
+var (
+	policyRuleNewConnectionHitPacketsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(MetricOvnkubeNamespace, MetricOvnkubeSubsystemNode, "policy_rule_new_connection_hit_packets_total"),
+		"The number of packets of new connections and stateless traffic that hit the OVS flows of a network "+
+			"policy, admin network policy or baseline admin network policy rule on the node.",
+		policyRuleHitLabels, nil,
+	)
+	policyRuleNewConnectionHitBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(MetricOvnkubeNamespace, MetricOvnkubeSubsystemNode, "policy_rule_new_connection_hit_bytes_total"),
+		"The number of bytes of new connections and stateless traffic that hit the OVS flows of a network "+
+			"policy, admin network policy or baseline admin network policy rule on the node.",
+		policyRuleHitLabels, nil,
+	)
+)
+
+// aclSampleTablesRefreshUpdates is the number of updates after which the whole bridge is dumped
+// again to find the OpenFlow tables of ACLs that were sampled since the tables were last learnt.
+const aclSampleTablesRefreshUpdates = 10
+
+// aclSampleRegex matches the sample action OVN adds to the flows of sampled ACLs. Only literal
+// observation point IDs are matched: the flows of established connections are shared by all the
+// ACLs and load the observation point ID from the conntrack label, so their hits can't be
+// attributed to a rule.
+var aclSampleRegex = regexp.MustCompile(`obs_domain_id=(\d+),obs_point_id=(\d+)[,)]`)
+
+// aclSampleDecoder maps observability sample IDs to the ACL owner
+type aclSampleDecoder interface {
+	DecodeCookieIDs(obsDomainID, obsPointID uint32) (model.NetworkEvent, error)
+}
+
+type aclSample struct {
+	obsDomainID uint32
+	obsPointID  uint32
+}
+
+type flowStats struct {
+	packets float64
+	bytes   float64
+}
+
+// aclSampleTableRegex matches the OpenFlow table of a dumped flow
+var aclSampleTableRegex = regexp.MustCompile(`[ ,]table=(\d+),`)
+
+// getACLSampleFlowStats returns the stats of the bridge flows carrying an ACL sample action,
+// summed up by observation domain and point IDs, along with the tables holding those flows. Only
+// the given tables are dumped, or the whole bridge if none is given.
+func getACLSampleFlowStats(ovsOfctl ovsClient, bridgeName string, tables []string) (map[aclSample]*flowStats, []string, error) {
+	stats := map[aclSample]*flowStats{}
+	sampleTables := sets.New[string]()
+	filters := []string{""}
+	if len(tables) > 0 {
+		filters = make([]string, 0, len(tables))
+		for _, table := range tables {
+			filters = append(filters, "table="+table)
+		}
+	}
+	for _, filter := range filters {
+		args := []string{"-t", "5", "-O", "OpenFlow15", "--no-names", "dump-flows", bridgeName}
+		if filter != "" {
+			args = append(args, filter)
+		}
+		stdout, stderr, err := ovsOfctl(args...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dump flows for %s, stderr(%s): (%v)", bridgeName, stderr, err)
+		}
+		if stderr != "" {
+			return nil, nil, fmt.Errorf("failed to dump flows for %s due to stderr: %s", bridgeName, stderr)
+		}
+		for _, flow := range strings.Split(stdout, "\n") {
+			match := aclSampleRegex.FindStringSubmatch(flow)
+			if match == nil {
+				continue
+			}
+			obsDomainID, err := strconv.ParseUint(match[1], 10, 32)
+			if err != nil {
+				continue
+			}
+			// only the samples of the ACL flows of new connections are mapped to policy rules
+			if obsDomainID>>24 != observability.ACLNewTrafficSamplingID {
+				continue
+			}
+			obsPointID, err := strconv.ParseUint(match[2], 10, 32)
+			if err != nil {
+				continue
+			}
+			if tableMatch := aclSampleTableRegex.FindStringSubmatch(flow); tableMatch != nil {
+				sampleTables.Insert(tableMatch[1])
+			}
+			var packets, bytes float64
+			for _, field := range strings.FieldsFunc(flow, func(r rune) bool { return r == ',' || r == ' ' }) {
+				if value, found := strings.CutPrefix(field, "n_packets="); found {
+					packets = parseMetricToFloat(MetricOvnkubeSubsystemNode, "policy_rule_new_connection_hit_packets_total", value)
+				} else if value, found := strings.CutPrefix(field, "n_bytes="); found {
+					bytes = parseMetricToFloat(MetricOvnkubeSubsystemNode, "policy_rule_new_connection_hit_bytes_total", value)
+				}
+			}
+			sample := aclSample{obsDomainID: uint32(obsDomainID), obsPointID: uint32(obsPointID)}
+			if stats[sample] == nil {
+				stats[sample] = &flowStats{}
+			}
+			stats[sample].packets += packets
+			stats[sample].bytes += bytes
+		}
+	}
+	return stats, sets.List(sampleTables), nil
+}
+
+// policyRuleHitCollector exports the new connection hit counters of the policy rules computed by
+// the last update, so that scrapes don't dump the flows of br-int.
+type policyRuleHitCollector struct {
+	ovsOfctl ovsClient
+
+	lock sync.Mutex
+	// tables are the OpenFlow tables holding the sampled ACL flows, learnt from a full dump of br-int
+	tables []string
+	// updates counts the updates since the tables were learnt
+	updates   int
+	ruleStats map[[6]string]*flowStats
+}
+
+func newPolicyRuleHitCollector(ovsOfctl ovsClient) *policyRuleHitCollector {
+	return &policyRuleHitCollector{
+		ovsOfctl:  ovsOfctl,
+		ruleStats: map[[6]string]*flowStats{},
+	}
+}
+
+// getFlowStats dumps the flows of the learnt ACL sample tables. The whole bridge is dumped when
+// the tables aren't known yet, periodically to catch the ACL stages that got sampled flows since
+// then, and when the learnt tables don't hold any sampled flow anymore, e.g. after the OVN
+// pipeline was renumbered.
+func (c *policyRuleHitCollector) getFlowStats() (map[aclSample]*flowStats, error) {
+	if len(c.tables) > 0 && c.updates < aclSampleTablesRefreshUpdates {
+		stats, _, err := getACLSampleFlowStats(c.ovsOfctl, "br-int", c.tables)
+		if err != nil {
+			return nil, err
+		}
+		if len(stats) > 0 {
+			c.updates++
+			return stats, nil
+		}
+	}
+	stats, tables, err := getACLSampleFlowStats(c.ovsOfctl, "br-int", nil)
+	if err != nil {
+		return nil, err
+	}
+	c.tables = tables
+	c.updates = 0
+	return stats, nil
+}
+
+// update computes the new connection hit counters of every network policy, admin network policy
+// and baseline admin network policy rule that has sampled flows on br-int.
+func (c *policyRuleHitCollector) update(decoder aclSampleDecoder) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stats, err := c.getFlowStats()
+	if err != nil {
+		return err
+	}
+	// multiple ACLs may be created for the same rule, e.g. one per protocol
+	ruleStats := map[[6]string]*flowStats{}
+	for sample, sampleStats := range stats {
+		event, err := decoder.DecodeCookieIDs(sample.obsDomainID, sample.obsPointID)
+		if err != nil {
+			klog.V(5).Infof("Failed to decode ACL sample %+v: %v", sample, err)
+			continue
+		}
+		aclEvent, ok := event.(*model.ACLEvent)
+		if !ok {
+			continue
+		}
+		switch aclEvent.Actor {
+		case libovsdbops.NetworkPolicyOwnerType, libovsdbops.NetpolSharedRuleOwnerType,
+			libovsdbops.AdminNetworkPolicyOwnerType, libovsdbops.BaselineAdminNetworkPolicyOwnerType:
+		default:
+			continue
+		}
+		labels := [6]string{aclEvent.Actor, aclEvent.Namespace, aclEvent.Name, aclEvent.Direction,
+			aclEvent.RuleIndex, aclEvent.Action}
+		if ruleStats[labels] == nil {
+			ruleStats[labels] = &flowStats{}
+		}
+		ruleStats[labels].packets += sampleStats.packets
+		ruleStats[labels].bytes += sampleStats.bytes
+	}
+	// replaced as a whole to stop exporting the rules that were removed
+	c.ruleStats = ruleStats
+	return nil
+}
+
+// Describe is implemented with DescribeByCollect: the collector always returns metrics with the
+// same two descriptors.
+func (c *policyRuleHitCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+// Collect creates constant counters from the stats of the last update.
+func (c *policyRuleHitCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for labels, s := range c.ruleStats {
+		ch <- prometheus.MustNewConstMetric(policyRuleNewConnectionHitPacketsDesc, prometheus.CounterValue, s.packets, labels[:]...)
+		ch <- prometheus.MustNewConstMetric(policyRuleNewConnectionHitBytesDesc, prometheus.CounterValue, s.bytes, labels[:]...)
+	}
+}
+
+// policyRuleHitMetricsUpdater updates the policy rule hit metrics, connecting to the local
+// northbound database on the first successful iteration.
+func policyRuleHitMetricsUpdater(collector *policyRuleHitCollector, metricsScrapeInterval int, stopChan <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticker := time.NewTicker(time.Duration(metricsScrapeInterval) * time.Second)
+	defer ticker.Stop()
+	var decoder *sampledecoder.SampleDecoder
+	for {
+		select {
+		case <-ticker.C:
+			if decoder == nil {
+				basePath, err := getNBDBSockPath()
+				if err != nil {
+					klog.Errorf("Policy rule hit metrics can't be fetched: %v", err)
+					continue
+				}
+				decoder, err = sampledecoder.NewSampleDecoder(ctx, basePath+"ovnnb_db.sock")
+				if err != nil {
+					klog.Errorf("Failed to create sample decoder for policy rule hit metrics: %v", err)
+					decoder = nil
+					continue
+				}
+			}
+			if err := collector.update(decoder); err != nil {
+				klog.Errorf("Updating policy rule hit metrics failed: %v", err)
+			}
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+var registerPolicyRuleHitMetricsOnce sync.Once
+
+// RegisterPolicyRuleHitMetrics registers the per rule new connection hit counters of network policies and
+// admin network policies with the OVN metrics server. The counters are built from the flows of sampled
+// ACLs, so they require the observability feature to be enabled.
+func RegisterPolicyRuleHitMetrics(metricsScrapeInterval int, stopChan <-chan struct{}) {
+	registerPolicyRuleHitMetricsOnce.Do(func() {
+		collector := newPolicyRuleHitCollector(util.RunOVSOfctl)
+		ovnRegistry.MustRegister(collector)
+		go policyRuleHitMetricsUpdater(collector, metricsScrapeInterval, stopChan)
+	})
+}
@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+)
+
+// fakeACLSampleDecoder decodes the samples with the events it was built with
+type fakeACLSampleDecoder map[aclSample]model.NetworkEvent
+
+func (d fakeACLSampleDecoder) DecodeCookieIDs(obsDomainID, obsPointID uint32) (model.NetworkEvent, error) {
+	event, ok := d[aclSample{obsDomainID: obsDomainID, obsPointID: obsPointID}]
+	if !ok {
+		return nil, fmt.Errorf("sample not found")
+	}
+	return event, nil
+}
+
+// getPolicyRuleHitCounters returns the values of the counters exported by the collector for the
+// given descriptor, keyed by their comma separated label values.
+func getPolicyRuleHitCounters(collector *policyRuleHitCollector, desc *prometheus.Desc) map[string]float64 {
+	ch := make(chan prometheus.Metric, 20)
+	collector.Collect(ch)
+	close(ch)
+	counters := map[string]float64{}
+	for m := range ch {
+		if m.Desc() != desc {
+			continue
+		}
+		metric := &dto.Metric{}
+		gomega.Expect(m.Write(metric)).To(gomega.Succeed())
+		gomega.Expect(metric.GetCounter()).NotTo(gomega.BeNil())
+		labelValues := map[string]string{}
+		for _, label := range metric.GetLabel() {
+			labelValues[label.GetName()] = label.GetValue()
+		}
+		key := make([]string, 0, len(policyRuleHitLabels))
+		for _, name := range policyRuleHitLabels {
+			key = append(key, labelValues[name])
+		}
+		counters[strings.Join(key, ",")] = metric.GetCounter().GetValue()
+	}
+	return counters
+}
+
+var _ = ginkgo.Describe("Policy rule hit metrics", func() {
+	// obs_domain_id carries the observability app ID in the 8 most significant bits
+	const (
+		aclNewDomainID = 2<<24 + 1
+		aclEstDomainID = 3<<24 + 1
+		dropDomainID   = 1<<24 + 1
+	)
+	dumpFlowsOutput := strings.Join([]string{
+		fmt.Sprintf(" cookie=0x1b2c3d4e, duration=10.1s, table=44, n_packets=10, n_bytes=1000, priority=2001,ct_state=+new+trk,ip,reg15=0x3,metadata=0x2 actions=sample(probability=65535,collector_set_id=42,obs_domain_id=%d,obs_point_id=1),resubmit(,45)", aclNewDomainID),
+		fmt.Sprintf(" cookie=0x1b2c3d4f, duration=10.1s, table=44, n_packets=5, n_bytes=500, priority=2001,ct_state=+new+trk,ipv6,reg15=0x3,metadata=0x2 actions=sample(probability=65535,collector_set_id=42,obs_domain_id=%d,obs_point_id=1),resubmit(,45)", aclNewDomainID),
+		// both the tcp and udp ACL of the same rule
+		fmt.Sprintf(" cookie=0x2b2c3d4e, duration=10.1s, table=44, n_packets=3, n_bytes=300, priority=2000,tcp,reg15=0x3,metadata=0x2 actions=sample(probability=65535,collector_set_id=42,obs_domain_id=%d,obs_point_id=2),resubmit(,45)", aclNewDomainID),
+		fmt.Sprintf(" cookie=0x2b2c3d4f, duration=10.1s, table=44, n_packets=4, n_bytes=400, priority=2000,udp,reg15=0x3,metadata=0x2 actions=sample(probability=65535,collector_set_id=42,obs_domain_id=%d,obs_point_id=3),resubmit(,45)", aclNewDomainID),
+		// established traffic flows load the observation point ID from the conntrack label
+		fmt.Sprintf(" cookie=0x3b2c3d4e, duration=10.1s, table=44, n_packets=100, n_bytes=10000, priority=2000,ct_state=+est+trk,ip,metadata=0x2 actions=sample(probability=65535,collector_set_id=42,obs_domain_id=%d,obs_point_id=NXM_NX_CT_LABEL[96..127]),resubmit(,45)", aclEstDomainID),
+		fmt.Sprintf(" cookie=0x4b2c3d4e, duration=10.1s, table=44, n_packets=7, n_bytes=700, priority=2000,ip,metadata=0x2 actions=sample(probability=65535,collector_set_id=42,obs_domain_id=%d,obs_point_id=4),drop", dropDomainID),
+		// egress firewall ACLs are sampled but are not policy rules
+		fmt.Sprintf(" cookie=0x5b2c3d4e, duration=10.1s, table=44, n_packets=8, n_bytes=800, priority=2000,ip,metadata=0x2 actions=sample(probability=65535,collector_set_id=42,obs_domain_id=%d,obs_point_id=5),resubmit(,45)", aclNewDomainID),
+		" cookie=0x0, duration=10.1s, table=45, n_packets=1000, n_bytes=100000, priority=0,metadata=0x2 actions=resubmit(,46)",
+	}, "\n")
+	decoder := fakeACLSampleDecoder{
+		{obsDomainID: aclNewDomainID, obsPointID: 1}: &model.ACLEvent{Action: "allow-related",
+			Actor: libovsdbops.NetworkPolicyOwnerType, Namespace: "demo", Name: "allow-from-client", Direction: "Ingress", RuleIndex: "0"},
+		{obsDomainID: aclNewDomainID, obsPointID: 2}: &model.ACLEvent{Action: "drop",
+			Actor: libovsdbops.AdminNetworkPolicyOwnerType, Name: "cluster-control", Direction: "Egress", RuleIndex: "5"},
+		{obsDomainID: aclNewDomainID, obsPointID: 3}: &model.ACLEvent{Action: "drop",
+			Actor: libovsdbops.AdminNetworkPolicyOwnerType, Name: "cluster-control", Direction: "Egress", RuleIndex: "5"},
+		{obsDomainID: aclNewDomainID, obsPointID: 5}: &model.ACLEvent{Action: "allow",
+			Actor: libovsdbops.EgressFirewallOwnerType, Namespace: "demo", Direction: "Egress"},
+	}
+
+	netpolLabels := strings.Join([]string{libovsdbops.NetworkPolicyOwnerType, "demo", "allow-from-client", "Ingress", "0", "allow-related"}, ",")
+	anpLabels := strings.Join([]string{libovsdbops.AdminNetworkPolicyOwnerType, "", "cluster-control", "Egress", "5", "drop"}, ",")
+
+	ginkgo.It("exports the hit counters of the sampled policy rules", func() {
+		ovsOfctl := NewFakeOVSClient([]clientOutput{{stdout: dumpFlowsOutput}})
+		collector := newPolicyRuleHitCollector(ovsOfctl.FakeCall)
+		err := collector.update(decoder)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(getPolicyRuleHitCounters(collector, policyRuleNewConnectionHitPacketsDesc)).To(gomega.Equal(map[string]float64{
+			netpolLabels: 15,
+			anpLabels:    7,
+		}))
+		gomega.Expect(getPolicyRuleHitCounters(collector, policyRuleNewConnectionHitBytesDesc)).To(gomega.Equal(map[string]float64{
+			netpolLabels: 1500,
+			anpLabels:    700,
+		}))
+	})
+
+	ginkgo.It("only dumps the tables of the sampled ACL flows once they are learnt", func() {
+		var calls [][]string
+		ovsOfctl := func(args ...string) (string, string, error) {
+			calls = append(calls, args)
+			return dumpFlowsOutput, "", nil
+		}
+		collector := newPolicyRuleHitCollector(ovsOfctl)
+		for i := 0; i <= aclSampleTablesRefreshUpdates+1; i++ {
+			gomega.Expect(collector.update(decoder)).To(gomega.Succeed())
+		}
+		gomega.Expect(calls).To(gomega.HaveLen(aclSampleTablesRefreshUpdates + 2))
+		for i, args := range calls {
+			lastArg := args[len(args)-1]
+			if i == 0 || i == aclSampleTablesRefreshUpdates+1 {
+				// the bridge is dumped as a whole first and then periodically
+				gomega.Expect(lastArg).To(gomega.Equal("br-int"))
+			} else {
+				gomega.Expect(lastArg).To(gomega.Equal("table=44"))
+			}
+		}
+		gomega.Expect(getPolicyRuleHitCounters(collector, policyRuleNewConnectionHitPacketsDesc)).To(gomega.HaveLen(2))
+	})
+
+	ginkgo.It("stops exporting the rules that are gone", func() {
+		// the learnt table holds no sampled flow anymore so the whole bridge is dumped again
+		ovsOfctl := NewFakeOVSClient([]clientOutput{{stdout: dumpFlowsOutput}, {stdout: ""}, {stdout: ""}})
+		collector := newPolicyRuleHitCollector(ovsOfctl.FakeCall)
+		err := collector.update(decoder)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(getPolicyRuleHitCounters(collector, policyRuleNewConnectionHitPacketsDesc)).To(gomega.HaveLen(2))
+		err = collector.update(decoder)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(getPolicyRuleHitCounters(collector, policyRuleNewConnectionHitPacketsDesc)).To(gomega.BeEmpty())
+		gomega.Expect(getPolicyRuleHitCounters(collector, policyRuleNewConnectionHitBytesDesc)).To(gomega.BeEmpty())
+		gomega.Expect(collector.tables).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("returns error when OVS ofctl returns non-blank stderr", func() {
+		ovsOfctl := NewFakeOVSClient([]clientOutput{{stderr: "ovs-ofctl: br-int is not a bridge or a socket"}})
+		collector := newPolicyRuleHitCollector(ovsOfctl.FakeCall)
+		err := collector.update(decoder)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+	})
+})
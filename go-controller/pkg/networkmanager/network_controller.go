@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"sync"
 	"time"
@@ -411,14 +412,16 @@ func (c *networkController) setAdvertisements(network util.MutableNetInfo) error
 
 	podAdvertisements := map[string][]string{}
 	eipAdvertisements := map[string][]string{}
-	for raName := range raNames {
+	var importPolicies []*ratypes.RouteImportPolicy
+	// process in order so that the resulting import policy is stable
+	for _, raName := range sets.List(raNames) {
 		ra, err := c.raLister.Get(raName)
 		if err != nil {
 			return err
 		}
 
 		advertisements := sets.New(ra.Spec.Advertisements...)
-		if !advertisements.Has(ratypes.PodNetwork) {
+		if !advertisements.Has(ratypes.PodNetwork) && !advertisements.Has(ratypes.EgressIP) {
 			continue
 		}
 
@@ -428,11 +431,23 @@ func (c *networkController) setAdvertisements(network util.MutableNetInfo) error
 			continue
 		}
 		if accepted.Status != metav1.ConditionTrue || accepted.ObservedGeneration != ra.Generation {
+			if !advertisements.Has(ratypes.PodNetwork) {
+				// EgressIP advertisements don't change the pod network of the
+				// node, no need to hold the rest of the network configuration
+				klog.Warningf("Ignoring EgressIP advertisements of RouteAdvertisements %q for network %q: not in accepted status",
+					ra.Name, network.GetNetworkName())
+				continue
+			}
 			// if the RA is not accepted, we commit to no change, best to
 			// preserve the old config while we can't validate new config
 			return fmt.Errorf("failed to reconcile network %q: RouteAdvertisements %q not in accepted status", network.GetNetworkName(), ra.Name)
 		}
 
+		if err := validateRouteImportPolicy(ra.Spec.RouteImportPolicy); err != nil {
+			return fmt.Errorf("failed to reconcile network %q: invalid route import policy on RouteAdvertisements %q: %w", network.GetNetworkName(), ra.Name, err)
+		}
+		importPolicies = append(importPolicies, ra.Spec.RouteImportPolicy)
+
 		nodeSelector, err := metav1.LabelSelectorAsSelector(&ra.Spec.NodeSelector)
 		if err != nil {
 			return err
@@ -444,8 +459,12 @@ func (c *networkController) setAdvertisements(network util.MutableNetInfo) error
 		}
 
 		vrf := ra.Spec.TargetVRF
-		if vrf == "" {
+		switch vrf {
+		case "":
 			vrf = types.DefaultNetworkName
+		case "auto":
+			// with 'auto', each network is advertised on its own VRF
+			vrf = util.GetNetworkVRFName(network)
 		}
 
 		for _, node := range nodes {
@@ -462,9 +481,65 @@ func (c *networkController) setAdvertisements(network util.MutableNetInfo) error
 	}
 	network.SetPodNetworkAdvertisedVRFs(podAdvertisements)
 	network.SetEgressIPAdvertisedVRFs(eipAdvertisements)
+	network.SetRouteImportPolicy(mergeRouteImportPolicies(importPolicies))
+	return nil
+}
+
+// validateRouteImportPolicy checks the prefixes of the route import policy of
+// a RouteAdvertisements
+func validateRouteImportPolicy(raPolicy *ratypes.RouteImportPolicy) error {
+	if raPolicy == nil {
+		return nil
+	}
+	for _, prefixes := range [][]ratypes.CIDR{raPolicy.AllowedPrefixes, raPolicy.DeniedPrefixes} {
+		for _, prefix := range prefixes {
+			if _, _, err := net.ParseCIDR(string(prefix)); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// mergeRouteImportPolicies combines the validated route import policies of the
+// RouteAdvertisements selecting the same network: denied prefixes add up, the
+// lowest maximum number of prefixes applies and default routes are ignored if
+// any of the policies says so. Allowed prefixes add up too, unless one of the
+// RouteAdvertisements has no allowed prefixes, which allows all of them. It
+// returns nil if none of the RouteAdvertisements has a route import policy.
+func mergeRouteImportPolicies(raPolicies []*ratypes.RouteImportPolicy) *util.RouteImportPolicy {
+	var policy *util.RouteImportPolicy
+	allowAll := false
+	for _, raPolicy := range raPolicies {
+		if raPolicy == nil {
+			allowAll = true
+			continue
+		}
+		if policy == nil {
+			policy = &util.RouteImportPolicy{}
+		}
+		if len(raPolicy.AllowedPrefixes) == 0 {
+			allowAll = true
+		}
+		for _, prefix := range raPolicy.AllowedPrefixes {
+			_, ipNet, _ := net.ParseCIDR(string(prefix))
+			policy.AllowedPrefixes = append(policy.AllowedPrefixes, ipNet)
+		}
+		for _, prefix := range raPolicy.DeniedPrefixes {
+			_, ipNet, _ := net.ParseCIDR(string(prefix))
+			policy.DeniedPrefixes = append(policy.DeniedPrefixes, ipNet)
+		}
+		if raPolicy.MaxPrefixes != nil && (policy.MaxPrefixes == 0 || int(*raPolicy.MaxPrefixes) < policy.MaxPrefixes) {
+			policy.MaxPrefixes = int(*raPolicy.MaxPrefixes)
+		}
+		policy.IgnoreDefaultRoute = policy.IgnoreDefaultRoute || raPolicy.IgnoreDefaultRoute
+	}
+	if policy != nil && allowAll {
+		policy.AllowedPrefixes = nil
+	}
+	return policy
+}
+
 func (c *networkController) hasRouteAdvertisements() bool {
 	return util.IsRouteAdvertisementsEnabled()
 }
@@ -2,6 +2,7 @@ package networkmanager
 
 import (
 	"context"
+	"net"
 	"testing"
 
 	cnitypes "github.com/containernetworking/cni/pkg/types"
@@ -15,6 +16,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	ratypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -81,12 +83,42 @@ func TestSetAdvertisements(t *testing.T) {
 			},
 		},
 	}
+	eipAutoRA := ratypes.RouteAdvertisements{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testRAName,
+		},
+		Spec: ratypes.RouteAdvertisementsSpec{
+			TargetVRF:    "auto",
+			NodeSelector: metav1.LabelSelector{},
+			Advertisements: []ratypes.AdvertisementType{
+				ratypes.EgressIP,
+			},
+		},
+		Status: ratypes.RouteAdvertisementsStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   "Accepted",
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+	importPolicyRA := *podNetworkRA.DeepCopy()
+	maxPrefixes := int32(10)
+	importPolicyRA.Spec.RouteImportPolicy = &ratypes.RouteImportPolicy{
+		AllowedPrefixes:    []ratypes.CIDR{"10.0.0.0/8"},
+		DeniedPrefixes:     []ratypes.CIDR{"10.1.0.0/16", "fd00::/64"},
+		MaxPrefixes:        &maxPrefixes,
+		IgnoreDefaultRoute: true,
+	}
 	podNetworkRANotAccepted := podNetworkRA
 	podNetworkRANotAccepted.Status = ratypes.RouteAdvertisementsStatus{}
 	podNetworkRARejected := *podNetworkRA.DeepCopy()
 	podNetworkRARejected.Status.Conditions[0].Status = metav1.ConditionFalse
 	podNetworkRAOutdated := podNetworkRA
 	podNetworkRAOutdated.Generation = 1
+	eipRARejected := *eipAutoRA.DeepCopy()
+	eipRARejected.Status.Conditions[0].Status = metav1.ConditionFalse
 
 	testNode := corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -114,6 +146,8 @@ func TestSetAdvertisements(t *testing.T) {
 		node            corev1.Node
 		expectNoNetwork bool
 		expected        map[string][]string
+		expectedEIP     map[string][]string
+		expectedPolicy  *util.RouteImportPolicy
 	}{
 		{
 			name:    "reconciles VRF advertisements for selected node of default node network controller",
@@ -133,6 +167,30 @@ func TestSetAdvertisements(t *testing.T) {
 				testNodeOnZoneName: {testVRFName},
 			},
 		},
+		{
+			name:    "reconciles EgressIP advertisements on the network VRF with auto target VRF",
+			network: defaultNetwork,
+			ra:      &eipAutoRA,
+			node:    testNode,
+			expectedEIP: map[string][]string{
+				testNodeName: {types.DefaultNetworkName},
+			},
+		},
+		{
+			name:    "reconciles route import policy",
+			network: primaryNetwork,
+			ra:      &importPolicyRA,
+			node:    testNodeOnZone,
+			expected: map[string][]string{
+				testNodeOnZoneName: {testVRFName},
+			},
+			expectedPolicy: &util.RouteImportPolicy{
+				AllowedPrefixes:    []*net.IPNet{ovntest.MustParseIPNet("10.0.0.0/8")},
+				DeniedPrefixes:     []*net.IPNet{ovntest.MustParseIPNet("10.1.0.0/16"), ovntest.MustParseIPNet("fd00::/64")},
+				MaxPrefixes:        10,
+				IgnoreDefaultRoute: true,
+			},
+		},
 		{
 			name:    "ignores advertisements that are not for the pod network",
 			network: defaultNetwork,
@@ -158,6 +216,12 @@ func TestSetAdvertisements(t *testing.T) {
 			node:            testNode,
 			expectNoNetwork: true,
 		},
+		{
+			name:    "ignores EgressIP advertisements that are rejected",
+			network: primaryNetwork,
+			ra:      &eipRARejected,
+			node:    testNode,
+		},
 		{
 			name:            "fails for advertisements that are old",
 			network:         primaryNetwork,
@@ -233,6 +297,11 @@ func TestSetAdvertisements(t *testing.T) {
 					tt.expected = map[string][]string{}
 				}
 				g.Expect(reconcilable.GetPodNetworkAdvertisedVRFs()).To(gomega.Equal(tt.expected))
+				if tt.expectedEIP == nil {
+					tt.expectedEIP = map[string][]string{}
+				}
+				g.Expect(reconcilable.GetEgressIPAdvertisedVRFs()).To(gomega.Equal(tt.expectedEIP))
+				g.Expect(reconcilable.GetRouteImportPolicy()).To(gomega.Equal(tt.expectedPolicy))
 			}
 
 			g.Eventually(meetsExpectations).Should(gomega.Succeed())
@@ -240,3 +309,63 @@ func TestSetAdvertisements(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeRouteImportPolicies(t *testing.T) {
+	maxPrefixes := int32(10)
+	lowerMaxPrefixes := int32(5)
+	allowList := &ratypes.RouteImportPolicy{
+		AllowedPrefixes: []ratypes.CIDR{"10.0.0.0/8"},
+		DeniedPrefixes:  []ratypes.CIDR{"10.1.0.0/16"},
+		MaxPrefixes:     &maxPrefixes,
+	}
+	otherAllowList := &ratypes.RouteImportPolicy{
+		AllowedPrefixes:    []ratypes.CIDR{"fd00::/64"},
+		MaxPrefixes:        &lowerMaxPrefixes,
+		IgnoreDefaultRoute: true,
+	}
+	denyList := &ratypes.RouteImportPolicy{
+		DeniedPrefixes: []ratypes.CIDR{"192.168.0.0/16"},
+	}
+	tests := []struct {
+		name       string
+		raPolicies []*ratypes.RouteImportPolicy
+		expected   *util.RouteImportPolicy
+	}{
+		{
+			name:       "no route import policy",
+			raPolicies: []*ratypes.RouteImportPolicy{nil, nil},
+		},
+		{
+			name:       "allowed prefixes add up",
+			raPolicies: []*ratypes.RouteImportPolicy{allowList, otherAllowList},
+			expected: &util.RouteImportPolicy{
+				AllowedPrefixes:    []*net.IPNet{ovntest.MustParseIPNet("10.0.0.0/8"), ovntest.MustParseIPNet("fd00::/64")},
+				DeniedPrefixes:     []*net.IPNet{ovntest.MustParseIPNet("10.1.0.0/16")},
+				MaxPrefixes:        5,
+				IgnoreDefaultRoute: true,
+			},
+		},
+		{
+			name:       "a policy without allowed prefixes allows all of them",
+			raPolicies: []*ratypes.RouteImportPolicy{allowList, denyList},
+			expected: &util.RouteImportPolicy{
+				DeniedPrefixes: []*net.IPNet{ovntest.MustParseIPNet("10.1.0.0/16"), ovntest.MustParseIPNet("192.168.0.0/16")},
+				MaxPrefixes:    10,
+			},
+		},
+		{
+			name:       "a RouteAdvertisements without route import policy allows all prefixes",
+			raPolicies: []*ratypes.RouteImportPolicy{nil, allowList},
+			expected: &util.RouteImportPolicy{
+				DeniedPrefixes: []*net.IPNet{ovntest.MustParseIPNet("10.1.0.0/16")},
+				MaxPrefixes:    10,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(mergeRouteImportPolicies(tt.raPolicies)).To(gomega.Equal(tt.expected))
+		})
+	}
+}
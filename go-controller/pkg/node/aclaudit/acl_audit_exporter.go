@@ -0,0 +1,432 @@
+//go:build linux
+// +build linux
+
+package aclaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"golang.org/x/time/rate"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/sampledecoder"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// collectorOwner is the owner of the OVS collector set the exporter creates to receive the samples
+	collectorOwner = "ovnkube-node-acl-audit"
+	// psampleGroupID is the psample group the OVS collector set sends the samples to
+	psampleGroupID = 124
+
+	retryInterval = 10 * time.Second
+	// the local pods are indexed by IP again when a sample has an unknown IP, but not more than once per
+	// podIndexMinAge, and at least once per podIndexMaxAge to forget the IPs of deleted pods
+	podIndexMinAge = time.Second
+	podIndexMaxAge = 30 * time.Second
+)
+
+// Entry is an ACL audit log entry, written as a JSON line
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Namespace and Pod identify the local pod the ACL was applied to
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	// Severity is the ACL logging severity set for the verdict by the k8s.ovn.org/acl-logging annotation
+	// of the namespace
+	Severity string `json:"severity"`
+	// Verdict is the ACL action, or "would-drop" for the ACLs of policies in audit mode
+	Verdict         string `json:"verdict"`
+	Direction       string `json:"direction,omitempty"`
+	PolicyKind      string `json:"policyKind"`
+	PolicyNamespace string `json:"policyNamespace,omitempty"`
+	PolicyName      string `json:"policyName,omitempty"`
+	RuleIndex       string `json:"ruleIndex,omitempty"`
+	Message         string `json:"message"`
+	Protocol        string `json:"protocol,omitempty"`
+	SrcIP           string `json:"srcIP"`
+	SrcPort         uint16 `json:"srcPort,omitempty"`
+	SrcPod          string `json:"srcPod,omitempty"`
+	DstIP           string `json:"dstIP"`
+	DstPort         uint16 `json:"dstPort,omitempty"`
+	DstPod          string `json:"dstPod,omitempty"`
+	// Suppressed is the number of entries of the namespace dropped by its rate limit since the previous entry
+	Suppressed uint64 `json:"suppressed,omitempty"`
+}
+
+// sample is a packet sample of an ACL
+type sample struct {
+	cookie           []byte
+	srcIP, dstIP     net.IP
+	protocol         string
+	srcPort, dstPort uint16
+}
+
+// parseSamplePacket fills in the addresses and ports of the sampled ethernet frame
+func parseSamplePacket(data []byte, s *sample) {
+	packet := gopacket.NewPacket(data, layers.LayerTypeEthernet, gopacket.Lazy)
+	switch l := packet.NetworkLayer().(type) {
+	case *layers.IPv4:
+		s.srcIP, s.dstIP = l.SrcIP, l.DstIP
+	case *layers.IPv6:
+		s.srcIP, s.dstIP = l.SrcIP, l.DstIP
+	}
+	switch l := packet.TransportLayer().(type) {
+	case *layers.TCP:
+		s.protocol, s.srcPort, s.dstPort = string(corev1.ProtocolTCP), uint16(l.SrcPort), uint16(l.DstPort)
+	case *layers.UDP:
+		s.protocol, s.srcPort, s.dstPort = string(corev1.ProtocolUDP), uint16(l.SrcPort), uint16(l.DstPort)
+	case *layers.SCTP:
+		s.protocol, s.srcPort, s.dstPort = string(corev1.ProtocolSCTP), uint16(l.SrcPort), uint16(l.DstPort)
+	default:
+		if packet.Layer(layers.LayerTypeICMPv4) != nil {
+			s.protocol = "ICMP"
+		} else if packet.Layer(layers.LayerTypeICMPv6) != nil {
+			s.protocol = "ICMPv6"
+		}
+	}
+}
+
+// aclEventDecoder maps the sample cookies to the ACL owner
+type aclEventDecoder interface {
+	DecodeCookieBytes(cookie []byte) (model.NetworkEvent, error)
+}
+
+type watchFactory interface {
+	GetAllPods() ([]*corev1.Pod, error)
+	GetNamespace(name string) (*corev1.Namespace, error)
+}
+
+// namespaceConfig is the ACL audit export configuration of a namespace. It is read from the same
+// k8s.ovn.org/acl-logging annotation that sets the severities of the ACL logs of the namespace.
+type namespaceConfig struct {
+	annotation string
+	levels     libovsdbutil.ACLLoggingLevels
+	rateLimit  int
+	limiter    *rate.Limiter
+	suppressed uint64
+}
+
+// namespaceAnnotation is the format of the k8s.ovn.org/acl-logging annotation
+type namespaceAnnotation struct {
+	libovsdbutil.ACLLoggingLevels
+	// ExportRateLimit overrides the default number of audit log entries exported per second
+	// for the namespace, 0 disables the export
+	ExportRateLimit *int `json:"export-rate-limit,omitempty"`
+}
+
+// Exporter writes ACL audit log entries for the local pods, built from the samples of the ACLs
+// applied to them. Entries are exported for the namespaces that enable ACL logging for the verdict,
+// up to the rate limit of the namespace.
+type Exporter struct {
+	nodeName       string
+	watchFactory   watchFactory
+	nbdbSocketPath string
+	writers        []writer
+	rateLimit      int
+
+	decoder aclEventDecoder
+
+	// only accessed by the goroutine handling the samples
+	podsByIP        map[string]*corev1.Pod
+	podsByIPUpdated time.Time
+	namespaces      map[string]*namespaceConfig
+}
+
+// nbdbSocketPaths are the paths the local northbound database socket is looked up at
+var nbdbSocketPaths = []string{"/var/run/ovn/ovnnb_db.sock", "/var/run/openvswitch/ovnnb_db.sock"}
+
+// NewExporter creates an exporter to the directory and syslog server set in the configuration.
+// The ACL samples are decoded with the local northbound database, so the exporter requires
+// observability and interconnect, and can't run on DPU hosts.
+func NewExporter(nodeName string, wf watchFactory) (*Exporter, error) {
+	if !config.OVNKubernetesFeature.EnableObservability {
+		return nil, fmt.Errorf("exporting ACL audit logs requires enable-observability")
+	}
+	if !config.OVNKubernetesFeature.EnableInterconnect {
+		return nil, fmt.Errorf("exporting ACL audit logs requires enable-interconnect, the node must run its own northbound database")
+	}
+	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		return nil, fmt.Errorf("exporting ACL audit logs is not supported in %s mode", types.NodeModeDPUHost)
+	}
+	nbdbSocketPath, err := getNBDBSocketPath()
+	if err != nil {
+		return nil, err
+	}
+	e := &Exporter{
+		nodeName:       nodeName,
+		watchFactory:   wf,
+		nbdbSocketPath: nbdbSocketPath,
+		rateLimit:      config.Logging.ACLAuditExportRateLimit,
+		podsByIP:       map[string]*corev1.Pod{},
+		namespaces:     map[string]*namespaceConfig{},
+	}
+	if config.Logging.ACLAuditExportDir != "" {
+		if err := os.MkdirAll(config.Logging.ACLAuditExportDir, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create ACL audit export directory: %w", err)
+		}
+		e.writers = append(e.writers, newFileWriter(config.Logging.ACLAuditExportDir))
+	}
+	if config.Logging.ACLAuditExportSyslogAddress != "" {
+		w, err := newSyslogWriter(config.Logging.ACLAuditExportSyslogAddress)
+		if err != nil {
+			return nil, err
+		}
+		e.writers = append(e.writers, w)
+	}
+	if len(e.writers) == 0 {
+		return nil, fmt.Errorf("no ACL audit export destination is configured")
+	}
+	return e, nil
+}
+
+// Run exports the ACL samples received on the node until stopChan is closed
+func (e *Exporter) Run(stopChan <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopChan
+		cancel()
+	}()
+	defer func() {
+		for _, w := range e.writers {
+			if err := w.Close(); err != nil {
+				klog.Warningf("Failed to close ACL audit log writer: %v", err)
+			}
+		}
+	}()
+	for {
+		err := e.run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		klog.Errorf("ACL audit export failed, retrying in %v: %v", retryInterval, err)
+		select {
+		case <-time.After(retryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (e *Exporter) run(ctx context.Context) error {
+	decoder, err := sampledecoder.NewSampleDecoderWithDefaultCollector(ctx, e.nbdbSocketPath, collectorOwner, psampleGroupID)
+	if err != nil {
+		return fmt.Errorf("failed to create sample decoder: %w", err)
+	}
+	defer decoder.Shutdown()
+	e.decoder = decoder
+	klog.Infof("Exporting ACL audit logs of node %s", e.nodeName)
+	return readSamples(ctx, psampleGroupID, e.handleSample)
+}
+
+// getNBDBSocketPath returns the path of the local northbound database socket
+func getNBDBSocketPath() (string, error) {
+	for _, path := range nbdbSocketPaths {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("northbound database socket wasn't found at %s", strings.Join(nbdbSocketPaths, " or "))
+}
+
+func (e *Exporter) handleSample(s *sample) {
+	event, err := e.decoder.DecodeCookieBytes(s.cookie)
+	if err != nil {
+		klog.V(5).Infof("Failed to decode ACL sample: %v", err)
+		return
+	}
+	aclEvent, ok := event.(*model.ACLEvent)
+	if !ok {
+		return
+	}
+	srcPod := e.getLocalPod(s.srcIP)
+	dstPod := e.getLocalPod(s.dstIP)
+	// the ACL is applied to the source pod for egress traffic and to the destination pod for ingress traffic
+	var pod *corev1.Pod
+	switch aclEvent.Direction {
+	case string(libovsdbutil.ACLEgress):
+		pod = srcPod
+	case string(libovsdbutil.ACLIngress):
+		pod = dstPod
+	default:
+		pod = dstPod
+		if pod == nil {
+			pod = srcPod
+		}
+	}
+	if pod == nil {
+		return
+	}
+	nsConfig := e.getNamespaceConfig(pod.Namespace)
+	if nsConfig == nil {
+		return
+	}
+	severity := getSeverity(&nsConfig.levels, aclEvent.Action)
+	if severity == "" {
+		return
+	}
+	if !nsConfig.limiter.Allow() {
+		nsConfig.suppressed++
+		return
+	}
+	entry := &Entry{
+		Time:            time.Now().UTC(),
+		Namespace:       pod.Namespace,
+		Pod:             pod.Name,
+		Severity:        severity,
+		Verdict:         aclEvent.Action,
+		Direction:       aclEvent.Direction,
+		PolicyKind:      aclEvent.Actor,
+		PolicyNamespace: aclEvent.Namespace,
+		PolicyName:      aclEvent.Name,
+		RuleIndex:       aclEvent.RuleIndex,
+		Message:         aclEvent.String(),
+		Protocol:        s.protocol,
+		SrcIP:           s.srcIP.String(),
+		SrcPort:         s.srcPort,
+		SrcPod:          getPodKey(srcPod),
+		DstIP:           s.dstIP.String(),
+		DstPort:         s.dstPort,
+		DstPod:          getPodKey(dstPod),
+		Suppressed:      nsConfig.suppressed,
+	}
+	nsConfig.suppressed = 0
+	e.write(entry)
+}
+
+func (e *Exporter) write(entry *Entry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		klog.Errorf("Failed to marshal ACL audit log entry: %v", err)
+		return
+	}
+	for _, w := range e.writers {
+		if err := w.Write(entry, line); err != nil {
+			klog.Errorf("Failed to write ACL audit log entry of namespace %s: %v", entry.Namespace, err)
+		}
+	}
+}
+
+// getSeverity returns the severity the namespace logs the ACL action with, empty if not logged
+func getSeverity(levels *libovsdbutil.ACLLoggingLevels, action string) string {
+	switch action {
+	case nbdb.ACLActionAllow, nbdb.ACLActionAllowRelated, nbdb.ACLActionAllowStateless:
+		return levels.Allow
+	case nbdb.ACLActionDrop, nbdb.ACLActionReject, libovsdbutil.ACLAuditWouldDrop:
+		return levels.Deny
+	case nbdb.ACLActionPass:
+		return levels.Pass
+	}
+	return ""
+}
+
+func getPodKey(pod *corev1.Pod) string {
+	if pod == nil {
+		return ""
+	}
+	return pod.Namespace + "/" + pod.Name
+}
+
+// getNamespaceConfig returns the export configuration of the namespace, nil if nothing is exported for it
+func (e *Exporter) getNamespaceConfig(namespace string) *namespaceConfig {
+	ns, err := e.watchFactory.GetNamespace(namespace)
+	if err != nil {
+		delete(e.namespaces, namespace)
+		return nil
+	}
+	annotation := ns.Annotations[util.AclLoggingAnnotation]
+	nsConfig := e.namespaces[namespace]
+	if nsConfig != nil && nsConfig.annotation == annotation {
+		return nsConfig.get()
+	}
+	nsConfig = &namespaceConfig{annotation: annotation}
+	e.namespaces[namespace] = nsConfig
+	if annotation == "" {
+		return nil
+	}
+	var parsed namespaceAnnotation
+	if err := json.Unmarshal([]byte(annotation), &parsed); err != nil {
+		klog.Warningf("Namespace %s: not exporting ACL audit logs due to malformed ACL logging annotation: %v",
+			namespace, err)
+		return nil
+	}
+	nsConfig.levels = parsed.ACLLoggingLevels
+	nsConfig.rateLimit = e.rateLimit
+	if parsed.ExportRateLimit != nil {
+		if *parsed.ExportRateLimit < 0 {
+			klog.Warningf("Namespace %s: not exporting ACL audit logs due to negative export-rate-limit %d",
+				namespace, *parsed.ExportRateLimit)
+			return nil
+		}
+		nsConfig.rateLimit = *parsed.ExportRateLimit
+	}
+	nsConfig.limiter = rate.NewLimiter(rate.Limit(nsConfig.rateLimit), nsConfig.rateLimit)
+	return nsConfig.get()
+}
+
+func (c *namespaceConfig) get() *namespaceConfig {
+	if c.limiter == nil || c.rateLimit == 0 {
+		return nil
+	}
+	return c
+}
+
+// getLocalPod returns the pod of the node with the given IP on any of its networks
+func (e *Exporter) getLocalPod(ip net.IP) *corev1.Pod {
+	if ip == nil {
+		return nil
+	}
+	key := ip.String()
+	pod, found := e.podsByIP[key]
+	age := time.Since(e.podsByIPUpdated)
+	if age > podIndexMaxAge || (!found && age > podIndexMinAge) {
+		e.indexLocalPods()
+		pod = e.podsByIP[key]
+	}
+	return pod
+}
+
+func (e *Exporter) indexLocalPods() {
+	e.podsByIPUpdated = time.Now()
+	pods, err := e.watchFactory.GetAllPods()
+	if err != nil {
+		klog.Errorf("Failed to list pods for the ACL audit logs: %v", err)
+		return
+	}
+	podsByIP := map[string]*corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != e.nodeName || pod.Spec.HostNetwork || util.PodCompleted(pod) {
+			continue
+		}
+		podNetworks, err := util.UnmarshalPodAnnotationAllNetworks(pod.Annotations)
+		if err != nil {
+			continue
+		}
+		for _, podNetwork := range podNetworks {
+			for _, podIP := range podNetwork.IPs {
+				ip, _, err := net.ParseCIDR(podIP)
+				if err != nil {
+					continue
+				}
+				podsByIP[ip.String()] = pod
+			}
+		}
+	}
+	e.podsByIP = podsByIP
+}
@@ -0,0 +1,346 @@
+//go:build linux
+// +build linux
+
+package aclaudit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/model"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	nodeName   = "node1"
+	namespace1 = "namespace1"
+	namespace2 = "namespace2"
+)
+
+type fakeDecoder struct {
+	events map[string]*model.ACLEvent
+}
+
+func (d *fakeDecoder) DecodeCookieBytes(cookie []byte) (model.NetworkEvent, error) {
+	event, ok := d.events[string(cookie)]
+	if !ok {
+		return nil, fmt.Errorf("unknown cookie %q", cookie)
+	}
+	return event, nil
+}
+
+type fakeWatchFactory struct {
+	pods       []*corev1.Pod
+	namespaces map[string]*corev1.Namespace
+}
+
+func (f *fakeWatchFactory) GetAllPods() ([]*corev1.Pod, error) {
+	return f.pods, nil
+}
+
+func (f *fakeWatchFactory) GetNamespace(name string) (*corev1.Namespace, error) {
+	ns, ok := f.namespaces[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, name)
+	}
+	return ns, nil
+}
+
+type fakeWriter struct {
+	entries []*Entry
+}
+
+func (w *fakeWriter) Write(entry *Entry, _ []byte) error {
+	w.entries = append(w.entries, entry)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	return nil
+}
+
+func newPod(namespace, name, node, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Annotations: map[string]string{
+				util.OvnPodAnnotationName: fmt.Sprintf(`{"default":{"ip_addresses":["%s/24"],"mac_address":"0a:58:0a:80:01:03","role":"primary"}}`, ip),
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+	}
+}
+
+func newNamespace(name, aclLogging string) *corev1.Namespace {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if aclLogging != "" {
+		ns.Annotations = map[string]string{util.AclLoggingAnnotation: aclLogging}
+	}
+	return ns
+}
+
+func newSample(cookie, srcIP, dstIP string) *sample {
+	return &sample{
+		cookie:   []byte(cookie),
+		srcIP:    net.ParseIP(srcIP),
+		dstIP:    net.ParseIP(dstIP),
+		protocol: "TCP",
+		srcPort:  34567,
+		dstPort:  8080,
+	}
+}
+
+var _ = ginkgo.Describe("ACL audit exporter", func() {
+	var (
+		wf         *fakeWatchFactory
+		testWriter *fakeWriter
+		e          *Exporter
+		decoder    *fakeDecoder
+	)
+
+	ginkgo.BeforeEach(func() {
+		wf = &fakeWatchFactory{
+			pods: []*corev1.Pod{
+				newPod(namespace1, "pod1", nodeName, "10.128.1.3"),
+				newPod(namespace2, "pod2", nodeName, "10.128.1.4"),
+				newPod(namespace2, "remote", "node2", "10.128.2.3"),
+			},
+			namespaces: map[string]*corev1.Namespace{
+				namespace1: newNamespace(namespace1, `{"deny": "alert"}`),
+				namespace2: newNamespace(namespace2, `{"deny": "warning", "allow": "info"}`),
+			},
+		}
+		decoder = &fakeDecoder{events: map[string]*model.ACLEvent{
+			"ingress-drop": {
+				Action:    "drop",
+				Actor:     libovsdbops.NetworkPolicyOwnerType,
+				Namespace: namespace1,
+				Name:      "deny-all",
+				Direction: "Ingress",
+				RuleIndex: "0",
+			},
+			"egress-allow": {
+				Action:    "allow-related",
+				Actor:     libovsdbops.NetworkPolicyOwnerType,
+				Namespace: namespace1,
+				Name:      "allow-dns",
+				Direction: "Egress",
+				RuleIndex: "1",
+			},
+			"anp-drop": {
+				Action:    "drop",
+				Actor:     libovsdbops.AdminNetworkPolicyOwnerType,
+				Name:      "cluster-control",
+				Direction: "Egress",
+				RuleIndex: "2",
+			},
+		}}
+		testWriter = &fakeWriter{}
+		e = &Exporter{
+			nodeName:     nodeName,
+			watchFactory: wf,
+			writers:      []writer{testWriter},
+			rateLimit:    20,
+			decoder:      decoder,
+			podsByIP:     map[string]*corev1.Pod{},
+			namespaces:   map[string]*namespaceConfig{},
+		}
+	})
+
+	ginkgo.It("exports the verdicts logged by the namespace of the local pod the ACL is applied to", func() {
+		e.handleSample(newSample("ingress-drop", "10.128.1.4", "10.128.1.3"))
+		gomega.Expect(testWriter.entries).To(gomega.HaveLen(1))
+		entry := testWriter.entries[0]
+		entry.Time = time.Time{}
+		gomega.Expect(entry).To(gomega.Equal(&Entry{
+			Namespace:       namespace1,
+			Pod:             "pod1",
+			Severity:        "alert",
+			Verdict:         "drop",
+			Direction:       "Ingress",
+			PolicyKind:      libovsdbops.NetworkPolicyOwnerType,
+			PolicyNamespace: namespace1,
+			PolicyName:      "deny-all",
+			RuleIndex:       "0",
+			Message:         "Dropped by network policy deny-all in namespace namespace1, direction Ingress",
+			Protocol:        "TCP",
+			SrcIP:           "10.128.1.4",
+			SrcPort:         34567,
+			SrcPod:          namespace2 + "/pod2",
+			DstIP:           "10.128.1.3",
+			DstPort:         8080,
+			DstPod:          namespace1 + "/pod1",
+		}))
+
+		// egress cluster wide policies are exported to the namespace of the source pod
+		e.handleSample(newSample("anp-drop", "10.128.1.4", "10.128.2.3"))
+		gomega.Expect(testWriter.entries).To(gomega.HaveLen(2))
+		entry = testWriter.entries[1]
+		gomega.Expect(entry.Namespace).To(gomega.Equal(namespace2))
+		gomega.Expect(entry.Pod).To(gomega.Equal("pod2"))
+		gomega.Expect(entry.Severity).To(gomega.Equal("warning"))
+		gomega.Expect(entry.PolicyKind).To(gomega.Equal(libovsdbops.AdminNetworkPolicyOwnerType))
+		gomega.Expect(entry.PolicyName).To(gomega.Equal("cluster-control"))
+		// pods of other nodes are not identified
+		gomega.Expect(entry.DstPod).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("does not export the verdicts the namespace does not log", func() {
+		// namespace1 doesn't log allowed traffic
+		e.handleSample(newSample("egress-allow", "10.128.1.3", "10.128.1.4"))
+		// the ACL is not applied to a local pod
+		e.handleSample(newSample("ingress-drop", "10.128.1.3", "10.128.2.3"))
+		// the sample can't be decoded
+		e.handleSample(newSample("unknown", "10.128.1.4", "10.128.1.3"))
+		gomega.Expect(testWriter.entries).To(gomega.BeEmpty())
+
+		wf.namespaces[namespace1] = newNamespace(namespace1, `{"deny": "alert", "allow": "notice"}`)
+		e.handleSample(newSample("egress-allow", "10.128.1.3", "10.128.1.4"))
+		gomega.Expect(testWriter.entries).To(gomega.HaveLen(1))
+		gomega.Expect(testWriter.entries[0].Namespace).To(gomega.Equal(namespace1))
+		gomega.Expect(testWriter.entries[0].Severity).To(gomega.Equal("notice"))
+
+		for _, annotation := range []string{"", "not json", `{"deny": "alert", "export-rate-limit": 0}`,
+			`{"deny": "alert", "export-rate-limit": -1}`} {
+			wf.namespaces[namespace1] = newNamespace(namespace1, annotation)
+			e.handleSample(newSample("ingress-drop", "10.128.1.4", "10.128.1.3"))
+		}
+		gomega.Expect(testWriter.entries).To(gomega.HaveLen(1))
+	})
+
+	ginkgo.It("rate limits the entries of every namespace", func() {
+		wf.namespaces[namespace1] = newNamespace(namespace1, `{"deny": "alert", "export-rate-limit": 2}`)
+		for i := 0; i < 5; i++ {
+			e.handleSample(newSample("ingress-drop", "10.128.1.4", "10.128.1.3"))
+			e.handleSample(newSample("anp-drop", "10.128.1.4", "10.128.2.3"))
+		}
+		var namespace1Entries []*Entry
+		for _, entry := range testWriter.entries {
+			if entry.Namespace == namespace1 {
+				namespace1Entries = append(namespace1Entries, entry)
+			}
+		}
+		gomega.Expect(namespace1Entries).To(gomega.HaveLen(2))
+		// namespace2 uses the default rate limit
+		gomega.Expect(testWriter.entries).To(gomega.HaveLen(7))
+
+		// the next entry reports the suppressed ones
+		gomega.Eventually(func() []*Entry {
+			e.handleSample(newSample("ingress-drop", "10.128.1.4", "10.128.1.3"))
+			return testWriter.entries
+		}, 2*time.Second, 100*time.Millisecond).Should(gomega.HaveLen(8))
+		gomega.Expect(testWriter.entries[7].Namespace).To(gomega.Equal(namespace1))
+		gomega.Expect(testWriter.entries[7].Suppressed).To(gomega.BeNumerically(">=", 3))
+	})
+
+	ginkgo.It("writes the entries of every namespace to its own file", func() {
+		dir := ginkgo.GinkgoT().TempDir()
+		e.writers = []writer{newFileWriter(dir)}
+		e.handleSample(newSample("ingress-drop", "10.128.1.4", "10.128.1.3"))
+		e.handleSample(newSample("anp-drop", "10.128.1.4", "10.128.2.3"))
+		e.handleSample(newSample("anp-drop", "10.128.1.4", "10.128.2.3"))
+		gomega.Expect(e.writers[0].Close()).To(gomega.Succeed())
+
+		for namespace, lines := range map[string]int{namespace1: 1, namespace2: 2} {
+			data, err := os.ReadFile(filepath.Join(dir, namespace+".log"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			entries := strings.Split(strings.TrimSpace(string(data)), "\n")
+			gomega.Expect(entries).To(gomega.HaveLen(lines))
+			for _, line := range entries {
+				entry := &Entry{}
+				gomega.Expect(json.Unmarshal([]byte(line), entry)).To(gomega.Succeed())
+				gomega.Expect(entry.Namespace).To(gomega.Equal(namespace))
+			}
+		}
+	})
+
+	ginkgo.It("parses the addresses and ports of the sampled packets", func() {
+		buf := gopacket.NewSerializeBuffer()
+		ip := &layers.IPv6{
+			Version:    6,
+			NextHeader: layers.IPProtocolUDP,
+			HopLimit:   64,
+			SrcIP:      net.ParseIP("fd00:10:244:1::3"),
+			DstIP:      net.ParseIP("fd00:10:244:1::4"),
+		}
+		udp := &layers.UDP{SrcPort: 5353, DstPort: 53}
+		gomega.Expect(udp.SetNetworkLayerForChecksum(ip)).To(gomega.Succeed())
+		err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true},
+			&layers.Ethernet{
+				SrcMAC:       net.HardwareAddr{0x0a, 0x58, 0x0a, 0x80, 0x01, 0x03},
+				DstMAC:       net.HardwareAddr{0x0a, 0x58, 0x0a, 0x80, 0x01, 0x04},
+				EthernetType: layers.EthernetTypeIPv6,
+			}, ip, udp, gopacket.Payload([]byte("query")))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		s := &sample{}
+		parseSamplePacket(buf.Bytes(), s)
+		gomega.Expect(s.srcIP.String()).To(gomega.Equal("fd00:10:244:1::3"))
+		gomega.Expect(s.dstIP.String()).To(gomega.Equal("fd00:10:244:1::4"))
+		gomega.Expect(s.protocol).To(gomega.Equal("UDP"))
+		gomega.Expect(s.srcPort).To(gomega.Equal(uint16(5353)))
+		gomega.Expect(s.dstPort).To(gomega.Equal(uint16(53)))
+	})
+
+	ginkgo.It("requires observability and the local northbound database", func() {
+		gomega.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+		socketPath := filepath.Join(ginkgo.GinkgoT().TempDir(), "ovnnb_db.sock")
+		oldSocketPaths := nbdbSocketPaths
+		nbdbSocketPaths = []string{socketPath}
+		ginkgo.DeferCleanup(func() {
+			nbdbSocketPaths = oldSocketPaths
+		})
+		config.Logging.ACLAuditExportDir = ginkgo.GinkgoT().TempDir()
+
+		_, err := NewExporter(nodeName, wf)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("enable-observability")))
+
+		config.OVNKubernetesFeature.EnableObservability = true
+		_, err = NewExporter(nodeName, wf)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("enable-interconnect")))
+
+		config.OVNKubernetesFeature.EnableInterconnect = true
+		config.OvnKubeNode.Mode = types.NodeModeDPUHost
+		_, err = NewExporter(nodeName, wf)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(types.NodeModeDPUHost)))
+
+		config.OvnKubeNode.Mode = types.NodeModeFull
+		_, err = NewExporter(nodeName, wf)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(socketPath)))
+
+		gomega.Expect(os.WriteFile(socketPath, nil, 0o600)).To(gomega.Succeed())
+		exporter, err := NewExporter(nodeName, wf)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(exporter.nbdbSocketPath).To(gomega.Equal(socketPath))
+	})
+
+	ginkgo.It("validates the syslog address", func() {
+		for _, address := range []string{"127.0.0.1:514", "udp:", "http:collector:80"} {
+			_, err := newSyslogWriter(address)
+			gomega.Expect(err).To(gomega.HaveOccurred(), address)
+		}
+		w, err := newSyslogWriter("udp:127.0.0.1:514")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(w.network).To(gomega.Equal("udp"))
+		gomega.Expect(w.raddr).To(gomega.Equal("127.0.0.1:514"))
+	})
+})
@@ -0,0 +1,16 @@
+//go:build linux
+// +build linux
+
+package aclaudit
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+func TestACLAudit(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "ACL Audit Suite")
+}
@@ -0,0 +1,121 @@
+//go:build linux
+// +build linux
+
+package aclaudit
+
+import (
+	"fmt"
+	"log/syslog"
+	"path/filepath"
+	"strings"
+
+	lumberjack "gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// syslogTag is the tag of the ACL audit log messages forwarded to syslog
+const syslogTag = "ovnkube-acl-audit"
+
+type writer interface {
+	// Write exports the entry, line is the entry marshaled to JSON
+	Write(entry *Entry, line []byte) error
+	Close() error
+}
+
+// fileWriter writes the entries of every namespace to <dir>/<namespace>.log, rotated with the same
+// size, age and backups limits as the ovnkube log file
+type fileWriter struct {
+	dir   string
+	files map[string]*lumberjack.Logger
+}
+
+func newFileWriter(dir string) *fileWriter {
+	return &fileWriter{
+		dir:   dir,
+		files: map[string]*lumberjack.Logger{},
+	}
+}
+
+func (w *fileWriter) Write(entry *Entry, line []byte) error {
+	file := w.files[entry.Namespace]
+	if file == nil {
+		file = &lumberjack.Logger{
+			Filename:   filepath.Join(w.dir, entry.Namespace+".log"),
+			MaxSize:    config.Logging.LogFileMaxSize, // megabytes
+			MaxBackups: config.Logging.LogFileMaxBackups,
+			MaxAge:     config.Logging.LogFileMaxAge, // days
+		}
+		w.files[entry.Namespace] = file
+	}
+	_, err := file.Write(append(line, '\n'))
+	return err
+}
+
+func (w *fileWriter) Close() error {
+	var errs []error
+	for namespace, file := range w.files {
+		if err := file.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close ACL audit log of namespace %s: %w", namespace, err))
+		}
+	}
+	w.files = map[string]*lumberjack.Logger{}
+	return utilerrors.Join(errs...)
+}
+
+// syslogWriter forwards the entries to a syslog server, with the priority of the ACL logging severity.
+// The connection is made on the first entry, so that the exporter starts while the server is unreachable.
+type syslogWriter struct {
+	address string
+	network string
+	raddr   string
+	writer  *syslog.Writer
+}
+
+// newSyslogWriter returns a writer to the syslog server at address, in the network:address format
+func newSyslogWriter(address string) (*syslogWriter, error) {
+	network, raddr, found := strings.Cut(address, ":")
+	if !found || raddr == "" {
+		return nil, fmt.Errorf("invalid ACL audit syslog address %q, expected network:address", address)
+	}
+	switch network {
+	case "udp", "tcp", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("invalid ACL audit syslog address %q, unsupported network %s", address, network)
+	}
+	return &syslogWriter{address: address, network: network, raddr: raddr}, nil
+}
+
+func (w *syslogWriter) Write(entry *Entry, line []byte) error {
+	if w.writer == nil {
+		writer, err := syslog.Dial(w.network, w.raddr, syslog.LOG_INFO|syslog.LOG_LOCAL0, syslogTag)
+		if err != nil {
+			return fmt.Errorf("failed to connect to ACL audit syslog server %s: %w", w.address, err)
+		}
+		w.writer = writer
+	}
+	msg := string(line)
+	switch entry.Severity {
+	case nbdb.ACLSeverityAlert:
+		return w.writer.Alert(msg)
+	case nbdb.ACLSeverityWarning:
+		return w.writer.Warning(msg)
+	case nbdb.ACLSeverityNotice:
+		return w.writer.Notice(msg)
+	case nbdb.ACLSeverityDebug:
+		return w.writer.Debug(msg)
+	default:
+		return w.writer.Info(msg)
+	}
+}
+
+func (w *syslogWriter) Close() error {
+	if w.writer == nil {
+		return nil
+	}
+	err := w.writer.Close()
+	w.writer = nil
+	return err
+}
@@ -0,0 +1,93 @@
+//go:build linux
+// +build linux
+
+package aclaudit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+
+	observ "github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib"
+)
+
+// readSamples calls handler for every sample of the psample group received until the context is done
+func readSamples(ctx context.Context, groupID uint32, handler func(*sample)) error {
+	fam, err := netlink.GenlFamilyGet(observ.PSAMPLE_GENL_NAME)
+	if err != nil {
+		return fmt.Errorf("error getting netlink family %s: %w", observ.PSAMPLE_GENL_NAME, err)
+	}
+	var mcastGroupID uint32
+	for _, group := range fam.Groups {
+		if group.Name == observ.PSAMPLE_NL_MCGRP_SAMPLE_NAME {
+			mcastGroupID = group.ID
+		}
+	}
+	if mcastGroupID == 0 {
+		return fmt.Errorf("no mcast group found for %s", observ.PSAMPLE_NL_MCGRP_SAMPLE_NAME)
+	}
+	sock, err := nl.Subscribe(nl.GENL_ID_CTRL, uint(mcastGroupID))
+	if err != nil {
+		return fmt.Errorf("error subscribing to netlink group %d: %w", mcastGroupID, err)
+	}
+	defer sock.Close()
+	// wake up every second to check if the context is done
+	if err = sock.SetReceiveTimeout(&unix.Timeval{Sec: 1}); err != nil {
+		return fmt.Errorf("error setting receive timeout: %w", err)
+	}
+
+	for ctx.Err() == nil {
+		msgs, _, err := sock.Receive()
+		if err != nil {
+			if errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EINTR) {
+				continue
+			}
+			if errors.Is(err, syscall.ENOBUFS) {
+				klog.Warningf("ACL audit samples were lost, the socket receive buffer is full")
+				continue
+			}
+			return fmt.Errorf("error receiving samples: %w", err)
+		}
+		for _, msg := range msgs {
+			if s := parseSampleMsg(msg, groupID); s != nil {
+				handler(s)
+			}
+		}
+	}
+	return nil
+}
+
+// parseSampleMsg returns the sample carried by the psample message, nil if it was sent to another
+// group or has no cookie
+func parseSampleMsg(msg syscall.NetlinkMessage, groupID uint32) *sample {
+	if len(msg.Data) < nl.SizeofGenlmsg {
+		return nil
+	}
+	s := &sample{}
+	var group uint32
+	var data []byte
+	for attr := range nl.ParseAttributes(msg.Data[nl.SizeofGenlmsg:]) {
+		switch attr.Type {
+		case observ.PSAMPLE_ATTR_SAMPLE_GROUP:
+			if len(attr.Value) == 4 {
+				// group is encoded using host endian
+				group = nl.NativeEndian().Uint32(attr.Value)
+			}
+		case observ.PSAMPLE_ATTR_USER_COOKIE:
+			s.cookie = attr.Value
+		case observ.PSAMPLE_ATTR_DATA:
+			data = attr.Value
+		}
+	}
+	if group != groupID || s.cookie == nil {
+		return nil
+	}
+	parseSamplePacket(data, s)
+	return s
+}
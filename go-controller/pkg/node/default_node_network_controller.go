@@ -125,6 +125,10 @@ type DefaultNodeNetworkController struct {
 
 	udnHostIsolationManager *UDNHostIsolationManager
 
+	hostNetworkPolicyManager *HostNetworkPolicyManager
+
+	podInterfaceHotplugManager *PodInterfaceHotplugManager
+
 	nodeAddress net.IP
 	sbZone      string
 
@@ -148,6 +152,10 @@ func newDefaultNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, sto
 		c.udnHostIsolationManager = NewUDNHostIsolationManager(config.IPv4Mode, config.IPv6Mode,
 			cnnci.watchFactory.PodCoreInformer(), cnnci.name, cnnci.recorder)
 	}
+	if config.OVNKubernetesFeature.EnableHostNetworkPolicy {
+		c.hostNetworkPolicyManager = NewHostNetworkPolicyManager(config.IPv4Mode, config.IPv6Mode, cnnci.client,
+			cnnci.watchFactory.NodeCoreInformer(), cnnci.watchFactory.NamespaceInformer(), cnnci.watchFactory.NetworkPolicyCoreInformer(), cnnci.name, cnnci.recorder)
+	}
 	c.linkManager = linkmanager.NewController(cnnci.name, config.IPv4Mode, config.IPv6Mode, c.updateGatewayMAC)
 	return c
 }
@@ -874,6 +882,21 @@ func (nc *DefaultNodeNetworkController) Init(ctx context.Context) error {
 				return fmt.Errorf("failed cleaning up UDN host isolation: %w", err)
 			}
 		}
+		if nc.hostNetworkPolicyManager != nil {
+			if err = nc.hostNetworkPolicyManager.Start(); err != nil {
+				return fmt.Errorf("failed to start host network policy manager: %w", err)
+			}
+			nc.wg.Add(1)
+			go func() {
+				defer nc.wg.Done()
+				<-nc.stopChan
+				nc.hostNetworkPolicyManager.Stop()
+			}()
+		} else {
+			if err = CleanupHostNetworkPolicy(); err != nil {
+				return fmt.Errorf("failed cleaning up host network policies: %w", err)
+			}
+		}
 	}
 
 	// First wait for the node logical switch to be created by the Master, timeout is 300s.
@@ -905,6 +928,12 @@ func (nc *DefaultNodeNetworkController) Init(ctx context.Context) error {
 			return err
 		}
 		nc.cniServer = cniServer
+
+		if config.OVNKubernetesFeature.EnableMultiNetwork && config.OVNKubernetesFeature.EnablePodInterfaceHotplug {
+			nc.podInterfaceHotplugManager = NewPodInterfaceHotplugManager(nc.name, nc.Kube, nc.watchFactory.PodCoreInformer(),
+				nc.watchFactory.NADInformer().Lister(), nc.networkManager, nc.ovsClient,
+				cni.NewClientSet(kclient.KClient, nc.watchFactory.PodCoreInformer().Lister()))
+		}
 	}
 
 	nodeAnnotator := kube.NewNodeAnnotator(nc.Kube, node.Name)
@@ -1256,6 +1285,18 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 			}
 		}
 
+		if nc.podInterfaceHotplugManager != nil {
+			if err := nc.podInterfaceHotplugManager.Start(); err != nil {
+				return fmt.Errorf("failed to start pod interface hotplug manager: %w", err)
+			}
+			nc.wg.Add(1)
+			go func() {
+				defer nc.wg.Done()
+				<-nc.stopChan
+				nc.podInterfaceHotplugManager.Stop()
+			}()
+		}
+
 		// Write CNI config file if it doesn't already exist
 		if err := config.WriteCNIConfig(); err != nil {
 			return err
@@ -1301,6 +1342,11 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		ovspinning.Run(nc.stopChan)
 	}()
 
+	if nc.cniServer != nil {
+		// let the runtime know through CNI STATUS that pods can be started
+		nc.cniServer.SetReady()
+	}
+
 	klog.Infof("Default node network controller initialized and ready.")
 	return nil
 }
@@ -12,8 +12,10 @@ import (
 	"golang.org/x/sys/unix"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
@@ -21,6 +23,8 @@ import (
 	"sigs.k8s.io/knftables"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/generator/udn"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iprulemanager"
@@ -89,6 +93,13 @@ type UserDefinedNetworkGateway struct {
 
 	// gwInterfaceIndex holds the link index of gateway interface
 	gwInterfaceIndex int
+
+	// eipHandler watches EgressIPs to reconcile the routes towards the
+	// EgressIPs advertised from the network's VRF
+	eipHandler *factory.Handler
+	// eipRoutes holds the routes towards advertised EgressIPs currently
+	// added to the network's VRF
+	eipRoutes []netlink.Route
 }
 
 // UTILS Needed for UDN (also leveraged for default netInfo) in bridgeConfiguration
@@ -375,6 +386,10 @@ func (udng *UserDefinedNetworkGateway) AddNetwork() error {
 		return fmt.Errorf("failed to update ip routes for network %s: %w", udng.GetNetworkName(), err)
 	}
 
+	if err := udng.updateAdvertisedEgressIPRoutes(); err != nil {
+		return fmt.Errorf("failed to update advertised EgressIP routes for network %s: %w", udng.GetNetworkName(), err)
+	}
+
 	// add loose mode for rp filter on management port
 	mgmtPortName := util.GetNetworkScopedK8sMgmtHostIntfName(uint(udng.GetNetworkID()))
 	if err := addRPFilterLooseModeForManagementPort(mgmtPortName); err != nil {
@@ -421,6 +436,10 @@ func (udng *UserDefinedNetworkGateway) AddNetwork() error {
 	// run gateway reconciliation loop on network configuration changes
 	udng.run()
 
+	if err := udng.addEgressIPHandler(); err != nil {
+		return fmt.Errorf("failed to add EgressIP handler for network %s: %w", udng.GetNetworkName(), err)
+	}
+
 	return nil
 }
 
@@ -432,6 +451,12 @@ func (udng *UserDefinedNetworkGateway) GetNetworkRuleMetadata() string {
 // the gateway side. It's considered invalid to call this instance after
 // DelNetwork has returned succesfully.
 func (udng *UserDefinedNetworkGateway) DelNetwork() error {
+	// stop handling EgressIP events before anything else as they would
+	// trigger reconciliations
+	if udng.eipHandler != nil {
+		udng.watchFactory.RemoveEgressIPHandler(udng.eipHandler)
+		udng.eipHandler = nil
+	}
 	vrfDeviceName := util.GetNetworkVRFName(udng.NetInfo)
 	// delete the iprules for this network
 	if err := udng.ruleManager.DeleteWithMetadata(udng.GetNetworkRuleMetadata()); err != nil {
@@ -523,6 +548,36 @@ func (udng *UserDefinedNetworkGateway) addUDNManagementPort() (netlink.Link, err
 	return mplink, nil
 }
 
+// UpdateMTU updates the MTU of the management port of the network and of the
+// routes through it after the network MTU changed
+func (udng *UserDefinedNetworkGateway) UpdateMTU() error {
+	interfaceName := util.GetNetworkScopedK8sMgmtHostIntfName(uint(udng.GetNetworkID()))
+	stdout, stderr, err := util.RunOVSVsctl(
+		"set", "interface", interfaceName, "mtu_request="+fmt.Sprintf("%d", udng.NetInfo.MTU()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update MTU of management port %s for network %s, stdout: %q, stderr: %q, error: %w",
+			interfaceName, udng.GetNetworkName(), stdout, stderr, err)
+	}
+	mplink, err := util.GetNetLinkOps().LinkByName(interfaceName)
+	if err != nil {
+		return fmt.Errorf("failed to get management port %s for network %s: %w", interfaceName, udng.GetNetworkName(), err)
+	}
+	routes, err := udng.computeRoutesForUDN(mplink)
+	if err != nil {
+		return fmt.Errorf("failed to compute routes for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	vrfDeviceName := util.GetNetworkVRFName(udng.NetInfo)
+	if err = udng.vrfManager.AddVRFRoutes(vrfDeviceName, routes); err != nil {
+		return fmt.Errorf("could not update VRF %s routes for network %s, err: %v", vrfDeviceName, udng.GetNetworkName(), err)
+	}
+	isNetworkAdvertised := util.IsPodNetworkAdvertisedAtNode(udng.NetInfo, udng.node.Name)
+	if err := udng.updateUDNVRFIPRoute(isNetworkAdvertised); err != nil {
+		return fmt.Errorf("failed to update ip routes for network %s: %w", udng.GetNetworkName(), err)
+	}
+	return nil
+}
+
 // getLocalSubnets returns pod subnets used by the current node.
 // For L3 networks it parses the ovnNodeSubnets annotation, for L2 networks it returns the network subnets.
 func (udng *UserDefinedNetworkGateway) getLocalSubnets() ([]*net.IPNet, error) {
@@ -929,6 +984,10 @@ func (udng *UserDefinedNetworkGateway) doReconcile() error {
 		return fmt.Errorf("error while updating ip route for UDN %s: %s", udng.GetNetworkName(), err)
 	}
 
+	if err := udng.updateAdvertisedEgressIPRoutes(); err != nil {
+		return fmt.Errorf("error while updating advertised EgressIP routes for UDN %s: %w", udng.GetNetworkName(), err)
+	}
+
 	// add below OpenFlows based on the gateway mode and whether the network is advertised or not:
 	// table=1, n_packets=0, n_bytes=0, priority=16,ip,nw_dst=128.192.0.2 actions=LOCAL (Both gateway modes)
 	// table=1, n_packets=0, n_bytes=0, priority=15,ip,nw_dst=128.192.0.0/14 actions=output:3 (shared gateway mode)
@@ -1000,6 +1059,148 @@ func (udng *UserDefinedNetworkGateway) removeDefaultRouteFromVRF() error {
 	return nil
 }
 
+// updateAdvertisedEgressIPRoutes adds to the network's VRF the routes towards
+// the EgressIPs advertised from it and removes the stale ones. BGP announces
+// these EgressIPs from the VRF, so their return traffic is received on the
+// VRF and needs to be forwarded to the gateway interface.
+func (udng *UserDefinedNetworkGateway) updateAdvertisedEgressIPRoutes() error {
+	vrfDeviceName := util.GetNetworkVRFName(udng.NetInfo)
+	routes, err := udng.getAdvertisedEgressIPRoutes()
+	if err != nil {
+		return err
+	}
+	var stale []netlink.Route
+	for _, route := range udng.eipRoutes {
+		if !slices.ContainsFunc(routes, route.Equal) {
+			stale = append(stale, route)
+		}
+	}
+	if len(stale) > 0 {
+		if err = udng.vrfManager.DeleteVRFRoutes(vrfDeviceName, stale); err != nil {
+			return fmt.Errorf("unable to delete EgressIP routes from VRF %s: %w", vrfDeviceName, err)
+		}
+	}
+	if len(routes) > 0 {
+		if err = udng.vrfManager.AddVRFRoutes(vrfDeviceName, routes); err != nil {
+			return fmt.Errorf("unable to add EgressIP routes to VRF %s: %w", vrfDeviceName, err)
+		}
+	}
+	udng.eipRoutes = routes
+	return nil
+}
+
+// getAdvertisedEgressIPRoutes returns the routes towards the EgressIPs
+// assigned to this node that serve namespaces of this network, if EgressIPs
+// are advertised from the network's own VRF on this node. Only EgressIPs
+// hosted on the gateway interface are considered.
+func (udng *UserDefinedNetworkGateway) getAdvertisedEgressIPRoutes() ([]netlink.Route, error) {
+	if !config.OVNKubernetesFeature.EnableEgressIP || udng.watchFactory == nil {
+		return nil, nil
+	}
+	vrfs := udng.GetEgressIPAdvertisedOnNodeVRFs(udng.node.Name)
+	if !slices.Contains(vrfs, util.GetNetworkVRFName(udng.NetInfo)) {
+		return nil, nil
+	}
+
+	node, err := udng.watchFactory.GetNode(udng.node.Name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get node %s: %w", udng.node.Name, err)
+	}
+	primaryIfAddr, err := util.ParseNodePrimaryIfAddr(node)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse primary interface address of node %s: %w", udng.node.Name, err)
+	}
+	var gwSubnets []*net.IPNet
+	for _, ifAddr := range []util.ParsedIFAddr{primaryIfAddr.V4, primaryIfAddr.V6} {
+		if ifAddr.Net != nil {
+			gwSubnets = append(gwSubnets, ifAddr.Net)
+		}
+	}
+
+	eips, err := udng.watchFactory.GetEgressIPs()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list EgressIPs: %w", err)
+	}
+	namespaces := sets.New(udng.GetNADNamespaces()...)
+	var routes []netlink.Route
+	for _, eip := range eips {
+		var ips []net.IP
+		for _, status := range eip.Status.Items {
+			if status.Node != udng.node.Name {
+				continue
+			}
+			ip := net.ParseIP(status.EgressIP)
+			if ip == nil || !util.IsContainedInAnyCIDR(util.GetIPNetFullMaskFromIP(ip), gwSubnets...) {
+				continue
+			}
+			ips = append(ips, ip)
+		}
+		if len(ips) == 0 {
+			continue
+		}
+		selected, err := udng.watchFactory.GetNamespacesBySelector(eip.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get namespaces selected by EgressIP %s: %w", eip.Name, err)
+		}
+		servesNetwork := slices.ContainsFunc(selected, func(namespace *corev1.Namespace) bool {
+			return namespaces.Has(namespace.Name)
+		})
+		if !servesNetwork {
+			continue
+		}
+		for _, ip := range ips {
+			routes = append(routes, netlink.Route{
+				LinkIndex: udng.gwInterfaceIndex,
+				Dst:       util.GetIPNetFullMaskFromIP(ip),
+				Table:     udng.vrfTableId,
+				Scope:     netlink.SCOPE_LINK,
+			})
+		}
+	}
+	return routes, nil
+}
+
+// addEgressIPHandler watches EgressIPs assigned to this node to reconcile the
+// routes towards the EgressIPs advertised from the network's VRF.
+func (udng *UserDefinedNetworkGateway) addEgressIPHandler() error {
+	if !config.OVNKubernetesFeature.EnableEgressIP || !util.IsRouteAdvertisementsEnabled() || udng.watchFactory == nil {
+		return nil
+	}
+	reconcileIfAssigned := func(objs ...interface{}) {
+		for _, obj := range objs {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			eip, ok := obj.(*egressipv1.EgressIP)
+			if !ok {
+				continue
+			}
+			for _, status := range eip.Status.Items {
+				if status.Node == udng.node.Name {
+					udng.Reconcile()
+					return
+				}
+			}
+		}
+	}
+	handler, err := udng.watchFactory.AddEgressIPHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			reconcileIfAssigned(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			reconcileIfAssigned(oldObj, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			reconcileIfAssigned(obj)
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	udng.eipHandler = handler
+	return nil
+}
+
 // updateAdvertisedUDNIsolationRules adds the full UDN subnets to nftablesAdvertisedUDNsSetV[4|6] nft set that is used
 // in the following chain/rules to drop locally generated traffic towards a UDN network:
 //
@@ -0,0 +1,754 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	knet "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	netinformers "k8s.io/client-go/informers/networking/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	netlisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	kapi "k8s.io/kubernetes/pkg/apis/core"
+	utilnet "k8s.io/utils/net"
+	"sigs.k8s.io/knftables"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	nodenft "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/nftables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+const (
+	// host-network-policy-ingress chain contains the rules of the network policies isolating host network pods for ingress
+	HostNetworkPolicyIngressChain = "host-network-policy-ingress"
+	// host-network-policy-egress chain contains the rules of the network policies isolating host network pods for egress
+	HostNetworkPolicyEgressChain = "host-network-policy-egress"
+
+	// hostNetworkPolicySyncKey is the only key of the reconciler, every change rebuilds all the rules
+	hostNetworkPolicySyncKey = "host-network-policies"
+	// podCgroupMaxLevel is the deepest level of the pod cgroups, e.g.
+	// kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod<uid>.slice with the systemd cgroup driver
+	podCgroupMaxLevel = 4
+	// podCgroupRetryInterval is the interval of the syncs retried for running pods without a cgroup yet
+	podCgroupRetryInterval = 5 * time.Second
+)
+
+// HostNetworkPolicyManager enforces the network policies selecting the host network pods of the node with
+// nftables rules, when the node is labeled with k8s.ovn.org/host-network-policy=true.
+// Host network pods share the node IPs, so their traffic is told apart by:
+//   - egress: the cgroup of the pod sockets, which requires cgroup v2
+//   - ingress: the container ports declared by the pod
+//
+// Every change of the node, the selected pods, their policies or the policy peers rebuilds all the rules.
+// The pods of all nodes are needed to resolve the policy peers, they are only watched while the node is labeled.
+type HostNetworkPolicyManager struct {
+	nft        knftables.Interface
+	ipv4, ipv6 bool
+	nodeName   string
+	recorder   record.EventRecorder
+	kubeClient kubernetes.Interface
+	// cgroupRoot is the cgroup v2 mountpoint, empty on cgroup v1 hosts where egress rules are not supported
+	cgroupRoot string
+
+	nodeLister      corelisters.NodeLister
+	namespaceLister corelisters.NamespaceLister
+	policyLister    netlisters.NetworkPolicyLister
+
+	nodeController      controller.Controller
+	namespaceController controller.Controller
+	policyController    controller.Controller
+	reconciler          controller.Reconciler
+
+	// podFactory, podLister and podController watch the pods of all nodes, they are only set while the node is
+	// labeled and are only accessed by the reconciler
+	podFactory    informers.SharedInformerFactory
+	podLister     corelisters.PodLister
+	podStopChan   chan struct{}
+	podController controller.Controller
+
+	// podCgroups caches the cgroup paths of the selected pods, relative to cgroupRoot
+	podCgroupsLock sync.Mutex
+	podCgroups     map[ktypes.UID]string
+}
+
+// NewHostNetworkPolicyManager returns a manager of the host network policies of the given node. kubeClient is used
+// to watch the pods of all nodes while the node is labeled.
+func NewHostNetworkPolicyManager(ipv4, ipv6 bool, kubeClient kubernetes.Interface, nodeInformer coreinformers.NodeInformer,
+	namespaceInformer coreinformers.NamespaceInformer, policyInformer netinformers.NetworkPolicyInformer, nodeName string,
+	recorder record.EventRecorder) *HostNetworkPolicyManager {
+	m := &HostNetworkPolicyManager{
+		ipv4:            ipv4,
+		ipv6:            ipv6,
+		nodeName:        nodeName,
+		recorder:        recorder,
+		kubeClient:      kubeClient,
+		nodeLister:      nodeInformer.Lister(),
+		namespaceLister: namespaceInformer.Lister(),
+		policyLister:    policyInformer.Lister(),
+		podCgroups:      map[ktypes.UID]string{},
+	}
+	m.reconciler = controller.NewReconciler("host-network-policy-manager", &controller.ReconcilerConfig{
+		RateLimiter: workqueue.NewTypedItemFastSlowRateLimiter[string](time.Second, 5*time.Second, 5),
+		Reconcile:   m.reconcile,
+		Threadiness: 1,
+		MaxAttempts: controller.InfiniteAttempts,
+	})
+	m.nodeController = controller.NewController[corev1.Node]("host-network-policy-node-controller", &controller.ControllerConfig[corev1.Node]{
+		RateLimiter:    workqueue.DefaultTypedControllerRateLimiter[string](),
+		Informer:       nodeInformer.Informer(),
+		Lister:         nodeInformer.Lister().List,
+		ObjNeedsUpdate: m.nodeNeedsUpdate,
+		Reconcile:      m.requestSync,
+		Threadiness:    1,
+	})
+	m.namespaceController = controller.NewController[corev1.Namespace]("host-network-policy-namespace-controller", &controller.ControllerConfig[corev1.Namespace]{
+		RateLimiter: workqueue.DefaultTypedControllerRateLimiter[string](),
+		Informer:    namespaceInformer.Informer(),
+		Lister:      namespaceInformer.Lister().List,
+		ObjNeedsUpdate: func(oldObj, newObj *corev1.Namespace) bool {
+			return oldObj == nil || newObj == nil || !reflect.DeepEqual(oldObj.Labels, newObj.Labels)
+		},
+		Reconcile:   m.requestSync,
+		Threadiness: 1,
+	})
+	m.policyController = controller.NewController[knet.NetworkPolicy]("host-network-policy-policy-controller", &controller.ControllerConfig[knet.NetworkPolicy]{
+		RateLimiter: workqueue.DefaultTypedControllerRateLimiter[string](),
+		Informer:    policyInformer.Informer(),
+		Lister:      policyInformer.Lister().List,
+		ObjNeedsUpdate: func(oldObj, newObj *knet.NetworkPolicy) bool {
+			return oldObj == nil || newObj == nil || !reflect.DeepEqual(oldObj.Spec, newObj.Spec)
+		},
+		Reconcile:   m.requestSync,
+		Threadiness: 1,
+	})
+	return m
+}
+
+// Start must be called on node setup.
+func (m *HostNetworkPolicyManager) Start() error {
+	klog.Infof("Starting host network policy manager")
+	if hostUsesCgroupv2() {
+		m.cgroupRoot = unifiedMountpoint
+	} else {
+		message := fmt.Sprintf("Egress network policies of host network pods are not supported on the node %s as it uses cgroup v1.", m.nodeName)
+		klog.Warning(message)
+		nodeRef := &corev1.ObjectReference{
+			Kind: "Node",
+			Name: m.nodeName,
+		}
+		m.recorder.Eventf(nodeRef, kapi.EventTypeWarning, "HostNetworkPolicyEgressNotSupported", message)
+	}
+	nft, err := nodenft.GetNFTablesHelper()
+	if err != nil {
+		return fmt.Errorf("failed getting nftables helper: %w", err)
+	}
+	m.nft = nft
+	return controller.StartWithInitialSync(m.syncRules, m.reconciler, m.nodeController, m.namespaceController,
+		m.policyController)
+}
+
+func (m *HostNetworkPolicyManager) Stop() {
+	controller.Stop(m.nodeController, m.namespaceController, m.policyController, m.reconciler)
+	m.stopPodWatch()
+}
+
+// startPodWatch starts watching the pods of all nodes, if not watched yet. They are only watched while the node
+// is labeled, so that the other nodes don't pay for the memory of the pods of the cluster and the API server
+// doesn't have to send them the pod updates.
+func (m *HostNetworkPolicyManager) startPodWatch() error {
+	if m.podController != nil {
+		return nil
+	}
+	klog.Infof("Starting to watch the pods of all nodes for host network policies")
+	m.podFactory = factory.NewClusterPodInformerFactory(m.kubeClient)
+	podInformer := m.podFactory.Core().V1().Pods()
+	m.podController = controller.NewController[corev1.Pod]("host-network-policy-pod-controller", &controller.ControllerConfig[corev1.Pod]{
+		RateLimiter:    workqueue.DefaultTypedControllerRateLimiter[string](),
+		Informer:       podInformer.Informer(),
+		Lister:         podInformer.Lister().List,
+		ObjNeedsUpdate: hostNetworkPolicyPodNeedsUpdate,
+		Reconcile:      m.requestSync,
+		Threadiness:    1,
+	})
+	m.podLister = podInformer.Lister()
+	m.podStopChan = make(chan struct{})
+	m.podFactory.Start(m.podStopChan)
+	if err := controller.Start(m.podController); err != nil {
+		m.stopPodWatch()
+		return fmt.Errorf("failed to start watching pods: %w", err)
+	}
+	return nil
+}
+
+// stopPodWatch stops watching the pods of all nodes, if watched.
+func (m *HostNetworkPolicyManager) stopPodWatch() {
+	if m.podController == nil {
+		return
+	}
+	klog.Infof("Stopping to watch the pods of all nodes for host network policies")
+	controller.Stop(m.podController)
+	close(m.podStopChan)
+	m.podFactory.Shutdown()
+	m.podFactory = nil
+	m.podStopChan = nil
+	m.podController = nil
+	m.podLister = nil
+}
+
+// CleanupHostNetworkPolicy removes the nftables chains created by HostNetworkPolicyManager.
+func CleanupHostNetworkPolicy() error {
+	nft, err := nodenft.GetNFTablesHelper()
+	if err != nil {
+		return fmt.Errorf("failed getting nftables helper: %w", err)
+	}
+	tx := nft.NewTransaction()
+	safeDelete(tx, &knftables.Chain{
+		Name: HostNetworkPolicyIngressChain,
+	})
+	safeDelete(tx, &knftables.Chain{
+		Name: HostNetworkPolicyEgressChain,
+	})
+	return nft.Run(context.TODO(), tx)
+}
+
+func (m *HostNetworkPolicyManager) nodeNeedsUpdate(oldObj, newObj *corev1.Node) bool {
+	if newObj == nil {
+		return true
+	}
+	if newObj.Name != m.nodeName {
+		return false
+	}
+	return oldObj == nil || oldObj.Labels[types.HostNetworkPolicyNodeLabel] != newObj.Labels[types.HostNetworkPolicyNodeLabel]
+}
+
+func hostNetworkPolicyPodNeedsUpdate(oldObj, newObj *corev1.Pod) bool {
+	if oldObj == nil || newObj == nil {
+		return true
+	}
+	return !reflect.DeepEqual(oldObj.Labels, newObj.Labels) ||
+		!reflect.DeepEqual(oldObj.Status.PodIPs, newObj.Status.PodIPs) ||
+		oldObj.Status.Phase != newObj.Status.Phase ||
+		oldObj.Spec.NodeName != newObj.Spec.NodeName
+}
+
+// requestSync is the reconcile function of the watched objects, any change triggers a sync of all the rules
+func (m *HostNetworkPolicyManager) requestSync(string) error {
+	m.reconciler.Reconcile(hostNetworkPolicySyncKey)
+	return nil
+}
+
+func (m *HostNetworkPolicyManager) reconcile(string) error {
+	return m.syncRules()
+}
+
+// syncRules replaces the rules of the host network policy chains with the rules of the current policies
+func (m *HostNetworkPolicyManager) syncRules() error {
+	ingressRules, egressRules, retry, err := m.buildRules()
+	if err != nil {
+		return err
+	}
+	tx := m.nft.NewTransaction()
+	tx.Add(&knftables.Chain{
+		Name:     HostNetworkPolicyIngressChain,
+		Comment:  knftables.PtrTo("Network policies of host network pods, ingress"),
+		Type:     knftables.PtrTo(knftables.FilterType),
+		Hook:     knftables.PtrTo(knftables.InputHook),
+		Priority: knftables.PtrTo(knftables.FilterPriority),
+	})
+	tx.Flush(&knftables.Chain{
+		Name: HostNetworkPolicyIngressChain,
+	})
+	tx.Add(&knftables.Chain{
+		Name:     HostNetworkPolicyEgressChain,
+		Comment:  knftables.PtrTo("Network policies of host network pods, egress"),
+		Type:     knftables.PtrTo(knftables.FilterType),
+		Hook:     knftables.PtrTo(knftables.OutputHook),
+		Priority: knftables.PtrTo(knftables.FilterPriority),
+	})
+	tx.Flush(&knftables.Chain{
+		Name: HostNetworkPolicyEgressChain,
+	})
+	for _, rule := range ingressRules {
+		tx.Add(&knftables.Rule{
+			Chain: HostNetworkPolicyIngressChain,
+			Rule:  rule,
+		})
+	}
+	for _, rule := range egressRules {
+		tx.Add(&knftables.Rule{
+			Chain: HostNetworkPolicyEgressChain,
+			Rule:  rule,
+		})
+	}
+	if err = m.nft.Run(context.TODO(), tx); err != nil {
+		return fmt.Errorf("could not update nftables rules for host network policies: %w", err)
+	}
+	if retry {
+		m.reconciler.ReconcileAfter(hostNetworkPolicySyncKey, podCgroupRetryInterval)
+	}
+	return nil
+}
+
+// buildRules returns the rules of the ingress and egress chains. retry is true if the egress rules of some
+// running pods were skipped because their cgroup was not found.
+func (m *HostNetworkPolicyManager) buildRules() (ingressRules, egressRules []string, retry bool, err error) {
+	node, err := m.nodeLister.Get(m.nodeName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			m.stopPodWatch()
+			return nil, nil, false, nil
+		}
+		return nil, nil, false, fmt.Errorf("failed to get node %s: %w", m.nodeName, err)
+	}
+	if node.Labels[types.HostNetworkPolicyNodeLabel] != "true" {
+		m.stopPodWatch()
+		m.updatePodCgroups(nil)
+		return nil, nil, false, nil
+	}
+	if err = m.startPodWatch(); err != nil {
+		return nil, nil, false, err
+	}
+	pods, err := m.getLocalHostNetworkPods()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	localPodUIDs := map[ktypes.UID]bool{}
+	for _, pod := range pods {
+		policies, err := m.getPodPolicies(pod)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		var ingressPolicies, egressPolicies []*knet.NetworkPolicy
+		for _, policy := range policies {
+			ingress, egress := getHostNetworkPolicyTypes(policy)
+			if ingress {
+				ingressPolicies = append(ingressPolicies, policy)
+			}
+			if egress {
+				egressPolicies = append(egressPolicies, policy)
+			}
+		}
+		if len(ingressPolicies) > 0 {
+			rules, err := m.buildPodIngressRules(pod, ingressPolicies)
+			if err != nil {
+				return nil, nil, false, err
+			}
+			ingressRules = append(ingressRules, rules...)
+		}
+		if len(egressPolicies) > 0 && m.cgroupRoot != "" {
+			localPodUIDs[pod.UID] = true
+			cgroupPath, err := m.getPodCgroupPath(pod.UID)
+			if err != nil {
+				klog.Warningf("Egress network policies of host network pod %s/%s are not enforced yet: %v", pod.Namespace, pod.Name, err)
+				retry = true
+				continue
+			}
+			rules, err := m.buildPodEgressRules(pod, cgroupPath, egressPolicies)
+			if err != nil {
+				return nil, nil, false, err
+			}
+			egressRules = append(egressRules, rules...)
+		}
+	}
+	m.updatePodCgroups(localPodUIDs)
+	// the chains only drop the traffic of the isolated pods, so the common rules are only needed with pod rules
+	if len(ingressRules) > 0 {
+		ingressRules = append([]string{
+			"ct state established,related accept",
+			knftables.Concat("iifname", "lo", "accept"),
+		}, ingressRules...)
+	}
+	if len(egressRules) > 0 {
+		egressRules = append([]string{
+			"ct state established,related accept",
+			knftables.Concat("oifname", "lo", "accept"),
+		}, egressRules...)
+	}
+	return ingressRules, egressRules, retry, nil
+}
+
+// getLocalHostNetworkPods returns the running host network pods of the node, sorted by namespace and name
+func (m *HostNetworkPolicyManager) getLocalHostNetworkPods() ([]*corev1.Pod, error) {
+	allPods, err := m.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var pods []*corev1.Pod
+	for _, pod := range allPods {
+		if pod.Spec.NodeName == m.nodeName && pod.Spec.HostNetwork && pod.Status.Phase == corev1.PodRunning {
+			pods = append(pods, pod)
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return pods, nil
+}
+
+// getPodPolicies returns the network policies selecting the pod, sorted by name
+func (m *HostNetworkPolicyManager) getPodPolicies(pod *corev1.Pod) ([]*knet.NetworkPolicy, error) {
+	policies, err := m.policyLister.NetworkPolicies(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies in namespace %s: %w", pod.Namespace, err)
+	}
+	var podPolicies []*knet.NetworkPolicy
+	for _, policy := range policies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			klog.Errorf("Invalid pod selector of network policy %s/%s: %v", policy.Namespace, policy.Name, err)
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			podPolicies = append(podPolicies, policy)
+		}
+	}
+	sort.Slice(podPolicies, func(i, j int) bool {
+		return podPolicies[i].Name < podPolicies[j].Name
+	})
+	return podPolicies, nil
+}
+
+// getHostNetworkPolicyTypes returns if the policy isolates the pods it selects for ingress and egress,
+// with the default of the policy types
+func getHostNetworkPolicyTypes(policy *knet.NetworkPolicy) (ingress, egress bool) {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return true, len(policy.Spec.Egress) > 0
+	}
+	for _, policyType := range policy.Spec.PolicyTypes {
+		switch policyType {
+		case knet.PolicyTypeIngress:
+			ingress = true
+		case knet.PolicyTypeEgress:
+			egress = true
+		}
+	}
+	return
+}
+
+// hostNetworkPodPort is a container port of a host network pod, that is open on the node
+type hostNetworkPodPort struct {
+	protocol string
+	port     int32
+	name     string
+}
+
+func (p hostNetworkPodPort) match() string {
+	return knftables.Concat("meta l4proto", p.protocol, "th dport", strconv.Itoa(int(p.port)))
+}
+
+// getPodPorts returns the declared container ports of the pod
+func getPodPorts(pod *corev1.Pod) []hostNetworkPodPort {
+	var ports []hostNetworkPodPort
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			protocol := corev1.ProtocolTCP
+			if port.Protocol != "" {
+				protocol = port.Protocol
+			}
+			ports = append(ports, hostNetworkPodPort{
+				protocol: strings.ToLower(string(protocol)),
+				port:     port.ContainerPort,
+				name:     port.Name,
+			})
+		}
+	}
+	return ports
+}
+
+// portMatches returns true if the network policy port allows the traffic to the pod port
+func portMatches(policyPort knet.NetworkPolicyPort, podPort hostNetworkPodPort) bool {
+	protocol := corev1.ProtocolTCP
+	if policyPort.Protocol != nil {
+		protocol = *policyPort.Protocol
+	}
+	if strings.ToLower(string(protocol)) != podPort.protocol {
+		return false
+	}
+	if policyPort.Port == nil {
+		return true
+	}
+	if policyPort.Port.Type == intstr.String {
+		// named port
+		return policyPort.Port.StrVal == podPort.name
+	}
+	if policyPort.EndPort != nil {
+		return podPort.port >= policyPort.Port.IntVal && podPort.port <= *policyPort.EndPort
+	}
+	return podPort.port == policyPort.Port.IntVal
+}
+
+// buildPodIngressRules returns the rules letting through the traffic to the declared ports of the pod allowed by the
+// ingress rules of the policies, followed by the rules dropping the rest of the traffic to these ports.
+func (m *HostNetworkPolicyManager) buildPodIngressRules(pod *corev1.Pod, policies []*knet.NetworkPolicy) ([]string, error) {
+	podPorts := getPodPorts(pod)
+	if len(podPorts) == 0 {
+		return nil, nil
+	}
+	var rules []string
+	for _, policy := range policies {
+		for _, ingress := range policy.Spec.Ingress {
+			peerMatches, err := m.getPeerMatches(policy.Namespace, ingress.From, "saddr")
+			if err != nil {
+				return nil, err
+			}
+			for _, podPort := range podPorts {
+				allowed := len(ingress.Ports) == 0
+				for _, policyPort := range ingress.Ports {
+					if portMatches(policyPort, podPort) {
+						allowed = true
+						break
+					}
+				}
+				if !allowed {
+					continue
+				}
+				for _, peerMatch := range peerMatches {
+					rules = append(rules, knftables.Concat(peerMatch, podPort.match(), "accept"))
+				}
+			}
+		}
+	}
+	for _, podPort := range podPorts {
+		rules = append(rules, knftables.Concat(podPort.match(), "drop"))
+	}
+	return rules, nil
+}
+
+// buildPodEgressRules returns the rules letting through the traffic of the pod cgroup allowed by the egress rules
+// of the policies, followed by the rule dropping the rest of the traffic of the pod cgroup.
+func (m *HostNetworkPolicyManager) buildPodEgressRules(pod *corev1.Pod, cgroupPath string, policies []*knet.NetworkPolicy) ([]string, error) {
+	cgroupMatch := knftables.Concat("socket", "cgroupv2", "level", strconv.Itoa(strings.Count(cgroupPath, "/")+1), cgroupPath)
+	var rules []string
+	for _, policy := range policies {
+		for _, egress := range policy.Spec.Egress {
+			peerMatches, err := m.getPeerMatches(policy.Namespace, egress.To, "daddr")
+			if err != nil {
+				return nil, err
+			}
+			portMatches := getEgressPortMatches(pod, egress.Ports)
+			for _, peerMatch := range peerMatches {
+				for _, portMatch := range portMatches {
+					rules = append(rules, knftables.Concat(cgroupMatch, peerMatch, portMatch, "accept"))
+				}
+			}
+		}
+	}
+	rules = append(rules, knftables.Concat(cgroupMatch, "drop"))
+	return rules, nil
+}
+
+// getEgressPortMatches returns the matches of the destination ports of the egress rule, a single empty match
+// if the rule allows all ports
+func getEgressPortMatches(pod *corev1.Pod, ports []knet.NetworkPolicyPort) []string {
+	if len(ports) == 0 {
+		return []string{""}
+	}
+	var matches []string
+	for _, port := range ports {
+		protocol := corev1.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+		match := knftables.Concat("meta l4proto", strings.ToLower(string(protocol)))
+		switch {
+		case port.Port == nil:
+		case port.Port.Type == intstr.String:
+			// named ports are ports of the peer pods, that are not resolved for host network policies
+			klog.V(5).Infof("Ignoring named port %s of egress rule of host network pod %s/%s", port.Port.StrVal,
+				pod.Namespace, pod.Name)
+			continue
+		case port.EndPort != nil:
+			match = knftables.Concat(match, "th dport", fmt.Sprintf("%d-%d", port.Port.IntVal, *port.EndPort))
+		default:
+			match = knftables.Concat(match, "th dport", strconv.Itoa(int(port.Port.IntVal)))
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// getPeerMatches returns the matches of the addresses of the given peers, in the given direction (saddr or daddr).
+// A single empty match is returned if the peers allow all addresses, no matches if they don't select any address.
+func (m *HostNetworkPolicyManager) getPeerMatches(namespace string, peers []knet.NetworkPolicyPeer, direction string) ([]string, error) {
+	if len(peers) == 0 {
+		return []string{""}, nil
+	}
+	var matches []string
+	var peerIPsv4, peerIPsv6 []string
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			if match := m.getIPBlockMatch(peer.IPBlock, direction); match != "" {
+				matches = append(matches, match)
+			}
+			continue
+		}
+		ipsv4, ipsv6, err := m.getPeerPodIPs(namespace, peer)
+		if err != nil {
+			return nil, err
+		}
+		peerIPsv4 = append(peerIPsv4, ipsv4...)
+		peerIPsv6 = append(peerIPsv6, ipsv6...)
+	}
+	if m.ipv4 && len(peerIPsv4) > 0 {
+		matches = append(matches, knftables.Concat("ip", direction, "{", strings.Join(sortedUnique(peerIPsv4), ", "), "}"))
+	}
+	if m.ipv6 && len(peerIPsv6) > 0 {
+		matches = append(matches, knftables.Concat("ip6", direction, "{", strings.Join(sortedUnique(peerIPsv6), ", "), "}"))
+	}
+	return matches, nil
+}
+
+func (m *HostNetworkPolicyManager) getIPBlockMatch(ipBlock *knet.IPBlock, direction string) string {
+	family := "ip"
+	if utilnet.IsIPv6CIDRString(ipBlock.CIDR) {
+		if !m.ipv6 {
+			return ""
+		}
+		family = "ip6"
+	} else if !m.ipv4 {
+		return ""
+	}
+	match := knftables.Concat(family, direction, ipBlock.CIDR)
+	if len(ipBlock.Except) > 0 {
+		match = knftables.Concat(match, family, direction, "!=", "{", strings.Join(ipBlock.Except, ", "), "}")
+	}
+	return match
+}
+
+// getPeerPodIPs returns the IPs of the pods selected by the pod and namespace selectors of the peer
+func (m *HostNetworkPolicyManager) getPeerPodIPs(namespace string, peer knet.NetworkPolicyPeer) (ipsv4, ipsv6 []string, err error) {
+	podSelector := labels.Everything()
+	if peer.PodSelector != nil {
+		podSelector, err = metav1.LabelSelectorAsSelector(peer.PodSelector)
+		if err != nil {
+			klog.Errorf("Invalid pod selector of network policy peer in namespace %s: %v", namespace, err)
+			return nil, nil, nil
+		}
+	}
+	namespaces := []string{namespace}
+	if peer.NamespaceSelector != nil {
+		namespaceSelector, err := metav1.LabelSelectorAsSelector(peer.NamespaceSelector)
+		if err != nil {
+			klog.Errorf("Invalid namespace selector of network policy peer in namespace %s: %v", namespace, err)
+			return nil, nil, nil
+		}
+		selectedNamespaces, err := m.namespaceLister.List(namespaceSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list namespaces: %w", err)
+		}
+		namespaces = nil
+		for _, ns := range selectedNamespaces {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	for _, ns := range namespaces {
+		pods, err := m.podLister.Pods(ns).List(podSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list pods in namespace %s: %w", ns, err)
+		}
+		for _, pod := range pods {
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			for _, podIP := range pod.Status.PodIPs {
+				ip := net.ParseIP(podIP.IP)
+				if ip == nil {
+					continue
+				}
+				if ip.To4() != nil {
+					ipsv4 = append(ipsv4, ip.String())
+				} else {
+					ipsv6 = append(ipsv6, ip.String())
+				}
+			}
+		}
+	}
+	return ipsv4, ipsv6, nil
+}
+
+func sortedUnique(values []string) []string {
+	unique := map[string]bool{}
+	var result []string
+	for _, value := range values {
+		if !unique[value] {
+			unique[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// getPodCgroupPath returns the cgroup path of the pod, relative to the cgroup v2 mountpoint.
+// The pod cgroup is named pod<uid> with the cgroupfs cgroup driver, and <parent>-pod<uid with underscores>.slice
+// with the systemd cgroup driver.
+func (m *HostNetworkPolicyManager) getPodCgroupPath(podUID ktypes.UID) (string, error) {
+	m.podCgroupsLock.Lock()
+	defer m.podCgroupsLock.Unlock()
+	if path, ok := m.podCgroups[podUID]; ok {
+		return path, nil
+	}
+	cgroupfsName := "pod" + string(podUID)
+	systemdSuffix := "-pod" + strings.ReplaceAll(string(podUID), "-", "_") + ".slice"
+	var podPath string
+	err := filepath.WalkDir(m.cgroupRoot, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(m.cgroupRoot, path)
+		if err != nil || relPath == "." {
+			return nil
+		}
+		if d.Name() == cgroupfsName || strings.HasSuffix(d.Name(), systemdSuffix) {
+			podPath = relPath
+			return filepath.SkipAll
+		}
+		if strings.Count(relPath, "/")+1 >= podCgroupMaxLevel {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to find cgroup of pod %s: %w", podUID, err)
+	}
+	if podPath == "" {
+		return "", fmt.Errorf("cgroup of pod %s not found", podUID)
+	}
+	m.podCgroups[podUID] = podPath
+	return podPath, nil
+}
+
+// updatePodCgroups forgets the cgroup paths of the pods that are not selected anymore
+func (m *HostNetworkPolicyManager) updatePodCgroups(podUIDs map[ktypes.UID]bool) {
+	m.podCgroupsLock.Lock()
+	defer m.podCgroupsLock.Unlock()
+	for uid := range m.podCgroups {
+		if !podUIDs[uid] {
+			delete(m.podCgroups, uid)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	knet "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/knftables"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	nodenft "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/nftables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Host network policies", func() {
+	var (
+		manager    *HostNetworkPolicyManager
+		wf         *factory.WatchFactory
+		fakeClient *util.OVNNodeClientset
+		nft        *knftables.Fake
+		cgroupRoot string
+	)
+
+	const (
+		nodeName      = "node1"
+		namespace     = "host-namespace"
+		podCgroupPath = "kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-podhostnet_pod.slice"
+	)
+
+	const expectedChains = `add table inet ovn-kubernetes
+add chain inet ovn-kubernetes host-network-policy-egress { type filter hook output priority 0 ; comment "Network policies of host network pods, egress" ; }
+add chain inet ovn-kubernetes host-network-policy-ingress { type filter hook input priority 0 ; comment "Network policies of host network pods, ingress" ; }
+`
+
+	newNode := func(labeled bool) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   nodeName,
+				Labels: map[string]string{},
+			},
+		}
+		if labeled {
+			node.Labels[types.HostNetworkPolicyNodeLabel] = "true"
+		}
+		return node
+	}
+
+	newHostNetworkPod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "hostnet",
+				UID:       ktypes.UID("hostnet-pod"),
+				Namespace: namespace,
+				Labels:    map[string]string{"app": "server"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:    nodeName,
+				HostNetwork: true,
+				Containers: []corev1.Container{{
+					Name: "server",
+					Ports: []corev1.ContainerPort{
+						{Name: "http", ContainerPort: 8080},
+						{Name: "dns", ContainerPort: 5353, Protocol: corev1.ProtocolUDP},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+		}
+	}
+
+	newPeerPod := func(name string, ips ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				UID:       ktypes.UID(name),
+				Namespace: namespace,
+				Labels:    map[string]string{"app": "client"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node2",
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+		}
+		for _, ip := range ips {
+			pod.Status.PodIPs = append(pod.Status.PodIPs, corev1.PodIP{IP: ip})
+		}
+		return pod
+	}
+
+	newPolicy := func() *knet.NetworkPolicy {
+		tcp := corev1.ProtocolTCP
+		return &knet.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "server",
+				Namespace: namespace,
+			},
+			Spec: knet.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}},
+				PolicyTypes: []knet.PolicyType{knet.PolicyTypeIngress, knet.PolicyTypeEgress},
+				Ingress: []knet.NetworkPolicyIngressRule{{
+					From: []knet.NetworkPolicyPeer{{
+						PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}},
+					}},
+					Ports: []knet.NetworkPolicyPort{{
+						Protocol: &tcp,
+						Port:     &intstr.IntOrString{Type: intstr.String, StrVal: "http"},
+					}},
+				}},
+				Egress: []knet.NetworkPolicyEgressRule{{
+					To: []knet.NetworkPolicyPeer{{
+						IPBlock: &knet.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}},
+					}},
+					Ports: []knet.NetworkPolicyPort{{
+						Protocol: &tcp,
+						Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: 443},
+					}},
+				}},
+			},
+		}
+	}
+
+	const expectedPolicyRules = `add rule inet ovn-kubernetes host-network-policy-egress ct state established,related accept
+add rule inet ovn-kubernetes host-network-policy-egress oifname lo accept
+add rule inet ovn-kubernetes host-network-policy-egress socket cgroupv2 level 3 ` + podCgroupPath + ` ip daddr 10.0.0.0/8 ip daddr != { 10.1.0.0/16 } meta l4proto tcp th dport 443 accept
+add rule inet ovn-kubernetes host-network-policy-egress socket cgroupv2 level 3 ` + podCgroupPath + ` drop
+add rule inet ovn-kubernetes host-network-policy-ingress ct state established,related accept
+add rule inet ovn-kubernetes host-network-policy-ingress iifname lo accept
+add rule inet ovn-kubernetes host-network-policy-ingress ip saddr { 10.244.1.5 } meta l4proto tcp th dport 8080 accept
+add rule inet ovn-kubernetes host-network-policy-ingress ip6 saddr { fd00::5 } meta l4proto tcp th dport 8080 accept
+add rule inet ovn-kubernetes host-network-policy-ingress meta l4proto tcp th dport 8080 drop
+add rule inet ovn-kubernetes host-network-policy-ingress meta l4proto udp th dport 5353 drop
+`
+
+	start := func(objects ...runtime.Object) {
+		fakeClient = util.GetOVNClientset(objects...).GetNodeClientset()
+		var err error
+		wf, err = factory.NewNodeWatchFactory(fakeClient, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+
+		manager = NewHostNetworkPolicyManager(true, true, fakeClient.KubeClient, wf.NodeCoreInformer(),
+			wf.NamespaceInformer(), wf.NetworkPolicyCoreInformer(), nodeName, nil)
+
+		err = wf.Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		// Copy manager.Start() sequence, but using fake nft and cgroup root
+		manager.cgroupRoot = cgroupRoot
+		nft = nodenft.SetFakeNFTablesHelper()
+		manager.nft = nft
+		err = controller.StartWithInitialSync(manager.syncRules, manager.reconciler, manager.nodeController,
+			manager.namespaceController, manager.policyController)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableHostNetworkPolicy = true
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+
+		cgroupRoot = GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(cgroupRoot, podCgroupPath), 0o755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(cgroupRoot, "system.slice", "kubelet.service"), 0o755)).To(Succeed())
+
+		wf = nil
+		manager = nil
+	})
+
+	AfterEach(func() {
+		if wf != nil {
+			wf.Shutdown()
+		}
+		if manager != nil {
+			manager.Stop()
+		}
+	})
+
+	It("does not add rules on a node without the label", func() {
+		start(newNode(false), newHostNetworkPod(), newPeerPod("client", "10.244.1.5", "fd00::5"), newPolicy())
+		Expect(nft.Dump()).To(Equal(expectedChains))
+		// the pods of the cluster are not watched on nodes without the label
+		Expect(manager.podController).To(BeNil())
+	})
+
+	It("does not add rules for host network pods without policies", func() {
+		start(newNode(true), newHostNetworkPod(), newPeerPod("client", "10.244.1.5", "fd00::5"))
+		Expect(nft.Dump()).To(Equal(expectedChains))
+	})
+
+	It("correctly generates initial rules", func() {
+		start(newNode(true), newHostNetworkPod(), newPeerPod("client", "10.244.1.5", "fd00::5"), newPolicy())
+		Expect(nft.Dump()).To(Equal(expectedChains + expectedPolicyRules))
+	})
+
+	It("ignores pods that are not host network pods of the node", func() {
+		pod := newHostNetworkPod()
+		pod.Spec.HostNetwork = false
+		otherNodePod := newHostNetworkPod()
+		otherNodePod.Name = "other-node"
+		otherNodePod.UID = "other-node"
+		otherNodePod.Spec.NodeName = "node2"
+		start(newNode(true), pod, otherNodePod, newPolicy())
+		Expect(nft.Dump()).To(Equal(expectedChains))
+	})
+
+	It("updates rules on node label update", func() {
+		start(newNode(false), newHostNetworkPod(), newPeerPod("client", "10.244.1.5", "fd00::5"), newPolicy())
+		Expect(nft.Dump()).To(Equal(expectedChains))
+
+		_, err := fakeClient.KubeClient.CoreV1().Nodes().Update(context.TODO(), newNode(true), metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(nft.Dump).Should(Equal(expectedChains + expectedPolicyRules))
+
+		_, err = fakeClient.KubeClient.CoreV1().Nodes().Update(context.TODO(), newNode(false), metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(nft.Dump).Should(Equal(expectedChains))
+	})
+
+	It("updates rules on policy and peer updates", func() {
+		start(newNode(true), newHostNetworkPod(), newPeerPod("client", "10.244.1.5", "fd00::5"))
+		Expect(nft.Dump()).To(Equal(expectedChains))
+
+		_, err := fakeClient.KubeClient.NetworkingV1().NetworkPolicies(namespace).Create(context.TODO(), newPolicy(), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(nft.Dump).Should(Equal(expectedChains + expectedPolicyRules))
+
+		_, err = fakeClient.KubeClient.CoreV1().Pods(namespace).Create(context.TODO(), newPeerPod("client2", "10.244.2.5"), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(nft.Dump).Should(Equal(expectedChains + strings.Replace(expectedPolicyRules,
+			"ip saddr { 10.244.1.5 }", "ip saddr { 10.244.1.5, 10.244.2.5 }", 1)))
+
+		err = fakeClient.KubeClient.NetworkingV1().NetworkPolicies(namespace).Delete(context.TODO(), "server", metav1.DeleteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(nft.Dump).Should(Equal(expectedChains))
+	})
+
+	It("retries egress rules of pods without a cgroup", func() {
+		Expect(os.RemoveAll(filepath.Join(cgroupRoot, "kubepods.slice"))).To(Succeed())
+		start(newNode(true), newHostNetworkPod(), newPeerPod("client", "10.244.1.5", "fd00::5"), newPolicy())
+		Expect(nft.Dump()).NotTo(ContainSubstring("host-network-policy-egress socket cgroupv2"))
+
+		Expect(os.MkdirAll(filepath.Join(cgroupRoot, podCgroupPath), 0o755)).To(Succeed())
+		Eventually(nft.Dump, podCgroupRetryInterval*3).Should(Equal(expectedChains + expectedPolicyRules))
+	})
+})
@@ -0,0 +1,211 @@
+package mtumanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	ovsops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops/ovs"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/vswitchd"
+)
+
+const (
+	reasonUpdated    = "Updated"
+	reasonInProgress = "InProgress"
+	reasonFailed     = "Failed"
+)
+
+// updatePodInterfaceMTU is overridden in unit tests
+var updatePodInterfaceMTU = cni.UpdatePodInterfaceMTU
+
+// Controller rolls out network MTU changes to the interfaces of the pods
+// running on the node, without restarting them, and tracks the progress in
+// the NodeNetworkMTUCondition node condition.
+//
+// A MTU change is rolled out in two steps around the update of the network
+// infrastructure: a decrease is applied to the pods before the infrastructure
+// so that pods never send frames the infrastructure can't carry, while an
+// increase is applied to the pods after the infrastructure.
+type Controller struct {
+	mu        sync.Mutex
+	nodeName  string
+	kube      kube.Interface
+	ovsClient libovsdbclient.Client
+	// updates that are in progress or failed, by network name
+	inProgress map[string]string
+	failed     map[string]string
+}
+
+func NewController(nodeName string, kube kube.Interface, ovsClient libovsdbclient.Client) *Controller {
+	return &Controller{
+		nodeName:   nodeName,
+		kube:       kube,
+		ovsClient:  ovsClient,
+		inProgress: map[string]string{},
+		failed:     map[string]string{},
+	}
+}
+
+// StartUpdate starts rolling out a MTU change of the given network and must
+// be called before its infrastructure is updated. A MTU decrease is applied
+// to the pods at this point.
+func (c *Controller) StartUpdate(network string, oldMTU, newMTU int) error {
+	if oldMTU == newMTU {
+		return nil
+	}
+	klog.Infof("MTU manager: starting update of network %s MTU from %d to %d", network, oldMTU, newMTU)
+	c.setState(network, reasonInProgress, fmt.Sprintf("%s: %d -> %d", network, oldMTU, newMTU))
+	if newMTU > oldMTU {
+		return nil
+	}
+	return c.updatePods(network, newMTU)
+}
+
+// FinishUpdate completes rolling out a MTU change of the given network and
+// must be called after its infrastructure is updated. A MTU increase is
+// applied to the pods at this point.
+func (c *Controller) FinishUpdate(network string, oldMTU, newMTU int) error {
+	if oldMTU == newMTU {
+		return nil
+	}
+	if newMTU > oldMTU {
+		if err := c.updatePods(network, newMTU); err != nil {
+			return err
+		}
+	}
+	c.setState(network, reasonUpdated, "")
+	klog.Infof("MTU manager: finished update of network %s MTU from %d to %d", network, oldMTU, newMTU)
+	return nil
+}
+
+// updatePods sets the given MTU on the interfaces of all the pods attached to
+// the network on this node
+func (c *Controller) updatePods(network string, mtu int) error {
+	ifaces, err := ovsops.FindInterfacesWithPredicate(c.ovsClient, func(iface *vswitchd.Interface) bool {
+		if iface.ExternalIDs["iface-id"] == "" || iface.ExternalIDs["sandbox"] == "" {
+			return false
+		}
+		ifaceNetwork := iface.ExternalIDs[types.NetworkExternalID]
+		if ifaceNetwork == "" {
+			ifaceNetwork = types.DefaultNetworkName
+		}
+		return ifaceNetwork == network
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find pod interfaces of network %s: %w", network, err)
+		c.setState(network, reasonFailed, err.Error())
+		return err
+	}
+
+	var errs []string
+	for _, iface := range ifaces {
+		if err := updatePodInterfaceMTU(iface.Name, mtu); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		err = fmt.Errorf("failed to update the MTU of %d pod interfaces of network %s: %s",
+			len(errs), network, strings.Join(errs, "; "))
+		c.setState(network, reasonFailed, err.Error())
+		return err
+	}
+	klog.Infof("MTU manager: set MTU %d on %d pod interfaces of network %s", mtu, len(ifaces), network)
+	return nil
+}
+
+// setState records the state of the MTU update of a network and updates the
+// node condition accordingly
+func (c *Controller) setState(network, reason, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inProgress, network)
+	delete(c.failed, network)
+	switch reason {
+	case reasonInProgress:
+		c.inProgress[network] = message
+	case reasonFailed:
+		c.failed[network] = message
+	}
+	if err := c.updateCondition(); err != nil {
+		klog.Errorf("MTU manager: failed to update condition %s of node %s: %v", types.NodeNetworkMTUCondition, c.nodeName, err)
+	}
+}
+
+func (c *Controller) updateCondition() error {
+	condition := corev1.NodeCondition{
+		Type:    types.NodeNetworkMTUCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  reasonUpdated,
+		Message: "Network MTU changes have been rolled out to all pods",
+	}
+	switch {
+	case len(c.failed) > 0:
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = reasonFailed
+		condition.Message = joinMessages(c.failed)
+	case len(c.inProgress) > 0:
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = reasonInProgress
+		condition.Message = "Rolling out network MTU changes: " + joinMessages(c.inProgress)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := c.kube.GetNode(c.nodeName)
+		if err != nil {
+			return err
+		}
+		node = node.DeepCopy()
+		if !setNodeCondition(&node.Status, condition) {
+			return nil
+		}
+		return c.kube.UpdateNodeStatus(node)
+	})
+}
+
+func joinMessages(messages map[string]string) string {
+	networks := make([]string, 0, len(messages))
+	for network := range messages {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	joined := make([]string, 0, len(networks))
+	for _, network := range networks {
+		joined = append(joined, messages[network])
+	}
+	return strings.Join(joined, ", ")
+}
+
+// setNodeCondition sets the condition on the node status, returning whether
+// it changed
+func setNodeCondition(status *corev1.NodeStatus, condition corev1.NodeCondition) bool {
+	now := metav1.Now()
+	condition.LastHeartbeatTime = now
+	condition.LastTransitionTime = now
+	for i := range status.Conditions {
+		existing := &status.Conditions[i]
+		if existing.Type != condition.Type {
+			continue
+		}
+		if existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+			return false
+		}
+		if existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		*existing = condition
+		return true
+	}
+	status.Conditions = append(status.Conditions, condition)
+	return true
+}
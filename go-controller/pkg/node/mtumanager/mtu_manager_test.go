@@ -0,0 +1,133 @@
+package mtumanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/vswitchd"
+)
+
+var _ = ginkgo.Describe("MTU manager", func() {
+	const nodeName = "node1"
+
+	var (
+		controller *Controller
+		kubeClient *fake.Clientset
+		updated    map[string]int
+		failOn     string
+	)
+
+	getCondition := func() *corev1.NodeCondition {
+		node, err := kubeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == types.NodeNetworkMTUCondition {
+				return &condition
+			}
+		}
+		return nil
+	}
+
+	ginkgo.BeforeEach(func() {
+		ovsClient, cleanup, err := libovsdbtest.NewOVSTestHarness(libovsdbtest.TestSetup{
+			OVSData: []libovsdbtest.TestData{
+				&vswitchd.OpenvSwitch{UUID: "root-uuid", Bridges: []string{"br-int-uuid"}},
+				&vswitchd.Bridge{
+					UUID:  "br-int-uuid",
+					Name:  "br-int",
+					Ports: []string{"default-pod-port-uuid", "tenant-pod-port-uuid", "mgmt-port-port-uuid"},
+				},
+				&vswitchd.Port{UUID: "default-pod-port-uuid", Name: "defaultpod", Interfaces: []string{"default-pod-uuid"}},
+				&vswitchd.Port{UUID: "tenant-pod-port-uuid", Name: "tenantpod_3", Interfaces: []string{"tenant-pod-uuid"}},
+				&vswitchd.Port{UUID: "mgmt-port-port-uuid", Name: types.K8sMgmtIntfName, Interfaces: []string{"mgmt-port-uuid"}},
+				&vswitchd.Interface{
+					UUID:        "default-pod-uuid",
+					Name:        "defaultpod",
+					ExternalIDs: map[string]string{"iface-id": "ns_pod1", "sandbox": "sandbox1"},
+				},
+				&vswitchd.Interface{
+					UUID: "tenant-pod-uuid",
+					Name: "tenantpod_3",
+					ExternalIDs: map[string]string{
+						"iface-id":              "tenant_ns_pod1",
+						"sandbox":               "sandbox1",
+						types.NetworkExternalID: "tenant",
+					},
+				},
+				&vswitchd.Interface{
+					UUID:        "mgmt-port-uuid",
+					Name:        types.K8sMgmtIntfName,
+					ExternalIDs: map[string]string{"iface-id": types.K8sPrefix + nodeName},
+				},
+			},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		ginkgo.DeferCleanup(cleanup.Cleanup)
+
+		kubeClient = fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})
+		controller = NewController(nodeName, &kube.Kube{KClient: kubeClient}, ovsClient)
+
+		updated = map[string]int{}
+		failOn = ""
+		updatePodInterfaceMTU = func(hostIfaceName string, mtu int) error {
+			if hostIfaceName == failOn {
+				return fmt.Errorf("failed")
+			}
+			updated[hostIfaceName] = mtu
+			return nil
+		}
+		ginkgo.DeferCleanup(func() {
+			updatePodInterfaceMTU = nil
+		})
+	})
+
+	ginkgo.It("lowers the MTU of the pods before the infrastructure", func() {
+		gomega.Expect(controller.StartUpdate("tenant", 1400, 1300)).To(gomega.Succeed())
+		gomega.Expect(updated).To(gomega.Equal(map[string]int{"tenantpod_3": 1300}))
+		condition := getCondition()
+		gomega.Expect(condition).NotTo(gomega.BeNil())
+		gomega.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+		gomega.Expect(condition.Reason).To(gomega.Equal(reasonInProgress))
+
+		gomega.Expect(controller.FinishUpdate("tenant", 1400, 1300)).To(gomega.Succeed())
+		gomega.Expect(updated).To(gomega.HaveLen(1))
+		condition = getCondition()
+		gomega.Expect(condition.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(condition.Reason).To(gomega.Equal(reasonUpdated))
+	})
+
+	ginkgo.It("raises the MTU of the pods after the infrastructure", func() {
+		gomega.Expect(controller.StartUpdate(types.DefaultNetworkName, 1400, 8900)).To(gomega.Succeed())
+		gomega.Expect(updated).To(gomega.BeEmpty())
+
+		gomega.Expect(controller.FinishUpdate(types.DefaultNetworkName, 1400, 8900)).To(gomega.Succeed())
+		gomega.Expect(updated).To(gomega.Equal(map[string]int{"defaultpod": 8900}))
+		gomega.Expect(getCondition().Status).To(gomega.Equal(corev1.ConditionTrue))
+	})
+
+	ginkgo.It("does nothing when the MTU did not change", func() {
+		gomega.Expect(controller.StartUpdate("tenant", 1400, 1400)).To(gomega.Succeed())
+		gomega.Expect(controller.FinishUpdate("tenant", 1400, 1400)).To(gomega.Succeed())
+		gomega.Expect(updated).To(gomega.BeEmpty())
+		gomega.Expect(getCondition()).To(gomega.BeNil())
+	})
+
+	ginkgo.It("reports pods that failed to be updated", func() {
+		failOn = "tenantpod_3"
+		gomega.Expect(controller.StartUpdate("tenant", 1400, 1300)).NotTo(gomega.Succeed())
+		condition := getCondition()
+		gomega.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+		gomega.Expect(condition.Reason).To(gomega.Equal(reasonFailed))
+		gomega.Expect(condition.Message).To(gomega.ContainSubstring("tenant"))
+	})
+})
@@ -0,0 +1,311 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/containernetworking/cni/pkg/types"
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadlisters "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
+	nadutils "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/libovsdb/client"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// overridden in unit tests
+var (
+	getPodSandbox         = cni.GetPodSandbox
+	hotplugPodInterface   = cni.HotplugPodInterface
+	hotUnplugPodInterface = cni.HotUnplugPodInterface
+)
+
+// PodInterfaceHotplugManager plugs and unplugs the secondary network
+// interfaces of the pods running on the node when their
+// k8s.v1.cni.cncf.io/networks annotation changes, reflecting the result in
+// their k8s.v1.cni.cncf.io/network-status annotation.
+//
+// Only the NADs of secondary networks managed by ovn-kubernetes are handled,
+// and only once the network-status annotation is set, which signals that the
+// pod sandbox was set up by the runtime.
+type PodInterfaceHotplugManager struct {
+	nodeName       string
+	kube           kube.Interface
+	podLister      corelisters.PodLister
+	nadLister      nadlisters.NetworkAttachmentDefinitionLister
+	networkManager networkmanager.Interface
+	ovsClient      client.Client
+	clientSet      *cni.ClientSet
+	podController  controller.Controller
+}
+
+func NewPodInterfaceHotplugManager(nodeName string, kube kube.Interface, podInformer coreinformers.PodInformer,
+	nadLister nadlisters.NetworkAttachmentDefinitionLister, networkManager networkmanager.Interface,
+	ovsClient client.Client, clientSet *cni.ClientSet) *PodInterfaceHotplugManager {
+	m := &PodInterfaceHotplugManager{
+		nodeName:       nodeName,
+		kube:           kube,
+		podLister:      podInformer.Lister(),
+		nadLister:      nadLister,
+		networkManager: networkManager,
+		ovsClient:      ovsClient,
+		clientSet:      clientSet,
+	}
+	controllerConfig := &controller.ControllerConfig[corev1.Pod]{
+		RateLimiter:    workqueue.NewTypedItemFastSlowRateLimiter[string](time.Second, 5*time.Second, 5),
+		Informer:       podInformer.Informer(),
+		Lister:         podInformer.Lister().List,
+		ObjNeedsUpdate: podInterfacesNeedUpdate,
+		Reconcile:      m.reconcilePod,
+		// hot-plugging an interface waits for the pod to be annotated
+		Threadiness: 4,
+	}
+	m.podController = controller.NewController[corev1.Pod]("pod-interface-hotplug-manager", controllerConfig)
+	return m
+}
+
+func (m *PodInterfaceHotplugManager) Start() error {
+	klog.Infof("Starting pod interface hotplug manager")
+	return controller.Start(m.podController)
+}
+
+func (m *PodInterfaceHotplugManager) Stop() {
+	controller.Stop(m.podController)
+}
+
+func podInterfacesNeedUpdate(oldObj, newObj *corev1.Pod) bool {
+	if oldObj == nil || newObj == nil {
+		return true
+	}
+	return oldObj.Status.Phase != newObj.Status.Phase ||
+		oldObj.Annotations[nadapi.NetworkAttachmentAnnot] != newObj.Annotations[nadapi.NetworkAttachmentAnnot] ||
+		oldObj.Annotations[nadapi.NetworkStatusAnnot] != newObj.Annotations[nadapi.NetworkStatusAnnot] ||
+		oldObj.Annotations[util.OvnPodAnnotationName] != newObj.Annotations[util.OvnPodAnnotationName]
+}
+
+// isPodHotpluggable returns whether the interfaces of the pod can be plugged
+// and unplugged while it runs. KubeVirt hot-plugs the interfaces of VMs with
+// its own machinery.
+func (m *PodInterfaceHotplugManager) isPodHotpluggable(pod *corev1.Pod) bool {
+	return pod.Spec.NodeName == m.nodeName &&
+		!util.PodWantsHostNetwork(pod) &&
+		pod.Status.Phase == corev1.PodRunning &&
+		pod.DeletionTimestamp == nil &&
+		!kubevirt.IsPodOwnedByVirtualMachine(pod)
+}
+
+func (m *PodInterfaceHotplugManager) reconcilePod(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		klog.Errorf("PodInterfaceHotplugManager failed to split meta namespace cache key %s for pod: %v", key, err)
+		return nil
+	}
+	pod, err := m.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// the interfaces of deleted pods are removed with their sandbox
+			return nil
+		}
+		return fmt.Errorf("failed to fetch pod %s in namespace %s", name, namespace)
+	}
+	if !m.isPodHotpluggable(pod) {
+		return nil
+	}
+	if _, ok := pod.Annotations[nadapi.NetworkStatusAnnot]; !ok {
+		// the pod sandbox is still being set up
+		return nil
+	}
+	statuses, err := nadutils.GetNetworkStatus(pod)
+	if err != nil {
+		return fmt.Errorf("failed to get the network status of pod %s: %w", key, err)
+	}
+	requested, err := util.GetK8sPodAllNetworkSelections(pod)
+	if err != nil {
+		// configuration error, no need to retry
+		klog.Errorf("Failed to get the networks requested by pod %s: %v", key, err)
+		return nil
+	}
+
+	// pair the requested networks with the interfaces of the pod, those
+	// requesting a specific interface name first
+	matched := sets.New[int]()
+	var toPlug []*nadapi.NetworkSelectionElement
+	for _, withInterface := range []bool{true, false} {
+		for _, network := range requested {
+			if (network.InterfaceRequest != "") != withInterface {
+				continue
+			}
+			nadName := util.GetNADName(network.Namespace, network.Name)
+			if i := findNetworkStatus(statuses, nadName, network.InterfaceRequest, matched); i >= 0 {
+				matched.Insert(i)
+				continue
+			}
+			toPlug = append(toPlug, network)
+		}
+	}
+	var toUnplug []nadapi.NetworkStatus
+	for i, status := range statuses {
+		if status.Default || matched.Has(i) {
+			continue
+		}
+		toUnplug = append(toUnplug, status)
+	}
+	if len(toPlug) == 0 && len(toUnplug) == 0 {
+		return nil
+	}
+
+	sandbox, err := getPodSandbox(pod)
+	if err != nil {
+		return fmt.Errorf("failed to find the sandbox of pod %s: %w", key, err)
+	}
+
+	var errs []error
+	for _, status := range toUnplug {
+		netInfo, err := m.getSecondaryNetwork(status.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if netInfo == nil {
+			// not managed by ovn-kubernetes
+			continue
+		}
+		if err = hotUnplugPodInterface(m.clientSet, pod, sandbox, status.Interface, netInfo, status.Name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		statuses = removeNetworkStatus(statuses, status.Name, status.Interface)
+		if err = m.setPodNetworkStatus(pod, statuses); err != nil {
+			return err
+		}
+	}
+	for _, network := range toPlug {
+		nadName := util.GetNADName(network.Namespace, network.Name)
+		netInfo, err := m.getSecondaryNetwork(nadName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if netInfo == nil {
+			continue
+		}
+		ifName := network.InterfaceRequest
+		if ifName == "" {
+			ifName = nextPodInterfaceName(statuses)
+		}
+		result, err := hotplugPodInterface(m.clientSet, m.networkManager, m.ovsClient, pod, sandbox, ifName, netInfo, nadName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		status, err := nadutils.CreateNetworkStatus(types.Result(result), nadName, false, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create the network status of pod %s for NAD %s: %w", key, nadName, err))
+			continue
+		}
+		statuses = append(statuses, *status)
+		if err = m.setPodNetworkStatus(pod, statuses); err != nil {
+			return err
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// getSecondaryNetwork returns the secondary network of the NAD if it is
+// managed by ovn-kubernetes, nil otherwise
+func (m *PodInterfaceHotplugManager) getSecondaryNetwork(nadName string) (util.NetInfo, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(nadName)
+	if err != nil {
+		return nil, nil
+	}
+	nad, err := m.nadLister.NetworkAttachmentDefinitions(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get NAD %s: %w", nadName, err)
+	}
+	nadInfo, err := util.ParseNADInfo(nad)
+	if err != nil {
+		if errors.Is(err, config.ErrorAttachDefNotOvnManaged) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to parse NAD %s: %w", nadName, err)
+	}
+	if !nadInfo.IsSecondary() || nadInfo.IsPrimaryNetwork() {
+		return nil, nil
+	}
+	netInfo := m.networkManager.GetNetwork(nadInfo.GetNetworkName())
+	if netInfo == nil || !netInfo.HasNAD(nadName) {
+		return nil, fmt.Errorf("NAD %s of network %s is not processed yet", nadName, nadInfo.GetNetworkName())
+	}
+	return netInfo, nil
+}
+
+func (m *PodInterfaceHotplugManager) setPodNetworkStatus(pod *corev1.Pod, statuses []nadapi.NetworkStatus) error {
+	data, err := json.MarshalIndent(statuses, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the network status of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return m.kube.SetAnnotationsOnPod(pod.Namespace, pod.Name, map[string]interface{}{
+		nadapi.NetworkStatusAnnot: string(data),
+	})
+}
+
+// findNetworkStatus returns the index of the network status of the NAD
+// interface, any interface if ifName is empty, skipping the excluded ones, or
+// -1 if there is none
+func findNetworkStatus(statuses []nadapi.NetworkStatus, nadName, ifName string, excluded sets.Set[int]) int {
+	for i := range statuses {
+		if excluded.Has(i) || statuses[i].Name != nadName {
+			continue
+		}
+		if ifName == "" || statuses[i].Interface == ifName {
+			return i
+		}
+	}
+	return -1
+}
+
+func removeNetworkStatus(statuses []nadapi.NetworkStatus, nadName, ifName string) []nadapi.NetworkStatus {
+	result := make([]nadapi.NetworkStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if status.Name != nadName || status.Interface != ifName {
+			result = append(result, status)
+		}
+	}
+	return result
+}
+
+// nextPodInterfaceName returns the first netN interface name, following the
+// multus naming of secondary interfaces, not used by the pod
+func nextPodInterfaceName(statuses []nadapi.NetworkStatus) string {
+	used := sets.New[string]()
+	for _, status := range statuses {
+		used.Insert(status.Interface)
+	}
+	for i := 1; ; i++ {
+		ifName := fmt.Sprintf("net%d", i)
+		if !used.Has(ifName) {
+			return ifName
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	current "github.com/containernetworking/cni/pkg/types/100"
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadutils "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/ovn-org/libovsdb/client"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type hotplugNetworkManager struct {
+	networkmanager.Interface
+	networks map[string]util.NetInfo
+}
+
+func (nm *hotplugNetworkManager) GetNetwork(name string) util.NetInfo {
+	return nm.networks[name]
+}
+
+var _ = Describe("Pod interface hotplug", func() {
+	const (
+		nodeName    = "node1"
+		namespace   = "ns1"
+		networkName = "tenantblue"
+	)
+
+	var (
+		manager    *PodInterfaceHotplugManager
+		wf         *factory.WatchFactory
+		fakeClient *util.OVNNodeClientset
+		plugged    []string
+		unplugged  []string
+	)
+
+	defaultStatus := nadapi.NetworkStatus{
+		Name:      "ovn-kubernetes",
+		Interface: "eth0",
+		IPs:       []string{"10.128.0.4"},
+		Default:   true,
+	}
+
+	newPod := func(phase corev1.PodPhase, networks string, statuses ...nadapi.NetworkStatus) *corev1.Pod {
+		networkStatus, err := json.Marshal(statuses)
+		Expect(err).NotTo(HaveOccurred())
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod1",
+				Namespace: namespace,
+				Annotations: map[string]string{
+					nadapi.NetworkStatusAnnot: string(networkStatus),
+				},
+			},
+			Spec:   corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+		if networks != "" {
+			pod.Annotations[nadapi.NetworkAttachmentAnnot] = networks
+		}
+		return pod
+	}
+
+	start := func(objects ...runtime.Object) {
+		nad := ovntest.GenerateNAD(networkName, "nad1", namespace, types.Layer2Topology, "10.100.200.0/24", types.NetworkRoleSecondary)
+		macvlanNAD := ovntest.GenerateNADWithConfig("macvlan", namespace, `{"cniVersion": "0.4.0", "name": "macvlan", "type": "macvlan"}`)
+		objects = append(objects, nad, macvlanNAD)
+
+		nadInfo, err := util.ParseNADInfo(nad)
+		Expect(err).NotTo(HaveOccurred())
+		netInfo := util.NewMutableNetInfo(nadInfo)
+		netInfo.AddNADs(util.GetNADName(namespace, "nad1"))
+
+		fakeClient = util.GetOVNClientset(objects...).GetNodeClientset()
+		wf, err = factory.NewNodeWatchFactory(fakeClient, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		manager = NewPodInterfaceHotplugManager(nodeName, &kube.Kube{KClient: fakeClient.KubeClient}, wf.PodCoreInformer(),
+			wf.NADInformer().Lister(), &hotplugNetworkManager{networks: map[string]util.NetInfo{networkName: netInfo}},
+			nil, cni.NewClientSet(fakeClient.KubeClient, wf.PodCoreInformer().Lister()))
+		Expect(wf.Start()).To(Succeed())
+	}
+
+	getNetworkStatus := func() []nadapi.NetworkStatus {
+		pod, err := fakeClient.KubeClient.CoreV1().Pods(namespace).Get(context.TODO(), "pod1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		statuses, err := nadutils.GetNetworkStatus(pod)
+		Expect(err).NotTo(HaveOccurred())
+		return statuses
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+		config.OVNKubernetesFeature.EnablePodInterfaceHotplug = true
+		config.IPv4Mode = true
+
+		plugged = nil
+		unplugged = nil
+		getPodSandbox = func(*corev1.Pod) (*cni.PodSandbox, error) {
+			return &cni.PodSandbox{ID: "sandbox1", Netns: "/var/run/netns/pod1"}, nil
+		}
+		hotplugPodInterface = func(_ *cni.ClientSet, _ networkmanager.Interface, _ client.Client, _ *corev1.Pod,
+			sandbox *cni.PodSandbox, ifName string, _ util.NetInfo, nadName string) (*current.Result, error) {
+			plugged = append(plugged, nadName+"@"+ifName)
+			return &current.Result{
+				CNIVersion: "1.0.0",
+				Interfaces: []*current.Interface{{Name: ifName, Mac: "0a:58:0a:64:c8:04", Sandbox: sandbox.Netns}},
+				IPs: []*current.IPConfig{{
+					Interface: ptr.To(0),
+					Address:   net.IPNet{IP: net.ParseIP("10.100.200.4"), Mask: net.CIDRMask(24, 32)},
+				}},
+			}, nil
+		}
+		hotUnplugPodInterface = func(_ *cni.ClientSet, _ *corev1.Pod, _ *cni.PodSandbox, ifName string, _ util.NetInfo, nadName string) error {
+			unplugged = append(unplugged, nadName+"@"+ifName)
+			return nil
+		}
+		wf = nil
+	})
+
+	AfterEach(func() {
+		getPodSandbox = cni.GetPodSandbox
+		hotplugPodInterface = cni.HotplugPodInterface
+		hotUnplugPodInterface = cni.HotUnplugPodInterface
+		if wf != nil {
+			wf.Shutdown()
+		}
+	})
+
+	It("plugs the interfaces of ovn-kubernetes NADs requested by running pods", func() {
+		start(newPod(corev1.PodRunning, "ns1/nad1,ns1/macvlan", defaultStatus))
+
+		Expect(manager.reconcilePod("ns1/pod1")).To(Succeed())
+		Expect(plugged).To(Equal([]string{"ns1/nad1@net1"}))
+		Expect(unplugged).To(BeEmpty())
+
+		statuses := getNetworkStatus()
+		Expect(statuses).To(HaveLen(2))
+		Expect(statuses[1].Name).To(Equal("ns1/nad1"))
+		Expect(statuses[1].Interface).To(Equal("net1"))
+		Expect(statuses[1].IPs).To(Equal([]string{"10.100.200.4"}))
+		Expect(statuses[1].Mac).To(Equal("0a:58:0a:64:c8:04"))
+	})
+
+	It("honors the interface name requested for the NAD", func() {
+		start(newPod(corev1.PodRunning, `[{"name": "nad1", "namespace": "ns1", "interface": "blue0"}]`, defaultStatus))
+
+		Expect(manager.reconcilePod("ns1/pod1")).To(Succeed())
+		Expect(plugged).To(Equal([]string{"ns1/nad1@blue0"}))
+	})
+
+	It("unplugs the interfaces of ovn-kubernetes NADs no longer requested", func() {
+		nadStatus := nadapi.NetworkStatus{Name: "ns1/nad1", Interface: "net1", IPs: []string{"10.100.200.4"}}
+		macvlanStatus := nadapi.NetworkStatus{Name: "ns1/macvlan", Interface: "net2", IPs: []string{"192.168.1.4"}}
+		start(newPod(corev1.PodRunning, "", defaultStatus, nadStatus, macvlanStatus))
+
+		Expect(manager.reconcilePod("ns1/pod1")).To(Succeed())
+		Expect(plugged).To(BeEmpty())
+		Expect(unplugged).To(Equal([]string{"ns1/nad1@net1"}))
+		Expect(getNetworkStatus()).To(Equal([]nadapi.NetworkStatus{defaultStatus, macvlanStatus}))
+	})
+
+	It("plugs the NAD again when the requested interface name differs", func() {
+		nadStatus := nadapi.NetworkStatus{Name: "ns1/nad1", Interface: "net1", IPs: []string{"10.100.200.4"}}
+		start(newPod(corev1.PodRunning, `[{"name": "nad1", "namespace": "ns1", "interface": "blue0"}]`, defaultStatus, nadStatus))
+
+		Expect(manager.reconcilePod("ns1/pod1")).To(Succeed())
+		Expect(unplugged).To(Equal([]string{"ns1/nad1@net1"}))
+		Expect(plugged).To(Equal([]string{"ns1/nad1@blue0"}))
+		statuses := getNetworkStatus()
+		Expect(statuses).To(HaveLen(2))
+		Expect(statuses[1].Interface).To(Equal("blue0"))
+	})
+
+	It("unplugs only the interface of the NAD no longer requested", func() {
+		nadStatus := nadapi.NetworkStatus{Name: "ns1/nad1", Interface: "net1", IPs: []string{"10.100.200.4"}}
+		blueStatus := nadapi.NetworkStatus{Name: "ns1/nad1", Interface: "blue0", IPs: []string{"10.100.200.5"}}
+		start(newPod(corev1.PodRunning, `[{"name": "nad1", "namespace": "ns1", "interface": "blue0"}]`, defaultStatus, nadStatus, blueStatus))
+
+		Expect(manager.reconcilePod("ns1/pod1")).To(Succeed())
+		Expect(plugged).To(BeEmpty())
+		Expect(unplugged).To(Equal([]string{"ns1/nad1@net1"}))
+		Expect(getNetworkStatus()).To(Equal([]nadapi.NetworkStatus{defaultStatus, blueStatus}))
+	})
+
+	It("ignores pods that are not running", func() {
+		start(newPod(corev1.PodPending, "ns1/nad1", defaultStatus))
+
+		Expect(manager.reconcilePod("ns1/pod1")).To(Succeed())
+		Expect(plugged).To(BeEmpty())
+		Expect(getNetworkStatus()).To(Equal([]nadapi.NetworkStatus{defaultStatus}))
+	})
+})
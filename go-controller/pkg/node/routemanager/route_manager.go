@@ -274,10 +274,17 @@ func (c *Controller) addRouteToStore(r netlink.Route) bool {
 		c.store[r.LinkIndex] = []netlink.Route{r}
 		return true
 	}
-	for _, existingRoute := range existingRoutes {
+	for i, existingRoute := range existingRoutes {
 		if RoutePartiallyEqual(existingRoute, r) {
 			return false
 		}
+		// a route that only differs in MTU is applied in place of the
+		// existing one (see applyRoute), so replace it in the store too
+		existingRoute.MTU = r.MTU
+		if RoutePartiallyEqual(existingRoute, r) {
+			existingRoutes[i] = r
+			return true
+		}
 	}
 	c.store[r.LinkIndex] = append(existingRoutes, r)
 	return true
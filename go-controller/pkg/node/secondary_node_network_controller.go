@@ -3,13 +3,16 @@ package node
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iprulemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/mtumanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/vrfmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -23,6 +26,8 @@ type SecondaryNodeNetworkController struct {
 	podHandler *factory.Handler
 	// responsible for programing gateway elements for this network
 	gateway *UserDefinedNetworkGateway
+	// rolls out network MTU changes to existing pods
+	mtuManager *mtumanager.Controller
 }
 
 // NewSecondaryNodeNetworkController creates a new OVN controller for creating logical network
@@ -33,6 +38,7 @@ func NewSecondaryNodeNetworkController(
 	netInfo util.NetInfo,
 	vrfManager *vrfmanager.Controller,
 	ruleManager *iprulemanager.Controller,
+	mtuManager *mtumanager.Controller,
 	defaultNetworkGateway Gateway,
 ) (*SecondaryNodeNetworkController, error) {
 
@@ -43,6 +49,7 @@ func NewSecondaryNodeNetworkController(
 			stopChan:                        make(chan struct{}),
 			wg:                              &sync.WaitGroup{},
 		},
+		mtuManager: mtuManager,
 	}
 	if util.IsNetworkSegmentationSupportEnabled() && snnc.IsPrimaryNetwork() {
 		node, err := snnc.watchFactory.GetNode(snnc.name)
@@ -103,15 +110,32 @@ func (nc *SecondaryNodeNetworkController) Cleanup() error {
 func (nc *SecondaryNodeNetworkController) shouldReconcileNetworkChange(old, new util.NetInfo) bool {
 	wasUDNNetworkAdvertisedAtNode := util.IsPodNetworkAdvertisedAtNode(old, nc.name)
 	isUDNNetworkAdvertisedAtNode := util.IsPodNetworkAdvertisedAtNode(new, nc.name)
-	return wasUDNNetworkAdvertisedAtNode != isUDNNetworkAdvertisedAtNode
+	if wasUDNNetworkAdvertisedAtNode != isUDNNetworkAdvertisedAtNode {
+		return true
+	}
+	// EgressIPs advertised from the network VRF or the namespaces served by
+	// the network changed
+	return !slices.Equal(old.GetEgressIPAdvertisedOnNodeVRFs(nc.name), new.GetEgressIPAdvertisedOnNodeVRFs(nc.name)) ||
+		!sets.New(old.GetNADNamespaces()...).Equal(sets.New(new.GetNADNamespaces()...))
 }
 
 // Reconcile function reconciles three entities based on whether UDN network is advertised
 // and the gateway mode:
 // 1. IP rules
 // 2. OpenFlows on br-ex bridge to forward traffic to correct ofports
+// 3. Routes towards EgressIPs advertised from the network VRF
+// It also rolls out network MTU changes to the management port, its routes
+// and the interfaces of existing pods.
 func (nc *SecondaryNodeNetworkController) Reconcile(netInfo util.NetInfo) error {
 	reconcilePodNetwork := nc.shouldReconcileNetworkChange(nc.ReconcilableNetInfo, netInfo)
+	oldMTU, newMTU := networkMTU(nc.ReconcilableNetInfo), networkMTU(netInfo)
+	reconcileMTU := oldMTU != newMTU && nc.mtuManager != nil
+
+	if reconcileMTU {
+		if err := nc.mtuManager.StartUpdate(nc.GetNetworkName(), oldMTU, newMTU); err != nil {
+			klog.Errorf("Failed to start MTU update for network %s: %v", nc.GetNetworkName(), err)
+		}
+	}
 
 	err := util.ReconcileNetInfo(nc.ReconcilableNetInfo, netInfo)
 	if err != nil {
@@ -124,5 +148,25 @@ func (nc *SecondaryNodeNetworkController) Reconcile(netInfo util.NetInfo) error
 		}
 	}
 
+	if reconcileMTU {
+		if nc.gateway != nil {
+			if err := nc.gateway.UpdateMTU(); err != nil {
+				klog.Errorf("Failed to update gateway MTU for network %s: %v", nc.GetNetworkName(), err)
+			}
+		}
+		if err := nc.mtuManager.FinishUpdate(nc.GetNetworkName(), oldMTU, newMTU); err != nil {
+			klog.Errorf("Failed to finish MTU update for network %s: %v", nc.GetNetworkName(), err)
+		}
+	}
+
 	return nil
 }
+
+// networkMTU returns the MTU of the network, which defaults to the MTU of the
+// default network when not set
+func networkMTU(netInfo util.NetInfo) int {
+	if netInfo.MTU() == 0 {
+		return config.Default.MTU
+	}
+	return netInfo.MTU()
+}
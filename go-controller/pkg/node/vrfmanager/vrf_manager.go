@@ -278,7 +278,8 @@ func (vrfm *Controller) AddVRF(name string, slaveInterface string, table uint32,
 	return vrfm.sync(vrfDev)
 }
 
-// AddVRFRoutes adds routes to the specified VRF
+// AddVRFRoutes adds routes to the specified VRF. Routes already managed for
+// the VRF are ignored.
 func (vrfm *Controller) AddVRFRoutes(name string, routes []netlink.Route) error {
 	vrfm.mu.Lock()
 	defer vrfm.mu.Unlock()
@@ -293,7 +294,23 @@ func (vrfm *Controller) AddVRFRoutes(name string, routes []netlink.Route) error
 		return fmt.Errorf("failed to find VRF %s", name)
 	}
 
-	vrfDev.routes = append(vrfDev.routes, routes...)
+	for _, route := range routes {
+		// skip routes already managed for this VRF
+		if slices.ContainsFunc(vrfDev.routes, route.Equal) {
+			continue
+		}
+		// replace a managed route towards the same destination, i.e. when
+		// its MTU changed
+		i := slices.IndexFunc(vrfDev.routes, func(r netlink.Route) bool {
+			r.MTU = route.MTU
+			return r.Equal(route)
+		})
+		if i >= 0 {
+			vrfDev.routes[i] = route
+			continue
+		}
+		vrfDev.routes = append(vrfDev.routes, route)
+	}
 
 	return vrfm.sync(vrfDev)
 }
@@ -2,6 +2,7 @@ package vrfmanager
 
 import (
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -275,4 +277,33 @@ var _ = ginkgo.Describe("VRF manager tests with a network namespace", func() {
 		})
 		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
 	})
+
+	ovntest.OnSupportedPlatformsIt("ensure VRF manager does not duplicate VRF routes", func() {
+		err := testNS.Do(func(ns.NetNS) error {
+			defer ginkgo.GinkgoRecover()
+			err := c.AddVRF(vrfLinkName1, "", 1000, nil)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+			_, dst, err := net.ParseCIDR("10.10.10.0/24")
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			route := netlink.Route{
+				LinkIndex: 1,
+				Dst:       dst,
+				Table:     1000,
+				Type:      unix.RTN_UNREACHABLE,
+			}
+			err = c.AddVRFRoutes(vrfLinkName1, []netlink.Route{route})
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			err = c.AddVRFRoutes(vrfLinkName1, []netlink.Route{route})
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+			link, err := util.GetNetLinkOps().LinkByName(vrfLinkName1)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			gomega.Expect(c.vrfs[link.Attrs().Index].routes).To(gomega.HaveLen(1))
+			return nil
+		})
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	})
 })
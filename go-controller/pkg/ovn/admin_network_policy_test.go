@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	"github.com/urfave/cli/v2"
@@ -26,7 +27,9 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	anpovn "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/admin_network_policy"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	testnm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/networkmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -75,11 +78,15 @@ func getANPRulePriority(basePriority, index int32) int32 {
 }
 
 func getDefaultPGForANPSubject(anpName string, portUUIDs []string, acls []*nbdb.ACL, banp bool) *nbdb.PortGroup {
+	return getPGForANPSubject(DefaultNetworkControllerName, anpName, portUUIDs, acls, banp)
+}
+
+func getPGForANPSubject(controllerName, anpName string, portUUIDs []string, acls []*nbdb.ACL, banp bool) *nbdb.PortGroup {
 	lsps := []*nbdb.LogicalSwitchPort{}
 	for _, uuid := range portUUIDs {
 		lsps = append(lsps, &nbdb.LogicalSwitchPort{UUID: uuid})
 	}
-	pgDbIDs := anpovn.GetANPPortGroupDbIDs(anpName, banp, DefaultNetworkControllerName)
+	pgDbIDs := anpovn.GetANPPortGroupDbIDs(anpName, banp, controllerName)
 
 	pg := libovsdbutil.BuildPortGroup(
 		pgDbIDs,
@@ -91,6 +98,13 @@ func getDefaultPGForANPSubject(anpName string, portUUIDs []string, acls []*nbdb.
 }
 
 func getANPGressACL(action, anpName, direction string, rulePriority int32,
+	ruleIndex int32, ports *[]anpapi.AdminNetworkPolicyPort,
+	namedPorts map[string][]libovsdbutil.NamedNetworkPolicyPort, banp bool) []*nbdb.ACL {
+	return getANPGressACLForController(DefaultNetworkControllerName, action, anpName, direction, rulePriority,
+		ruleIndex, ports, namedPorts, banp)
+}
+
+func getANPGressACLForController(controllerName, action, anpName, direction string, rulePriority int32,
 	ruleIndex int32, ports *[]anpapi.AdminNetworkPolicyPort,
 	namedPorts map[string][]libovsdbutil.NamedNetworkPolicyPort, banp bool) []*nbdb.ACL {
 	retACLs := []*nbdb.ACL{}
@@ -106,24 +120,24 @@ func getANPGressACL(action, anpName, direction string, rulePriority int32,
 		acl.Tier = types.DefaultBANPACLTier
 	}
 	acl.ExternalIDs = map[string]string{
-		libovsdbops.OwnerControllerKey.String():    DefaultNetworkControllerName,
+		libovsdbops.OwnerControllerKey.String():    controllerName,
 		libovsdbops.ObjectNameKey.String():         anpName,
 		libovsdbops.GressIdxKey.String():           fmt.Sprintf("%d", ruleIndex),
 		libovsdbops.PolicyDirectionKey.String():    direction,
 		libovsdbops.PortPolicyProtocolKey.String(): "None",
 		libovsdbops.OwnerTypeKey.String():          "AdminNetworkPolicy",
-		libovsdbops.PrimaryIDKey.String():          fmt.Sprintf("%s:AdminNetworkPolicy:%s:%s:%d:None", DefaultNetworkControllerName, anpName, direction, ruleIndex),
+		libovsdbops.PrimaryIDKey.String():          fmt.Sprintf("%s:AdminNetworkPolicy:%s:%s:%d:None", controllerName, anpName, direction, ruleIndex),
 	}
 	acl.Name = ptr.To(fmt.Sprintf("ANP:%s:%s:%d", anpName, direction, ruleIndex)) // tests logic for GetACLName
 	if banp {
 		acl.ExternalIDs[libovsdbops.OwnerTypeKey.String()] = "BaselineAdminNetworkPolicy"
 		acl.ExternalIDs[libovsdbops.PrimaryIDKey.String()] = fmt.Sprintf("%s:BaselineAdminNetworkPolicy:%s:%s:%d:None",
-			DefaultNetworkControllerName, anpName, direction, ruleIndex)
+			controllerName, anpName, direction, ruleIndex)
 		acl.Name = ptr.To(fmt.Sprintf("BANP:%s:%s:%d", anpName, direction, ruleIndex)) // tests logic for GetACLName
 	}
 	acl.UUID = fmt.Sprintf("%s_%s_%d-%f-UUID", anpName, direction, ruleIndex, rand.Float64())
 	// determine ACL match
-	pgName := libovsdbutil.GetPortGroupName(anpovn.GetANPPortGroupDbIDs(anpName, banp, DefaultNetworkControllerName))
+	pgName := libovsdbutil.GetPortGroupName(anpovn.GetANPPortGroupDbIDs(anpName, banp, controllerName))
 	var lPortMatch, l3Match, matchDirection, match string
 	if direction == string(libovsdbutil.ACLIngress) {
 		acl.Direction = nbdb.ACLDirectionToLport
@@ -138,7 +152,7 @@ func getANPGressACL(action, anpName, direction string, rulePriority int32,
 		matchDirection = "dst"
 	}
 	asIndex := anpovn.GetANPPeerAddrSetDbIDs(anpName, direction, fmt.Sprintf("%d", ruleIndex),
-		DefaultNetworkControllerName, banp)
+		controllerName, banp)
 	asv4, asv6 := addressset.GetHashNamesForAS(asIndex)
 	if config.IPv4Mode && config.IPv6Mode {
 		l3Match = fmt.Sprintf("((ip4.%s == $%s || ip6.%s == $%s))", matchDirection, asv4, matchDirection, asv6)
@@ -181,10 +195,10 @@ func getANPGressACL(action, anpName, direction string, rulePriority int32,
 		aclCopy.ExternalIDs[libovsdbops.PortPolicyProtocolKey.String()] = protocol
 		aclCopy.Match = match
 		aclCopy.ExternalIDs[libovsdbops.PrimaryIDKey.String()] = fmt.Sprintf("%s:AdminNetworkPolicy:%s:%s:%d:%s",
-			DefaultNetworkControllerName, anpName, direction, ruleIndex, protocol)
+			controllerName, anpName, direction, ruleIndex, protocol)
 		if banp {
 			aclCopy.ExternalIDs[libovsdbops.PrimaryIDKey.String()] = fmt.Sprintf("%s:BaselineAdminNetworkPolicy:%s:%s:%d:%s",
-				DefaultNetworkControllerName, anpName, direction, ruleIndex, protocol)
+				controllerName, anpName, direction, ruleIndex, protocol)
 		}
 		aclCopy.UUID = fmt.Sprintf("%s_%s_%d.%s-%f-UUID", anpName, direction, ruleIndex, protocol, rand.Float64())
 		retACLs = append(retACLs, &aclCopy)
@@ -204,10 +218,10 @@ func getANPGressACL(action, anpName, direction string, rulePriority int32,
 		aclCopy.ExternalIDs[libovsdbops.PortPolicyProtocolKey.String()] = protocol + "-namedPort"
 		aclCopy.Match = match
 		aclCopy.ExternalIDs[libovsdbops.PrimaryIDKey.String()] = fmt.Sprintf("%s:AdminNetworkPolicy:%s:%s:%d:%s",
-			DefaultNetworkControllerName, anpName, direction, ruleIndex, protocol+"-namedPort")
+			controllerName, anpName, direction, ruleIndex, protocol+"-namedPort")
 		if banp {
 			aclCopy.ExternalIDs[libovsdbops.PrimaryIDKey.String()] = fmt.Sprintf("%s:BaselineAdminNetworkPolicy:%s:%s:%d:%s",
-				DefaultNetworkControllerName, anpName, direction, ruleIndex, protocol+"-namedPort")
+				controllerName, anpName, direction, ruleIndex, protocol+"-namedPort")
 		}
 		retACLs = append(retACLs, &aclCopy)
 	}
@@ -251,8 +265,13 @@ func getACLsForANPRules(anp *anpapi.AdminNetworkPolicy) []*nbdb.ACL {
 }
 
 func buildANPAddressSets(anp *anpapi.AdminNetworkPolicy, index int32, ips []string, gressPrefix libovsdbutil.ACLDirection) (*nbdb.AddressSet, *nbdb.AddressSet) {
+	return buildANPAddressSetsForController(DefaultNetworkControllerName, anp, index, ips, gressPrefix)
+}
+
+func buildANPAddressSetsForController(controllerName string, anp *anpapi.AdminNetworkPolicy, index int32, ips []string,
+	gressPrefix libovsdbutil.ACLDirection) (*nbdb.AddressSet, *nbdb.AddressSet) {
 	asIndex := anpovn.GetANPPeerAddrSetDbIDs(anp.Name, string(gressPrefix),
-		fmt.Sprintf("%d", index), DefaultNetworkControllerName, false)
+		fmt.Sprintf("%d", index), controllerName, false)
 	return addressset.GetTestDbAddrSets(asIndex, ips)
 }
 
@@ -1628,6 +1647,92 @@ var _ = ginkgo.Describe("OVN ANP Operations", func() {
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		})
+		ginkgo.It("Audit mode for ANP", func() {
+			app.Action = func(*cli.Context) error {
+				config.IPv4Mode = true
+				config.IPv6Mode = true
+				fakeOVN.start()
+				fakeOVN.InitAndRunANPController()
+				fakeOVN.fakeClient.ANPClient.(*anpfake.Clientset).PrependReactor("update", "adminnetworkpolicies", func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
+					update := action.(clienttesting.UpdateAction)
+					// update status is caught by the reactor while update spec is delegated
+					// to the main code for handling, see "ACL Logging for ANP"
+					if action.GetSubresource() == "status" {
+						return true, update.GetObject(), nil
+					}
+					return false, update.GetObject(), nil
+				})
+				ginkgo.By("1. Create ANP with the audit annotation and ensure its deny rules pass and log the traffic")
+				anpSubject := newANPSubjectObject(
+					&metav1.LabelSelector{
+						MatchLabels: anpLabel,
+					},
+					nil,
+				)
+				anp := newANPObject("harry-potter", 75, anpSubject,
+					[]anpapi.AdminNetworkPolicyIngressRule{
+						{
+							Name:   "deny-traffic-from-slytherin-to-gryffindor",
+							Action: anpapi.AdminNetworkPolicyRuleActionDeny,
+							From: []anpapi.AdminNetworkPolicyIngressPeer{
+								{
+									Namespaces: &metav1.LabelSelector{
+										MatchLabels: peerDenyLabel,
+									},
+								},
+							},
+						},
+					},
+					[]anpapi.AdminNetworkPolicyEgressRule{
+						{
+							Name:   "allow-traffic-to-hufflepuff-from-gryffindor",
+							Action: anpapi.AdminNetworkPolicyRuleActionAllow,
+							To: []anpapi.AdminNetworkPolicyEgressPeer{
+								{
+									Namespaces: &metav1.LabelSelector{
+										MatchLabels: peerAllowLabel,
+									},
+								},
+							},
+						},
+					},
+				)
+				anp.ResourceVersion = "1"
+				anp.Annotations = map[string]string{
+					util.NetworkPolicyAuditAnnotation: "true",
+				}
+				anp, err := fakeOVN.fakeClient.ANPClient.PolicyV1alpha1().AdminNetworkPolicies().Create(context.TODO(), anp, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				getExpectedDatabaseState := func(audit bool) []libovsdbtest.TestData {
+					acls := getACLsForANPRules(anp)
+					if audit {
+						for _, acl := range acls {
+							libovsdbutil.SetACLAudit(acl, nbdb.ACLActionPass, nil)
+						}
+					}
+					expectedDatabaseState := []libovsdbtest.TestData{getDefaultPGForANPSubject(anp.Name, []string{}, acls, false)}
+					for _, acl := range acls {
+						expectedDatabaseState = append(expectedDatabaseState, acl)
+					}
+					peerASIngressRule0v4, peerASIngressRule0v6 := buildANPAddressSets(anp, 0, []string{}, libovsdbutil.ACLIngress)
+					peerASEgressRule0v4, peerASEgressRule0v6 := buildANPAddressSets(anp, 0, []string{}, libovsdbutil.ACLEgress)
+					return append(expectedDatabaseState, peerASIngressRule0v4, peerASIngressRule0v6, peerASEgressRule0v4, peerASEgressRule0v6)
+				}
+				expectedDatabaseState := getExpectedDatabaseState(true)
+				gomega.Expect(expectedDatabaseState[1].(*nbdb.ACL).Action).To(gomega.Equal(nbdb.ACLActionPass))
+				gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(expectedDatabaseState))
+
+				ginkgo.By("2. Update ANP by deleting the audit annotation and ensure its deny rules are enforced")
+				anp.ResourceVersion = "2"
+				anp.Annotations = map[string]string{}
+				_, err = fakeOVN.fakeClient.ANPClient.PolicyV1alpha1().AdminNetworkPolicies().Update(context.TODO(), anp, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(getExpectedDatabaseState(false)))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
 		ginkgo.It("egress node+network peers: should create/update/delete address-sets, acls, port-groups correctly", func() {
 			app.Action = func(*cli.Context) error {
 				anpNamespaceSubject := *newNamespaceWithLabels(anpSubjectNamespaceName, anpLabel)
@@ -2173,3 +2278,188 @@ var _ = ginkgo.Describe("OVN ANP Operations", func() {
 		})
 	})
 })
+
+var _ = ginkgo.Describe("OVN ANP Operations on primary user defined networks", func() {
+	const (
+		networkName           = "bluenet"
+		nadName               = "blue-nad"
+		udnSubjectNamespace   = "anp-udn-subject-namespace"
+		udnPeerNamespace      = "anp-udn-peer-namespace"
+		defSubjectNamespace   = "anp-subject-namespace"
+		defPeerNamespace      = "anp-peer-namespace"
+		node1Name             = "node1"
+		defaultSubjectPodIP   = "10.128.1.3"
+		defaultPeerPodIP      = "10.128.1.4"
+		udnSubjectPodIP       = "100.128.1.3"
+		udnPeerPodIP          = "100.128.1.4"
+		udnSubjectPodName     = "anp-udn-subject-pod"
+		udnPeerPodName        = "anp-udn-peer-pod"
+		defaultSubjectPodName = "anp-subject-pod"
+		defaultPeerPodName    = "anp-peer-pod"
+	)
+
+	var fakeOVN *FakeOVN
+
+	ginkgo.BeforeEach(func() {
+		gomega.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+		config.OVNKubernetesFeature.EnableAdminNetworkPolicy = true
+		config.OVNKubernetesFeature.EnableInterconnect = true
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+		config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+		fakeOVN = NewFakeOVN(false)
+	})
+
+	ginkgo.AfterEach(func() {
+		fakeOVN.shutdown()
+	})
+
+	// udnPod returns a pod of the primary user defined network annotated with
+	// its IP on the network
+	udnPod := func(namespace, name, ip string) *corev1.Pod {
+		pod := newPod(namespace, name, node1Name, "10.128.1.10")
+		var err error
+		pod.Annotations, err = util.MarshalPodAnnotation(pod.Annotations, &util.PodAnnotation{
+			IPs:  ovntest.MustParseIPNets(ip + "/24"),
+			MAC:  util.IPAddrToHWAddr(ovntest.MustParseIP(ip)),
+			Role: types.NetworkRolePrimary,
+		}, util.GetNADName(namespace, nadName))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		return pod
+	}
+
+	ginkgo.DescribeTable("enforces the policies on the pods of the network", func(topology, subnets string) {
+		udnSubjectNAD := ovntest.GenerateNAD(networkName, nadName, udnSubjectNamespace, topology, subnets, types.NetworkRolePrimary)
+		udnPeerNAD := ovntest.GenerateNAD(networkName, nadName, udnPeerNamespace, topology, subnets, types.NetworkRolePrimary)
+		netInfo, err := util.ParseNADInfo(udnSubjectNAD)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		mutableNetInfo := util.NewMutableNetInfo(netInfo)
+		mutableNetInfo.AddNADs(util.GetNADName(udnSubjectNamespace, nadName), util.GetNADName(udnPeerNamespace, nadName))
+		netInfo = mutableNetInfo
+		udnControllerName := getNetworkControllerName(networkName)
+
+		switchName := netInfo.GetNetworkScopedSwitchName(node1Name)
+		if topology == types.Layer2Topology {
+			switchName = netInfo.GetNetworkScopedSwitchName(types.OVNLayer2Switch)
+		}
+		defaultSubjectLSP := &nbdb.LogicalSwitchPort{
+			UUID: "default-subject-lsp-UUID",
+			Name: util.GetLogicalPortName(defSubjectNamespace, defaultSubjectPodName),
+		}
+		udnSubjectLSP := &nbdb.LogicalSwitchPort{
+			UUID: "udn-subject-lsp-UUID",
+			Name: util.GetSecondaryNetworkLogicalPortName(udnSubjectNamespace, udnSubjectPodName,
+				util.GetNADName(udnSubjectNamespace, nadName)),
+		}
+		defaultSwitch := &nbdb.LogicalSwitch{
+			UUID:  "default-switch-UUID",
+			Name:  node1Name,
+			Ports: []string{defaultSubjectLSP.UUID},
+		}
+		udnSwitch := &nbdb.LogicalSwitch{
+			UUID:  "udn-switch-UUID",
+			Name:  switchName,
+			Ports: []string{udnSubjectLSP.UUID},
+		}
+		initialDB := []libovsdbtest.TestData{defaultSwitch, defaultSubjectLSP, udnSwitch, udnSubjectLSP}
+
+		fakeOVN.startWithDBSetup(libovsdbtest.TestSetup{NBData: initialDB},
+			&corev1.NamespaceList{
+				Items: []corev1.Namespace{
+					*newNamespaceWithLabels(defSubjectNamespace, anpLabel),
+					*newNamespaceWithLabels(defPeerNamespace, peerDenyLabel),
+					*newNamespaceWithLabels(udnSubjectNamespace, anpLabel),
+					*newNamespaceWithLabels(udnPeerNamespace, peerDenyLabel),
+				},
+			},
+			&corev1.NodeList{
+				Items: []corev1.Node{
+					*nodeFor(node1Name, "100.100.100.0", "", "10.128.1.0/24", "", "", ""),
+				},
+			},
+			&corev1.PodList{
+				Items: []corev1.Pod{
+					*newPod(defSubjectNamespace, defaultSubjectPodName, node1Name, defaultSubjectPodIP),
+					*newPod(defPeerNamespace, defaultPeerPodName, node1Name, defaultPeerPodIP),
+					*udnPod(udnSubjectNamespace, udnSubjectPodName, udnSubjectPodIP),
+					*udnPod(udnPeerNamespace, udnPeerPodName, udnPeerPodIP),
+				},
+			},
+			&nettypes.NetworkAttachmentDefinitionList{
+				Items: []nettypes.NetworkAttachmentDefinition{*udnSubjectNAD, *udnPeerNAD},
+			},
+		)
+
+		fakeNetworkManager := &testnm.FakeNetworkManager{
+			PrimaryNetworks: map[string]util.NetInfo{
+				udnSubjectNamespace: netInfo,
+				udnPeerNamespace:    netInfo,
+			},
+		}
+		fakeOVN.controller.zone = node1Name
+		fakeOVN.controller.networkManager = fakeNetworkManager
+		fakeOVN.InitAndRunANPController()
+		udnController, ok := fakeOVN.secondaryControllers[networkName]
+		gomega.Expect(ok).To(gomega.BeTrue())
+		udnController.bnc.networkManager = fakeNetworkManager
+		gomega.Expect(udnController.bnc.runANPController()).To(gomega.Succeed())
+
+		ginkgo.By("creating an admin network policy denying the traffic from the peer namespaces")
+		anp := newANPObject("harry-potter", 5,
+			newANPSubjectObject(&metav1.LabelSelector{MatchLabels: anpLabel}, nil),
+			[]anpapi.AdminNetworkPolicyIngressRule{
+				{
+					Name:   "deny-traffic-from-slytherin-to-gryffindor",
+					Action: anpapi.AdminNetworkPolicyRuleActionDeny,
+					From: []anpapi.AdminNetworkPolicyIngressPeer{
+						{
+							Namespaces: &metav1.LabelSelector{MatchLabels: peerDenyLabel},
+						},
+					},
+				},
+			},
+			nil,
+		)
+		_, err = fakeOVN.fakeClient.ANPClient.PolicyV1alpha1().AdminNetworkPolicies().Create(context.TODO(), anp, metav1.CreateOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		aclAction := anpovn.GetACLActionForANPRule(anpapi.AdminNetworkPolicyRuleActionDeny)
+		aclPriority := getANPRulePriority(getBaseRulePriority(anp.Spec.Priority), 0)
+		defaultACLs := getANPGressACLForController(DefaultNetworkControllerName, aclAction, anp.Name,
+			string(libovsdbutil.ACLIngress), aclPriority, 0, nil, nil, false)
+		defaultPG := getPGForANPSubject(DefaultNetworkControllerName, anp.Name,
+			[]string{defaultSubjectLSP.UUID}, defaultACLs, false)
+		defaultASv4, defaultASv6 := buildANPAddressSetsForController(DefaultNetworkControllerName, anp, 0,
+			[]string{defaultPeerPodIP}, libovsdbutil.ACLIngress)
+		udnACLs := getANPGressACLForController(udnControllerName, aclAction, anp.Name,
+			string(libovsdbutil.ACLIngress), aclPriority, 0, nil, nil, false)
+		udnPG := getPGForANPSubject(udnControllerName, anp.Name, []string{udnSubjectLSP.UUID}, udnACLs, false)
+		// the network is single stack, only the IPv4 address set exists
+		udnASv4, _ := buildANPAddressSetsForController(udnControllerName, anp, 0,
+			[]string{udnPeerPodIP}, libovsdbutil.ACLIngress)
+		for _, acl := range udnACLs {
+			acl.Match = fmt.Sprintf("outport == @%s && ((ip4.src == $%s))", udnPG.Name, udnASv4.Name)
+		}
+
+		defaultData := append([]libovsdbtest.TestData{defaultPG, defaultASv4, defaultASv6}, initialDB...)
+		for _, acl := range defaultACLs {
+			defaultData = append(defaultData, acl)
+		}
+		expectedData := append([]libovsdbtest.TestData{udnPG, udnASv4}, defaultData...)
+		for _, acl := range udnACLs {
+			expectedData = append(expectedData, acl)
+		}
+		gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveData(expectedData))
+
+		ginkgo.By("cleaning up the policy entities of the user defined network controller")
+		ops, err := cleanupPolicyLogicalEntities(fakeOVN.nbClient, nil, udnControllerName)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = libovsdbops.TransactAndCheck(fakeOVN.nbClient, ops)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveData(defaultData))
+	},
+		ginkgo.Entry("layer3 network", types.Layer3Topology, "100.128.0.0/16/24"),
+		ginkgo.Entry("layer2 network", types.Layer2Topology, "100.128.1.0/24"),
+	)
+})
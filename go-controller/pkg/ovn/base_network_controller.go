@@ -36,6 +36,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
+	anpcontroller "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/admin_network_policy"
 	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/routeimport"
 	zoneic "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/zone_interconnect"
@@ -127,6 +128,12 @@ type BaseNetworkController struct {
 	namespaces      map[string]*namespaceInfo
 	namespacesMutex sync.Mutex
 
+	// multicastPeerNamespaces maps a namespace to the namespaces that allow multicast from its pods with the
+	// k8s.ovn.org/multicast-peer-namespaces annotation. It must be accessed with multicastPeerNamespacesLock,
+	// which may be taken while holding a namespaceInfo lock, but no other lock may be taken while holding it.
+	multicastPeerNamespaces     map[string]sets.Set[string]
+	multicastPeerNamespacesLock sync.Mutex
+
 	// An address set factory that creates address sets
 	addressSetFactory addressset.AddressSetFactory
 
@@ -144,6 +151,13 @@ type BaseNetworkController struct {
 	// make sure to keep this order to avoid deadlocks
 	sharedNetpolPortGroups *syncmap.SyncMap[*defaultDenyPortGroups]
 
+	// map of existing gress rules shared by network policies, only used with network policy rule sharing enabled
+	// port group exists in the db if and only if rule key is present in this map
+	// key is the normalized gress rule hash
+	// allowed locking order is namespace Lock -> networkPolicy.Lock -> sharedNetpolGressRules key Lock
+	// sharedNetpolGressRules and sharedNetpolPortGroups key locks are never held at the same time
+	sharedNetpolGressRules *syncmap.SyncMap[*sharedGressRule]
+
 	podSelectorAddressSets *syncmap.SyncMap[*PodSelectorAddressSet]
 
 	// stopChan per controller
@@ -187,11 +201,13 @@ func (oc *BaseNetworkController) reconcile(netInfo util.NetInfo, setNodeFailed f
 	// gather some information first
 	var err error
 	var retryNodes []*corev1.Node
+	// a MTU change needs to be rolled out to the gateway of every node
+	mtuChanged := oc.MTU() != netInfo.MTU()
 	oc.localZoneNodes.Range(func(key, _ any) bool {
 		nodeName := key.(string)
 		wasAdvertised := util.IsPodNetworkAdvertisedAtNode(oc, nodeName)
 		isAdvertised := util.IsPodNetworkAdvertisedAtNode(netInfo, nodeName)
-		if wasAdvertised == isAdvertised {
+		if wasAdvertised == isAdvertised && !mtuChanged {
 			// noop
 			return true
 		}
@@ -251,6 +267,8 @@ type BaseSecondaryNetworkController struct {
 	netPolicyHandler *factory.Handler
 	// multi-network policy events factory handler
 	multiNetPolicyHandler *factory.Handler
+	// controller enforcing the admin network policies on primary networks
+	anpController *anpcontroller.Controller
 }
 
 func (oc *BaseSecondaryNetworkController) FilterOutResource(objType reflect.Type, obj interface{}) bool {
@@ -513,8 +531,10 @@ func (bnc *BaseNetworkController) createNodeLogicalSwitch(nodeName string, hostS
 			"router-port": types.RouterToSwitchPrefix + switchName,
 		},
 	}
-	if bnc.IsDefault() {
-		logicalSwitchPort.Options["arp_proxy"] = kubevirt.ComposeARPProxyLSPOption()
+	// live migrated VMs keep their subnet gateway and same subnet peers
+	// reachable through the arp proxy
+	if bnc.IsDefault() || bnc.IsPrimaryNetwork() {
+		logicalSwitchPort.Options["arp_proxy"] = kubevirt.ComposeARPProxyLSPOption(bnc.Subnets())
 	}
 	sw := nbdb.LogicalSwitch{Name: switchName}
 	err = libovsdbops.CreateOrUpdateLogicalSwitchPortsOnSwitch(bnc.nbClient, &sw, &logicalSwitchPort)
@@ -852,6 +872,25 @@ func (bnc *BaseNetworkController) recordPodErrorEvent(pod *corev1.Pod, podErr er
 	}
 }
 
+// getDHCPOptionsOverrides returns the DHCP options the VM owner configured for the given NAD. A malformed
+// annotation doesn't fail the pod setup: it is reported with an event on the pod and the VM gets the DHCP
+// options composed by ovn-kubernetes only.
+func (bnc *BaseNetworkController) getDHCPOptionsOverrides(pod *corev1.Pod, nadName string) *kubevirt.DHCPOptionsOverrides {
+	overrides, err := kubevirt.ParseDHCPOptionsAnnotation(pod, nadName)
+	if err == nil {
+		return overrides
+	}
+	klog.Warningf("Ignoring the DHCP options of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	podRef, refErr := ref.GetReference(scheme.Scheme, pod)
+	if refErr != nil {
+		klog.Errorf("Couldn't get a reference to pod %s/%s to post an event: '%v'",
+			pod.Namespace, pod.Name, refErr)
+		return nil
+	}
+	bnc.recorder.Eventf(podRef, corev1.EventTypeWarning, "InvalidDHCPOptions", err.Error())
+	return nil
+}
+
 func (bnc *BaseNetworkController) doesNetworkRequireIPAM() bool {
 	return util.DoesNetworkRequireIPAM(bnc.GetNetInfo())
 }
@@ -956,7 +995,8 @@ func (bnc *BaseNetworkController) nodeZoneClusterChanged(oldNode, newNode *corev
 
 func (bnc *BaseNetworkController) findMigratablePodIPsForSubnets(subnets []*net.IPNet) ([]*net.IPNet, error) {
 	// live migration is not supported in combination with secondary networks
-	if bnc.IsSecondary() {
+	// other than primary user defined networks
+	if bnc.IsSecondary() && !bnc.IsPrimaryNetwork() {
 		return nil, nil
 	}
 
@@ -968,7 +1008,8 @@ func (bnc *BaseNetworkController) findMigratablePodIPsForSubnets(subnets []*net.
 	}
 
 	for _, liveMigratablePod := range liveMigratablePods {
-		if util.PodCompleted(liveMigratablePod) {
+		if util.PodCompleted(liveMigratablePod) ||
+			!kubevirt.IsPodLiveMigratableWithPointToPointRouting(liveMigratablePod, bnc.GetNetInfo()) {
 			continue
 		}
 		isMigratedSourcePodStale, err := kubevirt.IsMigratedSourcePodStale(bnc.watchFactory, liveMigratablePod)
@@ -978,7 +1019,19 @@ func (bnc *BaseNetworkController) findMigratablePodIPsForSubnets(subnets []*net.
 		if isMigratedSourcePodStale {
 			continue
 		}
-		podAnnotation, err := util.UnmarshalPodAnnotation(liveMigratablePod.Annotations, bnc.GetNetworkName())
+		nadName := bnc.GetNetworkName()
+		if bnc.IsPrimaryNetwork() {
+			nadNames, err := util.PodNadNames(liveMigratablePod, bnc.GetNetInfo())
+			if err != nil {
+				return nil, err
+			}
+			// the pod namespace is not served by this network
+			if len(nadNames) == 0 {
+				continue
+			}
+			nadName = nadNames[0]
+		}
+		podAnnotation, err := util.UnmarshalPodAnnotation(liveMigratablePod.Annotations, nadName)
 		if err != nil {
 			// even though it can be normal to not have an annotation now, live
 			// migration is a sensible process that might be used when draining
@@ -2,6 +2,7 @@ package ovn
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
@@ -10,6 +11,8 @@ import (
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
 )
 
 type defaultMcastACLTypeID string
@@ -17,9 +20,12 @@ type defaultMcastACLTypeID string
 const (
 	// IPv6 multicast traffic destined to dynamic groups must have the "T" bit
 	// set to 1: https://tools.ietf.org/html/rfc3307#section-4.3
-	ipv6DynamicMulticastMatch                       = "(ip6.dst[120..127] == 0xff && ip6.dst[116] == 1)"
-	mcastDefaultDenyID        defaultMcastACLTypeID = "DefaultDeny"
-	mcastAllowInterNodeID     defaultMcastACLTypeID = "AllowInterNode"
+	ipv6DynamicMulticastMatch = "(ip6.dst[120..127] == 0xff && ip6.dst[116] == 1)"
+	// IGMPv3 membership reports are sent to this address, while IGMPv1/v2 reports
+	// are sent to the reported group: https://tools.ietf.org/html/rfc3376#section-4.2.14
+	igmpv3ReportsAddress                        = "224.0.0.22"
+	mcastDefaultDenyID    defaultMcastACLTypeID = "DefaultDeny"
+	mcastAllowInterNodeID defaultMcastACLTypeID = "AllowInterNode"
 )
 
 // Legacy const, should only be used in sync and tests
@@ -43,43 +49,155 @@ func getMulticastACLMatch() string {
 	return "(ip4.mcast || mldv1 || mldv2 || " + ipv6DynamicMulticastMatch + ")"
 }
 
-// Allow IGMP traffic (e.g., IGMP queries) and namespace multicast traffic
-// towards pods.
-func getMulticastACLIgrMatchV4(addrSetName string) string {
-	return "(igmp || (ip4.src == $" + addrSetName + " && ip4.mcast))"
+// joinMulticastMatches returns a match for any of the given matches.
+func joinMulticastMatches(matches []string) string {
+	switch len(matches) {
+	case 0:
+		// nothing is allowed
+		return "0"
+	case 1:
+		return matches[0]
+	default:
+		return "(" + strings.Join(matches, " || ") + ")"
+	}
+}
+
+// getMulticastSrcMatch returns the value to match multicast sources against for the given address sets.
+func getMulticastSrcMatch(addrSetNames []string) string {
+	if len(addrSetNames) == 1 {
+		return "$" + addrSetNames[0]
+	}
+	return "{$" + strings.Join(addrSetNames, ", $") + "}"
+}
+
+// getMulticastGroupsMatch returns the match on the destination of the multicast traffic for
+// the given allowed groups, or an empty string if any group is allowed (groups is nil).
+func getMulticastGroupsMatch(ipVersion string, groups []string) string {
+	if groups == nil {
+		return ""
+	}
+	return " && " + ipVersion + ".dst == {" + strings.Join(groups, ", ") + "}"
+}
+
+// Allow IGMP traffic (e.g., IGMP queries) and multicast traffic from the given
+// address sets to the allowed groups (any group if groups is nil) towards pods.
+func getMulticastACLIgrMatchV4(addrSetNames []string, groups []string) string {
+	if groups != nil && len(groups) == 0 {
+		return "igmp"
+	}
+	return "(igmp || (ip4.src == " + getMulticastSrcMatch(addrSetNames) + " && ip4.mcast" +
+		getMulticastGroupsMatch("ip4", groups) + "))"
+}
+
+// Allow MLD traffic (e.g., MLD queries) and multicast traffic from the given
+// address sets to the allowed groups (any group if groups is nil) towards pods.
+func getMulticastACLIgrMatchV6(addrSetNames []string, groups []string) string {
+	if groups != nil && len(groups) == 0 {
+		return "(mldv1 || mldv2)"
+	}
+	return "(mldv1 || mldv2 || (ip6.src == " + getMulticastSrcMatch(addrSetNames) + " && " + ipv6DynamicMulticastMatch +
+		getMulticastGroupsMatch("ip6", groups) + "))"
 }
 
-// Allow MLD traffic (e.g., MLD queries) and namespace multicast traffic
-// towards pods.
-func getMulticastACLIgrMatchV6(addrSetName string) string {
-	return "(mldv1 || mldv2 || (ip6.src == $" + addrSetName + " && " + ipv6DynamicMulticastMatch + "))"
+// getMulticastPeerAddressSets returns the address set names of the peer namespaces allowed to send multicast
+// to a namespace. Only namespaces that exist and have an address set are returned, the multicast policy of
+// the namespace is updated when its peer namespaces are added or deleted.
+func (bnc *BaseNetworkController) getMulticastPeerAddressSets(peerNamespaces sets.Set[string]) ([]string, []string) {
+	var addrSetNamesV4, addrSetNamesV6 []string
+	for _, peer := range sets.List(peerNamespaces) {
+		if _, err := bnc.watchFactory.GetNamespace(peer); err != nil {
+			continue
+		}
+		as, err := bnc.addressSetFactory.GetAddressSet(getNamespaceAddrSetDbIDs(peer, bnc.controllerName))
+		if err != nil {
+			klog.V(5).Infof("Skipping multicast peer namespace %s: %v", peer, err)
+			continue
+		}
+		addrSetNameV4, addrSetNameV6 := as.GetASHashNames()
+		addrSetNamesV4 = append(addrSetNamesV4, addrSetNameV4)
+		addrSetNamesV6 = append(addrSetNamesV6, addrSetNameV6)
+	}
+	return addrSetNamesV4, addrSetNamesV6
 }
 
 // Creates the match string used for ACLs allowing incoming multicast into a
-// namespace, that is, from IPs that are in the namespace's address set.
+// namespace, that is, from IPs that are in the namespace's address set or
+// in the address sets of its multicast peer namespaces.
 func (bnc *BaseNetworkController) getMulticastACLIgrMatch(nsInfo *namespaceInfo) string {
 	var ipv4Match, ipv6Match string
+	var groupsV4, groupsV6 []string
 	addrSetNameV4, addrSetNameV6 := nsInfo.addressSet.GetASHashNames()
+	addrSetNamesV4, addrSetNamesV6 := []string{addrSetNameV4}, []string{addrSetNameV6}
+	if policy := nsInfo.multicastPolicy; policy != nil {
+		peerNamesV4, peerNamesV6 := bnc.getMulticastPeerAddressSets(policy.PeerNamespaces)
+		addrSetNamesV4 = append(addrSetNamesV4, peerNamesV4...)
+		addrSetNamesV6 = append(addrSetNamesV6, peerNamesV6...)
+		groupsV4, groupsV6 = policy.GetAllowedGroups(false), policy.GetAllowedGroups(true)
+	}
 	ipv4Mode, ipv6Mode := bnc.IPMode()
 	if ipv4Mode {
-		ipv4Match = getMulticastACLIgrMatchV4(addrSetNameV4)
+		ipv4Match = getMulticastACLIgrMatchV4(addrSetNamesV4, groupsV4)
 	}
 	if ipv6Mode {
-		ipv6Match = getMulticastACLIgrMatchV6(addrSetNameV6)
+		ipv6Match = getMulticastACLIgrMatchV6(addrSetNamesV6, groupsV6)
 	}
 	return getACLMatchAF(ipv4Match, ipv6Match, ipv4Mode, ipv6Mode)
 }
 
+// Allow IGMP membership reports of the allowed versions and multicast traffic
+// to the allowed groups from pods.
+func getMulticastACLEgrMatchV4(policy *util.NamespaceMulticastPolicy) string {
+	if policy == nil || (policy.AllowedGroups == nil && policy.Versions == nil) {
+		return "ip4.mcast"
+	}
+	matches := []string{}
+	if policy.Versions == nil {
+		matches = append(matches, "igmp")
+	} else {
+		if policy.Versions.Has(util.MulticastVersionIGMPv2) {
+			matches = append(matches, "(igmp && ip4.dst != "+igmpv3ReportsAddress+")")
+		}
+		if policy.Versions.Has(util.MulticastVersionIGMPv3) {
+			matches = append(matches, "(igmp && ip4.dst == "+igmpv3ReportsAddress+")")
+		}
+	}
+	if groups := policy.GetAllowedGroups(false); groups == nil || len(groups) > 0 {
+		matches = append(matches, "(ip4.mcast && !igmp"+getMulticastGroupsMatch("ip4", groups)+")")
+	}
+	return joinMulticastMatches(matches)
+}
+
+// Allow MLD membership reports of the allowed versions and multicast traffic
+// to the allowed groups from pods.
+func getMulticastACLEgrMatchV6(policy *util.NamespaceMulticastPolicy) string {
+	matches := []string{}
+	var groups []string
+	if policy != nil {
+		groups = policy.GetAllowedGroups(true)
+	}
+	for _, version := range []string{util.MulticastVersionMLDv1, util.MulticastVersionMLDv2} {
+		if policy == nil || policy.Versions == nil || policy.Versions.Has(version) {
+			matches = append(matches, version)
+		}
+	}
+	if groups == nil {
+		matches = append(matches, ipv6DynamicMulticastMatch)
+	} else if len(groups) > 0 {
+		matches = append(matches, "("+ipv6DynamicMulticastMatch+getMulticastGroupsMatch("ip6", groups)+")")
+	}
+	return joinMulticastMatches(matches)
+}
+
 // Creates the match string used for ACLs allowing outgoing multicast from a
-// namespace.
-func (bnc *BaseNetworkController) getMulticastACLEgrMatch() string {
+// namespace, restricted by the namespace multicast policy if it's not nil.
+func (bnc *BaseNetworkController) getMulticastACLEgrMatch(policy *util.NamespaceMulticastPolicy) string {
 	var ipv4Match, ipv6Match string
 	ipv4Mode, ipv6Mode := bnc.IPMode()
 	if ipv4Mode {
-		ipv4Match = "ip4.mcast"
+		ipv4Match = getMulticastACLEgrMatchV4(policy)
 	}
 	if ipv6Mode {
-		ipv6Match = "(mldv1 || mldv2 || " + ipv6DynamicMulticastMatch + ")"
+		ipv6Match = getMulticastACLEgrMatchV6(policy)
 	}
 	return getACLMatchAF(ipv4Match, ipv6Match, ipv4Mode, ipv6Mode)
 }
@@ -111,12 +229,15 @@ func getNamespaceMcastACLDbIDs(ns string, aclDir libovsdbutil.ACLDirection, cont
 //     in 'ns'
 //   - one "to-lport" ACL allowing ingress multicast traffic to pods in 'ns'.
 //     This matches only traffic originated by pods in 'ns' (based on the
-//     namespace address set).
+//     namespace address set), or in its multicast peer namespaces.
+//
+// Both ACLs are restricted to the groups and versions allowed by the namespace
+// multicast policy. Calling it again updates the ACLs to the current policy.
 func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *namespaceInfo) error {
 	portGroupName := bnc.getNamespacePortGroupName(ns)
 
 	aclDir := libovsdbutil.ACLEgress
-	egressMatch := libovsdbutil.GetACLMatch(portGroupName, bnc.getMulticastACLEgrMatch(), aclDir)
+	egressMatch := libovsdbutil.GetACLMatch(portGroupName, bnc.getMulticastACLEgrMatch(nsInfo.multicastPolicy), aclDir)
 	dbIDs := getNamespaceMcastACLDbIDs(ns, aclDir, bnc.controllerName)
 	aclPipeline := libovsdbutil.ACLDirectionToACLPipeline(aclDir)
 	egressACL := libovsdbutil.BuildACL(dbIDs, types.DefaultMcastAllowPriority, egressMatch, nbdb.ACLActionAllow, nil, aclPipeline)
@@ -146,6 +267,53 @@ func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *
 	return nil
 }
 
+// setMulticastPeerNamespaces updates the index of namespaces that allow multicast from their peer namespaces,
+// after the multicast peer namespaces of ns changed from oldPeers to newPeers.
+func (bnc *BaseNetworkController) setMulticastPeerNamespaces(ns string, oldPeers, newPeers sets.Set[string]) {
+	bnc.multicastPeerNamespacesLock.Lock()
+	defer bnc.multicastPeerNamespacesLock.Unlock()
+	for peer := range oldPeers.Difference(newPeers) {
+		bnc.multicastPeerNamespaces[peer].Delete(ns)
+		if bnc.multicastPeerNamespaces[peer].Len() == 0 {
+			delete(bnc.multicastPeerNamespaces, peer)
+		}
+	}
+	for peer := range newPeers.Difference(oldPeers) {
+		if bnc.multicastPeerNamespaces[peer] == nil {
+			bnc.multicastPeerNamespaces[peer] = sets.New[string]()
+		}
+		bnc.multicastPeerNamespaces[peer].Insert(ns)
+	}
+}
+
+// multicastUpdatePeerNamespace updates the multicast allow policies of the namespaces that allow multicast
+// from the pods of peerNs, after peerNs was added or deleted.
+// Must be called without holding any namespaceInfo lock.
+func (bnc *BaseNetworkController) multicastUpdatePeerNamespace(peerNs string) error {
+	if !bnc.multicastSupport {
+		return nil
+	}
+	bnc.multicastPeerNamespacesLock.Lock()
+	namespaces := sets.List(bnc.multicastPeerNamespaces[peerNs])
+	bnc.multicastPeerNamespacesLock.Unlock()
+
+	var errors []error
+	for _, ns := range namespaces {
+		nsInfo, nsUnlock := bnc.getNamespaceLocked(ns, false)
+		if nsInfo == nil {
+			continue
+		}
+		if nsInfo.multicastEnabled && nsInfo.multicastPolicy.PeerNamespaces.Has(peerNs) {
+			if err := bnc.createMulticastAllowPolicy(ns, nsInfo); err != nil {
+				errors = append(errors, fmt.Errorf("failed to update multicast policy of namespace %s for peer namespace %s: %v",
+					ns, peerNs, err))
+			}
+		}
+		nsUnlock()
+	}
+	return utilerrors.Join(errors...)
+}
+
 func (bnc *BaseNetworkController) deleteMulticastAllowPolicy(ns string) error {
 	portGroupName := bnc.getNamespacePortGroupName(ns)
 
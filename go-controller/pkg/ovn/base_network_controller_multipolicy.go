@@ -16,6 +16,7 @@ const PolicyForAnnotation = "k8s.v1.cni.cncf.io/policy-for"
 
 func (bsnc *BaseSecondaryNetworkController) syncMultiNetworkPolicies(multiPolicies []interface{}) error {
 	expectedPolicies := make(map[string]map[string]bool)
+	policies := make([]*knet.NetworkPolicy, 0, len(multiPolicies))
 	for _, npInterface := range multiPolicies {
 		policy, ok := npInterface.(*mnpapi.MultiNetworkPolicy)
 		if !ok {
@@ -26,6 +27,9 @@ func (bsnc *BaseSecondaryNetworkController) syncMultiNetworkPolicies(multiPolici
 				bsnc.GetNetworkName(), policy.Namespace, policy.Name)
 			continue
 		}
+		if np, err := bsnc.convertMultiNetPolicyToNetPolicy(policy); err == nil {
+			policies = append(policies, np)
+		}
 		if nsMap, ok := expectedPolicies[policy.Namespace]; ok {
 			nsMap[policy.Name] = true
 		} else {
@@ -35,7 +39,7 @@ func (bsnc *BaseSecondaryNetworkController) syncMultiNetworkPolicies(multiPolici
 		}
 	}
 
-	return bsnc.syncNetworkPoliciesCommon(expectedPolicies)
+	return bsnc.syncNetworkPoliciesCommon(expectedPolicies, bsnc.getExpectedSharedGressRules(policies))
 }
 
 func (bsnc *BaseSecondaryNetworkController) shouldApplyMultiPolicy(mpolicy *mnpapi.MultiNetworkPolicy) bool {
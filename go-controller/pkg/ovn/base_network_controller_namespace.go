@@ -56,9 +56,16 @@ type namespaceInfo struct {
 	routingExternalPodGWs map[string]gatewayInfo
 
 	multicastEnabled bool
+	// multicastPolicy restricts the multicast traffic of the namespace when multicast is enabled,
+	// parsed from the namespace multicast annotations
+	multicastPolicy *util.NamespaceMulticastPolicy
 
 	// If not empty, then it has to be set to a logging a severity level, e.g. "notice", "alert", etc
 	aclLogging libovsdbutil.ACLLoggingLevels
+
+	// networkPolicyAudit is set by the k8s.ovn.org/network-policy-audit annotation, the default deny
+	// ACLs of the network policies then let through and log the traffic they would drop
+	networkPolicyAudit bool
 }
 
 func getNamespaceAddrSetDbIDs(namespaceName, controller string) *libovsdbops.DbObjectIDs {
@@ -168,7 +175,10 @@ func (bnc *BaseNetworkController) syncNamespaces(namespaces []interface{}) error
 		}
 		expectedNs[ns.Name] = true
 		if bnc.multicastSupport && isNamespaceMulticastEnabled(ns.Annotations) {
-			nsWithMulticast[ns.Name] = true
+			// multicast is disabled for namespaces with an invalid multicast policy
+			if _, err := util.ParseNamespaceMulticastPolicy(ns.Annotations); err == nil {
+				nsWithMulticast[ns.Name] = true
+			}
 		}
 	}
 
@@ -207,7 +217,8 @@ func (bnc *BaseNetworkController) syncNamespaces(namespaces []interface{}) error
 }
 
 // Creates an explicit "allow" policy for multicast traffic within the
-// namespace if multicast is enabled. Otherwise, removes the "allow" policy.
+// namespace if multicast is enabled, or updates it when the namespace multicast
+// policy changes. Otherwise, removes the "allow" policy.
 // Traffic will be dropped by the default multicast deny ACL.
 func (bnc *BaseNetworkController) multicastUpdateNamespace(ns *corev1.Namespace, nsInfo *namespaceInfo) error {
 	if !bnc.multicastSupport {
@@ -215,13 +226,34 @@ func (bnc *BaseNetworkController) multicastUpdateNamespace(ns *corev1.Namespace,
 	}
 
 	enabled := isNamespaceMulticastEnabled(ns.Annotations)
+	var policy *util.NamespaceMulticastPolicy
+	if enabled {
+		var err error
+		policy, err = util.ParseNamespaceMulticastPolicy(ns.Annotations)
+		if err != nil {
+			// the multicast policy can't be enforced, don't allow any multicast traffic
+			klog.Warningf("Namespace %s: multicast is disabled due to an invalid multicast policy: %v", ns.Name, err)
+			enabled = false
+		}
+	}
 	enabledOld := nsInfo.multicastEnabled
-	if enabledOld == enabled {
+	if enabledOld == enabled && policy.Equal(nsInfo.multicastPolicy) {
 		return nil
 	}
 
 	var err error
+	var oldPeers, newPeers sets.Set[string]
+	if nsInfo.multicastPolicy != nil {
+		oldPeers = nsInfo.multicastPolicy.PeerNamespaces
+	}
+	if policy != nil {
+		newPeers = policy.PeerNamespaces
+	}
+	// update the peers before the policy is created, so that peer namespaces
+	// added in the meantime update it
+	bnc.setMulticastPeerNamespaces(ns.Name, oldPeers, newPeers)
 	nsInfo.multicastEnabled = enabled
+	nsInfo.multicastPolicy = policy
 	if enabled {
 		err = bnc.createMulticastAllowPolicy(ns.Name, nsInfo)
 	} else {
@@ -238,6 +270,8 @@ func (bnc *BaseNetworkController) multicastUpdateNamespace(ns *corev1.Namespace,
 func (bnc *BaseNetworkController) multicastDeleteNamespace(ns *corev1.Namespace, nsInfo *namespaceInfo) error {
 	if nsInfo.multicastEnabled {
 		nsInfo.multicastEnabled = false
+		bnc.setMulticastPeerNamespaces(ns.Name, nsInfo.multicastPolicy.PeerNamespaces, nil)
+		nsInfo.multicastPolicy = nil
 		if err := bnc.deleteMulticastAllowPolicy(ns.Name); err != nil {
 			return err
 		}
@@ -347,6 +381,11 @@ func (bnc *BaseNetworkController) configureNamespaceCommon(nsInfo *namespaceInfo
 		}
 	}
 
+	nsInfo.networkPolicyAudit = util.IsNetworkPolicyAuditEnabled(ns.Annotations)
+	if nsInfo.networkPolicyAudit {
+		klog.Infof("Namespace %s: network policies are in audit mode", ns.Name)
+	}
+
 	// TODO(trozet) figure out if there is any possibility of detecting if a pod GW already exists, which
 	// is servicing this namespace. Right now that would mean searching through all pods, which is very inefficient.
 	// For now it is required that a pod serving as a gateway for a namespace is added AFTER the serving namespace is
@@ -390,6 +429,15 @@ func (bnc *BaseNetworkController) updateNamespaceAclLogging(ns, aclAnnotation st
 	return nil
 }
 
+func (bnc *BaseNetworkController) updateNamespaceNetworkPolicyAudit(ns string, audit bool, nsInfo *namespaceInfo) error {
+	nsInfo.networkPolicyAudit = audit
+	if err := bnc.updateDefaultDenyACLs(ns, nsInfo); err != nil {
+		return fmt.Errorf("failed to update default deny ACLs for namespace %s: %v", ns, err)
+	}
+	klog.Infof("Namespace %s: network policies audit mode updated to %t", ns, audit)
+	return nil
+}
+
 func (bnc *BaseNetworkController) getAllNamespacePodAddresses(ns string) []net.IP {
 	if !bnc.doesNetworkRequireIPAM() {
 		return nil
@@ -466,8 +514,8 @@ func (bsnc *BaseNetworkController) removeRemoteZonePodFromNamespaceAddressSet(po
 	// tracked within the zone, nodeName will be empty which will force
 	// canReleasePodIPs to lookup all nodes.
 	nodeName := pod.Spec.NodeName
-	if !bsnc.IsSecondary() && kubevirt.IsPodLiveMigratable(pod) {
-		nodeName, _ = bsnc.lsManager.GetSubnetName(podIfAddrs)
+	if kubevirt.IsPodLiveMigratableWithPointToPointRouting(pod, bsnc.GetNetInfo()) {
+		nodeName = bsnc.getSubnetNodeName(podIfAddrs)
 	}
 
 	// Remove the pod ips from the namespace address set. Before that check if its a completed pod and
@@ -14,6 +14,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ktypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
@@ -23,6 +24,7 @@ import (
 
 	ipallocator "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
 	subnetipallocator "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip/subnet"
+	podallocator "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/pod"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
@@ -346,6 +348,40 @@ func (bnc *BaseNetworkController) canReleasePodIPs(podIfAddrs []*net.IPNet, node
 	return true, nil
 }
 
+func (bnc *BaseNetworkController) allocateSyncMigratablePodIPsOnZone(vms map[ktypes.NamespacedName]bool, pod *corev1.Pod,
+	nadName string) (map[ktypes.NamespacedName]bool, string, *util.PodAnnotation, error) {
+	allocatePodIPsOnSwitchWrapFn := func(liveMigratablePod *corev1.Pod, liveMigratablePodAnnotation *util.PodAnnotation, switchName, nadName string) (string, error) {
+		return bnc.allocatePodIPsOnSwitch(liveMigratablePod, liveMigratablePodAnnotation, switchName, nadName)
+	}
+	vmKey, expectedLogicalPortName, podAnnotation, err := kubevirt.AllocateSyncMigratablePodIPsOnZone(bnc.watchFactory, bnc.lsManager, nadName, pod, allocatePodIPsOnSwitchWrapFn)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// If there is a vmKey this VM is not stale so it should be in sync
+	if vmKey != nil {
+		vms[*vmKey] = bnc.isPodScheduledinLocalZone(pod)
+	}
+
+	// For remote pods we the logical switch port is not present so
+	// empty expectedLogicalPortName is returned
+	if _, ok := bnc.localZoneNodes.Load(pod.Spec.NodeName); !ok {
+		expectedLogicalPortName = ""
+	}
+
+	return vms, expectedLogicalPortName, podAnnotation, nil
+}
+
+// getSubnetNodeName returns the name of the node whose subnet contains the
+// given IPs, or an empty string if the subnet is not tracked within the zone.
+func (bnc *BaseNetworkController) getSubnetNodeName(podIfAddrs []*net.IPNet) string {
+	switchName, _ := bnc.lsManager.GetSubnetName(podIfAddrs)
+	if switchName == "" {
+		return ""
+	}
+	return strings.TrimPrefix(switchName, bnc.GetNetworkScopedName(""))
+}
+
 func (bnc *BaseNetworkController) releasePodIPs(pInfo *lpInfo) error {
 	if err := bnc.lsManager.ReleaseIPs(pInfo.logicalSwitch, pInfo.ips); err != nil {
 		if !errors.Is(err, logicalswitchmanager.SwitchNotFound) {
@@ -619,6 +655,18 @@ func (bnc *BaseNetworkController) addLogicalPortToNetwork(pod *corev1.Pod, nadNa
 			fmt.Errorf("error creating logical switch port %+v on switch %+v: %+v", *lsp, *ls, err)
 	}
 
+	if bnc.isPodScheduledinLocalZone(pod) {
+		if config.OVNKubernetesFeature.EnablePodBandwidthQoS {
+			ops, err = bnc.podBandwidthQoSOps(ops, pod, switchName, portName)
+		} else {
+			// clean up the rules left over from when the feature was enabled
+			ops, err = bnc.deleteLogicalPortBandwidthQoSOps(ops, switchName, portName)
+		}
+		if err != nil {
+			return nil, nil, nil, false, fmt.Errorf("[%s] %w", podDesc, err)
+		}
+	}
+
 	return ops, lsp, podAnnotation, annotationUpdated && !lspExist, nil
 }
 
@@ -702,6 +750,12 @@ func (bnc *BaseNetworkController) delLSPOps(logicalPort, switchName,
 	if err != nil {
 		return nil, fmt.Errorf("error deleting logical switch port %+v from switch %+v: %w", lsp, lsw, err)
 	}
+	// delete the bandwidth QoS rules even if the feature was disabled since
+	// they were created
+	ops, err = bnc.deleteLogicalPortBandwidthQoSOps(ops, switchName, logicalPort)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting bandwidth QoS rules of logical switch port %s: %w", logicalPort, err)
+	}
 
 	return ops, nil
 }
@@ -798,6 +852,14 @@ func (bnc *BaseNetworkController) allocatePodAnnotation(pod *corev1.Pod, existin
 
 	switchName := pod.Spec.NodeName
 
+	if network != nil && networkRole == ovntypes.NetworkRoleInfrastructure && util.IsPreconfiguredUDNAddressesEnabled() {
+		// the requested addresses are for the primary UDN of the pod
+		defaultNetwork := *network
+		defaultNetwork.IPRequest = nil
+		defaultNetwork.MacRequest = ""
+		network = &defaultNetwork
+	}
+
 	podAnnotation, zoneContainsPodSubnet, err := bnc.ensurePodAnnotation(pod, nadName)
 	if err != nil {
 		return nil, false, fmt.Errorf("unable to ensure pod annotation: %v", err)
@@ -956,6 +1018,27 @@ func (bnc *BaseNetworkController) allocatePodAnnotationForSecondaryNetwork(pod *
 		return podAnnotation, false, nil
 	}
 
+	// live migrated VMs keep the pod annotation, and so the IPs, of the pod
+	// they migrated from
+	if kubevirt.IsPodLiveMigratableWithPointToPointRouting(pod, bnc.GetNetInfo()) {
+		podAnnotation, err := kubevirt.EnsurePodAnnotationForVM(bnc.watchFactory, bnc.kube, pod, nadName)
+		if err != nil {
+			return nil, false, err
+		}
+		if podAnnotation != nil {
+			// ensure we have reserved the IPs in the switch owning their
+			// subnet, which might not be the switch of the pod's node
+			subnetSwitchName, zoneContainsPodSubnet := kubevirt.ZoneContainsPodSubnet(bnc.lsManager, podAnnotation.IPs)
+			if zoneContainsPodSubnet {
+				if err := bnc.lsManager.AllocateIPs(subnetSwitchName, podAnnotation.IPs); err != nil && !errors.Is(err, ipallocator.ErrAllocated) {
+					return nil, false, fmt.Errorf("unable to ensure IPs allocated for already annotated pod %s/%s/%s: %w",
+						nadName, pod.Namespace, pod.Name, err)
+				}
+			}
+			return podAnnotation, false, nil
+		}
+	}
+
 	if network == nil {
 		network = &nadapi.NetworkSelectionElement{}
 	}
@@ -994,7 +1077,8 @@ func (bnc *BaseNetworkController) allocatePodAnnotationForSecondaryNetwork(pod *
 	)
 
 	if err != nil {
-		if errors.Is(err, ipallocator.ErrFull) {
+		if errors.Is(err, ipallocator.ErrFull) || errors.Is(err, podallocator.ErrStaticAddressConflict) ||
+			errors.Is(err, podallocator.ErrUnsupportedStaticAddressTopology) {
 			bnc.recordPodErrorEvent(pod, err)
 		}
 		return nil, false, err
@@ -1032,7 +1116,7 @@ func (bnc *BaseNetworkController) allocatesPodAnnotation() bool {
 func (bnc *BaseNetworkController) shouldReleaseDeletedPod(pod *corev1.Pod, switchName, nad string, podIfAddrs []*net.IPNet) (bool, error) {
 	var err error
 	var isMigratedSourcePodStale bool
-	if !bnc.IsSecondary() {
+	if kubevirt.IsPodLiveMigratableWithPointToPointRouting(pod, bnc.GetNetInfo()) {
 		isMigratedSourcePodStale, err = kubevirt.IsMigratedSourcePodStale(bnc.watchFactory, pod)
 		if err != nil {
 			return false, err
@@ -1064,8 +1148,8 @@ func (bnc *BaseNetworkController) shouldReleaseDeletedPod(pod *corev1.Pod, switc
 		// tracked within the zone, nodeName will be empty which will force
 		// canReleasePodIPs to lookup all nodes.
 		nodeName := pod.Spec.NodeName
-		if !bnc.IsSecondary() && kubevirt.IsPodLiveMigratable(pod) {
-			nodeName, _ = bnc.lsManager.GetSubnetName(podIfAddrs)
+		if kubevirt.IsPodLiveMigratableWithPointToPointRouting(pod, bnc.GetNetInfo()) {
+			nodeName = bnc.getSubnetNodeName(podIfAddrs)
 		}
 
 		shouldRelease, err := bnc.canReleasePodIPs(podIfAddrs, nodeName)
@@ -41,6 +41,9 @@ const (
 	// ovnStatelessNetPolAnnotationName is an annotation on K8s Network Policy resource to specify that all
 	// the resulting OVN ACLs must be created as stateless
 	ovnStatelessNetPolAnnotationName = "k8s.ovn.org/acl-stateless"
+	// ovnStatelessReplyNetPolAnnotationName is an annotation on K8s Network Policy resource to specify that
+	// the reply traffic of a stateless network policy must be allowed with stateless OVN ACLs as well
+	ovnStatelessReplyNetPolAnnotationName = "k8s.ovn.org/acl-stateless-reply"
 )
 
 // defaultDenyPortGroups is a shared object and should be used by only 1 thread at a time
@@ -175,6 +178,11 @@ type networkPolicy struct {
 	// map of portName(string): portUUID(string)
 	localPods sync.Map
 
+	// sharedRuleKeys stores the keys of the shared gress rules this network policy was successfully added to,
+	// only used with network policy rule sharing enabled. Local pods are added to all these rules.
+	// Required for cleanup.
+	sharedRuleKeys sets.Set[string]
+
 	portGroupName string
 	// this is a signal for related event handlers that they are/should be stopped.
 	// it will be set to true before any networkPolicy infrastructure is deleted,
@@ -197,17 +205,20 @@ func NewNetworkPolicy(policy *knet.NetworkPolicy) *networkPolicy {
 		isEgress:        policyTypeEgress,
 		nsHandlerList:   make([]*factory.Handler, 0),
 		localPods:       sync.Map{},
+		sharedRuleKeys:  sets.New[string](),
 	}
 	return np
 }
 
 func (bnc *BaseNetworkController) syncNetworkPolicies(networkPolicies []interface{}) error {
 	expectedPolicies := make(map[string]map[string]bool)
+	policies := make([]*knet.NetworkPolicy, 0, len(networkPolicies))
 	for _, npInterface := range networkPolicies {
 		policy, ok := npInterface.(*knet.NetworkPolicy)
 		if !ok {
 			return fmt.Errorf("spurious object in syncNetworkPolicies: %v", npInterface)
 		}
+		policies = append(policies, policy)
 		if nsMap, ok := expectedPolicies[policy.Namespace]; ok {
 			nsMap[policy.Name] = true
 		} else {
@@ -216,7 +227,7 @@ func (bnc *BaseNetworkController) syncNetworkPolicies(networkPolicies []interfac
 			}
 		}
 	}
-	err := bnc.syncNetworkPoliciesCommon(expectedPolicies)
+	err := bnc.syncNetworkPoliciesCommon(expectedPolicies, bnc.getExpectedSharedGressRules(policies))
 	if err != nil {
 		return err
 	}
@@ -274,7 +285,9 @@ func (bnc *BaseNetworkController) addHairpinAllowACL() error {
 
 // syncNetworkPoliciesCommon syncs logical entities associated with existing network policies.
 // It serves both networkpolicies (for default network) and multi-networkpolicies (for secondary networks)
-func (bnc *BaseNetworkController) syncNetworkPoliciesCommon(expectedPolicies map[string]map[string]bool) error {
+// expectedSharedRules is a set of shared gress rule keys required by the existing network policies.
+func (bnc *BaseNetworkController) syncNetworkPoliciesCommon(expectedPolicies map[string]map[string]bool,
+	expectedSharedRules sets.Set[string]) error {
 	// find network policies that don't exist in k8s anymore, but still present in the dbs, and cleanup.
 	// Peer address sets and network policy's port groups (together with acls) will be cleaned up.
 	// Delete port groups with acls first, since address sets may be referenced in these acls, and
@@ -297,6 +310,17 @@ func (bnc *BaseNetworkController) syncNetworkPoliciesCommon(expectedPolicies map
 		return fmt.Errorf("cannot delete namespace NetworkPolicy port groups: %v", err)
 	}
 
+	// shared gress rule port groups, that are not used by the existing network policies.
+	// That includes all shared rules when network policy rule sharing was disabled, while per-policy ACLs
+	// are replaced by the shared ones (and the other way around) when network policies are added.
+	predicateIDs = libovsdbops.NewDbObjectIDs(libovsdbops.PortGroupNetpolSharedRule, bnc.controllerName, nil)
+	p = libovsdbops.GetPredicate[*nbdb.PortGroup](predicateIDs, func(item *nbdb.PortGroup) bool {
+		return !expectedSharedRules.Has(item.ExternalIDs[libovsdbops.ObjectNameKey.String()])
+	})
+	if err := libovsdbops.DeletePortGroupsWithPredicate(bnc.nbClient, p); err != nil {
+		return fmt.Errorf("cannot delete stale shared NetworkPolicy rule port groups: %v", err)
+	}
+
 	// netpol-namespace-owned default deny port groups
 	predicateIDs = libovsdbops.NewDbObjectIDs(libovsdbops.PortGroupNetpolNamespace, bnc.controllerName, nil)
 	p = libovsdbops.GetPredicate[*nbdb.PortGroup](predicateIDs, func(item *nbdb.PortGroup) bool {
@@ -377,19 +401,23 @@ func (bnc *BaseNetworkController) defaultDenyPortGroupName(namespace string, acl
 }
 
 func (bnc *BaseNetworkController) buildDenyACLs(namespace, pgName string, aclLogging *libovsdbutil.ACLLoggingLevels,
-	aclDir libovsdbutil.ACLDirection) (denyACL, allowACL *nbdb.ACL) {
+	audit bool, aclDir libovsdbutil.ACLDirection) (denyACL, allowACL *nbdb.ACL) {
 	denyMatch := libovsdbutil.GetACLMatch(pgName, "", aclDir)
 	allowMatch := libovsdbutil.GetACLMatch(pgName, arpAllowPolicyMatch, aclDir)
 	aclPipeline := libovsdbutil.ACLDirectionToACLPipeline(aclDir)
 
 	denyACL = libovsdbutil.BuildACL(bnc.getDefaultDenyPolicyACLIDs(namespace, aclDir, defaultDenyACL),
 		types.DefaultDenyPriority, denyMatch, nbdb.ACLActionDrop, aclLogging, aclPipeline)
+	if audit {
+		libovsdbutil.SetACLAudit(denyACL, nbdb.ACLActionAllow, aclLogging)
+	}
 	allowACL = libovsdbutil.BuildACL(bnc.getDefaultDenyPolicyACLIDs(namespace, aclDir, arpAllowACL),
 		types.DefaultAllowPriority, allowMatch, nbdb.ACLActionAllow, nil, aclPipeline)
 	return
 }
 
-func (bnc *BaseNetworkController) addPolicyToDefaultPortGroups(np *networkPolicy, aclLogging *libovsdbutil.ACLLoggingLevels) error {
+func (bnc *BaseNetworkController) addPolicyToDefaultPortGroups(np *networkPolicy, aclLogging *libovsdbutil.ACLLoggingLevels,
+	audit bool) error {
 	return bnc.sharedNetpolPortGroups.DoWithLock(np.namespace, func(pgKey string) error {
 		sharedPGs, loaded := bnc.sharedNetpolPortGroups.LoadOrStore(pgKey, &defaultDenyPortGroups{
 			ingressPortToPolicies: map[string]sets.Set[string]{},
@@ -398,7 +426,7 @@ func (bnc *BaseNetworkController) addPolicyToDefaultPortGroups(np *networkPolicy
 		})
 		if !loaded {
 			// create port groups with acls
-			err := bnc.createDefaultDenyPGAndACLs(np.namespace, np.name, aclLogging)
+			err := bnc.createDefaultDenyPGAndACLs(np.namespace, np.name, aclLogging, audit)
 			if err != nil {
 				bnc.sharedNetpolPortGroups.Delete(pgKey)
 				return fmt.Errorf("failed to create default deny port groups: %v", err)
@@ -430,13 +458,14 @@ func (bnc *BaseNetworkController) delPolicyFromDefaultPortGroups(np *networkPoli
 
 // createDefaultDenyPGAndACLs creates the default port groups and acls for a namespace
 // must be called with defaultDenyPortGroups lock
-func (bnc *BaseNetworkController) createDefaultDenyPGAndACLs(namespace, policy string, aclLogging *libovsdbutil.ACLLoggingLevels) error {
+func (bnc *BaseNetworkController) createDefaultDenyPGAndACLs(namespace, policy string, aclLogging *libovsdbutil.ACLLoggingLevels,
+	audit bool) error {
 	ingressPGIDs := bnc.getDefaultDenyPolicyPortGroupIDs(namespace, libovsdbutil.ACLIngress)
 	ingressPGName := libovsdbutil.GetPortGroupName(ingressPGIDs)
-	ingressDenyACL, ingressAllowACL := bnc.buildDenyACLs(namespace, ingressPGName, aclLogging, libovsdbutil.ACLIngress)
+	ingressDenyACL, ingressAllowACL := bnc.buildDenyACLs(namespace, ingressPGName, aclLogging, audit, libovsdbutil.ACLIngress)
 	egressPGIDs := bnc.getDefaultDenyPolicyPortGroupIDs(namespace, libovsdbutil.ACLEgress)
 	egressPGName := libovsdbutil.GetPortGroupName(egressPGIDs)
-	egressDenyACL, egressAllowACL := bnc.buildDenyACLs(namespace, egressPGName, aclLogging, libovsdbutil.ACLEgress)
+	egressDenyACL, egressAllowACL := bnc.buildDenyACLs(namespace, egressPGName, aclLogging, audit, libovsdbutil.ACLEgress)
 	ops, err := libovsdbops.CreateOrUpdateACLsOps(bnc.nbClient, nil, bnc.GetSamplingConfig(), ingressDenyACL, ingressAllowACL, egressDenyACL, egressAllowACL)
 	if err != nil {
 		return err
@@ -484,6 +513,11 @@ func (bnc *BaseNetworkController) deleteDefaultDenyPGAndACLs(namespace string) e
 
 // must be called with namespace lock
 func (bnc *BaseNetworkController) updateACLLoggingForPolicy(np *networkPolicy, aclLogging *libovsdbutil.ACLLoggingLevels) error {
+	if config.OVNKubernetesFeature.EnableNetworkPolicyRuleSharing {
+		if err := bnc.updateSharedGressRulesLogging(np, aclLogging); err != nil {
+			return fmt.Errorf("failed to update shared gress rules: %w", err)
+		}
+	}
 	np.RLock()
 	defer np.RUnlock()
 	if np.deleted {
@@ -498,25 +532,26 @@ func (bnc *BaseNetworkController) updateACLLoggingForPolicy(np *networkPolicy, a
 	return libovsdbutil.UpdateACLLoggingWithPredicate(bnc.nbClient, p, aclLogging)
 }
 
-func (bnc *BaseNetworkController) updateACLLoggingForDefaultACLs(ns string, nsInfo *namespaceInfo) error {
+// updateDefaultDenyACLs updates the log settings and the audit mode of the default deny ACLs
+// of the namespace, in a single transaction.
+// Must be called with namespace Lock
+func (bnc *BaseNetworkController) updateDefaultDenyACLs(ns string, nsInfo *namespaceInfo) error {
 	return bnc.sharedNetpolPortGroups.DoWithLock(ns, func(pgKey string) error {
 		_, loaded := bnc.sharedNetpolPortGroups.Load(pgKey)
 		if !loaded {
 			// shared port group doesn't exist, nothing to update
 			return nil
 		}
-		predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetpolNamespace, bnc.controllerName,
-			map[libovsdbops.ExternalIDKey]string{
-				libovsdbops.ObjectNameKey: ns,
-				libovsdbops.TypeKey:       string(defaultDenyACL),
-			})
-		p := libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil)
-		defaultDenyACLs, err := libovsdbops.FindACLsWithPredicate(bnc.nbClient, p)
+		ingressDenyACL, _ := bnc.buildDenyACLs(ns, bnc.defaultDenyPortGroupName(ns, libovsdbutil.ACLIngress),
+			&nsInfo.aclLogging, nsInfo.networkPolicyAudit, libovsdbutil.ACLIngress)
+		egressDenyACL, _ := bnc.buildDenyACLs(ns, bnc.defaultDenyPortGroupName(ns, libovsdbutil.ACLEgress),
+			&nsInfo.aclLogging, nsInfo.networkPolicyAudit, libovsdbutil.ACLEgress)
+		ops, err := libovsdbops.CreateOrUpdateACLsOps(bnc.nbClient, nil, bnc.GetSamplingConfig(), ingressDenyACL, egressDenyACL)
 		if err != nil {
-			return fmt.Errorf("failed to find netpol default deny acls for namespace %s: %v", ns, err)
+			return fmt.Errorf("failed to create netpol default deny ACL ops for namespace %s: %v", ns, err)
 		}
-		if err := libovsdbutil.UpdateACLLogging(bnc.nbClient, defaultDenyACLs, &nsInfo.aclLogging); err != nil {
-			return fmt.Errorf("unable to update ACL logging for namespace %s: %w", ns, err)
+		if _, err = libovsdbops.TransactAndCheck(bnc.nbClient, ops); err != nil {
+			return fmt.Errorf("unable to update netpol default deny ACLs for namespace %s: %w", ns, err)
 		}
 		return nil
 	})
@@ -526,7 +561,7 @@ func (bnc *BaseNetworkController) updateACLLoggingForDefaultACLs(ns string, nsIn
 // Must be called with namespace Lock, should be retriable
 func (bnc *BaseNetworkController) handleNetPolNamespaceUpdate(namespace string, nsInfo *namespaceInfo) error {
 	// update shared port group ACLs
-	if err := bnc.updateACLLoggingForDefaultACLs(namespace, nsInfo); err != nil {
+	if err := bnc.updateDefaultDenyACLs(namespace, nsInfo); err != nil {
 		return fmt.Errorf("failed to update default deny ACLs for namespace %s: %v", namespace, err)
 	}
 	// now update network policy specific ACLs
@@ -806,6 +841,10 @@ func (bnc *BaseNetworkController) handleLocalPodSelectorAddFunc(np *networkPolic
 				return fmt.Errorf("unable to get ops to add new pod to policy port group %s: %v", np.portGroupName, err)
 			}
 		}
+		// add pods to shared rule port groups, before adding them to default deny port group
+		if err = bnc.sharedGressRulesAddPorts(np, portNamesToUUIDs); err != nil {
+			return fmt.Errorf("unable to add new pod to shared rule port groups: %v", err)
+		}
 		// add pods to default deny port group
 		// make sure to only pass newly added pods
 		// ops will be transacted by denyPGAddPorts
@@ -856,6 +895,10 @@ func (bnc *BaseNetworkController) handleLocalPodSelectorDelFunc(np *networkPolic
 		if err = bnc.denyPGDeletePorts(np, portNamesToUUIDs, false, ops); err != nil {
 			return fmt.Errorf("unable to add new pod to default deny port group: %v", err)
 		}
+		// delete pods from shared rule port groups, after deleting them from default deny port group
+		if err = bnc.sharedGressRulesDeletePorts(np, portNamesToUUIDs); err != nil {
+			return fmt.Errorf("unable to delete pod from shared rule port groups: %v", err)
+		}
 		// all operations were successful, update np.localPods
 		for portName := range portNamesToUUIDs {
 			np.localPods.Delete(portName)
@@ -921,7 +964,8 @@ type policyHandler struct {
 // If network policy with given key exists, it will try to clean it up first, and return an error if it fails.
 // No need to log network policy key here, because caller of createNetworkPolicy should prepend error message with
 // that information.
-func (bnc *BaseNetworkController) createNetworkPolicy(policy *knet.NetworkPolicy, aclLogging *libovsdbutil.ACLLoggingLevels) (*networkPolicy, error) {
+func (bnc *BaseNetworkController) createNetworkPolicy(policy *knet.NetworkPolicy, aclLogging *libovsdbutil.ACLLoggingLevels,
+	audit bool) (*networkPolicy, error) {
 	// To avoid existing connections disruption, make sure to apply allow ACLs before applying deny ACLs.
 	// This requires to start peer handlers before local pod handlers.
 	// 1. Cleanup old policy if it failed to be created
@@ -939,19 +983,8 @@ func (bnc *BaseNetworkController) createNetworkPolicy(policy *knet.NetworkPolicy
 	var np *networkPolicy
 	var policyHandlers []*policyHandler
 
-	// network policy will be annotated with this
-	// annotation -- [ "k8s.ovn.org/acl-stateless": "true"] for the ingress/egress
-	// policies to be added as stateless OVN ACL's.
-	// if the above annotation is not present or set to false in network policy,
-	// then corresponding egress/ingress policies will be added as stateful OVN ACL's.
-	var statelessNetPol bool
-	if config.OVNKubernetesFeature.EnableStatelessNetPol {
-		// look for stateless annotation if the statelessNetPol feature flag is enabled
-		val, ok := policy.Annotations[ovnStatelessNetPolAnnotationName]
-		if ok && val == "true" {
-			statelessNetPol = true
-		}
-	}
+	statelessNetPol := isNetworkPolicyStateless(policy)
+	statelessReplyNetPol := isNetworkPolicyStatelessReply(policy)
 
 	err := bnc.networkPolicies.DoWithLock(npKey, func(npKey string) error {
 		oldNP, found := bnc.networkPolicies.Load(npKey)
@@ -1000,6 +1033,7 @@ func (bnc *BaseNetworkController) createNetworkPolicy(policy *knet.NetworkPolicy
 			klog.V(5).Infof("Network policy ingress is %+v", ingressJSON)
 
 			ingress := newGressPolicy(knet.PolicyTypeIngress, i, policy.Namespace, policy.Name, bnc.controllerName, statelessNetPol, bnc.GetNetInfo())
+			ingress.allowStatelessReply = statelessReplyNetPol
 			// append ingress policy to be able to cleanup created address set
 			// see cleanupNetworkPolicy for details
 			np.ingressPolicies = append(np.ingressPolicies, ingress)
@@ -1018,6 +1052,9 @@ func (bnc *BaseNetworkController) createNetworkPolicy(policy *knet.NetworkPolicy
 					policyHandlers = append(policyHandlers, handler)
 				}
 			}
+			if config.OVNKubernetesFeature.EnableNetworkPolicyRuleSharing {
+				ingress.sharedRuleSpec = getSharedGressRuleSpec(policy, knet.PolicyTypeIngress, ingressJSON.Ports, ingressJSON.From)
+			}
 		}
 
 		// Go through each egress rule.  For each egress rule, create an
@@ -1026,6 +1063,7 @@ func (bnc *BaseNetworkController) createNetworkPolicy(policy *knet.NetworkPolicy
 			klog.V(5).Infof("Network policy egress is %+v", egressJSON)
 
 			egress := newGressPolicy(knet.PolicyTypeEgress, i, policy.Namespace, policy.Name, bnc.controllerName, statelessNetPol, bnc.GetNetInfo())
+			egress.allowStatelessReply = statelessReplyNetPol
 			// append ingress policy to be able to cleanup created address set
 			// see cleanupNetworkPolicy for details
 			np.egressPolicies = append(np.egressPolicies, egress)
@@ -1044,18 +1082,30 @@ func (bnc *BaseNetworkController) createNetworkPolicy(policy *knet.NetworkPolicy
 					policyHandlers = append(policyHandlers, handler)
 				}
 			}
+			if config.OVNKubernetesFeature.EnableNetworkPolicyRuleSharing {
+				egress.sharedRuleSpec = getSharedGressRuleSpec(policy, knet.PolicyTypeEgress, egressJSON.Ports, egressJSON.To)
+			}
 		}
 		klog.Infof("Policy %s added to peer address sets %v", npKey, np.peerAddressSets)
 
 		// 3. Add policy to default deny port group
 		// Pods are not added to default deny port groups yet, this is just a preparation step
-		err = bnc.addPolicyToDefaultPortGroups(np, aclLogging)
+		err = bnc.addPolicyToDefaultPortGroups(np, aclLogging, audit)
 		if err != nil {
 			return err
 		}
 
 		// 4. Build policy ACLs and port group. All the local pods that this policy
 		// selects will be eventually added to this port group.
+		// Gress policies implemented by shared rules are added to the shared rule port groups instead.
+		for _, gp := range np.getGressPolicies() {
+			if gp.sharedRuleSpec == "" {
+				continue
+			}
+			if err = bnc.addPolicyToSharedGressRule(np, gp, aclLogging, nil); err != nil {
+				return err
+			}
+		}
 
 		pgDbIDs := bnc.getNetworkPolicyPortGroupDbIDs(policy.Namespace, policy.Name)
 		np.portGroupName = libovsdbutil.GetPortGroupName(pgDbIDs)
@@ -1194,13 +1244,14 @@ func (bnc *BaseNetworkController) addNetworkPolicy(policy *knet.NetworkPolicy) e
 		return fmt.Errorf("unable to get namespace for network policy %s: namespace doesn't exist", npKey)
 	}
 	aclLogging := nsInfo.aclLogging
+	audit := nsInfo.networkPolicyAudit
 	nsUnlock()
 
 	// 2. create network policy without ns Lock, cleanup on failure
 	var np *networkPolicy
 	var err error
 
-	np, err = bnc.createNetworkPolicy(policy, &aclLogging)
+	np, err = bnc.createNetworkPolicy(policy, &aclLogging, audit)
 	defer func() {
 		if err != nil {
 			klog.Infof("Create network policy %s failed, try to cleanup", npKey)
@@ -1238,10 +1289,10 @@ func (bnc *BaseNetworkController) addNetworkPolicy(policy *knet.NetworkPolicy) e
 	defer nsUnlock()
 
 	// 4. check if namespace information related to network policy has changed,
-	// network policy only reacts to namespace update ACL log level.
+	// network policy only reacts to namespace update ACL log level and audit mode.
 	// Run handleNetPolNamespaceUpdate sequence, but only for 1 newly added policy.
-	if nsInfo.aclLogging.Deny != aclLogging.Deny {
-		if err = bnc.updateACLLoggingForDefaultACLs(policy.Namespace, nsInfo); err != nil {
+	if nsInfo.aclLogging.Deny != aclLogging.Deny || nsInfo.networkPolicyAudit != audit {
+		if err = bnc.updateDefaultDenyACLs(policy.Namespace, nsInfo); err != nil {
 			return fmt.Errorf("network policy %s failed to be created: update default deny ACLs failed: %v", npKey, err)
 		} else {
 			klog.Infof("Policy %s: ACL logging setting updated to deny=%s allow=%s",
@@ -1267,10 +1318,16 @@ func (bnc *BaseNetworkController) addNetworkPolicy(policy *knet.NetworkPolicy) e
 func (bnc *BaseNetworkController) buildNetworkPolicyACLs(np *networkPolicy, aclLogging *libovsdbutil.ACLLoggingLevels) []*nbdb.ACL {
 	acls := []*nbdb.ACL{}
 	for _, gp := range np.ingressPolicies {
+		if gp.sharedRuleKey != "" {
+			continue
+		}
 		acl, _ := gp.buildLocalPodACLs(np.portGroupName, aclLogging)
 		acls = append(acls, acl...)
 	}
 	for _, gp := range np.egressPolicies {
+		if gp.sharedRuleKey != "" {
+			continue
+		}
 		acl, _ := gp.buildLocalPodACLs(np.portGroupName, aclLogging)
 		acls = append(acls, acl...)
 	}
@@ -1347,6 +1404,11 @@ func (bnc *BaseNetworkController) cleanupNetworkPolicy(np *networkPolicy) error
 	}
 	// transaction was successful, exec callback
 	txOkCallBack()
+
+	err = bnc.delPolicyFromSharedGressRules(np)
+	if err != nil {
+		return fmt.Errorf("unable to delete policy from shared gress rules: %v", err)
+	}
 	// cleanup local pods, since they were deleted from port groups
 	np.localPods = sync.Map{}
 
@@ -1465,13 +1527,15 @@ func (bnc *BaseNetworkController) peerNamespaceUpdate(np *networkPolicy, gp *gre
 	if np.deleted {
 		return nil
 	}
+	// shared rule ACLs are applied to the shared rule port group
+	pgName := np.getGressPolicyPortGroupName(gp)
 	// buildLocalPodACLs is safe for concurrent use, see function comment for details
-	acls, deletedACLs := gp.buildLocalPodACLs(np.portGroupName, aclLogging)
+	acls, deletedACLs := gp.buildLocalPodACLs(pgName, aclLogging)
 	ops, err := libovsdbops.CreateOrUpdateACLsOps(bnc.nbClient, nil, bnc.GetSamplingConfig(), acls...)
 	if err != nil {
 		return err
 	}
-	ops, err = libovsdbops.AddACLsToPortGroupOps(bnc.nbClient, ops, np.portGroupName, acls...)
+	ops, err = libovsdbops.AddACLsToPortGroupOps(bnc.nbClient, ops, pgName, acls...)
 	if err != nil {
 		return err
 	}
@@ -1481,7 +1545,7 @@ func (bnc *BaseNetworkController) peerNamespaceUpdate(np *networkPolicy, gp *gre
 			return fmt.Errorf("failed to find deleted acls: %w", err)
 		}
 
-		ops, err = libovsdbops.DeleteACLsFromPortGroupOps(bnc.nbClient, ops, np.portGroupName, deletedACLsWithUUID...)
+		ops, err = libovsdbops.DeleteACLsFromPortGroupOps(bnc.nbClient, ops, pgName, deletedACLsWithUUID...)
 		if err != nil {
 			return err
 		}
@@ -14,6 +14,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 	"k8s.io/utils/ptr"
@@ -28,6 +30,7 @@ import (
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	anpcontroller "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/admin_network_policy"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/persistentips"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -70,7 +73,11 @@ func (bsnc *BaseSecondaryNetworkController) AddSecondaryNetworkResourceCommon(ob
 		if !ok {
 			return fmt.Errorf("could not cast %T object to *kapi.Namespace", obj)
 		}
-		return bsnc.AddNamespaceForSecondaryNetwork(ns)
+		if err := bsnc.AddNamespaceForSecondaryNetwork(ns); err != nil {
+			return err
+		}
+		// allow multicast from the namespace in the namespaces that select it as multicast peer
+		return bsnc.multicastUpdatePeerNamespace(ns.Name)
 
 	case factory.MultiNetworkPolicyType:
 		mp, ok := obj.(*mnpapi.MultiNetworkPolicy)
@@ -179,7 +186,11 @@ func (bsnc *BaseSecondaryNetworkController) DeleteSecondaryNetworkResourceCommon
 
 	case factory.NamespaceType:
 		ns := obj.(*corev1.Namespace)
-		return bsnc.deleteNamespace4SecondaryNetwork(ns)
+		if err := bsnc.deleteNamespace4SecondaryNetwork(ns); err != nil {
+			return err
+		}
+		// stop referencing the namespace address set in the namespaces that select it as multicast peer
+		return bsnc.multicastUpdatePeerNamespace(ns.Name)
 
 	case factory.MultiNetworkPolicyType:
 		mp, ok := obj.(*mnpapi.MultiNetworkPolicy)
@@ -246,7 +257,11 @@ func (bsnc *BaseSecondaryNetworkController) ensurePodForSecondaryNetwork(pod *co
 	}
 	updatePort := kubevirtLiveMigrationStatus != nil && pod.Name == kubevirtLiveMigrationStatus.TargetPod.Name
 
-	if !addPort && !updatePort {
+	// the routing of live migratable VMs on layer3 networks depends on the
+	// migration state so it has to be reconciled on every pod update
+	ensureMigratedPodRoutes := kubevirt.IsPodLiveMigratableWithPointToPointRouting(pod, bsnc.GetNetInfo())
+
+	if !addPort && !updatePort && !ensureMigratedPodRoutes {
 		return nil
 	}
 
@@ -274,6 +289,14 @@ func (bsnc *BaseSecondaryNetworkController) ensurePodForSecondaryNetwork(pod *co
 		return nil
 	}
 
+	if addPort {
+		// the pod might have been hot-unplugged from some or all of the NADs
+		// of this network
+		if err = bsnc.removePodFromHotUnpluggedNADs(pod, networkMap); err != nil {
+			return err
+		}
+	}
+
 	if !on {
 		// the pod is not attached to this specific network
 		klog.V(5).Infof("Pod %s/%s is not attached on this network controller %s",
@@ -289,8 +312,16 @@ func (bsnc *BaseSecondaryNetworkController) ensurePodForSecondaryNetwork(pod *co
 
 	var errs []error
 	for nadName, network := range networkMap {
-		if err = bsnc.addLogicalPortToNetworkForNAD(pod, nadName, switchName, network, kubevirtLiveMigrationStatus); err != nil {
-			errs = append(errs, fmt.Errorf("failed to add logical port of Pod %s/%s for NAD %s: %w", pod.Namespace, pod.Name, nadName, err))
+		if addPort || updatePort {
+			if err = bsnc.addLogicalPortToNetworkForNAD(pod, nadName, switchName, network, kubevirtLiveMigrationStatus); err != nil {
+				errs = append(errs, fmt.Errorf("failed to add logical port of Pod %s/%s for NAD %s: %w", pod.Namespace, pod.Name, nadName, err))
+				continue
+			}
+		}
+		if ensureMigratedPodRoutes {
+			if err = bsnc.ensureMigratedPodRoutes(pod, nadName); err != nil {
+				errs = append(errs, fmt.Errorf("failed to ensure live migration routes of Pod %s/%s for NAD %s: %w", pod.Namespace, pod.Name, nadName, err))
+			}
 		}
 	}
 	if len(errs) != 0 {
@@ -299,6 +330,16 @@ func (bsnc *BaseSecondaryNetworkController) ensurePodForSecondaryNetwork(pod *co
 	return nil
 }
 
+// ensureMigratedPodRoutes routes the addresses of a live migratable VM pod to
+// the node the VM runs on, which may not be the node owning the VM subnet.
+func (bsnc *BaseSecondaryNetworkController) ensureMigratedPodRoutes(pod *corev1.Pod, nadName string) error {
+	if bsnc.isPodScheduledinLocalZone(pod) {
+		return kubevirt.EnsureLocalZonePodAddressesToNodeRoute(bsnc.watchFactory, bsnc.nbClient, bsnc.lsManager,
+			bsnc.GetNetInfo(), pod, nadName)
+	}
+	return kubevirt.EnsureRemoteZonePodAddressesToNodeRoute(bsnc.watchFactory, bsnc.nbClient, bsnc.GetNetInfo(), pod, nadName)
+}
+
 func (bsnc *BaseSecondaryNetworkController) addLogicalPortToNetworkForNAD(pod *corev1.Pod, nadName, switchName string,
 	network *nadapi.NetworkSelectionElement, kubevirtLiveMigrationStatus *kubevirt.LiveMigrationStatus) error {
 	var libovsdbExecuteTime time.Duration
@@ -403,7 +444,7 @@ func (bsnc *BaseSecondaryNetworkController) addLogicalPortToNetworkForNAD(pod *c
 	if lsp != nil {
 		_ = bsnc.logicalPortCache.add(pod, switchName, nadName, lsp.UUID, podAnnotation.MAC, podAnnotation.IPs)
 		if bsnc.requireDHCP(pod) {
-			if err := bsnc.ensureDHCP(pod, podAnnotation, lsp); err != nil {
+			if err := bsnc.ensureDHCP(pod, podAnnotation, lsp, nadName); err != nil {
 				return err
 			}
 		}
@@ -445,6 +486,8 @@ func (bsnc *BaseSecondaryNetworkController) removePodForSecondaryNetwork(pod *co
 		portInfoMap = map[string]*lpInfo{}
 	}
 
+	isMigratablePod := kubevirt.IsPodLiveMigratableWithPointToPointRouting(pod, bsnc.GetNetInfo())
+
 	var alreadyProcessed bool
 	for nadName, podAnnotation := range podNetworks {
 		if !bsnc.HasNAD(nadName) {
@@ -456,6 +499,11 @@ func (bsnc *BaseSecondaryNetworkController) removePodForSecondaryNetwork(pod *co
 
 		// handle remote pod clean up but only do this one time
 		if !hasLogicalPort && !alreadyProcessed {
+			if isMigratablePod {
+				if err := bsnc.removeRemoteZoneMigratablePod(pod, nadName); err != nil {
+					return err
+				}
+			}
 			if bsnc.doesNetworkRequireIPAM() &&
 				// address set is for network policy only. So either multi network policy is enabled or network
 				// segmentation, and it is a primary UDN (regular netpol)
@@ -494,6 +542,13 @@ func (bsnc *BaseSecondaryNetworkController) removePodForSecondaryNetwork(pod *co
 			continue
 		}
 
+		// a live migrated VM pod IPs belong to the switch owning its subnet
+		if isMigratablePod {
+			if switchName, zoneContainsPodSubnet := kubevirt.ZoneContainsPodSubnet(bsnc.lsManager, pInfo.ips); zoneContainsPodSubnet {
+				pInfo.logicalSwitch = switchName
+			}
+		}
+
 		// if we allow for persistent IPs, then we need to check if this pod has an IPAM Claim
 		if bsnc.allowPersistentIPs() {
 			hasIPAMClaim, err := bsnc.hasIPAMClaim(pod, nadName)
@@ -519,9 +574,95 @@ func (bsnc *BaseSecondaryNetworkController) removePodForSecondaryNetwork(pod *co
 		bsnc.forgetPodReleasedBeforeStartup(string(pod.UID), nadName)
 
 	}
+
+	if isMigratablePod {
+		return kubevirt.CleanUpLiveMigratablePod(bsnc.nbClient, bsnc.watchFactory, bsnc.GetNetInfo(), pod)
+	}
+	return nil
+}
+
+// removePodFromHotUnpluggedNADs deletes the logical ports of a running pod on
+// the NADs of this network it no longer requests, releasing their IPs and
+// PodAnnotation if this controller allocated them.
+func (bsnc *BaseSecondaryNetworkController) removePodFromHotUnpluggedNADs(pod *corev1.Pod,
+	networkMap map[string]*nadapi.NetworkSelectionElement) error {
+	if bsnc.IsPrimaryNetwork() {
+		return nil
+	}
+	isLocalPod := bsnc.isPodScheduledinLocalZone(pod)
+	if !isLocalPod && !bsnc.isLayer2Interconnect() {
+		return nil
+	}
+
+	// the PodAnnotation might be removed by cluster manager before the logical
+	// port, so also look for cached logical ports
+	unpluggedNADs, err := util.GetPodHotUnpluggedNADs(pod, bsnc.GetNetInfo())
+	if err != nil {
+		return err
+	}
+	nadNames := sets.New[string](unpluggedNADs...)
+	portInfoMap, _ := bsnc.logicalPortCache.getAll(pod)
+	for nadName := range portInfoMap {
+		if _, ok := networkMap[nadName]; !ok && bsnc.HasNAD(nadName) {
+			nadNames.Insert(nadName)
+		}
+	}
+
+	for _, nadName := range sets.List(nadNames) {
+		klog.Infof("Removing pod %s/%s hot-unplugged from network %s, NAD: %s",
+			pod.Namespace, pod.Name, bsnc.GetNetworkName(), nadName)
+		bsnc.logicalPortCache.remove(pod, nadName)
+		pInfo, err := bsnc.deletePodLogicalPort(pod, portInfoMap[nadName], nadName)
+		if err != nil {
+			return err
+		}
+		if !isLocalPod || !bsnc.allocatesPodAnnotation() {
+			continue
+		}
+		if pInfo != nil && len(pInfo.ips) > 0 {
+			if err = bsnc.releasePodIPs(pInfo); err != nil {
+				return err
+			}
+		}
+		if err = bsnc.podAnnotationAllocator.RemovePodAnnotation(pod, nadName); err != nil {
+			return fmt.Errorf("failed to remove the annotation of pod %s/%s for NAD %s: %w", pod.Namespace, pod.Name, nadName, err)
+		}
+	}
 	return nil
 }
 
+// removeRemoteZoneMigratablePod releases the IPs of a live migratable VM pod
+// running on a remote zone when the local zone owns their subnet, and cleans
+// up the VM routing once all its pods are gone.
+func (bsnc *BaseSecondaryNetworkController) removeRemoteZoneMigratablePod(pod *corev1.Pod, nadName string) error {
+	podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+	if err != nil {
+		if util.IsAnnotationNotSetError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get pod annotation of pod %s/%s for NAD %s: %w", pod.Namespace, pod.Name, nadName, err)
+	}
+	// while this check is only intended for local pods, we also need it for
+	// remote live migrated pods that might have been allocated from this zone
+	if bsnc.wasPodReleasedBeforeStartup(string(pod.UID), nadName) {
+		klog.Infof("Completed pod %s/%s was already released for nad %s before startup",
+			pod.Namespace, pod.Name, nadName)
+	} else {
+		// the IPs of a stale migration source pod are still used by the VM
+		isMigratedSourcePodStale, err := kubevirt.IsMigratedSourcePodStale(bsnc.watchFactory, pod)
+		if err != nil {
+			return err
+		}
+		switchName, zoneContainsPodSubnet := kubevirt.ZoneContainsPodSubnet(bsnc.lsManager, podAnnotation.IPs)
+		if zoneContainsPodSubnet && !isMigratedSourcePodStale {
+			if err := bsnc.lsManager.ReleaseIPs(switchName, podAnnotation.IPs); err != nil {
+				return err
+			}
+		}
+	}
+	return kubevirt.CleanUpLiveMigratablePod(bsnc.nbClient, bsnc.watchFactory, bsnc.GetNetInfo(), pod)
+}
+
 // hasIPAMClaim determines whether a pod's IPAM is being handled by IPAMClaim CR.
 // pod passed should already be validated as having a network connection to nadName
 func (bsnc *BaseSecondaryNetworkController) hasIPAMClaim(pod *corev1.Pod, nadNamespacedName string) (bool, error) {
@@ -532,8 +673,9 @@ func (bsnc *BaseSecondaryNetworkController) hasIPAMClaim(pod *corev1.Pod, nadNam
 	var ipamClaimName string
 	var wasPersistentIPRequested bool
 	if bsnc.IsPrimaryNetwork() {
-		// primary network ipam reference claim is on the annotation
-		ipamClaimName, wasPersistentIPRequested = pod.Annotations[util.OvnUDNIPAMClaimName]
+		// primary network ipam reference claim is on the annotation or
+		// derived from the StatefulSet ordinal of the pod
+		ipamClaimName, wasPersistentIPRequested = util.GetPrimaryUDNIPAMClaimName(pod)
 	} else {
 		// secondary network the IPAM claim reference is on the network selection element
 		nadKeys := strings.Split(nadNamespacedName, "/")
@@ -577,9 +719,11 @@ func (bsnc *BaseSecondaryNetworkController) hasIPAMClaim(pod *corev1.Pod, nadNam
 
 func (bsnc *BaseSecondaryNetworkController) syncPodsForSecondaryNetwork(pods []interface{}) error {
 	annotatedLocalPods := map[*corev1.Pod]map[string]*util.PodAnnotation{}
+	var allHostSubnets []*net.IPNet
 	// get the list of logical switch ports (equivalent to pods). Reserve all existing Pod IPs to
 	// avoid subsequent new Pods getting the same duplicate Pod IP.
 	expectedLogicalPorts := make(map[string]bool)
+	vms := make(map[ktypes.NamespacedName]bool)
 	for _, podInterface := range pods {
 		pod, ok := podInterface.(*corev1.Pod)
 		if !ok {
@@ -624,7 +768,34 @@ func (bsnc *BaseSecondaryNetworkController) syncPodsForSecondaryNetwork(pods []i
 				continue
 			}
 
-			if bsnc.allocatesPodAnnotation() && isLocalPod {
+			if kubevirt.IsPodLiveMigratableWithPointToPointRouting(pod, bsnc.GetNetInfo()) {
+				// live migrated VM IPs are allocated from the switch owning
+				// their subnet regardless of the node they run on
+				var expectedLogicalPortName string
+				vms, expectedLogicalPortName, annotations, err = bsnc.allocateSyncMigratablePodIPsOnZone(vms, pod, nadName)
+				if err != nil {
+					return err
+				}
+				if annotations == nil {
+					continue
+				}
+				var zoneContainsPodSubnetOrUntracked bool
+				allHostSubnets, zoneContainsPodSubnetOrUntracked, err = kubevirt.ZoneContainsPodSubnetOrUntracked(
+					bsnc.watchFactory, bsnc.lsManager, bsnc.GetNetInfo(), allHostSubnets, annotations)
+				if err != nil {
+					return err
+				}
+				if expectedLogicalPortName != "" {
+					expectedLogicalPorts[expectedLogicalPortName] = true
+				}
+				if !zoneContainsPodSubnetOrUntracked {
+					continue
+				}
+				if annotatedLocalPods[pod] == nil {
+					annotatedLocalPods[pod] = map[string]*util.PodAnnotation{}
+				}
+				annotatedLocalPods[pod][nadName] = annotations
+			} else if bsnc.allocatesPodAnnotation() && isLocalPod {
 				// only keep track of IPs/ports that have been allocated by this
 				// controller
 				expectedLogicalPortName, err := bsnc.allocatePodIPs(pod, annotations, nadName)
@@ -647,6 +818,12 @@ func (bsnc *BaseSecondaryNetworkController) syncPodsForSecondaryNetwork(pods []i
 		}
 	}
 
+	if bsnc.TopologyType() == types.Layer3Topology && bsnc.IsPrimaryNetwork() {
+		if err := kubevirt.SyncVirtualMachines(bsnc.nbClient, bsnc.GetNetInfo(), vms); err != nil {
+			return fmt.Errorf("failed syncing running virtual machines: %v", err)
+		}
+	}
+
 	// keep track of which pods might have already been released
 	bsnc.trackPodsReleasedBeforeStartup(annotatedLocalPods)
 
@@ -710,6 +887,12 @@ func (bsnc *BaseSecondaryNetworkController) updateNamespaceForSecondaryNetwork(o
 		}
 	}
 
+	if audit := util.IsNetworkPolicyAuditEnabled(newer.Annotations); audit != nsInfo.networkPolicyAudit {
+		if err := bsnc.updateNamespaceNetworkPolicyAudit(old.Name, audit, nsInfo); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
 	if err := bsnc.multicastUpdateNamespace(newer, nsInfo); err != nil {
 		errors = append(errors, err)
 	}
@@ -748,6 +931,51 @@ func (bsnc *BaseSecondaryNetworkController) WatchNetworkPolicy() error {
 	return nil
 }
 
+// runANPController creates and runs the controller that enforces the admin
+// and baseline admin network policies on the pods of this primary network
+func (bsnc *BaseSecondaryNetworkController) runANPController() error {
+	if bsnc.anpController != nil {
+		return nil
+	}
+	anpController, err := anpcontroller.NewController(
+		bsnc.controllerName,
+		bsnc.GetNetInfo(),
+		bsnc.nbClient,
+		bsnc.kube.ANPClient,
+		bsnc.watchFactory.ANPInformer(),
+		bsnc.watchFactory.BANPInformer(),
+		bsnc.watchFactory.NamespaceCoreInformer(),
+		bsnc.watchFactory.PodCoreInformer(),
+		bsnc.watchFactory.NodeCoreInformer(),
+		bsnc.addressSetFactory,
+		bsnc.isPodScheduledinLocalZone,
+		bsnc.networkManager.GetActiveNetworkForNamespace,
+		bsnc.zone,
+		bsnc.recorder,
+		bsnc.observManager,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create admin network policy controller for network %s: %w", bsnc.GetNetworkName(), err)
+	}
+	bsnc.anpController = anpController
+	bsnc.wg.Add(1)
+	go func() {
+		defer bsnc.wg.Done()
+		bsnc.anpController.Run(1, bsnc.stopChan)
+	}()
+	return nil
+}
+
+// cleanupANPStatus removes the status conditions the admin network policy controller of the network
+// set on the admin and baseline admin network policies
+func (bsnc *BaseSecondaryNetworkController) cleanupANPStatus() {
+	if !config.OVNKubernetesFeature.EnableAdminNetworkPolicy || bsnc.kube == nil || bsnc.kube.ANPClient == nil {
+		return
+	}
+	anpcontroller.DeleteNetworkStatus(bsnc.kube.ANPClient, bsnc.watchFactory.ANPInformer().Lister(),
+		bsnc.watchFactory.BANPInformer().Lister(), bsnc.zone, bsnc.GetNetworkName())
+}
+
 // WatchMultiNetworkPolicy starts the watching of multinetworkpolicy resource and calls
 // back the appropriate handler logic
 func (bsnc *BaseSecondaryNetworkController) WatchMultiNetworkPolicy() error {
@@ -837,7 +1065,8 @@ func (bsnc *BaseSecondaryNetworkController) buildUDNEgressSNAT(localPodSubnets [
 	return snats, nil
 }
 
-func (bsnc *BaseSecondaryNetworkController) ensureDHCP(pod *corev1.Pod, podAnnotation *util.PodAnnotation, lsp *nbdb.LogicalSwitchPort) error {
+func (bsnc *BaseSecondaryNetworkController) ensureDHCP(pod *corev1.Pod, podAnnotation *util.PodAnnotation, lsp *nbdb.LogicalSwitchPort,
+	nadName string) error {
 	opts := []kubevirt.DHCPConfigsOpt{}
 
 	ipv4DNSServer, ipv6DNSServer, err := kubevirt.RetrieveDNSServiceClusterIPs(bsnc.watchFactory)
@@ -845,8 +1074,11 @@ func (bsnc *BaseSecondaryNetworkController) ensureDHCP(pod *corev1.Pod, podAnnot
 		return err
 	}
 
-	ipv4Gateway, _ := util.MatchFirstIPFamily(false /*ipv4*/, podAnnotation.Gateways)
-	if ipv4Gateway != nil {
+	if kubevirt.IsPodLiveMigratableWithPointToPointRouting(pod, bsnc.GetNetInfo()) {
+		// the subnet gateway is only local to the node owning the subnet,
+		// live migrated VMs reach it through the arp proxy
+		opts = append(opts, kubevirt.WithIPv4Router(kubevirt.ARPProxyIPv4))
+	} else if ipv4Gateway, _ := util.MatchFirstIPFamily(false /*ipv4*/, podAnnotation.Gateways); ipv4Gateway != nil {
 		opts = append(opts, kubevirt.WithIPv4Router(ipv4Gateway.String()))
 	}
 
@@ -856,6 +1088,9 @@ func (bsnc *BaseSecondaryNetworkController) ensureDHCP(pod *corev1.Pod, podAnnot
 
 	opts = append(opts, kubevirt.WithIPv4DNSServer(ipv4DNSServer), kubevirt.WithIPv6DNSServer(ipv6DNSServer))
 
+	// the VM owner options go last as they depend on the router option
+	opts = append(opts, kubevirt.WithDHCPOptionsOverrides(bsnc.getDHCPOptionsOverrides(pod, nadName)))
+
 	return kubevirt.EnsureDHCPOptionsForLSP(bsnc.controllerName, bsnc.nbClient, pod, podAnnotation.IPs, lsp, opts...)
 }
 
@@ -865,10 +1100,11 @@ func getMasqueradeManagementIPSNATMatch(dstMac string) string {
 
 func (bsnc *BaseSecondaryNetworkController) requireDHCP(pod *corev1.Pod) bool {
 	// Configure DHCP only for kubevirt VMs layer2 primary udn with subnets
+	// or live migratable ones on layer3 primary udn
 	return kubevirt.IsPodOwnedByVirtualMachine(pod) &&
 		util.IsNetworkSegmentationSupportEnabled() &&
 		bsnc.IsPrimaryNetwork() &&
-		bsnc.TopologyType() == types.Layer2Topology
+		(bsnc.TopologyType() == types.Layer2Topology || kubevirt.IsPodLiveMigratable(pod))
 }
 
 func (bsnc *BaseSecondaryNetworkController) setPodLogicalSwitchPortAddressesAndEnabledField(
@@ -938,5 +1174,7 @@ func (bsnc *BaseSecondaryNetworkController) enableSourceLSPFailedLiveMigration(p
 }
 
 func shouldAddPort(oldPod, newPod *corev1.Pod, inRetryCache bool) bool {
-	return inRetryCache || util.PodScheduled(oldPod) != util.PodScheduled(newPod)
+	return inRetryCache || util.PodScheduled(oldPod) != util.PodScheduled(newPod) ||
+		podBandwidthAnnotationsChanged(oldPod, newPod) ||
+		oldPod.Annotations[nadapi.NetworkAttachmentAnnot] != newPod.Annotations[nadapi.NetworkAttachmentAnnot]
 }
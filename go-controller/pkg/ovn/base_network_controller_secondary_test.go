@@ -9,6 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/networkmanager"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -34,7 +35,9 @@ var _ = Describe("BaseSecondaryNetworkController", func() {
 		vmName                string
 		ips                   []string
 		dns                   []string
+		annotations           map[string]string
 		expectedDHCPv4Options *nbdb.DHCPOptions
+		expectedEvent         string
 		expectedDHCPv6Options *nbdb.DHCPOptions
 	}
 	DescribeTable("with layer2 primary UDN when configuring DHCP", func(t dhcpTest) {
@@ -89,6 +92,7 @@ var _ = Describe("BaseSecondaryNetworkController", func() {
 				Labels: map[string]string{
 					kubevirtv1.VirtualMachineNameLabel: t.vmName,
 				},
+				Annotations: t.annotations,
 			},
 		}
 		ips, err := util.ParseIPNets(t.ips)
@@ -96,7 +100,7 @@ var _ = Describe("BaseSecondaryNetworkController", func() {
 		podAnnotation := &util.PodAnnotation{
 			IPs: ips,
 		}
-		Expect(controller.bnc.ensureDHCP(pod, podAnnotation, lsp)).To(Succeed())
+		Expect(controller.bnc.ensureDHCP(pod, podAnnotation, lsp, util.GetNADName(layer2NAD.Namespace, layer2NAD.Name))).To(Succeed())
 		expectedDB := []libovsdbtest.TestData{}
 
 		By("asserting the OVN entities provisioned in the NBDB are the expected ones")
@@ -120,6 +124,9 @@ var _ = Describe("BaseSecondaryNetworkController", func() {
 		)
 		Expect(fakeOVN.nbClient).To(libovsdbtest.HaveData(expectedDB))
 
+		if t.expectedEvent != "" {
+			Expect(fakeOVN.fakeRecorder.Events).To(Receive(ContainSubstring(t.expectedEvent)))
+		}
 	},
 		Entry("for ipv4 singlestack", dhcpTest{
 			vmName: "vm1",
@@ -145,6 +152,32 @@ var _ = Describe("BaseSecondaryNetworkController", func() {
 				},
 			},
 		}),
+		Entry("for ipv4 singlestack ignoring malformed VM owner options", dhcpTest{
+			vmName:        "vm1",
+			dns:           []string{"10.96.0.100"},
+			ips:           []string{"192.168.100.4/24"},
+			annotations:   map[string]string{kubevirt.DHCPOptionsAnnotation: `{"greenamespace/rednad": {"ntpServers": ["fd00::10"]}}`},
+			expectedEvent: "InvalidDHCPOptions",
+			expectedDHCPv4Options: &nbdb.DHCPOptions{
+				Cidr: "192.168.100.0/24",
+				ExternalIDs: map[string]string{
+					"k8s.ovn.org/cidr":             "192.168.100.0/24",
+					"k8s.ovn.org/id":               "bluenet-network-controller:VirtualMachine:foo/vm1:192.168.100.0/24",
+					"k8s.ovn.org/zone":             "local",
+					"k8s.ovn.org/owner-controller": "bluenet-network-controller",
+					"k8s.ovn.org/owner-type":       "VirtualMachine",
+					"k8s.ovn.org/name":             "foo/vm1",
+				},
+				Options: map[string]string{
+					"lease_time": "3500",
+					"server_mac": "0a:58:a9:fe:01:01",
+					"hostname":   "\"vm1\"",
+					"mtu":        "1300",
+					"dns_server": "10.96.0.100",
+					"server_id":  "169.254.1.1",
+				},
+			},
+		}),
 		Entry("for ipv6 singlestack", dhcpTest{
 			vmName: "vm1",
 			dns:    []string{"2015:100:200::10"},
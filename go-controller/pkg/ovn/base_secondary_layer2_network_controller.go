@@ -77,6 +77,7 @@ func (oc *BaseSecondaryLayer2NetworkController) cleanup() error {
 	if err != nil {
 		return fmt.Errorf("failed to deleting switches of network %s: %v", netName, err)
 	}
+	oc.cleanupANPStatus()
 
 	return nil
 }
@@ -121,6 +122,12 @@ func (oc *BaseSecondaryLayer2NetworkController) run() error {
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableAdminNetworkPolicy && oc.IsPrimaryNetwork() {
+		if err := oc.runANPController(); err != nil {
+			return err
+		}
+	}
+
 	// Add ourselves to the route import manager
 	if oc.routeImportManager != nil && config.Gateway.Mode == config.GatewayModeShared {
 		err := oc.routeImportManager.AddNetwork(oc.GetNetInfo())
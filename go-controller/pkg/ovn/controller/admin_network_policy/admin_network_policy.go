@@ -217,7 +217,7 @@ func (c *Controller) convertANPRulesToACLs(desiredANPState, currentANPState *adm
 		len(currentANPState.ingressRules) == len(desiredANPState.ingressRules) &&
 		len(currentANPState.egressRules) == len(desiredANPState.egressRules))
 	for i, ingressRule := range desiredANPState.ingressRules {
-		acl := c.convertANPRuleToACL(ingressRule, pgName, desiredANPState.name, desiredANPState.aclLoggingParams, isBanp)
+		acl := c.convertANPRuleToACL(ingressRule, pgName, desiredANPState.name, desiredANPState.aclLoggingParams, desiredANPState.audit, isBanp)
 		acls = append(acls, acl...)
 		if isAtLeastOneRuleUpdatedCheckRequired &&
 			!*atLeastOneRuleUpdated &&
@@ -229,7 +229,7 @@ func (c *Controller) convertANPRulesToACLs(desiredANPState, currentANPState *adm
 		}
 	}
 	for i, egressRule := range desiredANPState.egressRules {
-		acl := c.convertANPRuleToACL(egressRule, pgName, desiredANPState.name, desiredANPState.aclLoggingParams, isBanp)
+		acl := c.convertANPRuleToACL(egressRule, pgName, desiredANPState.name, desiredANPState.aclLoggingParams, desiredANPState.audit, isBanp)
 		acls = append(acls, acl...)
 		if isAtLeastOneRuleUpdatedCheckRequired &&
 			!*atLeastOneRuleUpdated &&
@@ -245,12 +245,16 @@ func (c *Controller) convertANPRulesToACLs(desiredANPState, currentANPState *adm
 }
 
 // convertANPRuleToACL takes the given gressRule and converts it into an ACL(0 ports rule) or
-// multiple ACLs(ports are set) and returns those ACLs for a given gressRule
-func (c *Controller) convertANPRuleToACL(rule *gressRule, pgName, anpName string, aclLoggingParams *libovsdbutil.ACLLoggingLevels, isBanp bool) []*nbdb.ACL {
+// multiple ACLs(ports are set) and returns those ACLs for a given gressRule.
+// In audit mode, the ACLs of deny rules pass the traffic they would drop to the lower tiers
+// (or allow it for BANPs) and log it.
+func (c *Controller) convertANPRuleToACL(rule *gressRule, pgName, anpName string, aclLoggingParams *libovsdbutil.ACLLoggingLevels,
+	audit, isBanp bool) []*nbdb.ACL {
 	klog.V(5).Infof("Creating ACL for rule %d/%s belonging to ANP %s", rule.priority, rule.gressPrefix, anpName)
 	// create match based on direction and address-set name
 	asIndex := GetANPPeerAddrSetDbIDs(anpName, rule.gressPrefix, fmt.Sprintf("%d", rule.gressIndex), c.controllerName, isBanp)
-	l3Match := constructMatchFromAddressSet(rule.gressPrefix, asIndex)
+	ipv4Mode, ipv6Mode := c.netInfo.IPMode()
+	l3Match := constructMatchFromAddressSet(rule.gressPrefix, asIndex, ipv4Mode, ipv6Mode)
 	// create match based on rule type (ingress/egress) and port-group
 	lportMatch := libovsdbutil.GetACLMatch(pgName, "", libovsdbutil.ACLDirection(rule.gressPrefix))
 	var match string
@@ -300,6 +304,15 @@ func (c *Controller) convertANPRuleToACL(rule *gressRule, pgName, anpName string
 		)
 		acls = append(acls, acl)
 	}
+	if audit {
+		auditAction := nbdb.ACLActionPass
+		if isBanp {
+			auditAction = nbdb.ACLActionAllowRelated
+		}
+		for _, acl := range acls {
+			libovsdbutil.SetACLAudit(acl, auditAction, aclLoggingParams)
+		}
+	}
 
 	return acls
 }
@@ -339,6 +352,13 @@ func (c *Controller) expandRulePeers(rule *gressRule) error {
 		namespaceCache := make(map[string]sets.Set[string])
 		// NOTE: Multiple peers may match on same podIP which is fine, we use sets to store them to avoid duplication
 		for _, namespace := range namespaces {
+			onNetwork, err := c.isNamespaceOnNetwork(namespace.Name)
+			if err != nil {
+				return err
+			}
+			if !onNetwork {
+				continue
+			}
 			podCache, ok := namespaceCache[namespace.Name]
 			if !ok {
 				podCache = sets.Set[string]{}
@@ -354,7 +374,7 @@ func (c *Controller) expandRulePeers(rule *gressRule) error {
 				if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) || !util.PodScheduled(pod) {
 					continue
 				}
-				podIPs, err := util.GetPodIPsOfNetwork(pod, &util.DefaultNetInfo{})
+				podIPs, err := util.GetPodIPsOfNetwork(pod, c.netInfo)
 				if err != nil {
 					if errors.Is(err, util.ErrNoPodIPFound) {
 						// we ignore podIPsNotFound error here because onANPPodUpdate
@@ -433,6 +453,13 @@ func (c *Controller) convertANPSubjectToLSPs(anp *adminNetworkPolicyState) ([]*n
 	}
 	namespaceCache := make(map[string]sets.Set[string])
 	for _, namespace := range namespaces {
+		onNetwork, err := c.isNamespaceOnNetwork(namespace.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !onNetwork {
+			continue
+		}
 		podCache, ok := namespaceCache[namespace.Name]
 		if !ok {
 			podCache = sets.Set[string]{}
@@ -447,7 +474,15 @@ func (c *Controller) convertANPSubjectToLSPs(anp *adminNetworkPolicyState) ([]*n
 			if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) || !util.PodScheduled(pod) || !c.isPodScheduledinLocalZone(pod) {
 				continue
 			}
-			logicalPortName := util.GetLogicalPortName(pod.Namespace, pod.Name)
+			logicalPortName, err := c.getPodLogicalPortName(pod)
+			if err != nil {
+				return nil, err
+			}
+			if logicalPortName == "" {
+				// the network will be reconciled with the NAD of the namespace
+				// and the pod will be processed again once its LSP is created
+				continue
+			}
 			lsp := &nbdb.LogicalSwitchPort{Name: logicalPortName}
 			lsp, err = libovsdbops.GetLogicalSwitchPort(c.nbClient, lsp)
 			if err != nil {
@@ -472,7 +507,7 @@ func (c *Controller) convertANPSubjectToLSPs(anp *adminNetworkPolicyState) ([]*n
 				continue
 			}
 			// we need to collect podIP:cPort information
-			podIPs, err := util.GetPodIPsOfNetwork(pod, &util.DefaultNetInfo{})
+			podIPs, err := util.GetPodIPsOfNetwork(pod, c.netInfo)
 			if err != nil {
 				if errors.Is(err, util.ErrNoPodIPFound) {
 					// we ignore podIPsNotFound error here because onANPPodUpdate
@@ -671,7 +706,10 @@ func (c *Controller) updateExistingANP(currentANPState, desiredANPState *adminNe
 	// (2) atLeastOneRuleUpdated=true which means the gress rules were of same lengths but action or ports changed on at least one rule
 	// (3) hasPriorityChanged=true which means we should update acl.Priority for every ACL
 	// (4) hasACLLoggingParamsChanged=true which means we should update acl.Severity/acl.Log for every ACL
-	if fullPeerRecompute || atLeastOneRuleUpdated || hasPriorityChanged || hasACLLoggingParamsChanged {
+	// (5) hasAuditChanged=true which means we should update acl.Action of the deny rules' ACLs
+	// The ACLs of deny rules are switched between audit and enforcing mode in the same transaction
+	hasAuditChanged := currentANPState.audit != desiredANPState.audit
+	if fullPeerRecompute || atLeastOneRuleUpdated || hasPriorityChanged || hasACLLoggingParamsChanged || hasAuditChanged {
 		klog.V(3).Infof("ANP %s with priority %d was updated", desiredANPState.name, desiredANPState.anpPriority)
 		// now update the acls to the desired ones
 		ops, err = libovsdbops.CreateOrUpdateACLsOps(c.nbClient, ops, c.GetSamplingConfig(), desiredACLs...)
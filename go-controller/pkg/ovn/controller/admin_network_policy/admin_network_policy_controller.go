@@ -45,6 +45,9 @@ type Controller struct {
 	// name of the controller that starts the ANP controller
 	// (values are default-network-controller, secondary-network-controller etc..)
 	controllerName string
+	// network the policies are enforced on: the default network or a primary
+	// user defined network
+	netInfo util.NetInfo
 	sync.RWMutex
 	anpClientSet anpclientset.Interface
 
@@ -61,6 +64,10 @@ type Controller struct {
 	// determine if we need to add pod's port to port group or not - future updates should
 	// take care of reconciling the state of the cluster
 	isPodScheduledinLocalZone func(*corev1.Pod) bool
+	// getActiveNetworkForNamespace returns the primary network of a namespace. Only the pods
+	// of the namespaces whose primary network is netInfo are subjects or peers of the policies
+	// handled by this controller, the others are handled by the controller of their network.
+	getActiveNetworkForNamespace func(namespace string) (util.NetInfo, error)
 	// store's the name of the zone that this controller belongs to
 	zone string
 
@@ -101,11 +108,21 @@ type Controller struct {
 	anpNodeQueue  workqueue.TypedRateLimitingInterface[string]
 
 	observManager *observability.Manager
+
+	// eventHandlers are the handlers added to the shared informers, removed when the controller stops
+	eventHandlers []eventHandler
+}
+
+// eventHandler is an event handler added to a shared informer
+type eventHandler struct {
+	informer     cache.SharedIndexInformer
+	registration cache.ResourceEventHandlerRegistration
 }
 
 // NewController returns a new *Controller.
 func NewController(
 	controllerName string,
+	netInfo util.NetInfo,
 	nbClient libovsdbclient.Client,
 	anpClient anpclientset.Interface,
 	anpInformer anpinformer.AdminNetworkPolicyInformer,
@@ -115,21 +132,24 @@ func NewController(
 	nodeInformer corev1informers.NodeInformer,
 	addressSetFactory addressset.AddressSetFactory,
 	isPodScheduledinLocalZone func(*corev1.Pod) bool,
+	getActiveNetworkForNamespace func(namespace string) (util.NetInfo, error),
 	zone string,
 	recorder record.EventRecorder,
 	observManager *observability.Manager) (*Controller, error) {
 
 	c := &Controller{
-		controllerName:            controllerName,
-		nbClient:                  nbClient,
-		anpClientSet:              anpClient,
-		addressSetFactory:         addressSetFactory,
-		isPodScheduledinLocalZone: isPodScheduledinLocalZone,
-		zone:                      zone,
-		anpCache:                  make(map[string]*adminNetworkPolicyState),
-		anpPriorityMap:            make(map[int32]string),
-		banpCache:                 &adminNetworkPolicyState{}, // safe to initialise pointer to empty struct than nil
-		observManager:             observManager,
+		controllerName:               controllerName,
+		netInfo:                      netInfo,
+		nbClient:                     nbClient,
+		anpClientSet:                 anpClient,
+		addressSetFactory:            addressSetFactory,
+		isPodScheduledinLocalZone:    isPodScheduledinLocalZone,
+		getActiveNetworkForNamespace: getActiveNetworkForNamespace,
+		zone:                         zone,
+		anpCache:                     make(map[string]*adminNetworkPolicyState),
+		anpPriorityMap:               make(map[int32]string),
+		banpCache:                    &adminNetworkPolicyState{}, // safe to initialise pointer to empty struct than nil
+		observManager:                observManager,
 	}
 
 	klog.V(5).Info("Setting up event handlers for Admin Network Policy")
@@ -140,7 +160,7 @@ func NewController(
 		workqueue.NewTypedItemFastSlowRateLimiter[string](1*time.Second, 5*time.Second, 5),
 		workqueue.TypedRateLimitingQueueConfig[string]{Name: "adminNetworkPolicy"},
 	)
-	_, err := anpInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+	err := c.addEventHandler(anpInformer.Informer(), factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.onANPAdd,
 		UpdateFunc: c.onANPUpdate,
 		DeleteFunc: c.onANPDelete,
@@ -158,7 +178,7 @@ func NewController(
 		workqueue.NewTypedItemFastSlowRateLimiter[string](1*time.Second, 5*time.Second, 5),
 		workqueue.TypedRateLimitingQueueConfig[string]{Name: "baselineAdminNetworkPolicy"},
 	)
-	_, err = banpInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+	err = c.addEventHandler(banpInformer.Informer(), factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.onBANPAdd,
 		UpdateFunc: c.onBANPUpdate,
 		DeleteFunc: c.onBANPDelete,
@@ -174,7 +194,7 @@ func NewController(
 		workqueue.NewTypedItemFastSlowRateLimiter[string](1*time.Second, 5*time.Second, 5),
 		workqueue.TypedRateLimitingQueueConfig[string]{Name: "anpNamespaces"},
 	)
-	_, err = namespaceInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+	err = c.addEventHandler(namespaceInformer.Informer(), factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.onANPNamespaceAdd,
 		UpdateFunc: c.onANPNamespaceUpdate,
 		DeleteFunc: c.onANPNamespaceDelete,
@@ -190,7 +210,7 @@ func NewController(
 		workqueue.NewTypedItemFastSlowRateLimiter[string](1*time.Second, 5*time.Second, 5),
 		workqueue.TypedRateLimitingQueueConfig[string]{Name: "anpPods"},
 	)
-	_, err = podInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+	err = c.addEventHandler(podInformer.Informer(), factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.onANPPodAdd,
 		UpdateFunc: c.onANPPodUpdate,
 		DeleteFunc: c.onANPPodDelete,
@@ -206,7 +226,7 @@ func NewController(
 		workqueue.NewTypedItemFastSlowRateLimiter[string](1*time.Second, 5*time.Second, 5),
 		workqueue.TypedRateLimitingQueueConfig[string]{Name: "anpNodes"},
 	)
-	_, err = nodeInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+	err = c.addEventHandler(nodeInformer.Informer(), factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.onANPNodeAdd,
 		UpdateFunc: c.onANPNodeUpdate,
 		DeleteFunc: c.onANPNodeDelete,
@@ -220,6 +240,28 @@ func NewController(
 	return c, nil
 }
 
+// addEventHandler adds the handler to the shared informer, keeping its registration to remove it
+// when the controller stops
+func (c *Controller) addEventHandler(informer cache.SharedIndexInformer, handler cache.ResourceEventHandler) error {
+	registration, err := informer.AddEventHandler(handler)
+	if err != nil {
+		return err
+	}
+	c.eventHandlers = append(c.eventHandlers, eventHandler{informer: informer, registration: registration})
+	return nil
+}
+
+// removeEventHandlers removes the handlers of the controller from the shared informers, which outlive
+// the controllers of deleted networks
+func (c *Controller) removeEventHandlers() {
+	for _, handler := range c.eventHandlers {
+		if err := handler.informer.RemoveEventHandler(handler.registration); err != nil {
+			klog.Errorf("Failed to remove event handler of controller %s: %v", c.controllerName, err)
+		}
+	}
+	c.eventHandlers = nil
+}
+
 // Run will not return until stopCh is closed. workers determines how many
 // objects (pods, namespaces, anps, banps) will be handled in parallel.
 func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) {
@@ -232,6 +274,7 @@ func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) {
 	if !util.WaitForInformerCacheSyncWithTimeout(c.controllerName, stopCh, c.anpCacheSynced, c.banpCacheSynced, c.anpNamespaceSynced, c.anpPodSynced) {
 		utilruntime.HandleError(fmt.Errorf("timed out waiting for admin network policy caches to sync"))
 		klog.Errorf("Error syncing caches for admin network policy and baseline admin network policy")
+		c.removeEventHandlers()
 		return
 	}
 
@@ -307,6 +350,7 @@ func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) {
 	<-stopCh
 
 	klog.Infof("Shutting down controller %s", c.controllerName)
+	c.removeEventHandlers()
 	c.anpQueue.ShutDown()
 	c.banpQueue.ShutDown()
 	c.anpNamespaceQueue.ShutDown()
@@ -370,7 +414,8 @@ func (c *Controller) onANPUpdate(oldObj, newObj interface{}) {
 	}
 	oldANPACLAnnotation := oldANP.Annotations[util.AclLoggingAnnotation]
 	newANPACLAnnotation := newANP.Annotations[util.AclLoggingAnnotation]
-	if reflect.DeepEqual(oldANP.Spec, newANP.Spec) && oldANPACLAnnotation == newANPACLAnnotation {
+	if reflect.DeepEqual(oldANP.Spec, newANP.Spec) && oldANPACLAnnotation == newANPACLAnnotation &&
+		util.IsNetworkPolicyAuditEnabled(oldANP.Annotations) == util.IsNetworkPolicyAuditEnabled(newANP.Annotations) {
 		return
 	}
 	key, err := cache.MetaNamespaceKeyFunc(newObj)
@@ -418,7 +463,8 @@ func (c *Controller) onBANPUpdate(oldObj, newObj interface{}) {
 	}
 	oldBANPACLAnnotation := oldBANP.Annotations[util.AclLoggingAnnotation]
 	newBANPACLAnnotation := newBANP.Annotations[util.AclLoggingAnnotation]
-	if reflect.DeepEqual(oldBANP.Spec, newBANP.Spec) && oldBANPACLAnnotation == newBANPACLAnnotation {
+	if reflect.DeepEqual(oldBANP.Spec, newBANP.Spec) && oldBANPACLAnnotation == newBANPACLAnnotation &&
+		util.IsNetworkPolicyAuditEnabled(oldBANP.Annotations) == util.IsNetworkPolicyAuditEnabled(newBANP.Annotations) {
 		return
 	}
 
@@ -515,8 +561,8 @@ func (c *Controller) onANPPodUpdate(oldObj, newObj interface{}) {
 	// zones. Rest of the cases we may return
 	oldPodLabels := labels.Set(oldPod.Labels)
 	newPodLabels := labels.Set(newPod.Labels)
-	oldPodIPs, _ := util.GetPodIPsOfNetwork(oldPod, &util.DefaultNetInfo{})
-	newPodIPs, _ := util.GetPodIPsOfNetwork(newPod, &util.DefaultNetInfo{})
+	oldPodIPs, _ := util.GetPodIPsOfNetwork(oldPod, c.netInfo)
+	newPodIPs, _ := util.GetPodIPsOfNetwork(newPod, c.netInfo)
 	oldPodRunning := util.PodRunning(oldPod)
 	newPodRunning := util.PodRunning(newPod)
 	oldPodCompleted := util.PodCompleted(oldPod)
@@ -0,0 +1,28 @@
+package adminnetworkpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	anpapi "sigs.k8s.io/network-policy-api/apis/v1alpha1"
+)
+
+func TestControllerRemovesEventHandlersOnStop(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	controller, err := newANPController(anpapi.AdminNetworkPolicyList{}, anpapi.BaselineAdminNetworkPolicyList{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	// one handler for each of the ANP, BANP, namespace, pod and node informers
+	g.Expect(controller.eventHandlers).To(gomega.HaveLen(5))
+
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		controller.Run(1, stopCh)
+	}()
+	close(stopCh)
+	g.Eventually(done, 5*time.Second).Should(gomega.BeClosed())
+	g.Expect(controller.eventHandlers).To(gomega.BeEmpty())
+}
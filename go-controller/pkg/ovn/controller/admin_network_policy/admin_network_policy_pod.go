@@ -103,6 +103,15 @@ func (c *Controller) syncAdminNetworkPolicyPod(key string) error {
 		// because anyways at that stage pod is considered to belong to remote zone
 		return nil
 	}
+	// pods of namespaces on other primary networks are handled by the
+	// controllers of those networks
+	onNetwork, err := c.isNamespaceOnNetwork(namespace)
+	if err != nil {
+		return err
+	}
+	if !onNetwork {
+		return nil
+	}
 	// case (i)/(ii)
 	for _, anp := range existingANPs {
 		anpObj, loaded := c.anpCache[anp.Name]
@@ -32,11 +32,20 @@ var (
 	)
 )
 
+// setupMetricsCollector registers the controller of the default network only:
+// the controllers of the primary user defined networks handle the same policies
+// so they would report the same rule counts.
 func (c *Controller) setupMetricsCollector() {
+	if !c.netInfo.IsDefault() {
+		return
+	}
 	prometheus.MustRegister(c)
 }
 
 func (c *Controller) teardownMetricsCollector() {
+	if !c.netInfo.IsDefault() {
+		return
+	}
 	prometheus.Unregister(c)
 }
 
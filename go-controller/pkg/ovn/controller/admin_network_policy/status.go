@@ -7,12 +7,16 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 	anpapiapply "sigs.k8s.io/network-policy-api/pkg/client/applyconfiguration/apis/v1alpha1"
+	anpclientset "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned"
+	anplister "sigs.k8s.io/network-policy-api/pkg/client/listers/apis/v1alpha1"
 )
 
 // Defined status.type fields for Admin Network Policy - This is prefixed with the zone name thus
-// creating one row per zone in the metav1.Condition array
+// creating one row per zone in the metav1.Condition array. Primary user defined networks add one
+// row per zone and network, suffixing the zone name with the network name.
 // NOTE: On every update of ANP, related pods and namespaces - if anything goes wrong this
 // this status type flaps between true and false. Users can use this to narrow down the malfunctioning zone
 // (DANGER): If this feature is used at 500-1000 node scale, then that many status rows will be created
@@ -50,7 +54,7 @@ const (
 // Each zone's ovnkube-controller will call this, hence let's update status using server-side-apply
 func (c *Controller) updateANPStatusToReady(anpName string) error {
 	readyCondition := metav1.Condition{
-		Type:    policyReadyStatusType + c.zone,
+		Type:    policyReadyStatusType + c.statusZone(),
 		Status:  metav1.ConditionTrue,
 		Reason:  policyReadyReason,
 		Message: "Setting up OVN DB plumbing was successful",
@@ -60,7 +64,7 @@ func (c *Controller) updateANPStatusToReady(anpName string) error {
 		return fmt.Errorf("unable to update the status of ANP %s, err: %v", anpName, err)
 	}
 	klog.V(5).Infof("Patched the status of ANP %v with condition type %v/%v",
-		anpName, policyReadyStatusType+c.zone, metav1.ConditionTrue)
+		anpName, policyReadyStatusType+c.statusZone(), metav1.ConditionTrue)
 	return nil
 }
 
@@ -74,7 +78,7 @@ func (c *Controller) updateANPStatusToNotReady(anpName, message string) error {
 		message = message[:32766]
 	}
 	notReadyCondition := metav1.Condition{
-		Type:    policyReadyStatusType + c.zone,
+		Type:    policyReadyStatusType + c.statusZone(),
 		Status:  metav1.ConditionFalse,
 		Reason:  policyNotReadyReason,
 		Message: message,
@@ -84,7 +88,7 @@ func (c *Controller) updateANPStatusToNotReady(anpName, message string) error {
 		return fmt.Errorf("unable update the status of ANP %s, err: %v", anpName, err)
 	}
 	klog.V(3).Infof("Patched the status of ANP %v with condition type %v/%v and reason %s/%s",
-		anpName, policyReadyStatusType+c.zone, metav1.ConditionFalse, policyNotReadyReason, message)
+		anpName, policyReadyStatusType+c.statusZone(), metav1.ConditionFalse, policyNotReadyReason, message)
 	return nil
 }
 
@@ -108,7 +112,7 @@ func (c *Controller) updateANPZoneStatusCondition(newCondition metav1.Condition,
 	applyObj := anpapiapply.AdminNetworkPolicy(anpName).
 		WithStatus(anpapiapply.AdminNetworkPolicyStatus().WithConditions(newCondition))
 	_, err = c.anpClientSet.PolicyV1alpha1().AdminNetworkPolicies().
-		ApplyStatus(context.TODO(), applyObj, metav1.ApplyOptions{FieldManager: c.zone, Force: true})
+		ApplyStatus(context.TODO(), applyObj, metav1.ApplyOptions{FieldManager: c.statusZone(), Force: true})
 	return err
 }
 
@@ -116,7 +120,7 @@ func (c *Controller) updateANPZoneStatusCondition(newCondition metav1.Condition,
 // Each zone's ovnkube-controller will call this, hence let's update status using server-side-apply
 func (c *Controller) updateBANPStatusToReady(banpName string) error {
 	readyCondition := metav1.Condition{
-		Type:    policyReadyStatusType + c.zone,
+		Type:    policyReadyStatusType + c.statusZone(),
 		Status:  metav1.ConditionTrue,
 		Reason:  policyReadyReason,
 		Message: "Setting up OVN DB plumbing was successful",
@@ -126,7 +130,7 @@ func (c *Controller) updateBANPStatusToReady(banpName string) error {
 		return fmt.Errorf("unable to update the status of BANP %s, err: %v", banpName, err)
 	}
 	klog.V(5).Infof("Patched the status of BANP %v with condition type %v/%v",
-		banpName, policyReadyStatusType+c.zone, metav1.ConditionTrue)
+		banpName, policyReadyStatusType+c.statusZone(), metav1.ConditionTrue)
 	return nil
 }
 
@@ -137,7 +141,7 @@ func (c *Controller) updateBANPStatusToReady(banpName string) error {
 // this ANP instead of having to manually check logs across zones
 func (c *Controller) updateBANPStatusToNotReady(banpName, message string) error {
 	notReadyCondition := metav1.Condition{
-		Type:    policyReadyStatusType + c.zone,
+		Type:    policyReadyStatusType + c.statusZone(),
 		Status:  metav1.ConditionFalse,
 		Reason:  policyNotReadyReason,
 		Message: message,
@@ -147,7 +151,7 @@ func (c *Controller) updateBANPStatusToNotReady(banpName, message string) error
 		return fmt.Errorf("unable update the status of BANP %s, err: %v", banpName, err)
 	}
 	klog.V(3).Infof("Patched the status of BANP %v with condition type %v/%v and reason %s",
-		banpName, policyReadyStatusType+c.zone, metav1.ConditionFalse, policyNotReadyReason)
+		banpName, policyReadyStatusType+c.statusZone(), metav1.ConditionFalse, policyNotReadyReason)
 	return nil
 }
 
@@ -171,6 +175,47 @@ func (c *Controller) updateBANPZoneStatusCondition(newCondition metav1.Condition
 	applyObj := anpapiapply.BaselineAdminNetworkPolicy(banpName).
 		WithStatus(anpapiapply.BaselineAdminNetworkPolicyStatus().WithConditions(newCondition))
 	_, err = c.anpClientSet.PolicyV1alpha1().BaselineAdminNetworkPolicies().
-		ApplyStatus(context.TODO(), applyObj, metav1.ApplyOptions{FieldManager: c.zone, Force: true})
+		ApplyStatus(context.TODO(), applyObj, metav1.ApplyOptions{FieldManager: c.statusZone(), Force: true})
 	return err
 }
+
+// DeleteNetworkStatus removes the status conditions the controller of the deleted network set in the
+// zone from the admin network policies and the baseline admin network policy. Only the policies with
+// a condition of the network are patched. This is best effort, so errors are only logged.
+func DeleteNetworkStatus(anpClient anpclientset.Interface, anpLister anplister.AdminNetworkPolicyLister,
+	banpLister anplister.BaselineAdminNetworkPolicyLister, zone, networkName string) {
+	statusZone := getStatusZone(zone, networkName)
+	conditionType := policyReadyStatusType + statusZone
+	anps, err := anpLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("Unable to list ANPs to remove the status of %s: %v", statusZone, err)
+	}
+	for _, anp := range anps {
+		if meta.FindStatusCondition(anp.Status.Conditions, conditionType) == nil {
+			continue
+		}
+		applyObj := anpapiapply.AdminNetworkPolicy(anp.Name).
+			WithStatus(anpapiapply.AdminNetworkPolicyStatus())
+		_, err := anpClient.PolicyV1alpha1().AdminNetworkPolicies().
+			ApplyStatus(context.TODO(), applyObj, metav1.ApplyOptions{FieldManager: statusZone, Force: true})
+		if err != nil {
+			klog.Warningf("Unable to remove the status of %s from ANP %s: %v", statusZone, anp.Name, err)
+		}
+	}
+	banps, err := banpLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("Unable to list BANPs to remove the status of %s: %v", statusZone, err)
+	}
+	for _, banp := range banps {
+		if meta.FindStatusCondition(banp.Status.Conditions, conditionType) == nil {
+			continue
+		}
+		applyObj := anpapiapply.BaselineAdminNetworkPolicy(banp.Name).
+			WithStatus(anpapiapply.BaselineAdminNetworkPolicyStatus())
+		_, err := anpClient.PolicyV1alpha1().BaselineAdminNetworkPolicies().
+			ApplyStatus(context.TODO(), applyObj, metav1.ApplyOptions{FieldManager: statusZone, Force: true})
+		if err != nil {
+			klog.Warningf("Unable to remove the status of %s from BANP %s: %v", statusZone, banp.Name, err)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
 	anpapi "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 	anpfake "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned/fake"
@@ -119,6 +120,7 @@ func newANPControllerWithDBSetup(dbSetup libovsdbtest.TestSetup, initANPs anpapi
 	recorder := record.NewFakeRecorder(10)
 	controller, err := NewController(
 		"default-network-controller",
+		&util.DefaultNetInfo{},
 		nbClient,
 		fakeClient.ANPClient,
 		watcher.ANPInformer(),
@@ -128,6 +130,7 @@ func newANPControllerWithDBSetup(dbSetup libovsdbtest.TestSetup, initANPs anpapi
 		watcher.NodeCoreInformer(),
 		addressSetFactory,
 		nil, // we don't care about pods in this test
+		nil,
 		"targaryen",
 		recorder,
 		nil,
@@ -217,3 +220,38 @@ func TestAddOrUpdateAdminNetworkPolicyStatus(t *testing.T) {
 	g.Expect(banp.Status.Conditions[0].Reason).To(gomega.Equal(policyReadyReason))
 	g.Expect(banp.Status.Conditions[0].Status).To(gomega.Equal(metav1.ConditionTrue))
 }
+
+func TestDeleteNetworkStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	networkCondition := metav1.Condition{
+		Type:   policyReadyStatusType + "targaryen-tenant",
+		Status: metav1.ConditionTrue,
+		Reason: policyReadyReason,
+	}
+	zoneCondition := metav1.Condition{
+		Type:   policyReadyStatusType + "targaryen",
+		Status: metav1.ConditionTrue,
+		Reason: policyReadyReason,
+	}
+	anp := *initialANP.DeepCopy()
+	anp.Status.Conditions = []metav1.Condition{zoneCondition, networkCondition}
+	banp := *initialBANP.DeepCopy()
+	banp.Status.Conditions = []metav1.Condition{zoneCondition}
+	controller, err := newANPController(
+		anpapi.AdminNetworkPolicyList{Items: []anpapi.AdminNetworkPolicy{anp}},
+		anpapi.BaselineAdminNetworkPolicyList{Items: []anpapi.BaselineAdminNetworkPolicy{banp}},
+	)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	fakeClient := controller.anpClientSet.(*anpfake.Clientset)
+	fakeClient.ClearActions()
+
+	// only the policies with a condition of the network are patched
+	DeleteNetworkStatus(controller.anpClientSet, controller.anpLister, controller.banpLister, "targaryen", "tenant")
+	var patched []string
+	for _, action := range fakeClient.Actions() {
+		if patch, ok := action.(clienttesting.PatchAction); ok && action.GetSubresource() == "status" {
+			patched = append(patched, action.GetResource().Resource+"/"+patch.GetName())
+		}
+	}
+	g.Expect(patched).To(gomega.Equal([]string{"adminnetworkpolicies/" + anp.Name}))
+}
@@ -11,6 +11,7 @@ import (
 	anpapi "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
 )
 
@@ -94,6 +95,9 @@ type adminNetworkPolicyState struct {
 	// aclLoggingParams stores the log levels for the ACLs created for this ANP
 	// this is based off the "k8s.ovn.org/acl-logging" annotation set on the ANP's
 	aclLoggingParams *libovsdbutil.ACLLoggingLevels
+	// audit is set by the "k8s.ovn.org/network-policy-audit" annotation on the ANP, the ACLs
+	// of its deny rules then let through and log the traffic they would drop
+	audit bool
 }
 
 // newAdminNetworkPolicyState takes the provided ANP API object and creates a new corresponding
@@ -138,6 +142,7 @@ func newAdminNetworkPolicyState(raw *anpapi.AdminNetworkPolicy) (*adminNetworkPo
 	}
 	klog.V(5).Infof("Logging parameters for ANP %s are Allow=%s/Deny=%s/Pass=%s", raw.Name,
 		anp.aclLoggingParams.Allow, anp.aclLoggingParams.Deny, anp.aclLoggingParams.Pass)
+	anp.audit = util.IsNetworkPolicyAuditEnabled(raw.Annotations)
 	return anp, utilerrors.Join(errs...)
 }
 
@@ -395,6 +400,7 @@ func newBaselineAdminNetworkPolicyState(raw *anpapi.BaselineAdminNetworkPolicy)
 	}
 	klog.V(5).Infof("Logging parameters for BANP %s are Allow=%s/Deny=%s", raw.Name,
 		banp.aclLoggingParams.Allow, banp.aclLoggingParams.Deny)
+	banp.audit = util.IsNetworkPolicyAuditEnabled(raw.Annotations)
 	return banp, utilerrors.Join(errs...)
 }
 
@@ -8,15 +8,16 @@ import (
 	"sort"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	utilnet "k8s.io/utils/net"
 	anpapi "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
-	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
 )
@@ -40,6 +41,55 @@ func (c *Controller) getANPPortGroupName(anpName string, isBanp bool) string {
 	return libovsdbutil.GetPortGroupName(GetANPPortGroupDbIDs(anpName, isBanp, c.controllerName))
 }
 
+// isNamespaceOnNetwork returns whether the primary network of the given
+// namespace is the network of this controller
+func (c *Controller) isNamespaceOnNetwork(namespace string) (bool, error) {
+	if c.netInfo.IsDefault() && !util.IsNetworkSegmentationSupportEnabled() {
+		return true, nil
+	}
+	activeNetwork, err := c.getActiveNetworkForNamespace(namespace)
+	if err != nil {
+		if util.IsInvalidPrimaryNetworkError(err) || apierrors.IsNotFound(err) {
+			// the primary network of the namespace is not available (yet),
+			// its pods can't be running on any network
+			return false, nil
+		}
+		return false, err
+	}
+	return activeNetwork.GetNetworkName() == c.netInfo.GetNetworkName(), nil
+}
+
+// getPodLogicalPortName returns the name of the LSP of the pod on the network
+// of this controller, empty if the NAD of the pod namespace is not known yet
+func (c *Controller) getPodLogicalPortName(pod *corev1.Pod) (string, error) {
+	if !c.netInfo.IsSecondary() {
+		return util.GetLogicalPortName(pod.Namespace, pod.Name), nil
+	}
+	nadNames, err := util.PodNadNames(pod, c.netInfo)
+	if err != nil {
+		return "", err
+	}
+	if len(nadNames) == 0 {
+		return "", nil
+	}
+	return util.GetSecondaryNetworkLogicalPortName(pod.Namespace, pod.Name, nadNames[0]), nil
+}
+
+// statusZone returns the name the status conditions of the policies are
+// reported with. Each primary user defined network has its own controller in
+// the zone, so their conditions are suffixed with the network name.
+func (c *Controller) statusZone() string {
+	return getStatusZone(c.zone, c.netInfo.GetNetworkName())
+}
+
+// getStatusZone returns the status name of the controller of the network in the zone
+func getStatusZone(zone, networkName string) string {
+	if networkName == types.DefaultNetworkName {
+		return zone
+	}
+	return zone + "-" + networkName
+}
+
 // getANPRuleACLDbIDs will return the dbObjectIDs for a given rule's ACLs
 func getANPRuleACLDbIDs(name, gressPrefix, gressIndex, protocol, controller string, isBanp bool) *libovsdbops.DbObjectIDs {
 	idType := libovsdbops.ACLAdminNetworkPolicy
@@ -107,18 +157,20 @@ func getDirectionFromGressPrefix(gressPrefix string) string {
 	return "dst"
 }
 
-// constructMatchFromAddressSet returns the L3Match for an ACL constructed from a gressRule
-func constructMatchFromAddressSet(gressPrefix string, addrSetIndex *libovsdbops.DbObjectIDs) string {
+// constructMatchFromAddressSet returns the L3Match for an ACL constructed from a gressRule.
+// Only the address sets of the IP families of the network exist, a user defined network might
+// be single stack in a dual stack cluster.
+func constructMatchFromAddressSet(gressPrefix string, addrSetIndex *libovsdbops.DbObjectIDs, ipv4Mode, ipv6Mode bool) string {
 	hashedAddressSetNameIPv4, hashedAddressSetNameIPv6 := addressset.GetHashNamesForAS(addrSetIndex)
 	var match string
 	direction := getDirectionFromGressPrefix(gressPrefix)
 
 	switch {
-	case config.IPv4Mode && config.IPv6Mode:
+	case ipv4Mode && ipv6Mode:
 		match = fmt.Sprintf("(ip4.%s == $%s || ip6.%s == $%s)", direction, hashedAddressSetNameIPv4, direction, hashedAddressSetNameIPv6)
-	case config.IPv4Mode:
+	case ipv4Mode:
 		match = fmt.Sprintf("(ip4.%s == $%s)", direction, hashedAddressSetNameIPv4)
-	case config.IPv6Mode:
+	case ipv6Mode:
 		match = fmt.Sprintf("(ip6.%s == $%s)", direction, hashedAddressSetNameIPv6)
 	}
 
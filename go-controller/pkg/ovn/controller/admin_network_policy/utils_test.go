@@ -5,10 +5,14 @@ import (
 	"strings"
 	"testing"
 
+	cnitypes "github.com/containernetworking/cni/pkg/types"
 	"github.com/onsi/gomega"
 
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
@@ -131,3 +135,90 @@ func TestGetACLLoggingLevelsForANP(t *testing.T) {
 	}
 
 }
+
+func TestIsNamespaceOnNetwork(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+	config.OVNKubernetesFeature.EnableMultiNetwork = true
+	config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+	defer func() {
+		g.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+	}()
+
+	blueNetwork, err := util.NewNetInfo(&ovncnitypes.NetConf{
+		NetConf:  cnitypes.NetConf{Name: "blue"},
+		Topology: types.Layer2Topology,
+		Role:     types.NetworkRolePrimary,
+		Subnets:  "10.100.0.0/16",
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	activeNetworks := map[string]util.NetInfo{
+		"default-ns": &util.DefaultNetInfo{},
+		"blue-ns":    blueNetwork,
+	}
+	getActiveNetworkForNamespace := func(namespace string) (util.NetInfo, error) {
+		if namespace == "invalid-ns" {
+			return nil, util.NewInvalidPrimaryNetworkError(namespace)
+		}
+		return activeNetworks[namespace], nil
+	}
+
+	tests := []struct {
+		name       string
+		netInfo    util.NetInfo
+		namespace  string
+		expected   bool
+		statusZone string
+	}{
+		{
+			name:       "default network controller, namespace on the default network",
+			netInfo:    &util.DefaultNetInfo{},
+			namespace:  "default-ns",
+			expected:   true,
+			statusZone: "zone1",
+		},
+		{
+			name:       "default network controller, namespace on a primary user defined network",
+			netInfo:    &util.DefaultNetInfo{},
+			namespace:  "blue-ns",
+			expected:   false,
+			statusZone: "zone1",
+		},
+		{
+			name:       "user defined network controller, namespace on the network",
+			netInfo:    blueNetwork,
+			namespace:  "blue-ns",
+			expected:   true,
+			statusZone: "zone1-blue",
+		},
+		{
+			name:       "user defined network controller, namespace on the default network",
+			netInfo:    blueNetwork,
+			namespace:  "default-ns",
+			expected:   false,
+			statusZone: "zone1-blue",
+		},
+		{
+			name:       "user defined network controller, primary network of the namespace not available",
+			netInfo:    blueNetwork,
+			namespace:  "invalid-ns",
+			expected:   false,
+			statusZone: "zone1-blue",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d_%s", i, tt.name), func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			c := &Controller{
+				netInfo:                      tt.netInfo,
+				getActiveNetworkForNamespace: getActiveNetworkForNamespace,
+				zone:                         "zone1",
+			}
+			onNetwork, err := c.isNamespaceOnNetwork(tt.namespace)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(onNetwork).To(gomega.Equal(tt.expected))
+			g.Expect(c.statusZone()).To(gomega.Equal(tt.statusZone))
+		})
+	}
+}
@@ -9,12 +9,15 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	knet "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
 	egressfirewall "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
@@ -141,6 +144,14 @@ type DefaultNetworkController struct {
 	// Please see zone_interconnect/chassis_handler.go for more details.
 	zoneChassisHandler *zoneic.ZoneChassisHandler
 
+	// peerClusterController connects the pods with the pods of peer clusters
+	// through the transit switch.
+	// Please see zone_interconnect/peer_cluster_controller.go for more details.
+	peerClusterController *zoneic.PeerClusterController
+	// ovsClient is the client of the local OVS database, it is nil if the OVS
+	// database is not available
+	ovsClient libovsdbclient.Client
+
 	gatewayTopologyFactory *topology.GatewayTopologyFactory
 }
 
@@ -153,10 +164,16 @@ func NewDefaultNetworkController(
 	routeImportManager routeimport.Manager,
 	eIPController *EgressIPController,
 	portCache *PortCache,
+	ovsClient libovsdbclient.Client,
 ) (*DefaultNetworkController, error) {
 	stopChan := make(chan struct{})
 	wg := &sync.WaitGroup{}
-	return newDefaultNetworkControllerCommon(cnci, stopChan, wg, nil, networkManager, routeImportManager, observManager, eIPController, portCache)
+	oc, err := newDefaultNetworkControllerCommon(cnci, stopChan, wg, nil, networkManager, routeImportManager, observManager, eIPController, portCache)
+	if err != nil {
+		return nil, err
+	}
+	oc.ovsClient = ovsClient
+	return oc, nil
 }
 
 func newDefaultNetworkControllerCommon(
@@ -218,9 +235,11 @@ func newDefaultNetworkControllerCommon(
 			logicalPortCache:            portCache,
 			namespaces:                  make(map[string]*namespaceInfo),
 			namespacesMutex:             sync.Mutex{},
+			multicastPeerNamespaces:     make(map[string]sets.Set[string]),
 			addressSetFactory:           addressSetFactory,
 			networkPolicies:             syncmap.NewSyncMap[*networkPolicy](),
 			sharedNetpolPortGroups:      syncmap.NewSyncMap[*defaultDenyPortGroups](),
+			sharedNetpolGressRules:      syncmap.NewSyncMap[*sharedGressRule](),
 			podSelectorAddressSets:      syncmap.NewSyncMap[*PodSelectorAddressSet](),
 			stopChan:                    defaultStopChan,
 			wg:                          defaultWg,
@@ -371,6 +390,9 @@ func (oc *DefaultNetworkController) Stop() {
 	if oc.routeImportManager != nil {
 		oc.routeImportManager.ForgetNetwork(oc.GetNetworkName())
 	}
+	if oc.peerClusterController != nil {
+		oc.peerClusterController.Stop()
+	}
 
 	close(oc.stopChan)
 	oc.cancelableCtx.Cancel()
@@ -574,6 +596,14 @@ func (oc *DefaultNetworkController) run(_ context.Context) error {
 		}
 	}
 
+	if util.IsPeerClustersEnabled() {
+		oc.peerClusterController = zoneic.NewPeerClusterController(oc.zoneICHandler, oc.kube.PeerClusterClient, oc.ovsClient,
+			oc.watchFactory, oc.zone)
+		if err = oc.peerClusterController.Start(); err != nil {
+			return fmt.Errorf("unable to start peer cluster controller: %w", err)
+		}
+	}
+
 	end := time.Since(start)
 	klog.Infof("Completing all the Watchers took %v", end)
 	metrics.MetricOVNKubeControllerSyncDuration.WithLabelValues("all watchers").Set(end.Seconds())
@@ -879,7 +909,11 @@ func (h *defaultNetworkControllerEventHandler) AddResource(obj interface{}, from
 		if !ok {
 			return fmt.Errorf("could not cast %T object to *kapi.Namespace", obj)
 		}
-		return h.oc.AddNamespace(ns)
+		if err := h.oc.AddNamespace(ns); err != nil {
+			return err
+		}
+		// allow multicast from the namespace in the namespaces that select it as multicast peer
+		return h.oc.multicastUpdatePeerNamespace(ns.Name)
 
 	default:
 		return h.oc.AddResourceCommon(h.objType, obj)
@@ -896,7 +930,7 @@ func (h *defaultNetworkControllerEventHandler) UpdateResource(oldObj, newObj int
 		oldPod := oldObj.(*corev1.Pod)
 		newPod := newObj.(*corev1.Pod)
 
-		return h.oc.ensurePod(oldPod, newPod, inRetryCache || util.PodScheduled(oldPod) != util.PodScheduled(newPod))
+		return h.oc.ensurePod(oldPod, newPod, shouldAddPort(oldPod, newPod, inRetryCache))
 
 	case factory.NodeType:
 		newNode, ok := newObj.(*corev1.Node)
@@ -1117,7 +1151,11 @@ func (h *defaultNetworkControllerEventHandler) DeleteResource(obj, cachedObj int
 
 	case factory.NamespaceType:
 		ns := obj.(*corev1.Namespace)
-		return h.oc.deleteNamespace(ns)
+		if err := h.oc.deleteNamespace(ns); err != nil {
+			return err
+		}
+		// stop referencing the namespace address set in the namespaces that select it as multicast peer
+		return h.oc.multicastUpdatePeerNamespace(ns.Name)
 
 	default:
 		return h.oc.DeleteResourceCommon(h.objType, obj)
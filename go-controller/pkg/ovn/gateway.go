@@ -424,8 +424,12 @@ func (gw *GatewayManager) GatewayInit(
 
 	var options map[string]string
 	if enableGatewayMTU {
+		mtu := gw.netInfo.MTU()
+		if mtu == 0 {
+			mtu = config.Default.MTU
+		}
 		options = map[string]string{
-			"gateway_mtu": strconv.Itoa(config.Default.MTU),
+			"gateway_mtu": strconv.Itoa(mtu),
 		}
 	}
 	logicalRouterPort := nbdb.LogicalRouterPort{
@@ -456,7 +460,7 @@ func (gw *GatewayManager) GatewayInit(
 
 	err = libovsdbops.CreateOrUpdateLogicalRouterPort(gw.nbClient, &logicalRouter,
 		&logicalRouterPort, nil, &logicalRouterPort.MAC, &logicalRouterPort.Networks,
-		&logicalRouterPort.Options)
+		&logicalRouterPort.Options, &logicalRouterPort.Ipv6RaConfigs)
 	if err != nil {
 		return fmt.Errorf("failed to create port %+v on router %+v: %v", logicalRouterPort, logicalRouter, err)
 	}
@@ -51,6 +51,15 @@ type gressPolicy struct {
 
 	// set to true for stateless network policies (stateless acls), otherwise set to false
 	isNetPolStateless bool
+	// set to true for stateless network policies that also allow their reply traffic with stateless acls
+	allowStatelessReply bool
+
+	// sharedRuleSpec is the normalized gress rule, only set when network policy rule sharing is enabled.
+	sharedRuleSpec string
+	// sharedRuleKey and sharedPortGroupName are set when the gress policy ACLs are implemented by a shared rule,
+	// see sharedGressRule. They may only be changed with networkPolicy Lock.
+	sharedRuleKey       string
+	sharedPortGroupName string
 
 	// supported IP mode
 	ipv4Mode bool
@@ -121,20 +130,25 @@ func (gp *gressPolicy) addIPBlock(ipblockJSON *knet.IPBlock) {
 	gp.ipBlocks = append(gp.ipBlocks, ipblockJSON)
 }
 
+// getPeerDirection returns the IP field matching the peers of the gressPolicy traffic,
+// or of its reply traffic
+func (gp *gressPolicy) getPeerDirection(reply bool) string {
+	if (gp.policyType == knet.PolicyTypeIngress) != reply {
+		return "src"
+	}
+	return "dst"
+}
+
 // getL3MatchFromAddressSet may return empty string, which means that there are no address sets selected for giver
 // gressPolicy at the time, and acl should not be created.
-func (gp *gressPolicy) getL3MatchFromAddressSet() string {
+func (gp *gressPolicy) getL3MatchFromAddressSet(reply bool) string {
 	v4AddressSets := syncMapToSortedList(gp.peerV4AddressSets)
 	v6AddressSets := syncMapToSortedList(gp.peerV6AddressSets)
 
 	// We sort address slice,
 	// Hence we'll be constructing the sorted address set string here
-	var direction, v4Match, v6Match, match string
-	if gp.policyType == knet.PolicyTypeIngress {
-		direction = "src"
-	} else {
-		direction = "dst"
-	}
+	var v4Match, v6Match, match string
+	direction := gp.getPeerDirection(reply)
 
 	//  At this point there will be address sets in one or both of them.
 	//  Contents in both address sets mean dual stack, else one will be empty because we will only populate
@@ -167,13 +181,8 @@ func (gp *gressPolicy) allIPsMatch() string {
 	}
 }
 
-func (gp *gressPolicy) getMatchFromIPBlock(lportMatch, l4Match string) []string {
-	var direction string
-	if gp.policyType == knet.PolicyTypeIngress {
-		direction = "src"
-	} else {
-		direction = "dst"
-	}
+func (gp *gressPolicy) getMatchFromIPBlock(lportMatch, l4Match string, reply bool) []string {
+	direction := gp.getPeerDirection(reply)
 	var matchStrings []string
 	var matchStr, ipVersion string
 	for _, ipBlock := range gp.ipBlocks {
@@ -264,9 +273,23 @@ func (gp *gressPolicy) isEmpty() bool {
 // buildLocalPodACLs is safe for concurrent use, since it only uses gressPolicy fields that don't change
 // since creation, or are safe for concurrent use like peerVXAddressSets
 func (gp *gressPolicy) buildLocalPodACLs(portGroupName string, aclLogging *libovsdbutil.ACLLoggingLevels) (createdACLs []*nbdb.ACL,
+	skippedACLs []*nbdb.ACL) {
+	createdACLs, skippedACLs = gp.buildGressACLs(portGroupName, aclLogging, false)
+	if gp.isNetPolStateless && gp.allowStatelessReply {
+		// stateless ACLs don't commit the connection to conntrack, so the reply traffic is not related to it.
+		// Let it through with stateless ACLs in the other direction, in case the pods are isolated for it.
+		replyACLs, skippedReplyACLs := gp.buildGressACLs(portGroupName, aclLogging, true)
+		createdACLs = append(createdACLs, replyACLs...)
+		skippedACLs = append(skippedACLs, skippedReplyACLs...)
+	}
+	return
+}
+
+// buildGressACLs builds the ACLs of the gress policy's rules, or of their reply traffic
+func (gp *gressPolicy) buildGressACLs(portGroupName string, aclLogging *libovsdbutil.ACLLoggingLevels, reply bool) (createdACLs []*nbdb.ACL,
 	skippedACLs []*nbdb.ACL) {
 	var lportMatch string
-	if gp.policyType == knet.PolicyTypeIngress {
+	if (gp.policyType == knet.PolicyTypeIngress) != reply {
 		lportMatch = fmt.Sprintf("outport == @%s", portGroupName)
 	} else {
 		lportMatch = fmt.Sprintf("inport == @%s", portGroupName)
@@ -275,14 +298,27 @@ func (gp *gressPolicy) buildLocalPodACLs(portGroupName string, aclLogging *libov
 	if gp.isNetPolStateless {
 		action = nbdb.ACLActionAllowStateless
 	}
-	for protocol, l4Match := range libovsdbutil.GetL4MatchesFromNetworkPolicyPorts(gp.portPolicies) {
+	aclPipeline := gp.aclPipeline
+	l4Matches := libovsdbutil.GetL4MatchesFromNetworkPolicyPorts(gp.portPolicies)
+	if reply {
+		if gp.policyType == knet.PolicyTypeIngress {
+			aclPipeline = libovsdbutil.PolicyTypeToAclPipeline(knet.PolicyTypeEgress)
+		} else {
+			aclPipeline = libovsdbutil.PolicyTypeToAclPipeline(knet.PolicyTypeIngress)
+		}
+		l4Matches = libovsdbutil.GetL4ReplyMatchesFromNetworkPolicyPorts(gp.portPolicies)
+	}
+	for protocol, l4Match := range l4Matches {
+		if reply {
+			protocol += libovsdbutil.ReplyL4MatchSuffix
+		}
 		if len(gp.ipBlocks) > 0 {
 			// Add ACL allow rule for IPBlock CIDR
-			ipBlockMatches := gp.getMatchFromIPBlock(lportMatch, l4Match)
+			ipBlockMatches := gp.getMatchFromIPBlock(lportMatch, l4Match, reply)
 			for ipBlockIdx, ipBlockMatch := range ipBlockMatches {
 				aclIDs := gp.getNetpolACLDbIDs(ipBlockIdx, protocol)
 				acl := libovsdbutil.BuildACL(aclIDs, types.DefaultAllowPriority, ipBlockMatch, action,
-					aclLogging, gp.aclPipeline)
+					aclLogging, aclPipeline)
 				createdACLs = append(createdACLs, acl)
 			}
 		}
@@ -293,7 +329,7 @@ func (gp *gressPolicy) buildLocalPodACLs(portGroupName string, aclLogging *libov
 			if gp.isEmpty() {
 				l3Match = gp.allIPsMatch()
 			} else {
-				l3Match = gp.getL3MatchFromAddressSet()
+				l3Match = gp.getL3MatchFromAddressSet(reply)
 			}
 
 			if l4Match == libovsdbutil.UnspecifiedL4Match {
@@ -303,7 +339,7 @@ func (gp *gressPolicy) buildLocalPodACLs(portGroupName string, aclLogging *libov
 			}
 			aclIDs := gp.getNetpolACLDbIDs(emptyIdx, protocol)
 			acl := libovsdbutil.BuildACL(aclIDs, types.DefaultAllowPriority, addrSetMatch, action,
-				aclLogging, gp.aclPipeline)
+				aclLogging, aclPipeline)
 			if l3Match == "" {
 				// if l3Match is empty, then no address sets are selected for a given gressPolicy.
 				// fortunately l3 match is not a part of externalIDs, that means that we can find
@@ -318,6 +354,16 @@ func (gp *gressPolicy) buildLocalPodACLs(portGroupName string, aclLogging *libov
 }
 
 func (gp *gressPolicy) getNetpolACLDbIDs(ipBlockIdx int, protocol string) *libovsdbops.DbObjectIDs {
+	if gp.sharedRuleKey != "" {
+		// shared rule ACLs don't belong to a specific policy, and are the same for every policy using the rule
+		return libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetpolSharedRule, gp.controllerName,
+			map[libovsdbops.ExternalIDKey]string{
+				libovsdbops.ObjectNameKey:         gp.sharedRuleKey,
+				libovsdbops.PolicyDirectionKey:    string(gp.policyType),
+				libovsdbops.IpBlockIndexKey:       strconv.Itoa(ipBlockIdx),
+				libovsdbops.PortPolicyProtocolKey: protocol,
+			})
+	}
 	return libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetworkPolicy, gp.controllerName,
 		map[libovsdbops.ExternalIDKey]string{
 			// policy namespace+name
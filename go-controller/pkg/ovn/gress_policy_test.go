@@ -118,7 +118,7 @@ func TestGetMatchFromIPBlock(t *testing.T) {
 		for _, ipBlock := range tc.ipBlocks {
 			gressPolicy.addIPBlock(ipBlock)
 		}
-		output := gressPolicy.getMatchFromIPBlock(tc.lportMatch, tc.l4Match)
+		output := gressPolicy.getMatchFromIPBlock(tc.lportMatch, tc.l4Match, false)
 		assert.Equal(t, tc.expected, output)
 	}
 }
@@ -541,7 +541,7 @@ var _ = Describe("OVN Kubevirt Operations", func() {
 				Type: "router",
 				Options: map[string]string{
 					"router-port": logicalRouterPort.Name,
-					"arp_proxy":   kubevirt.ComposeARPProxyLSPOption(),
+					"arp_proxy":   kubevirt.ComposeARPProxyLSPOption(config.Default.ClusterSubnets),
 				},
 			}
 			logicalSwitch = &nbdb.LogicalSwitch{
@@ -601,7 +601,7 @@ var _ = Describe("OVN Kubevirt Operations", func() {
 					Type: "router",
 					Options: map[string]string{
 						"router-port": migrationTargetLRP.Name,
-						"arp_proxy":   kubevirt.ComposeARPProxyLSPOption(),
+						"arp_proxy":   kubevirt.ComposeARPProxyLSPOption(config.Default.ClusterSubnets),
 					},
 				}
 				migrationTargetLS = &nbdb.LogicalSwitch{
@@ -0,0 +1,303 @@
+package ovn
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// layer2GatewayPeer is the gateway router of another node connected to the
+// layer2 switch of the network
+type layer2GatewayPeer struct {
+	node string
+	mac  net.HardwareAddr
+	ips  []net.IP
+}
+
+// syncLayer2GatewayECMP configures the gateway router of a local node so that
+// its default routes are ECMP routes towards its own uplink next hops and
+// towards the gateway routers of all the other nodes, reachable through the
+// layer2 switch. Every route is monitored with BFD so that a failed uplink or
+// gateway router is taken out of the ECMP set. Traffic received from other
+// gateway routers is always sent out of the local uplink so that it is never
+// bounced back and forth between gateway routers.
+// If the feature is disabled, any configuration left behind is removed.
+func (oc *SecondaryLayer2NetworkController) syncLayer2GatewayECMP(node *corev1.Node) error {
+	gwRouterName := oc.GetNetworkScopedGWRouterName(node.Name)
+	if !config.OVNKubernetesFeature.EnableLayer2GatewayECMP {
+		return oc.deleteLayer2GatewayECMP(gwRouterName)
+	}
+
+	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return fmt.Errorf("failed to get node %s network %s L3 gateway config: %w", node.Name, oc.GetNetworkName(), err)
+	}
+	peers, err := oc.getLayer2GatewayPeers(node.Name)
+	if err != nil {
+		return err
+	}
+
+	externalPort := types.GWRouterToExtSwitchPrefix + gwRouterName
+	switchPort := types.RouterToSwitchPrefix + oc.GetNetworkScopedSwitchName(types.OVNLayer2Switch)
+	externalIDs := map[string]string{
+		types.NetworkExternalID:  oc.GetNetworkName(),
+		types.TopologyExternalID: oc.TopologyType(),
+	}
+
+	var ops []ovsdb.Operation
+
+	// monitor the default routes through the local uplink with BFD
+	uplinkNextHops := sets.New[string]()
+	for _, nextHop := range l3GatewayConfig.NextHops {
+		uplinkNextHops.Insert(nextHop.String())
+		bfd := &nbdb.BFD{
+			LogicalPort: externalPort,
+			DstIP:       nextHop.String(),
+		}
+		ops, err = libovsdbops.CreateOrUpdateBFDOps(oc.nbClient, ops, bfd)
+		if err != nil {
+			return fmt.Errorf("error creating or updating BFD %+v: %w", bfd, err)
+		}
+		lrsr := &nbdb.LogicalRouterStaticRoute{
+			IPPrefix:    defaultRoutePrefix(nextHop),
+			Nexthop:     nextHop.String(),
+			OutputPort:  &externalPort,
+			BFD:         &bfd.UUID,
+			ExternalIDs: externalIDs,
+		}
+		p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+			return item.OutputPort != nil && *item.OutputPort == externalPort && item.IPPrefix == lrsr.IPPrefix &&
+				libovsdbops.PolicyEqualPredicate(lrsr.Policy, item.Policy)
+		}
+		ops, err = libovsdbops.CreateOrUpdateLogicalRouterStaticRoutesWithPredicateOps(oc.nbClient, ops, gwRouterName,
+			lrsr, p, &lrsr.Nexthop, &lrsr.BFD)
+		if err != nil {
+			return fmt.Errorf("error creating or updating static route %+v on router %s: %w", lrsr, gwRouterName, err)
+		}
+	}
+
+	// add the default routes towards the gateway routers of the other nodes
+	peerNextHops := sets.New[string]()
+	for _, peer := range peers {
+		for _, ip := range peer.ips {
+			peerNextHops.Insert(ip.String())
+			bfd := &nbdb.BFD{
+				LogicalPort: switchPort,
+				DstIP:       ip.String(),
+			}
+			ops, err = libovsdbops.CreateOrUpdateBFDOps(oc.nbClient, ops, bfd)
+			if err != nil {
+				return fmt.Errorf("error creating or updating BFD %+v: %w", bfd, err)
+			}
+			lrsr := &nbdb.LogicalRouterStaticRoute{
+				IPPrefix:    defaultRoutePrefix(ip),
+				Nexthop:     ip.String(),
+				OutputPort:  &switchPort,
+				BFD:         &bfd.UUID,
+				ExternalIDs: externalIDs,
+			}
+			p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+				return item.OutputPort != nil && *item.OutputPort == switchPort && item.IPPrefix == lrsr.IPPrefix &&
+					item.Nexthop == lrsr.Nexthop
+			}
+			ops, err = libovsdbops.CreateOrUpdateLogicalRouterStaticRoutesWithPredicateOps(oc.nbClient, ops, gwRouterName,
+				lrsr, p, &lrsr.BFD, &lrsr.ExternalIDs)
+			if err != nil {
+				return fmt.Errorf("error creating or updating static route %+v on router %s: %w", lrsr, gwRouterName, err)
+			}
+		}
+	}
+	ops, err = libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicateOps(oc.nbClient, ops, gwRouterName,
+		func(item *nbdb.LogicalRouterStaticRoute) bool {
+			return isLayer2GatewayPeerRoute(item, switchPort) && !peerNextHops.Has(item.Nexthop)
+		})
+	if err != nil {
+		return fmt.Errorf("error deleting stale gateway peer routes on router %s: %w", gwRouterName, err)
+	}
+
+	// send the traffic of the other gateway routers out of the local uplink
+	for _, isIPv6 := range []bool{false, true} {
+		p := func(item *nbdb.LogicalRouterPolicy) bool {
+			return oc.isLayer2GatewayPeerPolicy(item) && utilnet.IsIPv6String(item.Nexthops[0]) == isIPv6
+		}
+		// no next hops of the IP family is not an error, the policy is removed
+		nextHops, _ := util.MatchAllIPStringFamily(isIPv6, sets.List(uplinkNextHops))
+		var macs []string
+		for _, peer := range peers {
+			if _, err := util.MatchFirstIPFamily(isIPv6, peer.ips); err == nil {
+				macs = append(macs, peer.mac.String())
+			}
+		}
+		if len(nextHops) == 0 || len(macs) == 0 {
+			ops, err = libovsdbops.DeleteLogicalRouterPolicyWithPredicateOps(oc.nbClient, ops, gwRouterName, p)
+			if err != nil {
+				return fmt.Errorf("error deleting gateway peer policy on router %s: %w", gwRouterName, err)
+			}
+			continue
+		}
+		ipPrefix := "ip4"
+		if isIPv6 {
+			ipPrefix = "ip6"
+		}
+		lrp := &nbdb.LogicalRouterPolicy{
+			Priority:    types.Layer2GatewayPeerReroutePriority,
+			Match:       fmt.Sprintf("inport == %q && eth.src == {%s} && %s", switchPort, strings.Join(macs, ", "), ipPrefix),
+			Action:      nbdb.LogicalRouterPolicyActionReroute,
+			Nexthops:    nextHops,
+			ExternalIDs: externalIDs,
+		}
+		ops, err = libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicateOps(oc.nbClient, ops, gwRouterName, lrp, p)
+		if err != nil {
+			return fmt.Errorf("error creating or updating gateway peer policy %+v on router %s: %w", lrp, gwRouterName, err)
+		}
+	}
+
+	if _, err = libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to configure ECMP gateway routes on router %s: %w", gwRouterName, err)
+	}
+
+	err = libovsdbops.DeleteBFDsWithPredicate(oc.nbClient, func(item *nbdb.BFD) bool {
+		return (item.LogicalPort == externalPort && !uplinkNextHops.Has(item.DstIP)) ||
+			(item.LogicalPort == switchPort && !peerNextHops.Has(item.DstIP))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete stale BFDs of router %s: %w", gwRouterName, err)
+	}
+
+	klog.V(5).Infof("Configured ECMP gateway routes on router %s towards %d peer gateway routers", gwRouterName, len(peers))
+	return nil
+}
+
+// syncLayer2GatewayECMPForLocalNodes updates the ECMP gateway routes of the
+// gateway routers of all local nodes, for example after a remote node was
+// added or removed
+func (oc *SecondaryLayer2NetworkController) syncLayer2GatewayECMPForLocalNodes() error {
+	if !config.OVNKubernetesFeature.EnableLayer2GatewayECMP || !oc.IsPrimaryNetwork() {
+		return nil
+	}
+	var errs []error
+	oc.localZoneNodes.Range(func(key, _ any) bool {
+		nodeName := key.(string)
+		node, err := oc.watchFactory.GetNode(nodeName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get node %s: %w", nodeName, err))
+			return true
+		}
+		if err := oc.syncLayer2GatewayECMP(node); err != nil {
+			errs = append(errs, err)
+		}
+		return true
+	})
+	return utilerrors.Join(errs...)
+}
+
+// getLayer2GatewayPeers returns the gateway routers of all the nodes but the
+// given one. Nodes that were not allocated a gateway router join address yet
+// are skipped.
+func (oc *SecondaryLayer2NetworkController) getLayer2GatewayPeers(localNode string) ([]layer2GatewayPeer, error) {
+	nodes, err := oc.watchFactory.GetNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	var peers []layer2GatewayPeer
+	for _, node := range nodes {
+		if node.Name == localNode {
+			continue
+		}
+		joinAddrs, err := util.ParseNodeGatewayRouterJoinAddrs(node, oc.GetNetworkName())
+		if err != nil {
+			if util.IsAnnotationNotSetError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get the node %s join subnet IPs: %w", node.Name, err)
+		}
+		if len(joinAddrs) == 0 {
+			continue
+		}
+		peer := layer2GatewayPeer{
+			node: node.Name,
+			mac:  util.IPAddrToHWAddr(joinAddrs[0].IP),
+		}
+		for _, joinAddr := range joinAddrs {
+			peer.ips = append(peer.ips, joinAddr.IP)
+		}
+		peers = append(peers, peer)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].node < peers[j].node })
+	return peers, nil
+}
+
+// deleteLayer2GatewayECMP removes the ECMP gateway configuration of the given
+// gateway router
+func (oc *SecondaryLayer2NetworkController) deleteLayer2GatewayECMP(gwRouterName string) error {
+	externalPort := types.GWRouterToExtSwitchPrefix + gwRouterName
+	switchPort := types.RouterToSwitchPrefix + oc.GetNetworkScopedSwitchName(types.OVNLayer2Switch)
+
+	ops, err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicateOps(oc.nbClient, nil, gwRouterName,
+		func(item *nbdb.LogicalRouterStaticRoute) bool {
+			return isLayer2GatewayPeerRoute(item, switchPort)
+		})
+	if err != nil {
+		return fmt.Errorf("error deleting gateway peer routes on router %s: %w", gwRouterName, err)
+	}
+	ops, err = libovsdbops.DeleteLogicalRouterPolicyWithPredicateOps(oc.nbClient, ops, gwRouterName,
+		oc.isLayer2GatewayPeerPolicy)
+	if err != nil {
+		return fmt.Errorf("error deleting gateway peer policies on router %s: %w", gwRouterName, err)
+	}
+	if _, err = libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to delete ECMP gateway routes on router %s: %w", gwRouterName, err)
+	}
+
+	// the static routes only hold weak references to the BFDs, so deleting
+	// them also disables BFD on the uplink default routes
+	err = libovsdbops.DeleteBFDsWithPredicate(oc.nbClient, func(item *nbdb.BFD) bool {
+		return item.LogicalPort == externalPort || item.LogicalPort == switchPort
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete BFDs of router %s: %w", gwRouterName, err)
+	}
+	return nil
+}
+
+// deleteLayer2GatewayBFDs removes the BFDs of all the gateway routers of the
+// network, whose static routes and policies are removed with the routers
+func (oc *SecondaryLayer2NetworkController) deleteLayer2GatewayBFDs() error {
+	externalPortPrefix := types.GWRouterToExtSwitchPrefix + oc.GetNetworkScopedGWRouterName("")
+	switchPort := types.RouterToSwitchPrefix + oc.GetNetworkScopedSwitchName(types.OVNLayer2Switch)
+	return libovsdbops.DeleteBFDsWithPredicate(oc.nbClient, func(item *nbdb.BFD) bool {
+		return item.LogicalPort == switchPort || strings.HasPrefix(item.LogicalPort, externalPortPrefix)
+	})
+}
+
+func isLayer2GatewayPeerRoute(item *nbdb.LogicalRouterStaticRoute, switchPort string) bool {
+	return item.OutputPort != nil && *item.OutputPort == switchPort &&
+		(item.IPPrefix == "0.0.0.0/0" || item.IPPrefix == "::/0")
+}
+
+func (oc *SecondaryLayer2NetworkController) isLayer2GatewayPeerPolicy(item *nbdb.LogicalRouterPolicy) bool {
+	return item.Priority == types.Layer2GatewayPeerReroutePriority && len(item.Nexthops) > 0 &&
+		item.ExternalIDs[types.NetworkExternalID] == oc.GetNetworkName()
+}
+
+func defaultRoutePrefix(ip net.IP) string {
+	if utilnet.IsIPv6(ip) {
+		return "::/0"
+	}
+	return "0.0.0.0/0"
+}
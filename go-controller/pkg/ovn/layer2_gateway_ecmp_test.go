@@ -0,0 +1,220 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/libovsdb/client"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Layer2 gateway ECMP", func() {
+	const (
+		networkName    = "tenant"
+		localNodeName  = "node1"
+		gwRouterName   = "GR_tenant_node1"
+		externalPort   = "rtoe-GR_tenant_node1"
+		switchPort     = "rtos-tenant_ovn_layer2_switch"
+		uplinkNextHop  = "192.168.126.1"
+		peerMACs       = "0a:58:64:41:00:03, 0a:58:64:41:00:04"
+		peerPolicyUUID = "peer-policy-UUID"
+	)
+
+	var (
+		oc         *SecondaryLayer2NetworkController
+		kubeClient *fake.Clientset
+		wf         *factory.WatchFactory
+		nbClient   client.Client
+	)
+
+	newNode := func(name, joinIP string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Annotations: map[string]string{
+					"k8s.ovn.org/l3-gateway-config": fmt.Sprintf(`{"default":{"mode":"shared","bridge-id":"breth0","interface-id":"breth0_%s","mac-address":"0a:58:c0:a8:7e:ca","ip-addresses":["192.168.126.202/24"],"next-hops":[%q],"node-port-enable":"true","vlan-id":"0"}}`, name, uplinkNextHop),
+					util.OvnNodeChassisID:           "chassis-" + name,
+					util.OVNNodeGRLRPAddrs:          fmt.Sprintf(`{"default":{"ipv4":"100.64.0.2/16"},%q:{"ipv4":%q}}`, networkName, joinIP),
+				},
+			},
+		}
+	}
+
+	uplinkRoute := func(bfd *string) *nbdb.LogicalRouterStaticRoute {
+		port := externalPort
+		return &nbdb.LogicalRouterStaticRoute{
+			UUID:       "uplink-route-UUID",
+			IPPrefix:   "0.0.0.0/0",
+			Nexthop:    uplinkNextHop,
+			OutputPort: &port,
+			BFD:        bfd,
+			ExternalIDs: map[string]string{
+				ovntypes.NetworkExternalID:  networkName,
+				ovntypes.TopologyExternalID: ovntypes.Layer2Topology,
+			},
+		}
+	}
+
+	peerRoute := func(uuid, nextHop, bfd string) *nbdb.LogicalRouterStaticRoute {
+		port := switchPort
+		return &nbdb.LogicalRouterStaticRoute{
+			UUID:       uuid,
+			IPPrefix:   "0.0.0.0/0",
+			Nexthop:    nextHop,
+			OutputPort: &port,
+			BFD:        &bfd,
+			ExternalIDs: map[string]string{
+				ovntypes.NetworkExternalID:  networkName,
+				ovntypes.TopologyExternalID: ovntypes.Layer2Topology,
+			},
+		}
+	}
+
+	peerPolicy := func(macs string) *nbdb.LogicalRouterPolicy {
+		return &nbdb.LogicalRouterPolicy{
+			UUID:     peerPolicyUUID,
+			Priority: ovntypes.Layer2GatewayPeerReroutePriority,
+			Match:    fmt.Sprintf("inport == %q && eth.src == {%s} && ip4", switchPort, macs),
+			Action:   nbdb.LogicalRouterPolicyActionReroute,
+			Nexthops: []string{uplinkNextHop},
+			ExternalIDs: map[string]string{
+				ovntypes.NetworkExternalID:  networkName,
+				ovntypes.TopologyExternalID: ovntypes.Layer2Topology,
+			},
+		}
+	}
+
+	getLocalNode := func() *corev1.Node {
+		node, err := wf.GetNode(localNodeName)
+		Expect(err).NotTo(HaveOccurred())
+		return node
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableInterconnect = true
+		config.OVNKubernetesFeature.EnableLayer2GatewayECMP = true
+
+		netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: networkName},
+			Topology: ovntypes.Layer2Topology,
+			Role:     ovntypes.NetworkRolePrimary,
+			Subnets:  "10.100.0.0/16",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		kubeClient = fake.NewSimpleClientset(
+			newNode(localNodeName, "100.65.0.2/16"),
+			newNode("node2", "100.65.0.3/16"),
+			newNode("node3", "100.65.0.4/16"),
+		)
+		wf, err = factory.NewMasterWatchFactory(&util.OVNMasterClientset{KubeClient: kubeClient})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		DeferCleanup(wf.Shutdown)
+
+		var cleanup *libovsdbtest.Context
+		nbClient, cleanup, err = libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{
+				uplinkRoute(nil),
+				&nbdb.LogicalRouter{
+					UUID:         "gw-router-UUID",
+					Name:         gwRouterName,
+					StaticRoutes: []string{"uplink-route-UUID"},
+				},
+			},
+		}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(cleanup.Cleanup)
+
+		oc = &SecondaryLayer2NetworkController{}
+		oc.watchFactory = wf
+		oc.nbClient = nbClient
+		oc.ReconcilableNetInfo = util.NewReconcilableNetInfo(netInfo)
+		oc.localZoneNodes = &sync.Map{}
+		oc.localZoneNodes.Store(localNodeName, true)
+	})
+
+	It("load balances the default route across the uplink and the other gateway routers", func() {
+		Expect(oc.syncLayer2GatewayECMP(getLocalNode())).To(Succeed())
+
+		uplinkBFD := "uplink-bfd-UUID"
+		expectedData := []libovsdbtest.TestData{
+			&nbdb.BFD{UUID: uplinkBFD, LogicalPort: externalPort, DstIP: uplinkNextHop},
+			&nbdb.BFD{UUID: "node2-bfd-UUID", LogicalPort: switchPort, DstIP: "100.65.0.3"},
+			&nbdb.BFD{UUID: "node3-bfd-UUID", LogicalPort: switchPort, DstIP: "100.65.0.4"},
+			uplinkRoute(&uplinkBFD),
+			peerRoute("node2-route-UUID", "100.65.0.3", "node2-bfd-UUID"),
+			peerRoute("node3-route-UUID", "100.65.0.4", "node3-bfd-UUID"),
+			peerPolicy(peerMACs),
+			&nbdb.LogicalRouter{
+				UUID:         "gw-router-UUID",
+				Name:         gwRouterName,
+				StaticRoutes: []string{"uplink-route-UUID", "node2-route-UUID", "node3-route-UUID"},
+				Policies:     []string{peerPolicyUUID},
+			},
+		}
+		Eventually(nbClient).Should(libovsdbtest.HaveData(expectedData))
+	})
+
+	It("stops using the gateway router of a deleted node", func() {
+		Expect(oc.syncLayer2GatewayECMP(getLocalNode())).To(Succeed())
+
+		Expect(kubeClient.CoreV1().Nodes().Delete(context.TODO(), "node3", metav1.DeleteOptions{})).To(Succeed())
+		Eventually(func() error {
+			_, err := wf.GetNode("node3")
+			return err
+		}).Should(HaveOccurred())
+		Expect(oc.syncLayer2GatewayECMPForLocalNodes()).To(Succeed())
+
+		uplinkBFD := "uplink-bfd-UUID"
+		expectedData := []libovsdbtest.TestData{
+			&nbdb.BFD{UUID: uplinkBFD, LogicalPort: externalPort, DstIP: uplinkNextHop},
+			&nbdb.BFD{UUID: "node2-bfd-UUID", LogicalPort: switchPort, DstIP: "100.65.0.3"},
+			uplinkRoute(&uplinkBFD),
+			peerRoute("node2-route-UUID", "100.65.0.3", "node2-bfd-UUID"),
+			peerPolicy("0a:58:64:41:00:03"),
+			&nbdb.LogicalRouter{
+				UUID:         "gw-router-UUID",
+				Name:         gwRouterName,
+				StaticRoutes: []string{"uplink-route-UUID", "node2-route-UUID"},
+				Policies:     []string{peerPolicyUUID},
+			},
+		}
+		Eventually(nbClient).Should(libovsdbtest.HaveData(expectedData))
+	})
+
+	It("removes the configuration when the feature is disabled", func() {
+		Expect(oc.syncLayer2GatewayECMP(getLocalNode())).To(Succeed())
+
+		config.OVNKubernetesFeature.EnableLayer2GatewayECMP = false
+		Expect(oc.syncLayer2GatewayECMP(getLocalNode())).To(Succeed())
+
+		expectedData := []libovsdbtest.TestData{
+			uplinkRoute(nil),
+			&nbdb.LogicalRouter{
+				UUID:         "gw-router-UUID",
+				Name:         gwRouterName,
+				StaticRoutes: []string{"uplink-route-UUID"},
+			},
+		}
+		Eventually(nbClient).Should(libovsdbtest.HaveData(expectedData))
+	})
+})
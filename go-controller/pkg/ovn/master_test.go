@@ -354,7 +354,7 @@ func addNodeLogicalFlowsHelper(testData []libovsdbtest.TestData, expectedOVNClus
 		Type: "router",
 		Options: map[string]string{
 			"router-port": types.RouterToSwitchPrefix + node.Name,
-			"arp_proxy":   kubevirt.ComposeARPProxyLSPOption(),
+			"arp_proxy":   kubevirt.ComposeARPProxyLSPOption(config.Default.ClusterSubnets),
 		},
 		Addresses: []string{"router"},
 	})
@@ -149,12 +149,21 @@ func getMulticastStaleData(netInfo util.NetInfo, clusterPortGroup, clusterRtrPor
 func getMulticastPolicyExpectedData(netInfo util.NetInfo, ns string, ports []string) []libovsdb.TestData {
 	netControllerName := getNetworkControllerName(netInfo.GetNetworkName())
 	fakeController := getFakeController(netControllerName)
-	pg_hash := fakeController.getNamespacePortGroupName(ns)
-	egressMatch := libovsdbutil.GetACLMatch(pg_hash, fakeController.getMulticastACLEgrMatch(), libovsdbutil.ACLEgress)
-
 	ip4AddressSet, ip6AddressSet := getNsAddrSetHashNames(netControllerName, ns)
-	mcastMatch := getACLMatchAF(getMulticastACLIgrMatchV4(ip4AddressSet), getMulticastACLIgrMatchV6(ip6AddressSet), config.IPv4Mode, config.IPv6Mode)
-	ingressMatch := libovsdbutil.GetACLMatch(pg_hash, mcastMatch, libovsdbutil.ACLIngress)
+	mcastMatch := getACLMatchAF(getMulticastACLIgrMatchV4([]string{ip4AddressSet}, nil),
+		getMulticastACLIgrMatchV6([]string{ip6AddressSet}, nil), config.IPv4Mode, config.IPv6Mode)
+	return getMulticastPolicyExpectedDataWithMatches(netInfo, ns, ports, fakeController.getMulticastACLEgrMatch(nil), mcastMatch)
+}
+
+// getMulticastPolicyExpectedDataWithMatches returns the namespace multicast ACLs and port group,
+// with the given multicast matches for the egress and ingress ACLs.
+func getMulticastPolicyExpectedDataWithMatches(netInfo util.NetInfo, ns string, ports []string,
+	egressMcastMatch, ingressMcastMatch string) []libovsdb.TestData {
+	netControllerName := getNetworkControllerName(netInfo.GetNetworkName())
+	fakeController := getFakeController(netControllerName)
+	pg_hash := fakeController.getNamespacePortGroupName(ns)
+	egressMatch := libovsdbutil.GetACLMatch(pg_hash, egressMcastMatch, libovsdbutil.ACLEgress)
+	ingressMatch := libovsdbutil.GetACLMatch(pg_hash, ingressMcastMatch, libovsdbutil.ACLIngress)
 
 	aclIDs := getNamespaceMcastACLDbIDs(ns, libovsdbutil.ACLEgress, netControllerName)
 	aclName := libovsdbutil.GetACLName(aclIDs)
@@ -332,6 +341,7 @@ func startBaseNetworkController(fakeOvn *FakeOVN, nad *nadapi.NetworkAttachmentD
 var _ = Describe("OVN Multicast with IP Address Family", func() {
 	const (
 		namespaceName1         = "namespace1"
+		namespaceName2         = "namespace2"
 		longnamespaceName1Name = "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijk" // create with 63 characters
 
 	)
@@ -952,5 +962,140 @@ var _ = Describe("OVN Multicast with IP Address Family", func() {
 			Entry("[Network Segmentation] IPv4", true, false, nadFromIPMode(namespaceName1, true, false)),
 			Entry("[Network Segmentation] IPv6", false, true, nadFromIPMode(namespaceName1, false, true)),
 		)
+
+		DescribeTable("restricts namespace multicast to the allowed groups and versions", func(useIPv4, useIPv6 bool, nad *nadapi.NetworkAttachmentDefinition) {
+			app.Action = func(*cli.Context) error {
+				config.IPv4Mode = useIPv4
+				config.IPv6Mode = useIPv6
+
+				netInfo := getNetInfoFromNAD(nad)
+				namespace1 := *newNamespace(namespaceName1)
+
+				objs := []runtime.Object{&corev1.NamespaceList{
+					Items: []corev1.Namespace{
+						namespace1,
+					},
+				}}
+				if nad != nil {
+					objs = append(objs, &nadapi.NetworkAttachmentDefinitionList{
+						Items: []nadapi.NetworkAttachmentDefinition{*nad},
+					})
+				}
+
+				fakeOvn.startWithDBSetup(libovsdb.TestSetup{}, objs...)
+
+				if nad != nil {
+					Expect(fakeOvn.networkManager.Start()).To(Succeed())
+					defer fakeOvn.networkManager.Stop()
+				}
+
+				bnc, _ := startBaseNetworkController(fakeOvn, nad)
+				Expect(bnc.WatchNamespaces()).To(Succeed())
+
+				ns, err := fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Get(context.TODO(), namespace1.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(ns).NotTo(BeNil())
+
+				// Enable multicast in the namespace for the allowed groups and versions only.
+				ns.Annotations[util.NsMulticastAllowedGroupsAnnotation] = "239.1.1.0/24,ff3e::1234"
+				ns.Annotations[util.NsMulticastVersionsAnnotation] = "igmpv3,mldv2"
+				updateMulticast(fakeOvn, ns, true)
+				ip4AddressSet, ip6AddressSet := getNsAddrSetHashNames(bnc.controllerName, namespace1.Name)
+				var egressMatch, ingressMatch string
+				if useIPv4 {
+					egressMatch = "((igmp && ip4.dst == 224.0.0.22) || (ip4.mcast && !igmp && ip4.dst == {239.1.1.0/24}))"
+					ingressMatch = "(igmp || (ip4.src == $" + ip4AddressSet + " && ip4.mcast && ip4.dst == {239.1.1.0/24}))"
+				} else {
+					egressMatch = "(mldv2 || ((ip6.dst[120..127] == 0xff && ip6.dst[116] == 1) && ip6.dst == {ff3e::1234/128}))"
+					ingressMatch = "(mldv1 || mldv2 || (ip6.src == $" + ip6AddressSet +
+						" && (ip6.dst[120..127] == 0xff && ip6.dst[116] == 1) && ip6.dst == {ff3e::1234/128}))"
+				}
+				expectedData := getMulticastPolicyExpectedDataWithMatches(netInfo, namespace1.Name, nil, egressMatch, ingressMatch)
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+				// Removing the restrictions allows any group and version.
+				delete(ns.Annotations, util.NsMulticastAllowedGroupsAnnotation)
+				delete(ns.Annotations, util.NsMulticastVersionsAnnotation)
+				updateMulticast(fakeOvn, ns, true)
+				expectedData = getMulticastPolicyExpectedData(netInfo, namespace1.Name, nil)
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+				// An invalid multicast policy disables multicast in the namespace.
+				ns.Annotations[util.NsMulticastAllowedGroupsAnnotation] = "10.0.0.1"
+				updateMulticast(fakeOvn, ns, true)
+				namespacePortGroup := getNamespacePG(namespaceName1, bnc.controllerName)
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(namespacePortGroup))
+
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			Expect(err).NotTo(HaveOccurred())
+		},
+			Entry("IPv4", true, false, nil),
+			Entry("IPv6", false, true, nil),
+			Entry("[Network Segmentation] IPv4", true, false, nadFromIPMode(namespaceName1, true, false)),
+			Entry("[Network Segmentation] IPv6", false, true, nadFromIPMode(namespaceName1, false, true)),
+		)
+
+		DescribeTable("allows multicast from the peer namespaces", func(useIPv4, useIPv6 bool) {
+			app.Action = func(*cli.Context) error {
+				config.IPv4Mode = useIPv4
+				config.IPv6Mode = useIPv6
+
+				netInfo := getNetInfoFromNAD(nil)
+				namespace1 := *newNamespace(namespaceName1)
+				fakeOvn.startWithDBSetup(libovsdb.TestSetup{},
+					&corev1.NamespaceList{
+						Items: []corev1.Namespace{
+							namespace1,
+						},
+					})
+				bnc, _ := startBaseNetworkController(fakeOvn, nil)
+				Expect(bnc.WatchNamespaces()).To(Succeed())
+
+				ns, err := fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Get(context.TODO(), namespace1.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(ns).NotTo(BeNil())
+
+				// The peer namespace doesn't exist yet, only multicast within the namespace is allowed.
+				ns.Annotations[util.NsMulticastPeerNamespacesAnnotation] = namespaceName2
+				updateMulticast(fakeOvn, ns, true)
+				expectedData := getMulticastPolicyExpectedData(netInfo, namespace1.Name, nil)
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+				// Multicast from the peer namespace pods is allowed once it's created.
+				_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Create(context.TODO(),
+					newNamespace(namespaceName2), metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				ip4AddressSet1, ip6AddressSet1 := getNsAddrSetHashNames(bnc.controllerName, namespaceName1)
+				ip4AddressSet2, ip6AddressSet2 := getNsAddrSetHashNames(bnc.controllerName, namespaceName2)
+				var egressMatch, ingressMatch string
+				if useIPv4 {
+					egressMatch = "ip4.mcast"
+					ingressMatch = "(igmp || (ip4.src == {$" + ip4AddressSet1 + ", $" + ip4AddressSet2 + "} && ip4.mcast))"
+				} else {
+					egressMatch = "(mldv1 || mldv2 || (ip6.dst[120..127] == 0xff && ip6.dst[116] == 1))"
+					ingressMatch = "(mldv1 || mldv2 || (ip6.src == {$" + ip6AddressSet1 + ", $" + ip6AddressSet2 +
+						"} && (ip6.dst[120..127] == 0xff && ip6.dst[116] == 1)))"
+				}
+				peerData := getMulticastPolicyExpectedDataWithMatches(netInfo, namespace1.Name, nil, egressMatch, ingressMatch)
+				peerData = append(peerData, getNamespacePG(namespaceName2, bnc.controllerName))
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(peerData...))
+
+				// The deleted peer namespace address set is not referenced anymore.
+				err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Delete(context.TODO(), namespaceName2, metav1.DeleteOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			Expect(err).NotTo(HaveOccurred())
+		},
+			Entry("IPv4", true, false),
+			Entry("IPv6", false, true),
+		)
 	})
 })
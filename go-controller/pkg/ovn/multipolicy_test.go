@@ -452,6 +452,55 @@ var _ = ginkgo.Describe("OVN MultiNetworkPolicy Operations", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
+		ginkgo.It("creates stateless OVN ACLs for a multinetworkPolicy based off of the annotation", func() {
+			app.Action = func(*cli.Context) error {
+				var err error
+				config.OVNKubernetesFeature.EnableStatelessNetPol = true
+
+				topology := ovntypes.Layer2Topology
+				subnets := "10.1.0.0/24"
+				setSecondaryNetworkTestData(topology, subnets)
+
+				namespace1 := *newNamespace(namespaceName1)
+				namespace2 := *newNamespace(namespaceName2)
+				policy := getMatchLabelsNetworkPolicy(netPolicyName1, namespace1.Name,
+					namespace2.Name, "", true, true)
+				policy.Annotations = map[string]string{
+					PolicyForAnnotation:                   nadNamespacedName,
+					ovnStatelessNetPolAnnotationName:      "true",
+					ovnStatelessReplyNetPolAnnotationName: "true",
+				}
+				mpolicy := convertNetPolicyToMultiNetPolicy(policy)
+
+				watchNodes := false
+				node := *newNode(nodeName, "192.168.126.202/24")
+
+				startOvn(initialDB, watchNodes, []corev1.Node{node}, []corev1.Namespace{namespace1, namespace2}, nil, nil,
+					[]nettypes.NetworkAttachmentDefinition{*nad, *nad2}, nil, nil)
+
+				_, err = fakeOvn.fakeClient.MultiNetworkPolicyClient.K8sCniCncfIoV1beta1().MultiNetworkPolicies(mpolicy.Namespace).
+					Create(context.TODO(), mpolicy, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				ocInfo := fakeOvn.secondaryControllers[secondaryNetworkName]
+				ocInfo.asf.EventuallyExpectEmptyAddressSetExist(namespaceName1)
+				ocInfo.asf.EventuallyExpectEmptyAddressSetExist(namespaceName2)
+
+				expectedData := getNamespaceWithSinglePolicyExpectedData(
+					newNetpolDataParams(policy).
+						withPeerNamespaces(namespace2.Name).
+						withStateless(true).
+						withStatelessReply(true).
+						withNetInfo(netInfo),
+					initialDB.NBData)
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
 		ginkgo.It("correctly creates and deletes network policy and multi network policy with the same policy", func() {
 			app.Action = func(*cli.Context) error {
 				var err error
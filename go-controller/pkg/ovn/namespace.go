@@ -261,6 +261,12 @@ func (oc *DefaultNetworkController) updateNamespace(old, newer *corev1.Namespace
 		}
 	}
 
+	if audit := util.IsNetworkPolicyAuditEnabled(newer.Annotations); audit != nsInfo.networkPolicyAudit {
+		if err := oc.updateNamespaceNetworkPolicyAudit(old.Name, audit, nsInfo); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
 	if err := oc.multicastUpdateNamespace(newer, nsInfo); err != nil {
 		errors = append(errors, err)
 	}
@@ -196,8 +196,7 @@ func (oc *DefaultNetworkController) ensureLocalZonePod(oldPod, pod *corev1.Pod,
 	}
 
 	if kubevirt.IsPodLiveMigratable(pod) {
-		v4Subnets, v6Subnets := util.GetClusterSubnetsWithHostPrefix()
-		return kubevirt.EnsureLocalZonePodAddressesToNodeRoute(oc.watchFactory, oc.nbClient, oc.lsManager, pod, ovntypes.DefaultNetworkName, append(v4Subnets, v6Subnets...))
+		return kubevirt.EnsureLocalZonePodAddressesToNodeRoute(oc.watchFactory, oc.nbClient, oc.lsManager, oc.GetNetInfo(), pod, ovntypes.DefaultNetworkName)
 	}
 
 	return nil
@@ -245,7 +244,7 @@ func (oc *DefaultNetworkController) ensureRemoteZonePod(oldPod, pod *corev1.Pod,
 		}
 	}
 	if kubevirt.IsPodLiveMigratable(pod) {
-		return kubevirt.EnsureRemoteZonePodAddressesToNodeRoute(oc.watchFactory, oc.nbClient, pod, ovntypes.DefaultNetworkName)
+		return kubevirt.EnsureRemoteZonePodAddressesToNodeRoute(oc.watchFactory, oc.nbClient, oc.GetNetInfo(), pod, ovntypes.DefaultNetworkName)
 	}
 	return nil
 }
@@ -263,7 +262,7 @@ func (oc *DefaultNetworkController) removePod(pod *corev1.Pod, portInfo *lpInfo)
 		}
 	}
 
-	err := kubevirt.CleanUpLiveMigratablePod(oc.nbClient, oc.watchFactory, pod)
+	err := kubevirt.CleanUpLiveMigratablePod(oc.nbClient, oc.watchFactory, oc.GetNetInfo(), pod)
 	if err != nil {
 		return err
 	}
@@ -511,6 +510,7 @@ func (oc *DefaultNetworkController) newANPController() error {
 	var err error
 	oc.anpController, err = anpcontroller.NewController(
 		DefaultNetworkControllerName,
+		oc.GetNetInfo(),
 		oc.nbClient,
 		oc.kube.ANPClient,
 		oc.watchFactory.ANPInformer(),
@@ -520,6 +520,7 @@ func (oc *DefaultNetworkController) newANPController() error {
 		oc.watchFactory.NodeCoreInformer(),
 		oc.addressSetFactory,
 		oc.isPodScheduledinLocalZone,
+		oc.networkManager.GetActiveNetworkForNamespace,
 		oc.zone,
 		oc.recorder,
 		oc.observManager,
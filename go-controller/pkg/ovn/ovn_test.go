@@ -527,6 +527,7 @@ func (o *FakeOVN) NewSecondaryNetworkController(netattachdef *nettypes.NetworkAt
 			o.fakeClient.KubeClient,
 			&kube.KubeOVN{
 				Kube:                 kube.Kube{KClient: o.fakeClient.KubeClient},
+				ANPClient:            o.fakeClient.ANPClient,
 				EIPClient:            o.fakeClient.EgressIPClient,
 				EgressFirewallClient: o.fakeClient.EgressFirewallClient,
 				IPAMClaimsClient:     o.fakeClient.IPAMClaimsClient,
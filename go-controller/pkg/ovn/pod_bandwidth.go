@@ -0,0 +1,156 @@
+package ovn
+
+import (
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ref "k8s.io/client-go/tools/reference"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// podBandwidthRule describes how a pod bandwidth annotation maps to a QoS
+// rule on the pod's logical switch port. Directions are seen from the pod.
+type podBandwidthRule struct {
+	annotation   string
+	direction    libovsdbutil.ACLDirection
+	qosDirection nbdb.QoSDirection
+	portField    string
+}
+
+var podBandwidthRules = []podBandwidthRule{
+	{
+		annotation:   util.PodIngressBandwidthAnnotation,
+		direction:    libovsdbutil.ACLIngress,
+		qosDirection: nbdb.QoSDirectionToLport,
+		portField:    "outport",
+	},
+	{
+		annotation:   util.PodEgressBandwidthAnnotation,
+		direction:    libovsdbutil.ACLEgress,
+		qosDirection: nbdb.QoSDirectionFromLport,
+		portField:    "inport",
+	},
+}
+
+// maxPodBandwidthQoSRate is the largest rate in kbps OVN accepts for a QoS rule
+const maxPodBandwidthQoSRate = math.MaxUint32
+
+func getPodBandwidthQoSDbIDs(portName string, direction libovsdbutil.ACLDirection, controller string) *libovsdbops.DbObjectIDs {
+	return libovsdbops.NewDbObjectIDs(libovsdbops.QoSPodBandwidth, controller,
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.ObjectNameKey:      portName,
+			libovsdbops.PolicyDirectionKey: string(direction),
+		})
+}
+
+// podBandwidthQoSOps returns the ops to limit the bandwidth of the pod's
+// logical switch port according to the pod bandwidth annotations, removing
+// the limits of the annotations that are no longer set.
+func (bnc *BaseNetworkController) podBandwidthQoSOps(ops []ovsdb.Operation, pod *corev1.Pod, switchName,
+	portName string) ([]ovsdb.Operation, error) {
+	var err error
+	for _, rule := range podBandwidthRules {
+		dbIDs := getPodBandwidthQoSDbIDs(portName, rule.direction, bnc.controllerName)
+		bandwidth, found, parseErr := util.ParsePodBandwidthAnnotation(pod.Annotations, rule.annotation)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid %s annotation on pod %s/%s: %w", rule.annotation, pod.Namespace, pod.Name, parseErr)
+		}
+		if !found {
+			ops, err = bnc.deletePodBandwidthQoSOps(ops, switchName, dbIDs)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		// OVN expects the rate in kbps
+		rate := bandwidth / 1000
+		if rate > maxPodBandwidthQoSRate {
+			rate = maxPodBandwidthQoSRate
+			bnc.recordPodBandwidthClampedEvent(pod, rule.annotation, rate)
+		}
+		qos := &nbdb.QoS{
+			Direction:   rule.qosDirection,
+			Match:       fmt.Sprintf("%s == %q", rule.portField, portName),
+			Priority:    ovntypes.PodBandwidthQoSRulePriority,
+			Bandwidth:   map[string]int{nbdb.QoSBandwidthRate: int(rate)},
+			ExternalIDs: dbIDs.GetExternalIDs(),
+		}
+		ops, err = libovsdbops.CreateOrUpdateQoSesOps(bnc.nbClient, ops, qos)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create bandwidth QoS rule for port %s: %w", portName, err)
+		}
+		ops, err = libovsdbops.AddQoSesToLogicalSwitchOps(bnc.nbClient, ops, switchName, qos)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add bandwidth QoS rule for port %s to switch %s: %w", portName, switchName, err)
+		}
+	}
+	return ops, nil
+}
+
+// recordPodBandwidthClampedEvent warns that the bandwidth requested by the
+// annotation is more than an OVN QoS rule can enforce.
+func (bnc *BaseNetworkController) recordPodBandwidthClampedEvent(pod *corev1.Pod, annotation string, rate int64) {
+	podRef, err := ref.GetReference(scheme.Scheme, pod)
+	if err != nil {
+		klog.Errorf("Couldn't get a reference to pod %s/%s to post an event: '%v'",
+			pod.Namespace, pod.Name, err)
+		return
+	}
+	bnc.recorder.Eventf(podRef, corev1.EventTypeWarning, "PodBandwidthClamped",
+		"%s %s exceeds the maximum OVN QoS rate, limiting the bandwidth to %d kbps",
+		annotation, pod.Annotations[annotation], rate)
+}
+
+// deletePodBandwidthQoSOps returns the ops to delete the bandwidth QoS rules
+// matching the given dbIDs from the switch.
+func (bnc *BaseNetworkController) deletePodBandwidthQoSOps(ops []ovsdb.Operation, switchName string,
+	dbIDs *libovsdbops.DbObjectIDs) ([]ovsdb.Operation, error) {
+	qoses, err := libovsdbops.FindQoSesWithPredicate(bnc.nbClient, libovsdbops.GetPredicate[*nbdb.QoS](dbIDs, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bandwidth QoS rules: %w", err)
+	}
+	if len(qoses) == 0 {
+		return ops, nil
+	}
+	ops, err = libovsdbops.RemoveQoSesFromLogicalSwitchOps(bnc.nbClient, ops, switchName, qoses...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove bandwidth QoS rules from switch %s: %w", switchName, err)
+	}
+	return libovsdbops.DeleteQoSesOps(bnc.nbClient, ops, qoses...)
+}
+
+// deleteLogicalPortBandwidthQoSOps returns the ops to delete all the
+// bandwidth QoS rules of a logical switch port.
+func (bnc *BaseNetworkController) deleteLogicalPortBandwidthQoSOps(ops []ovsdb.Operation, switchName,
+	portName string) ([]ovsdb.Operation, error) {
+	dbIDs := libovsdbops.NewDbObjectIDs(libovsdbops.QoSPodBandwidth, bnc.controllerName,
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.ObjectNameKey: portName,
+		})
+	return bnc.deletePodBandwidthQoSOps(ops, switchName, dbIDs)
+}
+
+// podBandwidthAnnotationsChanged returns true when the pod bandwidth is
+// enforced with QoS rules and any of the bandwidth annotations changed.
+func podBandwidthAnnotationsChanged(oldPod, newPod *corev1.Pod) bool {
+	if !config.OVNKubernetesFeature.EnablePodBandwidthQoS || oldPod == nil || newPod == nil {
+		return false
+	}
+	for _, rule := range podBandwidthRules {
+		if oldPod.Annotations[rule.annotation] != newPod.Annotations[rule.annotation] {
+			return true
+		}
+	}
+	return false
+}
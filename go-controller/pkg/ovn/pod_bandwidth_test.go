@@ -0,0 +1,169 @@
+package ovn
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/libovsdb/client"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pod bandwidth QoS", func() {
+	const (
+		switchName = "node1"
+		portName   = "namespace1_pod1"
+	)
+
+	var (
+		bnc      *BaseNetworkController
+		nbClient client.Client
+		recorder *record.FakeRecorder
+	)
+
+	newPod := func(annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod1",
+				Namespace:   "namespace1",
+				Annotations: annotations,
+			},
+		}
+	}
+
+	bandwidthQoS := func(uuid string, direction libovsdbutil.ACLDirection, rate int) *nbdb.QoS {
+		qosDirection, portField := nbdb.QoSDirectionToLport, "outport"
+		if direction == libovsdbutil.ACLEgress {
+			qosDirection, portField = nbdb.QoSDirectionFromLport, "inport"
+		}
+		return &nbdb.QoS{
+			UUID:        uuid,
+			Direction:   qosDirection,
+			Match:       fmt.Sprintf("%s == %q", portField, portName),
+			Priority:    ovntypes.PodBandwidthQoSRulePriority,
+			Bandwidth:   map[string]int{nbdb.QoSBandwidthRate: rate},
+			ExternalIDs: getPodBandwidthQoSDbIDs(portName, direction, DefaultNetworkControllerName).GetExternalIDs(),
+		}
+	}
+
+	syncPod := func(pod *corev1.Pod) error {
+		ops, err := bnc.podBandwidthQoSOps(nil, pod, switchName, portName)
+		if err != nil {
+			return err
+		}
+		_, err = libovsdbops.TransactAndCheck(nbClient, ops)
+		return err
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnablePodBandwidthQoS = true
+
+		var cleanup *libovsdbtest.Context
+		var err error
+		nbClient, cleanup, err = libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{
+				&nbdb.LogicalSwitch{UUID: "switch-UUID", Name: switchName},
+			},
+		}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(cleanup.Cleanup)
+
+		recorder = record.NewFakeRecorder(10)
+		bnc = &BaseNetworkController{controllerName: DefaultNetworkControllerName}
+		bnc.nbClient = nbClient
+		bnc.recorder = recorder
+	})
+
+	It("limits the bandwidth of the pod's logical switch port", func() {
+		Expect(syncPod(newPod(map[string]string{
+			util.PodIngressBandwidthAnnotation: "10M",
+			util.PodEgressBandwidthAnnotation:  "1M",
+		}))).To(Succeed())
+
+		expectedData := []libovsdbtest.TestData{
+			bandwidthQoS("ingress-UUID", libovsdbutil.ACLIngress, 10000),
+			bandwidthQoS("egress-UUID", libovsdbutil.ACLEgress, 1000),
+			&nbdb.LogicalSwitch{UUID: "switch-UUID", Name: switchName, QOSRules: []string{"ingress-UUID", "egress-UUID"}},
+		}
+		Eventually(nbClient).Should(libovsdbtest.HaveData(expectedData))
+	})
+
+	It("updates and removes the limits when the annotations change", func() {
+		Expect(syncPod(newPod(map[string]string{
+			util.PodIngressBandwidthAnnotation: "10M",
+			util.PodEgressBandwidthAnnotation:  "1M",
+		}))).To(Succeed())
+		Expect(syncPod(newPod(map[string]string{
+			util.PodIngressBandwidthAnnotation: "20M",
+		}))).To(Succeed())
+
+		expectedData := []libovsdbtest.TestData{
+			bandwidthQoS("ingress-UUID", libovsdbutil.ACLIngress, 20000),
+			&nbdb.LogicalSwitch{UUID: "switch-UUID", Name: switchName, QOSRules: []string{"ingress-UUID"}},
+		}
+		Eventually(nbClient).Should(libovsdbtest.HaveData(expectedData))
+	})
+
+	It("clamps the bandwidth to the maximum OVN QoS rate", func() {
+		// 4294967295 kbps is the maximum rate
+		Expect(syncPod(newPod(map[string]string{
+			util.PodIngressBandwidthAnnotation: "4294967295k",
+			util.PodEgressBandwidthAnnotation:  "4294967296k",
+		}))).To(Succeed())
+
+		expectedData := []libovsdbtest.TestData{
+			bandwidthQoS("ingress-UUID", libovsdbutil.ACLIngress, 4294967295),
+			bandwidthQoS("egress-UUID", libovsdbutil.ACLEgress, 4294967295),
+			&nbdb.LogicalSwitch{UUID: "switch-UUID", Name: switchName, QOSRules: []string{"ingress-UUID", "egress-UUID"}},
+		}
+		Eventually(nbClient).Should(libovsdbtest.HaveData(expectedData))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring(util.PodEgressBandwidthAnnotation + " 4294967296k exceeds"))
+	})
+
+	It("fails on an invalid bandwidth annotation", func() {
+		Expect(syncPod(newPod(map[string]string{
+			util.PodEgressBandwidthAnnotation: "fast",
+		}))).NotTo(Succeed())
+	})
+
+	It("removes the limits with the logical switch port", func() {
+		Expect(syncPod(newPod(map[string]string{
+			util.PodIngressBandwidthAnnotation: "10M",
+			util.PodEgressBandwidthAnnotation:  "1M",
+		}))).To(Succeed())
+
+		ops, err := bnc.deleteLogicalPortBandwidthQoSOps(nil, switchName, portName)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = libovsdbops.TransactAndCheck(nbClient, ops)
+		Expect(err).NotTo(HaveOccurred())
+
+		expectedData := []libovsdbtest.TestData{
+			&nbdb.LogicalSwitch{UUID: "switch-UUID", Name: switchName},
+		}
+		Eventually(nbClient).Should(libovsdbtest.HaveData(expectedData))
+	})
+
+	It("detects bandwidth annotation changes only when the feature is enabled", func() {
+		oldPod := newPod(map[string]string{util.PodIngressBandwidthAnnotation: "10M"})
+		newPod := newPod(map[string]string{util.PodIngressBandwidthAnnotation: "20M"})
+		Expect(podBandwidthAnnotationsChanged(oldPod, newPod)).To(BeTrue())
+		Expect(podBandwidthAnnotationsChanged(oldPod, oldPod)).To(BeFalse())
+
+		config.OVNKubernetesFeature.EnablePodBandwidthQoS = false
+		Expect(podBandwidthAnnotationsChanged(oldPod, newPod)).To(BeFalse())
+	})
+})
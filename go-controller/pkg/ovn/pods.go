@@ -48,7 +48,7 @@ func (oc *DefaultNetworkController) syncPods(pods []interface{}) error {
 		expectedLogicalPortName := ""
 		var annotations *util.PodAnnotation
 		if kubevirt.IsPodLiveMigratable(pod) {
-			vms, expectedLogicalPortName, annotations, err = oc.allocateSyncMigratablePodIPsOnZone(vms, pod)
+			vms, expectedLogicalPortName, annotations, err = oc.allocateSyncMigratablePodIPsOnZone(vms, pod, types.DefaultNetworkName)
 			if err != nil {
 				return err
 			}
@@ -85,6 +85,7 @@ func (oc *DefaultNetworkController) syncPods(pods []interface{}) error {
 			allHostSubnets, zoneContainsPodSubnetOrUntracked, err = kubevirt.ZoneContainsPodSubnetOrUntracked(
 				oc.watchFactory,
 				oc.lsManager,
+				oc.GetNetInfo(),
 				allHostSubnets,
 				annotations)
 			if err != nil {
@@ -160,7 +161,7 @@ func (oc *DefaultNetworkController) syncPods(pods []interface{}) error {
 			}
 		}
 	}
-	if err := kubevirt.SyncVirtualMachines(oc.nbClient, vms); err != nil {
+	if err := kubevirt.SyncVirtualMachines(oc.nbClient, oc.GetNetInfo(), vms); err != nil {
 		return fmt.Errorf("failed syncing running virtual machines: %v", err)
 	}
 
@@ -350,7 +351,9 @@ func (oc *DefaultNetworkController) addLogicalPort(pod *corev1.Pod) (err error)
 	_ = oc.logicalPortCache.add(pod, switchName, types.DefaultNetworkName, lsp.UUID, podAnnotation.MAC, podAnnotation.IPs)
 
 	if kubevirt.IsPodLiveMigratable(pod) {
-		if err := kubevirt.EnsureDHCPOptionsForMigratablePod(oc.controllerName, oc.nbClient, oc.watchFactory, pod, podAnnotation.IPs, lsp); err != nil {
+		overrides := oc.getDHCPOptionsOverrides(pod, types.DefaultNetworkName)
+		if err := kubevirt.EnsureDHCPOptionsForMigratablePod(oc.controllerName, oc.nbClient, oc.watchFactory, pod,
+			podAnnotation.IPs, lsp, kubevirt.WithDHCPOptionsOverrides(overrides)); err != nil {
 			return err
 		}
 	}
@@ -373,26 +376,3 @@ func (oc *DefaultNetworkController) allocateSyncPodsIPs(pod *corev1.Pod) (string
 	}
 	return expectedLogicalPortName, annotations, nil
 }
-
-func (oc *DefaultNetworkController) allocateSyncMigratablePodIPsOnZone(vms map[ktypes.NamespacedName]bool, pod *corev1.Pod) (map[ktypes.NamespacedName]bool, string, *util.PodAnnotation, error) {
-	allocatePodIPsOnSwitchWrapFn := func(liveMigratablePod *corev1.Pod, liveMigratablePodAnnotation *util.PodAnnotation, switchName, nadName string) (string, error) {
-		return oc.allocatePodIPsOnSwitch(liveMigratablePod, liveMigratablePodAnnotation, switchName, nadName)
-	}
-	vmKey, expectedLogicalPortName, podAnnotation, err := kubevirt.AllocateSyncMigratablePodIPsOnZone(oc.watchFactory, oc.lsManager, oc.GetNetworkName(), pod, allocatePodIPsOnSwitchWrapFn)
-	if err != nil {
-		return nil, "", nil, err
-	}
-
-	// If there is a vmKey this VM is not stale so it should be in sync
-	if vmKey != nil {
-		vms[*vmKey] = oc.isPodScheduledinLocalZone(pod)
-	}
-
-	// For remote pods we the logical switch port is not present so
-	// empty expectedLogicalPortName is returned
-	if _, ok := oc.localZoneNodes.Load(pod.Spec.NodeName); !ok {
-		expectedLogicalPortName = ""
-	}
-
-	return vms, expectedLogicalPortName, podAnnotation, nil
-}
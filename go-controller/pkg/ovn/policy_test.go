@@ -105,6 +105,9 @@ func getDefaultDenyDataHelper(policyTypeIngress, policyTypeEgress bool, params *
 		types.DefaultACLTier,
 	)
 	egressDenyACL.UUID = aclIDs.String() + "-UUID"
+	if params.audit {
+		libovsdbutil.SetACLAudit(egressDenyACL, nbdb.ACLActionAllow, &libovsdbutil.ACLLoggingLevels{Deny: denyLogSeverity})
+	}
 
 	aclIDs = fakeController.getDefaultDenyPolicyACLIDs(namespace, libovsdbutil.ACLEgress, arpAllowACL)
 	egressAllowACL := libovsdbops.BuildACL(
@@ -140,6 +143,9 @@ func getDefaultDenyDataHelper(policyTypeIngress, policyTypeEgress bool, params *
 		types.DefaultACLTier,
 	)
 	ingressDenyACL.UUID = aclIDs.String() + "-UUID"
+	if params.audit {
+		libovsdbutil.SetACLAudit(ingressDenyACL, nbdb.ACLActionAllow, &libovsdbutil.ACLLoggingLevels{Deny: denyLogSeverity})
+	}
 
 	aclIDs = fakeController.getDefaultDenyPolicyACLIDs(namespace, libovsdbutil.ACLIngress, arpAllowACL)
 	ingressAllowACL := libovsdbops.BuildACL(
@@ -217,8 +223,18 @@ func getMultinetNsAddrSetHashNames(ns, controllerName string) (string, string) {
 
 // getGressACLs can only handle tcpPeerPorts for policies built with getPortNetworkPolicy, i.e. peer should only
 // have `Ports` filled, but no `To`/`From`.
+// Stateless policies that opted in also get the ACLs of the reply traffic.
 func getGressACLs(gressIdx int, peers []knet.NetworkPolicyPeer, policyType knet.PolicyType,
 	params *netpolDataParams) []*nbdb.ACL {
+	acls := getGressACLsHelper(gressIdx, peers, policyType, params, false)
+	if params.statelessNetPol && params.statelessReply {
+		acls = append(acls, getGressACLsHelper(gressIdx, peers, policyType, params, true)...)
+	}
+	return acls
+}
+
+func getGressACLsHelper(gressIdx int, peers []knet.NetworkPolicyPeer, policyType knet.PolicyType,
+	params *netpolDataParams, reply bool) []*nbdb.ACL {
 	namespace := params.networkPolicy.Namespace
 	fakeController := getFakeBaseController(params.netInfo)
 	pgName := fakeController.getNetworkPolicyPGName(namespace, params.networkPolicy.Name)
@@ -228,8 +244,14 @@ func getGressACLs(gressIdx int, peers []knet.NetworkPolicyPeer, policyType knet.
 	var direction string
 	var portDir string
 	var ipDir string
+	l4PortDir := "dst"
+	protocolSuffix := ""
+	action := nbdb.ACLActionAllowRelated
+	if params.statelessNetPol {
+		action = nbdb.ACLActionAllowStateless
+	}
 	acls := []*nbdb.ACL{}
-	if policyType == knet.PolicyTypeEgress {
+	if (policyType == knet.PolicyTypeEgress) != reply {
 		options = map[string]string{
 			"apply-after-lb": "true",
 		}
@@ -241,6 +263,12 @@ func getGressACLs(gressIdx int, peers []knet.NetworkPolicyPeer, policyType knet.
 		portDir = "outport"
 		ipDir = "src"
 	}
+	if reply {
+		// the reply traffic is matched like the traffic of a rule of the other policy type,
+		// but is sent from the rule ports
+		l4PortDir = "src"
+		protocolSuffix = libovsdbutil.ReplyL4MatchSuffix
+	}
 	hashedASNames := []string{}
 	for _, nsName := range params.peerNamespaces {
 		hashedASName, _ := getMultinetNsAddrSetHashNames(nsName, controllerName)
@@ -270,14 +298,14 @@ func getGressACLs(gressIdx int, peers []knet.NetworkPolicyPeer, policyType knet.
 		idx:             gressIdx,
 		controllerName:  controllerName,
 	}
+	if params.sharedRules {
+		gp.sharedRuleKey = getTestSharedGressRuleKey(gressIdx, policyType, params)
+		pgName = libovsdbutil.GetPortGroupName(fakeController.getSharedGressRulePortGroupDbIDs(gp.sharedRuleKey))
+	}
 	if len(hashedASNames) > 0 {
 		gressAsMatch := asMatch(hashedASNames)
 		match := fmt.Sprintf("ip4.%s == {%s} && %s == @%s", ipDir, gressAsMatch, portDir, pgName)
-		action := nbdb.ACLActionAllowRelated
-		if params.statelessNetPol {
-			action = nbdb.ACLActionAllowStateless
-		}
-		dbIDs := gp.getNetpolACLDbIDs(emptyIdx, libovsdbutil.UnspecifiedL4Protocol)
+		dbIDs := gp.getNetpolACLDbIDs(emptyIdx, libovsdbutil.UnspecifiedL4Protocol+protocolSuffix)
 		acl := libovsdbops.BuildACL(
 			libovsdbutil.GetACLName(dbIDs),
 			direction,
@@ -296,13 +324,13 @@ func getGressACLs(gressIdx int, peers []knet.NetworkPolicyPeer, policyType knet.
 	}
 	for i, ipBlock := range ipBlocks {
 		match := fmt.Sprintf("ip4.%s == %s && %s == @%s", ipDir, ipBlock, portDir, pgName)
-		dbIDs := gp.getNetpolACLDbIDs(i, libovsdbutil.UnspecifiedL4Protocol)
+		dbIDs := gp.getNetpolACLDbIDs(i, libovsdbutil.UnspecifiedL4Protocol+protocolSuffix)
 		acl := libovsdbops.BuildACL(
 			libovsdbutil.GetACLName(dbIDs),
 			direction,
 			types.DefaultAllowPriority,
 			match,
-			nbdb.ACLActionAllowRelated,
+			action,
 			types.OvnACLLoggingMeter,
 			params.allowLogSeverity,
 			shouldBeLogged,
@@ -314,13 +342,13 @@ func getGressACLs(gressIdx int, peers []knet.NetworkPolicyPeer, policyType knet.
 		acls = append(acls, acl)
 	}
 	for _, v := range params.tcpPeerPorts {
-		dbIDs := gp.getNetpolACLDbIDs(emptyIdx, "tcp")
+		dbIDs := gp.getNetpolACLDbIDs(emptyIdx, "tcp"+protocolSuffix)
 		acl := libovsdbops.BuildACL(
 			libovsdbutil.GetACLName(dbIDs),
 			direction,
 			types.DefaultAllowPriority,
-			fmt.Sprintf("ip4 && tcp && tcp.dst==%d && %s == @%s", v, portDir, pgName),
-			nbdb.ACLActionAllowRelated,
+			fmt.Sprintf("ip4 && tcp && tcp.%s==%d && %s == @%s", l4PortDir, v, portDir, pgName),
+			action,
 			types.OvnACLLoggingMeter,
 			params.allowLogSeverity,
 			shouldBeLogged,
@@ -342,17 +370,62 @@ type netpolDataParams struct {
 	allowLogSeverity nbdb.ACLSeverity
 	denyLogSeverity  nbdb.ACLSeverity
 	statelessNetPol  bool
+	statelessReply   bool
+	audit            bool
+	sharedRules      bool
 	netInfo          util.NetInfo
 }
 
-func getPolicyData(params *netpolDataParams) []libovsdbtest.TestData {
-	acls := []*nbdb.ACL{}
+func getTestSharedGressRuleKey(gressIdx int, policyType knet.PolicyType, params *netpolDataParams) string {
+	var spec string
+	if policyType == knet.PolicyTypeIngress {
+		rule := params.networkPolicy.Spec.Ingress[gressIdx]
+		spec = getSharedGressRuleSpec(params.networkPolicy, policyType, rule.Ports, rule.From)
+	} else {
+		rule := params.networkPolicy.Spec.Egress[gressIdx]
+		spec = getSharedGressRuleSpec(params.networkPolicy, policyType, rule.Ports, rule.To)
+	}
+	return getSharedGressRuleKey(spec, &libovsdbutil.ACLLoggingLevels{Allow: params.allowLogSeverity})
+}
 
+// getSharedRulesData returns the ACLs and port groups of the shared gress rules of the given policy,
+// with the given ports of all the policies sharing these rules.
+func getSharedRulesData(params *netpolDataParams, sharedPortUUIDs ...string) []libovsdbtest.TestData {
+	fakeController := getFakeBaseController(params.netInfo)
+	lsps := []*nbdb.LogicalSwitchPort{}
+	for _, uuid := range sharedPortUUIDs {
+		lsps = append(lsps, &nbdb.LogicalSwitchPort{UUID: uuid})
+	}
+	data := []libovsdbtest.TestData{}
+	addRule := func(gressIdx int, policyType knet.PolicyType, acls []*nbdb.ACL) {
+		key := getTestSharedGressRuleKey(gressIdx, policyType, params)
+		pg := libovsdbutil.BuildPortGroup(fakeController.getSharedGressRulePortGroupDbIDs(key), lsps, acls)
+		pg.UUID = pg.Name + "-UUID"
+		for _, acl := range acls {
+			data = append(data, acl)
+		}
+		data = append(data, pg)
+	}
 	for i, ingress := range params.networkPolicy.Spec.Ingress {
-		acls = append(acls, getGressACLs(i, ingress.From, knet.PolicyTypeIngress, params)...)
+		addRule(i, knet.PolicyTypeIngress, getGressACLs(i, ingress.From, knet.PolicyTypeIngress, params))
 	}
 	for i, egress := range params.networkPolicy.Spec.Egress {
-		acls = append(acls, getGressACLs(i, egress.To, knet.PolicyTypeEgress, params)...)
+		addRule(i, knet.PolicyTypeEgress, getGressACLs(i, egress.To, knet.PolicyTypeEgress, params))
+	}
+	return data
+}
+
+func getPolicyData(params *netpolDataParams) []libovsdbtest.TestData {
+	acls := []*nbdb.ACL{}
+
+	// with shared rules, gress rules ACLs are applied to the shared rule port groups, see getSharedRulesData
+	if !params.sharedRules {
+		for i, ingress := range params.networkPolicy.Spec.Ingress {
+			acls = append(acls, getGressACLs(i, ingress.From, knet.PolicyTypeIngress, params)...)
+		}
+		for i, egress := range params.networkPolicy.Spec.Egress {
+			acls = append(acls, getGressACLs(i, egress.To, knet.PolicyTypeEgress, params)...)
+		}
 	}
 
 	lsps := []*nbdb.LogicalSwitchPort{}
@@ -415,11 +488,26 @@ func (p *netpolDataParams) withDenyLogSeverity(denyLogSeverity nbdb.ACLSeverity)
 	return p
 }
 
+func (p *netpolDataParams) withAudit(audit bool) *netpolDataParams {
+	p.audit = audit
+	return p
+}
+
+func (p *netpolDataParams) withSharedRules(sharedRules bool) *netpolDataParams {
+	p.sharedRules = sharedRules
+	return p
+}
+
 func (p *netpolDataParams) withStateless(statelessNetPol bool) *netpolDataParams {
 	p.statelessNetPol = statelessNetPol
 	return p
 }
 
+func (p *netpolDataParams) withStatelessReply(statelessReply bool) *netpolDataParams {
+	p.statelessReply = statelessReply
+	return p
+}
+
 func (p *netpolDataParams) withNetInfo(netInfo util.NetInfo) *netpolDataParams {
 	p.netInfo = netInfo
 	return p
@@ -1922,6 +2010,126 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations", func() {
 		})
 	})
 
+	ginkgo.Context("with network policy rule sharing", func() {
+		const namespaceName3 = "monitoring"
+
+		ginkgo.BeforeEach(func() {
+			config.OVNKubernetesFeature.EnableNetworkPolicyRuleSharing = true
+		})
+
+		ginkgo.It("implements identical rules of policies in different namespaces with shared ACLs", func() {
+			app.Action = func(*cli.Context) error {
+				namespace1 := *newNamespace(namespaceName1)
+				namespace2 := *newNamespace(namespaceName2)
+				peerNamespace := *newNamespace(namespaceName3)
+				nPodTest1 := getTestPod(namespace1.Name, nodeName)
+				nPodTest2 := newTPod(nodeName, "10.128.1.0/24", "10.128.1.2", "10.128.1.1", "myPod2",
+					"10.128.1.4", "0a:58:0a:80:01:04", namespace2.Name)
+				// the same templated policy allowing traffic from the peer namespace in both namespaces
+				networkPolicy1 := getMatchLabelsNetworkPolicy(netPolicyName1, namespace1.Name,
+					peerNamespace.Name, "", true, true)
+				networkPolicy2 := getMatchLabelsNetworkPolicy(netPolicyName1, namespace2.Name,
+					peerNamespace.Name, "", true, true)
+				startOvn(initialDB, []corev1.Namespace{namespace1, namespace2, peerNamespace},
+					[]knet.NetworkPolicy{*networkPolicy1, *networkPolicy2},
+					[]testPod{nPodTest1}, nil)
+				// add the second pod after startup so that pod IPs are allocated in a stable order
+				_, err := fakeOvn.fakeClient.KubeClient.CoreV1().Pods(nPodTest2.namespace).Create(context.TODO(),
+					newPod(nPodTest2.namespace, nPodTest2.podName, nPodTest2.nodeName, nPodTest2.podIP),
+					metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				dataParams1 := newNetpolDataParams(networkPolicy1).withPeerNamespaces(peerNamespace.Name).
+					withLocalPortUUIDs(nPodTest1.portUUID).withSharedRules(true)
+				dataParams2 := newNetpolDataParams(networkPolicy2).withPeerNamespaces(peerNamespace.Name).
+					withLocalPortUUIDs(nPodTest2.portUUID).withSharedRules(true)
+				namespace1AddressSetv4, _ := buildNamespaceAddressSets(namespace1.Name, []string{nPodTest1.podIP})
+				namespace2AddressSetv4, _ := buildNamespaceAddressSets(namespace2.Name, []string{nPodTest2.podIP})
+				peerNamespaceAddressSetv4, _ := buildNamespaceAddressSets(peerNamespace.Name, nil)
+				initialData := getUpdatedInitialDB([]testPod{nPodTest1, nPodTest2})
+				initialData = append(initialData, namespace1AddressSetv4, namespace2AddressSetv4, peerNamespaceAddressSetv4)
+
+				ginkgo.By("applying a single copy of the rules to the pods of both policies")
+				expectedData := append([]libovsdbtest.TestData{}, initialData...)
+				expectedData = append(expectedData, getPolicyData(dataParams1)...)
+				expectedData = append(expectedData, getDefaultDenyData(dataParams1)...)
+				expectedData = append(expectedData, getPolicyData(dataParams2)...)
+				expectedData = append(expectedData, getDefaultDenyData(dataParams2)...)
+				expectedData = append(expectedData, getSharedRulesData(dataParams1, nPodTest1.portUUID, nPodTest2.portUUID)...)
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedData...))
+
+				ginkgo.By("keeping the shared rules for the remaining policy")
+				err = fakeOvn.fakeClient.KubeClient.NetworkingV1().NetworkPolicies(networkPolicy1.Namespace).
+					Delete(context.TODO(), networkPolicy1.Name, metav1.DeleteOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				expectedData = append([]libovsdbtest.TestData{}, initialData...)
+				expectedData = append(expectedData, getPolicyData(dataParams2)...)
+				expectedData = append(expectedData, getDefaultDenyData(dataParams2)...)
+				expectedData = append(expectedData, getSharedRulesData(dataParams2, nPodTest2.portUUID)...)
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedData...))
+
+				ginkgo.By("deleting the shared rules with the last policy")
+				err = fakeOvn.fakeClient.KubeClient.NetworkingV1().NetworkPolicies(networkPolicy2.Namespace).
+					Delete(context.TODO(), networkPolicy2.Name, metav1.DeleteOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(initialData...))
+				return nil
+			}
+			gomega.Expect(app.Run([]string{app.Name})).To(gomega.Succeed())
+		})
+
+		ginkgo.It("moves the policy to the shared rules with the new allow log level", func() {
+			app.Action = func(*cli.Context) error {
+				namespace1 := *newNamespace(namespaceName1)
+				peerNamespace := *newNamespace(namespaceName3)
+				nPodTest := getTestPod(namespace1.Name, nodeName)
+				networkPolicy := getMatchLabelsNetworkPolicy(netPolicyName1, namespace1.Name,
+					peerNamespace.Name, "", true, true)
+				startOvn(initialDB, []corev1.Namespace{namespace1, peerNamespace}, []knet.NetworkPolicy{*networkPolicy},
+					[]testPod{nPodTest}, nil)
+
+				namespace1AddressSetv4, _ := buildNamespaceAddressSets(namespace1.Name, []string{nPodTest.podIP})
+				peerNamespaceAddressSetv4, _ := buildNamespaceAddressSets(peerNamespace.Name, nil)
+				initialData := getUpdatedInitialDB([]testPod{nPodTest})
+				initialData = append(initialData, namespace1AddressSetv4, peerNamespaceAddressSetv4)
+				getExpectedData := func(allowLogSeverity nbdb.ACLSeverity) []libovsdbtest.TestData {
+					dataParams := newNetpolDataParams(networkPolicy).withPeerNamespaces(peerNamespace.Name).
+						withLocalPortUUIDs(nPodTest.portUUID).withSharedRules(true).
+						withAllowLogSeverity(allowLogSeverity)
+					expectedData := append([]libovsdbtest.TestData{}, initialData...)
+					expectedData = append(expectedData, getPolicyData(dataParams)...)
+					expectedData = append(expectedData, getDefaultDenyData(dataParams)...)
+					return append(expectedData, getSharedRulesData(dataParams, nPodTest.portUUID)...)
+				}
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(getExpectedData("")...))
+
+				namespace1.Annotations = map[string]string{
+					util.AclLoggingAnnotation: fmt.Sprintf(`{ "allow": "%s" }`, nbdb.ACLSeverityNotice),
+				}
+				_, err := fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), &namespace1, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(getExpectedData(nbdb.ACLSeverityNotice)...))
+				return nil
+			}
+			gomega.Expect(app.Run([]string{app.Name})).To(gomega.Succeed())
+		})
+
+		ginkgo.It("deletes stale shared rule port groups on startup", func() {
+			app.Action = func(*cli.Context) error {
+				fakeController := getFakeController(DefaultNetworkControllerName)
+				staleSharedRulePG := libovsdbutil.BuildPortGroup(fakeController.getSharedGressRulePortGroupDbIDs("stale"), nil, nil)
+				staleSharedRulePG.UUID = staleSharedRulePG.Name + "-UUID"
+				expectedData := append([]libovsdbtest.TestData{}, initialDB.NBData...)
+				initialDB.NBData = append(initialDB.NBData, staleSharedRulePG)
+
+				startOvn(initialDB, nil, nil, nil, nil)
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedData...))
+				return nil
+			}
+			gomega.Expect(app.Run([]string{app.Name})).To(gomega.Succeed())
+		})
+	})
+
 	ginkgo.Context("ACL logging for network policies", func() {
 
 		var originalNamespace corev1.Namespace
@@ -2042,6 +2250,36 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations", func() {
 			gomega.Expect(app.Run([]string{app.Name})).To(gomega.Succeed())
 		})
 
+		ginkgo.It("switches default deny ACLs between audit and enforcing mode with the namespace annotation", func() {
+			app.Action = func(*cli.Context) error {
+				originalNamespace.Annotations[util.NetworkPolicyAuditAnnotation] = "true"
+				networkPolicy := getMatchLabelsNetworkPolicy(netPolicyName1, namespaceName1, namespaceName2, "", true, true)
+				startOvn(initialDB, []corev1.Namespace{originalNamespace}, []knet.NetworkPolicy{*networkPolicy}, nil, nil)
+
+				namespace1AddressSetv4, _ := buildNamespaceAddressSets(namespaceName1, nil)
+				getExpectedData := func(audit bool) []libovsdbtest.TestData {
+					expectedData := append([]libovsdbtest.TestData{}, initialDB.NBData...)
+					expectedData = append(expectedData, buildNetworkPolicyAddressSets(networkPolicy)...)
+					expectedData = append(expectedData, getPolicyData(newNetpolDataParams(networkPolicy).
+						withAllowLogSeverity(nbdb.ACLSeverityNotice))...)
+					expectedData = append(expectedData, getDefaultDenyData(newNetpolDataParams(networkPolicy).
+						withDenyLogSeverity(nbdb.ACLSeverityAlert).
+						withAudit(audit))...)
+					return append(expectedData, namespace1AddressSetv4)
+				}
+				ginkgo.By("letting through and logging the traffic the default deny ACLs would drop")
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(getExpectedData(true)...))
+
+				ginkgo.By("enforcing the default deny ACLs once the annotation is removed")
+				delete(originalNamespace.Annotations, util.NetworkPolicyAuditAnnotation)
+				_, err := fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), &originalNamespace, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(getExpectedData(false)...))
+				return nil
+			}
+			gomega.Expect(app.Run([]string{app.Name})).To(gomega.Succeed())
+		})
+
 		ginkgo.It("creates stateless OVN ACLs based off of the annotation", func() {
 			app.Action = func(*cli.Context) error {
 				namespace1 := *newNamespace(namespaceName1)
@@ -2050,6 +2288,7 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations", func() {
 				networkPolicy.Annotations = map[string]string{
 					ovnStatelessNetPolAnnotationName: "true",
 				}
+				config.OVNKubernetesFeature.EnableStatelessNetPol = true
 				startOvn(initialDB, []corev1.Namespace{namespace1}, []knet.NetworkPolicy{*networkPolicy},
 					[]testPod{nPodTest}, map[string]string{labelName: labelVal})
 
@@ -2386,4 +2625,55 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Low-Level Operations", func() {
 		// deleting again is no-op
 		gomega.Expect(gp.delNamespaceAddressSet(four.GetObjectID(libovsdbops.ObjectNameKey))).To(gomega.BeFalse())
 	})
+
+	ginkgo.It("builds reply ACLs of both IP families for stateless policies that opted in", func() {
+		const (
+			pgName         string = "pg-name"
+			controllerName        = DefaultNetworkControllerName
+		)
+		gomega.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+		udp := corev1.ProtocolUDP
+		gp := newGressPolicy(knet.PolicyTypeIngress, 0, "testing", "policy", controllerName,
+			true, &util.DefaultNetInfo{})
+		gp.hasPeerSelector = true
+		gp.addPeerAddressSets("as-v4", "as-v6")
+		gp.addPortPolicy(&knet.NetworkPolicyPort{Protocol: &udp, Port: &intstr.IntOrString{IntVal: 53}})
+		gp.addIPBlock(&knet.IPBlock{CIDR: "fd00::/64"})
+		gp.allowStatelessReply = true
+		aclLogging := &libovsdbutil.ACLLoggingLevels{}
+
+		ingressPipeline := libovsdbutil.PolicyTypeToAclPipeline(knet.PolicyTypeIngress)
+		egressPipeline := libovsdbutil.PolicyTypeToAclPipeline(knet.PolicyTypeEgress)
+		replyProtocol := "udp" + libovsdbutil.ReplyL4MatchSuffix
+		expected := []libovsdbtest.TestData{
+			libovsdbutil.BuildACL(gp.getNetpolACLDbIDs(0, "udp"), types.DefaultAllowPriority,
+				"ip6.src == fd00::/64 && udp && udp.dst==53 && outport == @"+pgName,
+				nbdb.ACLActionAllowStateless, aclLogging, ingressPipeline),
+			libovsdbutil.BuildACL(gp.getNetpolACLDbIDs(emptyIdx, "udp"), types.DefaultAllowPriority,
+				"(ip4.src == {$as-v4} || ip6.src == {$as-v6}) && udp && udp.dst==53 && outport == @"+pgName,
+				nbdb.ACLActionAllowStateless, aclLogging, ingressPipeline),
+			libovsdbutil.BuildACL(gp.getNetpolACLDbIDs(0, replyProtocol), types.DefaultAllowPriority,
+				"ip6.dst == fd00::/64 && udp && udp.src==53 && inport == @"+pgName,
+				nbdb.ACLActionAllowStateless, aclLogging, egressPipeline),
+			libovsdbutil.BuildACL(gp.getNetpolACLDbIDs(emptyIdx, replyProtocol), types.DefaultAllowPriority,
+				"(ip4.dst == {$as-v4} || ip6.dst == {$as-v6}) && udp && udp.src==53 && inport == @"+pgName,
+				nbdb.ACLActionAllowStateless, aclLogging, egressPipeline),
+		}
+		actual, skipped := gp.buildLocalPodACLs(pgName, aclLogging)
+		gomega.Expect(skipped).To(gomega.BeEmpty())
+		gomega.Expect(actual).To(libovsdbtest.ConsistOfIgnoringUUIDs(expected...))
+
+		ginkgo.By("not building reply ACLs for stateless policies that didn't opt in")
+		gp.allowStatelessReply = false
+		actual, _ = gp.buildLocalPodACLs(pgName, aclLogging)
+		gomega.Expect(actual).To(gomega.HaveLen(2))
+
+		ginkgo.By("not building reply ACLs for stateful policies")
+		gp.allowStatelessReply = true
+		gp.isNetPolStateless = false
+		actual, _ = gp.buildLocalPodACLs(pgName, aclLogging)
+		gomega.Expect(actual).To(gomega.HaveLen(2))
+	})
 })
@@ -0,0 +1,73 @@
+package policysim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+
+	"k8s.io/klog/v2"
+)
+
+// DebugPath is the path of the policy simulation endpoint, served by the ovnkube-controller metrics server
+const DebugPath = "/debug/ovnkube/policy-simulation"
+
+// NewHandler returns the HTTP handler simulating the flow given by the query parameters
+// srcPod, srcIP, dstPod, dstIP, protocol, srcPort, dstPort and network against the current NB state.
+// The result is returned as JSON.
+func NewHandler(nbClient libovsdbclient.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "unsupported http method", http.StatusMethodNotAllowed)
+			return
+		}
+		flow, err := flowFromQuery(req.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		snapshot, err := GetSnapshot(nbClient)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		result, err := snapshot.Simulate(flow)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(result); err != nil {
+			klog.Errorf("Failed to write policy simulation result: %v", err)
+		}
+	})
+}
+
+func flowFromQuery(query url.Values) (*Flow, error) {
+	flow := &Flow{
+		SrcPod:   query.Get("srcPod"),
+		SrcIP:    query.Get("srcIP"),
+		DstPod:   query.Get("dstPod"),
+		DstIP:    query.Get("dstIP"),
+		Protocol: query.Get("protocol"),
+		Network:  query.Get("network"),
+	}
+	if flow.Protocol == "" {
+		flow.Protocol = ProtocolTCP
+	}
+	for param, port := range map[string]*int{"srcPort": &flow.SrcPort, "dstPort": &flow.DstPort} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", param, value)
+		}
+		*port = int(number)
+	}
+	return flow, nil
+}
@@ -0,0 +1,456 @@
+package policysim
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// This file implements the subset of the OVN match language (see ovn-sb(5), Logical_Flow match)
+// used by the ACLs ovn-kubernetes creates, evaluated against a single simulated packet.
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenLParen
+	tokenRParen
+	tokenLBrace
+	tokenRBrace
+	tokenComma
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenRelOp
+	tokenWord
+	tokenString
+)
+
+type token struct {
+	typ   tokenType
+	value string
+}
+
+// wordDelimiters end a word token, on top of white spaces
+const wordDelimiters = `(){},&|!<>="`
+
+func tokenize(match string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(match); {
+		c := match[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")"})
+			i++
+		case c == '{':
+			tokens = append(tokens, token{tokenLBrace, "{"})
+			i++
+		case c == '}':
+			tokens = append(tokens, token{tokenRBrace, "}"})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{tokenComma, ","})
+			i++
+		case strings.HasPrefix(match[i:], "&&"):
+			tokens = append(tokens, token{tokenAnd, "&&"})
+			i += 2
+		case strings.HasPrefix(match[i:], "||"):
+			tokens = append(tokens, token{tokenOr, "||"})
+			i += 2
+		case strings.HasPrefix(match[i:], "=="), strings.HasPrefix(match[i:], "!="),
+			strings.HasPrefix(match[i:], "<="), strings.HasPrefix(match[i:], ">="):
+			tokens = append(tokens, token{tokenRelOp, match[i : i+2]})
+			i += 2
+		case c == '<', c == '>':
+			tokens = append(tokens, token{tokenRelOp, match[i : i+1]})
+			i++
+		case c == '!':
+			tokens = append(tokens, token{tokenNot, "!"})
+			i++
+		case c == '"':
+			end := i + 1
+			var value strings.Builder
+			for ; end < len(match) && match[end] != '"'; end++ {
+				if match[end] == '\\' && end+1 < len(match) {
+					end++
+				}
+				value.WriteByte(match[end])
+			}
+			if end == len(match) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, token{tokenString, value.String()})
+			i = end + 1
+		default:
+			end := i
+			for end < len(match) && !unicode.IsSpace(rune(match[end])) && !strings.ContainsRune(wordDelimiters, rune(match[end])) {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{tokenWord, match[i:end]})
+			i = end
+		}
+	}
+	return append(tokens, token{typ: tokenEOF}), nil
+}
+
+// matchExpr is a parsed ACL match expression
+type matchExpr interface {
+	eval(p *packet, s *Snapshot) (bool, error)
+}
+
+type andExpr struct {
+	left, right matchExpr
+}
+
+func (e *andExpr) eval(p *packet, s *Snapshot) (bool, error) {
+	left, err := e.left.eval(p, s)
+	if err != nil || !left {
+		return false, err
+	}
+	return e.right.eval(p, s)
+}
+
+type orExpr struct {
+	left, right matchExpr
+}
+
+func (e *orExpr) eval(p *packet, s *Snapshot) (bool, error) {
+	left, err := e.left.eval(p, s)
+	if err != nil || left {
+		return left, err
+	}
+	return e.right.eval(p, s)
+}
+
+type notExpr struct {
+	expr matchExpr
+}
+
+func (e *notExpr) eval(p *packet, s *Snapshot) (bool, error) {
+	result, err := e.expr.eval(p, s)
+	return !result, err
+}
+
+// symbolExpr is a boolean field such as tcp or ip4, or a boolean constant
+type symbolExpr struct {
+	symbol string
+}
+
+func (e *symbolExpr) eval(p *packet, _ *Snapshot) (bool, error) {
+	switch e.symbol {
+	case "1":
+		return true, nil
+	case "0":
+		return false, nil
+	case "ip":
+		return true, nil
+	case "ip4":
+		return p.isIPv4(), nil
+	case "ip6":
+		return !p.isIPv4(), nil
+	case "tcp", "udp", "sctp":
+		return p.protocol == e.symbol, nil
+	case "icmp":
+		return p.protocol == ProtocolICMP, nil
+	case "icmp4":
+		return p.isIPv4() && p.protocol == ProtocolICMP, nil
+	case "icmp6":
+		return !p.isIPv4() && p.protocol == ProtocolICMP, nil
+	case "ip4.mcast":
+		return p.isIPv4() && p.dstIP.IsMulticast(), nil
+	case "ip6.mcast":
+		return !p.isIPv4() && p.dstIP.IsMulticast(), nil
+	case "eth.mcast":
+		return p.dstIP.IsMulticast(), nil
+	case "eth.bcast", "arp", "rarp", "nd", "nd_ns", "nd_na", "nd_rs", "nd_ra", "igmp", "mldv1", "mldv2":
+		return false, nil
+	case "ct.trk", "ct.new":
+		// the simulated packet is the first packet of a new connection
+		return true, nil
+	case "ct.est", "ct.rel", "ct.rpl", "ct.inv":
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported symbol %s", e.symbol)
+}
+
+// relationExpr compares a field with one or more constants, e.g. ip4.src == {$as, 10.0.0.1}
+type relationExpr struct {
+	field  string
+	op     string
+	values []string
+}
+
+func (e *relationExpr) eval(p *packet, s *Snapshot) (bool, error) {
+	switch e.field {
+	case "inport", "outport":
+		port := p.inport
+		if e.field == "outport" {
+			port = p.outport
+		}
+		return e.compare(func(value string) (bool, error) {
+			if strings.HasPrefix(value, "@") {
+				return s.portGroupHasPort(value[1:], port), nil
+			}
+			return value == port, nil
+		})
+	case "ip4.src", "ip4.dst", "ip6.src", "ip6.dst":
+		// the prerequisite of the field is its IP family
+		if strings.HasPrefix(e.field, "ip4") != p.isIPv4() {
+			return false, nil
+		}
+		ip := p.srcIP
+		if strings.HasSuffix(e.field, ".dst") {
+			ip = p.dstIP
+		}
+		return e.compare(func(value string) (bool, error) {
+			if strings.HasPrefix(value, "$") {
+				return s.addressSetHasIP(value[1:], ip), nil
+			}
+			return ipMatches(value, ip)
+		})
+	case "tcp.src", "tcp.dst", "udp.src", "udp.dst", "sctp.src", "sctp.dst":
+		protocol, direction, _ := strings.Cut(e.field, ".")
+		if protocol != p.protocol {
+			return false, nil
+		}
+		port := p.dstPort
+		if direction == "src" {
+			port = p.srcPort
+		}
+		return e.compare(func(value string) (bool, error) {
+			number, err := strconv.ParseInt(value, 0, 32)
+			if err != nil {
+				return false, fmt.Errorf("invalid port %s of field %s", value, e.field)
+			}
+			return compareInts(port, e.op, int(number)), nil
+		})
+	}
+	return false, fmt.Errorf("unsupported field %s", e.field)
+}
+
+// compare returns if the relation holds: any of the values is equal to the field for ==, none of them for !=.
+// Ordering operators are only allowed with a single value, that equals must evaluate with the operator.
+func (e *relationExpr) compare(equals func(value string) (bool, error)) (bool, error) {
+	switch e.op {
+	case "==", "!=":
+		for _, value := range e.values {
+			equal, err := equals(value)
+			if err != nil {
+				return false, err
+			}
+			if equal {
+				return e.op == "==", nil
+			}
+		}
+		return e.op == "!=", nil
+	default:
+		if len(e.values) != 1 {
+			return false, fmt.Errorf("operator %s of field %s used with a set", e.op, e.field)
+		}
+		return equals(e.values[0])
+	}
+}
+
+func compareInts(field int, op string, value int) bool {
+	switch op {
+	case "==":
+		return field == value
+	case "!=":
+		return field != value
+	case "<":
+		return field < value
+	case "<=":
+		return field <= value
+	case ">":
+		return field > value
+	case ">=":
+		return field >= value
+	}
+	return false
+}
+
+// ipMatches returns if ip is the given IP or belongs to the given CIDR
+func ipMatches(value string, ip net.IP) (bool, error) {
+	if strings.Contains(value, "/") {
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid CIDR %s", value)
+		}
+		return ipNet.Contains(ip), nil
+	}
+	valueIP := net.ParseIP(value)
+	if valueIP == nil {
+		return false, fmt.Errorf("invalid IP %s", value)
+	}
+	return valueIP.Equal(ip), nil
+}
+
+// reverseOp returns the operator of the relation with swapped operands, e.g. 1 <= tcp.dst is tcp.dst >= 1
+func reverseOp(op string) string {
+	switch op {
+	case "<":
+		return ">"
+	case "<=":
+		return ">="
+	case ">":
+		return "<"
+	case ">=":
+		return "<="
+	}
+	return op
+}
+
+type matchParser struct {
+	tokens []token
+	pos    int
+}
+
+// parseMatch parses an ACL match expression
+func parseMatch(match string) (matchExpr, error) {
+	tokens, err := tokenize(match)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse match %q: %w", match, err)
+	}
+	p := &matchParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err == nil && p.peek().typ != tokenEOF {
+		err = fmt.Errorf("unexpected %q", p.peek().value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse match %q: %w", match, err)
+	}
+	return expr, nil
+}
+
+func (p *matchParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *matchParser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *matchParser) parseOr() (matchExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().typ == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *matchParser) parseAnd() (matchExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().typ == tokenAnd {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *matchParser) parseNot() (matchExpr, error) {
+	if p.peek().typ == tokenNot {
+		p.next()
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *matchParser) parsePrimary() (matchExpr, error) {
+	t := p.next()
+	switch t.typ {
+	case tokenLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().typ != tokenRParen {
+			return nil, fmt.Errorf("missing )")
+		}
+		return expr, nil
+	case tokenWord:
+		if p.peek().typ != tokenRelOp {
+			return &symbolExpr{symbol: t.value}, nil
+		}
+		op := p.next().value
+		if _, err := strconv.ParseInt(t.value, 0, 64); err == nil {
+			// constant first, e.g. 1 <= tcp.dst <= 10
+			field := p.next()
+			if field.typ != tokenWord {
+				return nil, fmt.Errorf("expected field after %s %s", t.value, op)
+			}
+			var expr matchExpr = &relationExpr{field: field.value, op: reverseOp(op), values: []string{t.value}}
+			if p.peek().typ == tokenRelOp {
+				op2 := p.next().value
+				values, err := p.parseValues()
+				if err != nil {
+					return nil, err
+				}
+				expr = &andExpr{left: expr, right: &relationExpr{field: field.value, op: op2, values: values}}
+			}
+			return expr, nil
+		}
+		values, err := p.parseValues()
+		if err != nil {
+			return nil, err
+		}
+		return &relationExpr{field: t.value, op: op, values: values}, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", t.value)
+}
+
+// parseValues parses a constant or a set of constants between braces
+func (p *matchParser) parseValues() ([]string, error) {
+	t := p.next()
+	switch t.typ {
+	case tokenWord, tokenString:
+		return []string{t.value}, nil
+	case tokenLBrace:
+		var values []string
+		for {
+			t = p.next()
+			switch t.typ {
+			case tokenRBrace:
+				return values, nil
+			case tokenComma:
+			case tokenWord, tokenString:
+				values = append(values, t.value)
+			default:
+				return nil, fmt.Errorf("unexpected %q in set", t.value)
+			}
+		}
+	}
+	return nil, fmt.Errorf("expected constant, got %q", t.value)
+}
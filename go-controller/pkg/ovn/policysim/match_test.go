@@ -0,0 +1,119 @@
+package policysim
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+)
+
+func TestMatch(t *testing.T) {
+	snapshot := newSnapshot(
+		nil,
+		[]*nbdb.PortGroup{{UUID: "pg-uuid", Name: "pg1", Ports: []string{"port-uuid"}}},
+		[]*nbdb.AddressSet{
+			{UUID: "as1-uuid", Name: "as1", Addresses: []string{"10.0.0.0/24", "10.1.0.5"}},
+			{UUID: "as2-uuid", Name: "as2", Addresses: []string{"fd00::/64"}},
+			{UUID: "empty-uuid", Name: "empty"},
+		},
+		nil,
+		[]*nbdb.LogicalSwitchPort{{UUID: "port-uuid", Name: "ns_a"}},
+	)
+	tcpv4 := packet{
+		inport:   "ns_a",
+		srcIP:    net.ParseIP("10.0.0.5"),
+		dstIP:    net.ParseIP("10.1.0.5"),
+		protocol: ProtocolTCP,
+		srcPort:  34567,
+		dstPort:  8080,
+	}
+	udpv6 := packet{
+		outport:  "ns_a",
+		srcIP:    net.ParseIP("fd00::5"),
+		dstIP:    net.ParseIP("fd01::5"),
+		protocol: ProtocolUDP,
+		srcPort:  53,
+		dstPort:  34567,
+	}
+	testcases := []struct {
+		desc     string
+		match    string
+		packet   packet
+		expected bool
+	}{
+		{"port group input port", "inport == @pg1", tcpv4, true},
+		{"port group output port", "outport == @pg1", tcpv4, false},
+		{"port group output port unknown", "outport != @pg1", tcpv4, true},
+		{"port name", `inport == "ns_a"`, tcpv4, true},
+		{"address set", "ip4.src == $as1", tcpv4, true},
+		{"address set ip", "ip4.dst == {$as1}", tcpv4, true},
+		{"empty address set", "ip4.src == {$empty}", tcpv4, false},
+		{"not in address sets", "ip4.src != {$empty, $as1}", tcpv4, false},
+		{"other family field", "ip6.src == $as2", tcpv4, false},
+		{"other family field not equal", "ip6.src != $as2", tcpv4, false},
+		{"negated other family field", "!(ip6.src == $as2)", tcpv4, true},
+		{"ipv6 address set", "ip6.src == $as2", udpv6, true},
+		{"cidr", "ip4.dst == 10.1.0.0/16", tcpv4, true},
+		{"ipv6 cidr", "ip6.dst == fd01::/64", udpv6, true},
+		{"port list", "tcp && tcp.dst=={80,8080}", tcpv4, true},
+		{"port", "tcp && tcp.dst==80", tcpv4, false},
+		{"port range", "tcp && (tcp.dst==80 || 8000<=tcp.dst<=8090)", tcpv4, true},
+		{"port range mismatch", "1<=tcp.dst<=1024", tcpv4, false},
+		{"other protocol port", "udp.dst == 8080", tcpv4, false},
+		{"reply port", "udp && udp.src==53 && outport == @pg1", udpv6, true},
+		{"protocol symbols", "ip4 && tcp && !udp && !icmp", tcpv4, true},
+		{"new connection", "ct.new && !ct.est", tcpv4, true},
+		{"multicast", "ip4.mcast", tcpv4, false},
+		{"egress firewall", "(ip4.dst == 10.1.0.0/24) && inport == @pg1 && ((udp) || (tcp && ( tcp.dst == 80 || tcp.dst == 8080)))", tcpv4, true},
+		{"egress firewall exclusion", "(ip4.dst == 0.0.0.0/0 && ip4.dst != 10.0.0.0/8) && inport == @pg1", tcpv4, false},
+		{"constant", "1", tcpv4, true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			expr, err := parseMatch(tc.match)
+			if !assert.NoError(t, err) {
+				return
+			}
+			result, err := expr.eval(&tc.packet, snapshot)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	testcases := []struct {
+		desc       string
+		match      string
+		parseError bool
+	}{
+		{"unbalanced parentheses", "(ip4 && tcp", true},
+		{"unterminated set", "ip4.src == {10.0.0.1", true},
+		{"missing operand", "ip4 &&", true},
+		{"unterminated string", `inport == "ns_a`, true},
+		{"unsupported field", "ct_label.blockNonLocal == 1", false},
+		{"unsupported symbol", "ip.is_frag", false},
+		{"invalid IP", "ip4.src == 10.0.0", false},
+	}
+	pkt := &packet{
+		srcIP:    net.ParseIP("10.0.0.5"),
+		dstIP:    net.ParseIP("10.1.0.5"),
+		protocol: ProtocolTCP,
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			expr, err := parseMatch(tc.match)
+			if tc.parseError {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = expr.eval(pkt, newSnapshot(nil, nil, nil, nil, nil))
+			assert.Error(t, err)
+		})
+	}
+}
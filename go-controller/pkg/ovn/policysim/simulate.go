@@ -0,0 +1,412 @@
+package policysim
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/observability-lib/sampledecoder"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	ProtocolTCP  = "tcp"
+	ProtocolUDP  = "udp"
+	ProtocolSCTP = "sctp"
+	ProtocolICMP = "icmp"
+)
+
+// Flow is the simulated flow. At least one of the source and the destination must be a pod, whose ACLs are
+// evaluated, the other one may be given by its IP.
+type Flow struct {
+	// SrcPod is the source pod, as namespace/name
+	SrcPod string `json:"srcPod,omitempty"`
+	// SrcIP is the source IP, the IP of SrcPod by default
+	SrcIP string `json:"srcIP,omitempty"`
+	// DstPod is the destination pod, as namespace/name
+	DstPod string `json:"dstPod,omitempty"`
+	// DstIP is the destination IP, the IP of DstPod by default
+	DstIP string `json:"dstIP,omitempty"`
+	// Protocol is tcp, udp, sctp or icmp
+	Protocol string `json:"protocol"`
+	SrcPort  int    `json:"srcPort,omitempty"`
+	DstPort  int    `json:"dstPort,omitempty"`
+	// Network is the name of the network of the pods, by default the primary network of the pods
+	Network string `json:"network,omitempty"`
+}
+
+// packet is the first packet of the simulated flow, as seen by a pipeline stage
+type packet struct {
+	inport, outport  string
+	srcIP, dstIP     net.IP
+	protocol         string
+	srcPort, dstPort int
+}
+
+func (p *packet) isIPv4() bool {
+	return p.srcIP.To4() != nil
+}
+
+// ACLResult is an ACL matching the simulated flow
+type ACLResult struct {
+	UUID        string            `json:"uuid"`
+	Name        string            `json:"name,omitempty"`
+	Direction   string            `json:"direction"`
+	Priority    int               `json:"priority"`
+	Tier        int               `json:"tier"`
+	Action      string            `json:"action"`
+	Match       string            `json:"match"`
+	ExternalIDs map[string]string `json:"externalIDs,omitempty"`
+	// Owner describes the action of the ACL and the object it was created for
+	Owner string `json:"owner,omitempty"`
+}
+
+// StageResult is the verdict of an ACL pipeline stage
+type StageResult struct {
+	// Stage is from-lport and from-lport-after-lb for the source pod, to-lport for the destination pod
+	Stage   string `json:"stage"`
+	Switch  string `json:"switch"`
+	Port    string `json:"port"`
+	Allowed bool   `json:"allowed"`
+	// ACL decided the verdict of the stage, it is empty if no ACL matched
+	ACL *ACLResult `json:"acl,omitempty"`
+	// Passed are the ACLs with the pass action that delegated the verdict to the next tiers
+	Passed []*ACLResult `json:"passed,omitempty"`
+}
+
+// Result is the verdict of the simulated flow
+type Result struct {
+	Allowed bool   `json:"allowed"`
+	SrcIP   string `json:"srcIP"`
+	DstIP   string `json:"dstIP"`
+	// ACL decided the verdict: the ACL dropping the flow, or the last ACL allowing it.
+	// It is empty if the flow is allowed by default.
+	ACL    *ACLResult     `json:"acl,omitempty"`
+	Stages []*StageResult `json:"stages"`
+	// Warnings report the ACLs that could not be evaluated
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// endpoint is the source or destination of the flow
+type endpoint struct {
+	port *nbdb.LogicalSwitchPort
+	ls   *nbdb.LogicalSwitch
+	ips  []net.IP
+}
+
+// Simulate evaluates the ACLs of the source and destination pods for the first packet of the flow.
+// Load balancing is not simulated, the destination must be the IP of the backend.
+func (s *Snapshot) Simulate(flow *Flow) (*Result, error) {
+	protocol := strings.ToLower(flow.Protocol)
+	switch protocol {
+	case ProtocolTCP, ProtocolUDP, ProtocolSCTP, ProtocolICMP:
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q", flow.Protocol)
+	}
+	if flow.SrcPod == "" && flow.DstPod == "" {
+		return nil, fmt.Errorf("the source or the destination of the flow must be a pod")
+	}
+	src, err := s.getEndpoint(flow.SrcPod, flow.SrcIP, flow.Network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source: %w", err)
+	}
+	dst, err := s.getEndpoint(flow.DstPod, flow.DstIP, flow.Network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination: %w", err)
+	}
+	if src.ls != nil && dst.ls != nil {
+		// pods of different networks are isolated from each other regardless of their ACLs
+		srcNetwork, dstNetwork := getSwitchNetwork(src.ls), getSwitchNetwork(dst.ls)
+		if srcNetwork != dstNetwork {
+			return nil, fmt.Errorf("source pod on network %s can't reach destination pod on network %s",
+				srcNetwork, dstNetwork)
+		}
+	}
+	srcIP, dstIP, err := selectIPs(src.ips, dst.ips)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Allowed: true,
+		SrcIP:   srcIP.String(),
+		DstIP:   dstIP.String(),
+	}
+	pkt := packet{
+		srcIP:    srcIP,
+		dstIP:    dstIP,
+		protocol: protocol,
+		srcPort:  flow.SrcPort,
+		dstPort:  flow.DstPort,
+	}
+	if src.port != nil {
+		// the output port is only known after the ingress pipeline of the switch
+		pkt.inport = src.port.Name
+		result.Stages = append(result.Stages,
+			s.evaluateStage(string(libovsdbutil.LportEgress), src, pkt, result),
+			s.evaluateStage(string(libovsdbutil.LportEgressAfterLB), src, pkt, result))
+	}
+	if dst.port != nil {
+		// packets from other switches enter the switch of the destination from a router port
+		pkt.inport = ""
+		if src.port != nil && src.ls == dst.ls {
+			pkt.inport = src.port.Name
+		}
+		pkt.outport = dst.port.Name
+		result.Stages = append(result.Stages, s.evaluateStage(string(libovsdbutil.LportIngress), dst, pkt, result))
+	}
+	for _, stage := range result.Stages {
+		if stage.ACL != nil {
+			result.ACL = stage.ACL
+		}
+		if !stage.Allowed {
+			result.Allowed = false
+			break
+		}
+	}
+	return result, nil
+}
+
+// getEndpoint returns the endpoint of the given pod, or of the given IP
+func (s *Snapshot) getEndpoint(pod, ip, network string) (*endpoint, error) {
+	ep := &endpoint{}
+	if pod != "" {
+		port, err := s.getPodPort(pod, network)
+		if err != nil {
+			return nil, err
+		}
+		ep.port = port
+		ep.ls = s.portSwitches[port.UUID]
+		if ep.ls == nil {
+			return nil, fmt.Errorf("logical switch of port %s not found", port.Name)
+		}
+		ep.ips = getPortIPs(port)
+	}
+	if ip != "" {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return nil, fmt.Errorf("invalid IP %q", ip)
+		}
+		ep.ips = []net.IP{parsed}
+	}
+	if len(ep.ips) == 0 {
+		return nil, fmt.Errorf("no IP found")
+	}
+	return ep, nil
+}
+
+// getPodPort returns the logical switch port of the pod on the given network. Without network,
+// the port on the primary user defined network of the pod is preferred to the default network port.
+func (s *Snapshot) getPodPort(pod, network string) (*nbdb.LogicalSwitchPort, error) {
+	namespace, name, found := strings.Cut(pod, "/")
+	if !found || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid pod %q, expected namespace/name", pod)
+	}
+	portName := util.GetLogicalPortName(namespace, name)
+	var defaultPort, primaryPort, networkPort *nbdb.LogicalSwitchPort
+	var networks []string
+	for _, port := range s.ports {
+		if port.ExternalIDs["pod"] != "true" || port.ExternalIDs["namespace"] != namespace ||
+			(port.Name != portName && !strings.HasSuffix(port.Name, "_"+portName)) {
+			continue
+		}
+		portNetwork := port.ExternalIDs[types.NetworkExternalID]
+		if portNetwork == "" {
+			portNetwork = types.DefaultNetworkName
+			defaultPort = port
+		}
+		networks = append(networks, portNetwork)
+		if portNetwork == network {
+			networkPort = port
+		}
+		if ls := s.portSwitches[port.UUID]; ls != nil && ls.ExternalIDs[types.NetworkRoleExternalID] == types.NetworkRolePrimary {
+			primaryPort = port
+		}
+	}
+	switch {
+	case len(networks) == 0:
+		return nil, fmt.Errorf("logical switch port of pod %s not found, the pod may be in another zone", pod)
+	case network != "" && networkPort == nil:
+		sort.Strings(networks)
+		return nil, fmt.Errorf("pod %s has no logical switch port on network %s, found networks %v", pod, network, networks)
+	case networkPort != nil:
+		return networkPort, nil
+	case primaryPort != nil:
+		return primaryPort, nil
+	case defaultPort != nil:
+		return defaultPort, nil
+	}
+	sort.Strings(networks)
+	return nil, fmt.Errorf("pod %s has no logical switch port on its primary network, found networks %v", pod, networks)
+}
+
+// getSwitchNetwork returns the name of the network of the logical switch
+func getSwitchNetwork(ls *nbdb.LogicalSwitch) string {
+	if network := ls.ExternalIDs[types.NetworkExternalID]; network != "" {
+		return network
+	}
+	return types.DefaultNetworkName
+}
+
+// getPortIPs returns the IPs of the "<mac> <ip>..." addresses of the port
+func getPortIPs(port *nbdb.LogicalSwitchPort) []net.IP {
+	addresses := port.Addresses
+	if len(addresses) == 0 {
+		addresses = port.PortSecurity
+	}
+	var ips []net.IP
+	for _, address := range addresses {
+		fields := strings.Fields(address)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			ip, _, err := net.ParseCIDR(field)
+			if err != nil {
+				ip = net.ParseIP(field)
+			}
+			if ip != nil {
+				ips = append(ips, ip)
+			}
+		}
+	}
+	return ips
+}
+
+// selectIPs returns a source and a destination IP of the same family, IPv4 if possible
+func selectIPs(srcIPs, dstIPs []net.IP) (net.IP, net.IP, error) {
+	for _, ipv4 := range []bool{true, false} {
+		for _, srcIP := range srcIPs {
+			if (srcIP.To4() != nil) != ipv4 {
+				continue
+			}
+			for _, dstIP := range dstIPs {
+				if (dstIP.To4() != nil) == ipv4 {
+					return srcIP, dstIP, nil
+				}
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("source IPs %v and destination IPs %v have no common IP family", srcIPs, dstIPs)
+}
+
+// getStageACLs returns the ACLs of the pipeline stage applied by the logical switch: its own ACLs and
+// the ACLs of the port groups with ports on the switch, sorted by evaluation order
+func (s *Snapshot) getStageACLs(stage string, ls *nbdb.LogicalSwitch) []*nbdb.ACL {
+	uuids := sets.New(ls.ACLs...)
+	switchPorts := sets.New(ls.Ports...)
+	for _, portGroup := range s.portGroups {
+		for _, port := range portGroup.Ports {
+			if switchPorts.Has(port) {
+				uuids.Insert(portGroup.ACLs...)
+				break
+			}
+		}
+	}
+	var acls []*nbdb.ACL
+	for uuid := range uuids {
+		acl := s.acls[uuid]
+		if acl != nil && getACLStage(acl) == stage {
+			acls = append(acls, acl)
+		}
+	}
+	sort.Slice(acls, func(i, j int) bool {
+		if acls[i].Tier != acls[j].Tier {
+			return acls[i].Tier < acls[j].Tier
+		}
+		if acls[i].Priority != acls[j].Priority {
+			return acls[i].Priority > acls[j].Priority
+		}
+		return acls[i].UUID < acls[j].UUID
+	})
+	return acls
+}
+
+func getACLStage(acl *nbdb.ACL) string {
+	if acl.Direction == nbdb.ACLDirectionToLport {
+		return string(libovsdbutil.LportIngress)
+	}
+	if acl.Options["apply-after-lb"] == "true" {
+		return string(libovsdbutil.LportEgressAfterLB)
+	}
+	return string(libovsdbutil.LportEgress)
+}
+
+// evaluateStage returns the verdict of the ACLs of a pipeline stage. Tiers are evaluated in order and, in a tier,
+// the first matching ACL by priority decides, unless its action is pass and the next tier decides.
+// The flow is allowed if no ACL matches.
+func (s *Snapshot) evaluateStage(stage string, ep *endpoint, pkt packet, result *Result) *StageResult {
+	stageResult := &StageResult{
+		Stage:   stage,
+		Switch:  ep.ls.Name,
+		Port:    ep.port.Name,
+		Allowed: true,
+	}
+	passedTier := -1
+	for _, acl := range s.getStageACLs(stage, ep.ls) {
+		if acl.Tier <= passedTier {
+			continue
+		}
+		expr, err := parseMatch(acl.Match)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("ACL %s ignored: %v", acl.UUID, err))
+			continue
+		}
+		matched, err := expr.eval(&pkt, s)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("ACL %s with match %q ignored: %v", acl.UUID, acl.Match, err))
+			continue
+		}
+		if !matched {
+			continue
+		}
+		switch acl.Action {
+		case nbdb.ACLActionPass:
+			stageResult.Passed = append(stageResult.Passed, newACLResult(acl))
+			passedTier = acl.Tier
+			continue
+		case nbdb.ACLActionDrop, nbdb.ACLActionReject:
+			stageResult.Allowed = false
+		}
+		stageResult.ACL = newACLResult(acl)
+		break
+	}
+	return stageResult
+}
+
+func newACLResult(acl *nbdb.ACL) *ACLResult {
+	result := &ACLResult{
+		UUID:        acl.UUID,
+		Direction:   acl.Direction,
+		Priority:    acl.Priority,
+		Tier:        acl.Tier,
+		Action:      acl.Action,
+		Match:       acl.Match,
+		ExternalIDs: acl.ExternalIDs,
+	}
+	if acl.Name != nil {
+		result.Name = *acl.Name
+	}
+	ownerType := acl.ExternalIDs[libovsdbops.OwnerTypeKey.String()]
+	if ownerType == "" {
+		return result
+	}
+	event, err := sampledecoder.NewACLEvent(acl)
+	if err != nil {
+		result.Owner = ownerType
+		return result
+	}
+	result.Owner = event.String()
+	if strings.HasSuffix(result.Owner, " by ") {
+		// owner types without description
+		result.Owner += ownerType
+	}
+	return result
+}
@@ -0,0 +1,345 @@
+package policysim
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+func newTestPodPort(uuid, name, namespace, network, address string) *nbdb.LogicalSwitchPort {
+	port := &nbdb.LogicalSwitchPort{
+		UUID:      uuid,
+		Name:      name,
+		Addresses: []string{address},
+		ExternalIDs: map[string]string{
+			"pod":       "true",
+			"namespace": namespace,
+		},
+	}
+	if network != "" {
+		port.ExternalIDs[types.NetworkExternalID] = network
+	}
+	return port
+}
+
+func newTestACL(uuid, direction string, tier, priority int, action, match string, owner map[string]string) *nbdb.ACL {
+	return &nbdb.ACL{
+		UUID:        uuid,
+		Direction:   direction,
+		Tier:        tier,
+		Priority:    priority,
+		Action:      action,
+		Match:       match,
+		ExternalIDs: owner,
+	}
+}
+
+// getTestNBData returns a node switch with the pods ns1/a, ns1/b and ns2/c, a primary user defined network
+// switch with the pod ns3/d, also connected to the node switch, and ACLs of an admin network policy,
+// network policies and an egress firewall
+func getTestNBData() []libovsdbtest.TestData {
+	podA := newTestPodPort("a-UUID", "ns1_a", "ns1", "", "0a:58:0a:80:01:03 10.128.1.3 fd00:10:128:1::3")
+	podB := newTestPodPort("b-UUID", "ns1_b", "ns1", "", "0a:58:0a:80:01:04 10.128.1.4 fd00:10:128:1::4")
+	podC := newTestPodPort("c-UUID", "ns2_c", "ns2", "", "0a:58:0a:80:01:05 10.128.1.5 fd00:10:128:1::5")
+	podDDefault := newTestPodPort("d-default-UUID", "ns3_d", "ns3", "", "0a:58:0a:80:01:06 10.128.1.6")
+	podD := newTestPodPort("d-UUID", "tenant_ns3_d", "ns3", "tenant", "0a:58:0a:14:01:03 10.20.1.3")
+
+	anpPass := newTestACL("anp-pass-UUID", nbdb.ACLDirectionToLport, types.DefaultANPACLTier, 30000, nbdb.ACLActionPass,
+		"(ip4.src == $as-c) && outport == @pg-anp", map[string]string{
+			libovsdbops.OwnerTypeKey.String():       libovsdbops.AdminNetworkPolicyOwnerType,
+			libovsdbops.ObjectNameKey.String():      "delegate",
+			libovsdbops.PolicyDirectionKey.String(): string(libovsdbutil.ACLIngress),
+			libovsdbops.GressIdxKey.String():        "0",
+		})
+	npAllow := newTestACL("np-allow-UUID", nbdb.ACLDirectionToLport, types.DefaultACLTier, 1001, nbdb.ACLActionAllowRelated,
+		"ip4.src == {$as-a, $as-c} && outport == @pg-np && tcp && tcp.dst==80", map[string]string{
+			libovsdbops.OwnerTypeKey.String():       libovsdbops.NetworkPolicyOwnerType,
+			libovsdbops.ObjectNameKey.String():      "ns1:allow-a",
+			libovsdbops.PolicyDirectionKey.String(): string(libovsdbutil.ACLIngress),
+			libovsdbops.GressIdxKey.String():        "0",
+		})
+	npDeny := newTestACL("np-deny-UUID", nbdb.ACLDirectionToLport, types.DefaultACLTier, 1000, nbdb.ACLActionDrop,
+		"outport == @pg-np-deny", map[string]string{
+			libovsdbops.OwnerTypeKey.String():       libovsdbops.NetpolNamespaceOwnerType,
+			libovsdbops.ObjectNameKey.String():      "ns1",
+			libovsdbops.PolicyDirectionKey.String(): string(libovsdbutil.ACLIngress),
+		})
+	anpDeny := newTestACL("anp-deny-UUID", nbdb.ACLDirectionToLport, types.DefaultANPACLTier, 29999, nbdb.ACLActionDrop,
+		"(ip4.src == 10.128.1.6) && outport == @pg-anp", map[string]string{
+			libovsdbops.OwnerTypeKey.String():       libovsdbops.AdminNetworkPolicyOwnerType,
+			libovsdbops.ObjectNameKey.String():      "deny-d",
+			libovsdbops.PolicyDirectionKey.String(): string(libovsdbutil.ACLIngress),
+			libovsdbops.GressIdxKey.String():        "0",
+		})
+	egressFirewall := newTestACL("ef-UUID", nbdb.ACLDirectionFromLport, types.DefaultACLTier, 9999, nbdb.ACLActionDrop,
+		"(ip4.dst == 8.8.8.0/24) && inport == @pg-ef", map[string]string{
+			libovsdbops.OwnerTypeKey.String():  libovsdbops.EgressFirewallOwnerType,
+			libovsdbops.ObjectNameKey.String(): "ns1",
+		})
+	egressFirewall.Options = map[string]string{"apply-after-lb": "true"}
+
+	return []libovsdbtest.TestData{
+		podA, podB, podC, podDDefault, podD,
+		anpPass, anpDeny, npAllow, npDeny, egressFirewall,
+		&nbdb.LogicalSwitch{
+			UUID:  "node-UUID",
+			Name:  "node1",
+			Ports: []string{podA.UUID, podB.UUID, podC.UUID, podDDefault.UUID},
+		},
+		&nbdb.LogicalSwitch{
+			UUID:  "tenant-UUID",
+			Name:  "tenant_node1",
+			Ports: []string{podD.UUID},
+			ExternalIDs: map[string]string{
+				types.NetworkExternalID:     "tenant",
+				types.NetworkRoleExternalID: types.NetworkRolePrimary,
+			},
+		},
+		&nbdb.PortGroup{UUID: "pg-anp-UUID", Name: "pg-anp", Ports: []string{podB.UUID}, ACLs: []string{anpPass.UUID, anpDeny.UUID}},
+		&nbdb.PortGroup{UUID: "pg-np-UUID", Name: "pg-np", Ports: []string{podB.UUID}, ACLs: []string{npAllow.UUID}},
+		&nbdb.PortGroup{UUID: "pg-np-deny-UUID", Name: "pg-np-deny", Ports: []string{podB.UUID}, ACLs: []string{npDeny.UUID}},
+		&nbdb.PortGroup{UUID: "pg-ef-UUID", Name: "pg-ef", Ports: []string{podA.UUID}, ACLs: []string{egressFirewall.UUID}},
+		&nbdb.AddressSet{UUID: "as-a-UUID", Name: "as-a", Addresses: []string{"10.128.1.3"}},
+		&nbdb.AddressSet{UUID: "as-c-UUID", Name: "as-c", Addresses: []string{"10.128.1.5"}},
+	}
+}
+
+func newTestNBClient(t *testing.T) libovsdbclient.Client {
+	nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{NBData: getTestNBData()}, nil)
+	if err != nil {
+		t.Fatalf("failed to set up test harness: %v", err)
+	}
+	t.Cleanup(cleanup.Cleanup)
+	return nbClient
+}
+
+func TestSimulate(t *testing.T) {
+	snapshot, err := GetSnapshot(newTestNBClient(t))
+	if err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+	testcases := []struct {
+		desc           string
+		flow           Flow
+		expectedErr    bool
+		allowed        bool
+		srcIP          string
+		dstIP          string
+		owner          string
+		stages         []string
+		deciding       string
+		passed         int
+		expectedSwitch string
+	}{
+		{
+			desc:    "allowed by network policy",
+			flow:    Flow{SrcPod: "ns1/a", DstPod: "ns1/b", Protocol: ProtocolTCP, SrcPort: 34567, DstPort: 80},
+			allowed: true,
+			srcIP:   "10.128.1.3",
+			dstIP:   "10.128.1.4",
+			owner:   "Allowed by network policy allow-a in namespace ns1, direction Ingress",
+			stages: []string{string(libovsdbutil.LportEgress), string(libovsdbutil.LportEgressAfterLB),
+				string(libovsdbutil.LportIngress)},
+			deciding:       string(libovsdbutil.LportIngress),
+			expectedSwitch: "node1",
+		},
+		{
+			desc:  "dropped by default deny",
+			flow:  Flow{SrcPod: "ns1/a", DstPod: "ns1/b", Protocol: ProtocolTCP, DstPort: 8080},
+			srcIP: "10.128.1.3",
+			dstIP: "10.128.1.4",
+			owner: "Dropped by network policies isolation in namespace ns1, direction Ingress",
+			stages: []string{string(libovsdbutil.LportEgress), string(libovsdbutil.LportEgressAfterLB),
+				string(libovsdbutil.LportIngress)},
+			deciding:       string(libovsdbutil.LportIngress),
+			expectedSwitch: "node1",
+		},
+		{
+			desc:    "passed by admin network policy and allowed by network policy",
+			flow:    Flow{SrcPod: "ns2/c", DstPod: "ns1/b", Protocol: ProtocolTCP, DstPort: 80},
+			allowed: true,
+			srcIP:   "10.128.1.5",
+			dstIP:   "10.128.1.4",
+			owner:   "Allowed by network policy allow-a in namespace ns1, direction Ingress",
+			stages: []string{string(libovsdbutil.LportEgress), string(libovsdbutil.LportEgressAfterLB),
+				string(libovsdbutil.LportIngress)},
+			deciding:       string(libovsdbutil.LportIngress),
+			passed:         1,
+			expectedSwitch: "node1",
+		},
+		{
+			desc:  "dropped by admin network policy",
+			flow:  Flow{SrcPod: "ns3/d", DstPod: "ns1/b", Protocol: ProtocolTCP, DstPort: 80, Network: types.DefaultNetworkName},
+			srcIP: "10.128.1.6",
+			dstIP: "10.128.1.4",
+			owner: "Dropped by admin network policy deny-d, direction Ingress",
+			stages: []string{string(libovsdbutil.LportEgress), string(libovsdbutil.LportEgressAfterLB),
+				string(libovsdbutil.LportIngress)},
+			deciding:       string(libovsdbutil.LportIngress),
+			expectedSwitch: "node1",
+		},
+		{
+			desc:           "dropped by egress firewall",
+			flow:           Flow{SrcPod: "ns1/a", DstIP: "8.8.8.8", Protocol: ProtocolUDP, DstPort: 53},
+			srcIP:          "10.128.1.3",
+			dstIP:          "8.8.8.8",
+			owner:          "Dropped by egress firewall in namespace ns1",
+			stages:         []string{string(libovsdbutil.LportEgress), string(libovsdbutil.LportEgressAfterLB)},
+			deciding:       string(libovsdbutil.LportEgressAfterLB),
+			expectedSwitch: "node1",
+		},
+		{
+			desc:           "ingress from an IP",
+			flow:           Flow{SrcIP: "fd00:10:128:2::3", DstPod: "ns1/b", Protocol: ProtocolICMP},
+			srcIP:          "fd00:10:128:2::3",
+			dstIP:          "fd00:10:128:1::4",
+			owner:          "Dropped by network policies isolation in namespace ns1, direction Ingress",
+			stages:         []string{string(libovsdbutil.LportIngress)},
+			deciding:       string(libovsdbutil.LportIngress),
+			expectedSwitch: "node1",
+		},
+		{
+			desc:           "primary user defined network",
+			flow:           Flow{SrcPod: "ns3/d", DstIP: "8.8.8.8", Protocol: ProtocolTCP, DstPort: 443},
+			allowed:        true,
+			srcIP:          "10.20.1.3",
+			dstIP:          "8.8.8.8",
+			stages:         []string{string(libovsdbutil.LportEgress), string(libovsdbutil.LportEgressAfterLB)},
+			expectedSwitch: "tenant_node1",
+		},
+		{
+			desc:        "pods on different networks",
+			flow:        Flow{SrcPod: "ns3/d", DstPod: "ns1/b", Protocol: ProtocolTCP, DstPort: 80},
+			expectedErr: true,
+		},
+		{
+			desc:        "no pod",
+			flow:        Flow{SrcIP: "10.128.1.3", DstIP: "10.128.1.4", Protocol: ProtocolTCP},
+			expectedErr: true,
+		},
+		{
+			desc:        "unknown pod",
+			flow:        Flow{SrcPod: "ns1/e", DstIP: "8.8.8.8", Protocol: ProtocolTCP},
+			expectedErr: true,
+		},
+		{
+			desc:        "unknown network",
+			flow:        Flow{SrcPod: "ns1/a", DstIP: "8.8.8.8", Protocol: ProtocolTCP, Network: "tenant"},
+			expectedErr: true,
+		},
+		{
+			desc:        "unsupported protocol",
+			flow:        Flow{SrcPod: "ns1/a", DstIP: "8.8.8.8", Protocol: "gre"},
+			expectedErr: true,
+		},
+		{
+			desc:        "different IP families",
+			flow:        Flow{SrcPod: "ns3/d", DstIP: "fd00::1", Protocol: ProtocolTCP},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result, err := snapshot.Simulate(&tc.flow)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.allowed, result.Allowed)
+			assert.Equal(t, tc.srcIP, result.SrcIP)
+			assert.Equal(t, tc.dstIP, result.DstIP)
+			assert.Empty(t, result.Warnings)
+			var stages []string
+			var deciding string
+			passed := 0
+			for _, stage := range result.Stages {
+				stages = append(stages, stage.Stage)
+				assert.Equal(t, tc.expectedSwitch, stage.Switch)
+				if stage.ACL != nil {
+					deciding = stage.Stage
+				}
+				passed += len(stage.Passed)
+			}
+			assert.Equal(t, tc.stages, stages)
+			assert.Equal(t, tc.deciding, deciding)
+			assert.Equal(t, tc.passed, passed)
+			if tc.owner == "" {
+				assert.Nil(t, result.ACL)
+			} else if assert.NotNil(t, result.ACL) {
+				assert.Equal(t, tc.owner, result.ACL.Owner)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler(newTestNBClient(t))
+	testcases := []struct {
+		desc       string
+		method     string
+		query      string
+		statusCode int
+		allowed    bool
+	}{
+		{
+			desc:       "allowed flow",
+			method:     http.MethodGet,
+			query:      "srcPod=ns1/a&dstPod=ns1/b&dstPort=80",
+			statusCode: http.StatusOK,
+			allowed:    true,
+		},
+		{
+			desc:       "dropped flow",
+			method:     http.MethodGet,
+			query:      "srcPod=ns1/a&dstPod=ns1/b&protocol=udp&dstPort=80",
+			statusCode: http.StatusOK,
+		},
+		{
+			desc:       "invalid port",
+			method:     http.MethodGet,
+			query:      "srcPod=ns1/a&dstPod=ns1/b&dstPort=70000",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			desc:       "unknown pod",
+			method:     http.MethodGet,
+			query:      "srcPod=ns1/e&dstPod=ns1/b",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			desc:       "unsupported method",
+			method:     http.MethodPost,
+			query:      "srcPod=ns1/a&dstPod=ns1/b",
+			statusCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, DebugPath+"?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tc.statusCode, rec.Code)
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var result Result
+			if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result)) {
+				assert.Equal(t, tc.allowed, result.Allowed)
+			}
+		})
+	}
+}
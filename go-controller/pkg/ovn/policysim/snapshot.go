@@ -0,0 +1,436 @@
+package policysim
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/model"
+	"github.com/ovn-org/libovsdb/ovsdb"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+)
+
+// Snapshot is the NB state policies are evaluated against: ACLs, the port groups and address sets they refer to,
+// and the logical switches and ports of the pods.
+type Snapshot struct {
+	acls        map[string]*nbdb.ACL
+	portGroups  []*nbdb.PortGroup
+	addressSets map[string]*nbdb.AddressSet
+	switches    []*nbdb.LogicalSwitch
+	ports       map[string]*nbdb.LogicalSwitchPort
+
+	// portGroupPorts maps port group names to the names of their ports
+	portGroupPorts map[string]sets.Set[string]
+	// portSwitches maps port UUIDs to their logical switch
+	portSwitches map[string]*nbdb.LogicalSwitch
+}
+
+func newSnapshot(acls []*nbdb.ACL, portGroups []*nbdb.PortGroup, addressSets []*nbdb.AddressSet,
+	switches []*nbdb.LogicalSwitch, ports []*nbdb.LogicalSwitchPort) *Snapshot {
+	s := &Snapshot{
+		acls:           map[string]*nbdb.ACL{},
+		portGroups:     portGroups,
+		addressSets:    map[string]*nbdb.AddressSet{},
+		switches:       switches,
+		ports:          map[string]*nbdb.LogicalSwitchPort{},
+		portGroupPorts: map[string]sets.Set[string]{},
+		portSwitches:   map[string]*nbdb.LogicalSwitch{},
+	}
+	for _, acl := range acls {
+		s.acls[acl.UUID] = acl
+	}
+	for _, addressSet := range addressSets {
+		s.addressSets[addressSet.Name] = addressSet
+	}
+	for _, port := range ports {
+		s.ports[port.UUID] = port
+	}
+	for _, portGroup := range portGroups {
+		names := sets.New[string]()
+		for _, uuid := range portGroup.Ports {
+			if port := s.ports[uuid]; port != nil {
+				names.Insert(port.Name)
+			}
+		}
+		s.portGroupPorts[portGroup.Name] = names
+	}
+	for _, ls := range switches {
+		for _, uuid := range ls.Ports {
+			s.portSwitches[uuid] = ls
+		}
+	}
+	return s
+}
+
+// GetSnapshot returns a snapshot of the current state of the NB database
+func GetSnapshot(nbClient libovsdbclient.Client) (*Snapshot, error) {
+	acls, err := libovsdbops.FindACLsWithPredicate(nbClient, func(*nbdb.ACL) bool { return true })
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ACLs: %w", err)
+	}
+	portGroups, err := libovsdbops.FindPortGroupsWithPredicate(nbClient, func(*nbdb.PortGroup) bool { return true })
+	if err != nil {
+		return nil, fmt.Errorf("failed to list port groups: %w", err)
+	}
+	addressSets, err := libovsdbops.FindAddressSetsWithPredicate(nbClient, func(*nbdb.AddressSet) bool { return true })
+	if err != nil {
+		return nil, fmt.Errorf("failed to list address sets: %w", err)
+	}
+	switches, err := libovsdbops.FindLogicalSwitchesWithPredicate(nbClient, func(*nbdb.LogicalSwitch) bool { return true })
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logical switches: %w", err)
+	}
+	ports, err := libovsdbops.FindLogicalSwitchPortWithPredicate(nbClient, func(*nbdb.LogicalSwitchPort) bool { return true })
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logical switch ports: %w", err)
+	}
+	return newSnapshot(acls, portGroups, addressSets, switches, ports), nil
+}
+
+// snapshotTables are the NB tables loaded from database files
+var snapshotTables = []string{
+	nbdb.ACLTable,
+	nbdb.PortGroupTable,
+	nbdb.AddressSetTable,
+	nbdb.LogicalSwitchTable,
+	nbdb.LogicalSwitchPortTable,
+}
+
+// LoadSnapshotFile returns a snapshot of the NB database stored in the given file, e.g. a copy of
+// /etc/ovn/ovnnb_db.db. Both the standalone and the clustered database formats are supported.
+func LoadSnapshotFile(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	s, err := LoadSnapshot(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load NB database file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// LoadSnapshot returns a snapshot of the NB database read from an OVSDB database file.
+// The file is a sequence of records, each made of a "OVSDB JSON <length> <sha1>" header line
+// (OVSDB CLUSTER for clustered databases) followed by a JSON object:
+//   - standalone: the schema, then transactions mapping tables to the inserted, updated or deleted rows
+//   - clustered: a header with the last snapshot in prev_data, then raft entries with the transactions in data
+func LoadSnapshot(r io.Reader) (*Snapshot, error) {
+	schema := nbdb.Schema()
+	db := newDBFile(&schema)
+	reader := bufio.NewReader(r)
+	for record := 0; ; record++ {
+		magic, data, err := readDBRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case magic == "JSON" && record == 0:
+			err = checkDBName(data, "name")
+		case magic == "JSON":
+			err = db.applyTxn(data)
+		case magic == "CLUSTER" && record == 0:
+			if err = checkDBName(data, "name"); err == nil {
+				err = db.applyRaftRecord(data)
+			}
+		case magic == "CLUSTER":
+			err = db.applyRaftRecord(data)
+		default:
+			err = fmt.Errorf("unexpected %s record", magic)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read database record %d: %w", record, err)
+		}
+	}
+	return db.snapshot()
+}
+
+// readDBRecord returns the magic (JSON or CLUSTER) and the content of the next record
+func readDBRecord(reader *bufio.Reader) (string, []byte, error) {
+	header, err := reader.ReadString('\n')
+	if err == io.EOF && strings.TrimSpace(header) == "" {
+		return "", nil, io.EOF
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read record header: %w", err)
+	}
+	fields := strings.Fields(header)
+	if len(fields) != 4 || fields[0] != "OVSDB" {
+		return "", nil, fmt.Errorf("invalid record header %q", strings.TrimSpace(header))
+	}
+	length, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid record length in header %q", strings.TrimSpace(header))
+	}
+	data := make([]byte, length)
+	if _, err = io.ReadFull(reader, data); err != nil {
+		return "", nil, fmt.Errorf("failed to read record: %w", err)
+	}
+	// records are followed by a new line
+	if next, err := reader.Peek(1); err == nil && next[0] == '\n' {
+		_, _ = reader.ReadByte()
+	}
+	return fields[1], data, nil
+}
+
+func checkDBName(data []byte, key string) error {
+	var header map[string]json.RawMessage
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	var name string
+	if raw, ok := header[key]; ok {
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return err
+		}
+	}
+	if name != nbdb.Schema().Name {
+		return fmt.Errorf("database %q is not a %s database", name, nbdb.Schema().Name)
+	}
+	return nil
+}
+
+// dbFile replays the transactions of a database file, keeping the rows of the snapshot tables
+type dbFile struct {
+	schema *ovsdb.DatabaseSchema
+	tables map[string]map[string]ovsdb.Row
+}
+
+func newDBFile(schema *ovsdb.DatabaseSchema) *dbFile {
+	db := &dbFile{
+		schema: schema,
+		tables: map[string]map[string]ovsdb.Row{},
+	}
+	for _, table := range snapshotTables {
+		db.tables[table] = map[string]ovsdb.Row{}
+	}
+	return db
+}
+
+// applyRaftRecord applies the snapshot of a clustered database header, or the transaction of a raft entry
+func (db *dbFile) applyRaftRecord(data []byte) error {
+	var record struct {
+		PrevData json.RawMessage `json:"prev_data"`
+		Data     json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+	if len(record.PrevData) > 0 {
+		// the snapshot contains all the rows
+		for table := range db.tables {
+			db.tables[table] = map[string]ovsdb.Row{}
+		}
+		if err := db.applySchemaAndTxn(record.PrevData); err != nil {
+			return err
+		}
+	}
+	if len(record.Data) > 0 {
+		return db.applySchemaAndTxn(record.Data)
+	}
+	return nil
+}
+
+// applySchemaAndTxn applies the transaction of a [schema, transaction] pair of a clustered database
+func (db *dbFile) applySchemaAndTxn(data []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("expected schema and transaction, got %d elements", len(pair))
+	}
+	if string(pair[1]) == "null" {
+		return nil
+	}
+	return db.applyTxn(pair[1])
+}
+
+func (db *dbFile) applyTxn(data []byte) error {
+	var txn map[string]json.RawMessage
+	if err := json.Unmarshal(data, &txn); err != nil {
+		return err
+	}
+	isDiff := false
+	if raw, ok := txn["_is_diff"]; ok {
+		if err := json.Unmarshal(raw, &isDiff); err != nil {
+			return err
+		}
+	}
+	for table, rows := range db.tables {
+		raw, ok := txn[table]
+		if !ok {
+			continue
+		}
+		var updates map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &updates); err != nil {
+			return fmt.Errorf("invalid %s rows: %w", table, err)
+		}
+		for uuid, rawRow := range updates {
+			if string(rawRow) == "null" {
+				delete(rows, uuid)
+				continue
+			}
+			var update ovsdb.Row
+			if err := json.Unmarshal(rawRow, &update); err != nil {
+				return fmt.Errorf("invalid %s row %s: %w", table, uuid, err)
+			}
+			row := rows[uuid]
+			if row == nil {
+				row = ovsdb.NewRow()
+				rows[uuid] = row
+			}
+			if err := db.updateRow(table, row, update, isDiff); err != nil {
+				return fmt.Errorf("invalid %s row %s: %w", table, uuid, err)
+			}
+		}
+	}
+	return nil
+}
+
+// updateRow sets the columns of the update in the row. In diff transactions set and map columns
+// of existing rows contain the difference with the previous value.
+func (db *dbFile) updateRow(table string, row, update ovsdb.Row, isDiff bool) error {
+	tableSchema := db.schema.Table(table)
+	for column, value := range update {
+		columnSchema := tableSchema.Column(column)
+		if columnSchema == nil {
+			// columns of other schema versions are ignored
+			continue
+		}
+		switch columnSchema.Type {
+		case ovsdb.TypeSet:
+			newSet := toOvsSet(value)
+			if oldValue, ok := row[column]; ok && isDiff {
+				newSet = applySetDiff(toOvsSet(oldValue), newSet)
+			}
+			row[column] = newSet
+		case ovsdb.TypeMap:
+			newMap, ok := value.(ovsdb.OvsMap)
+			if !ok {
+				return fmt.Errorf("expected map in column %s", column)
+			}
+			if oldValue, ok := row[column]; ok && isDiff {
+				newMap = applyMapDiff(oldValue.(ovsdb.OvsMap), newMap)
+			}
+			row[column] = newMap
+		default:
+			row[column] = value
+		}
+	}
+	return nil
+}
+
+func toOvsSet(value interface{}) ovsdb.OvsSet {
+	if set, ok := value.(ovsdb.OvsSet); ok {
+		return set
+	}
+	return ovsdb.OvsSet{GoSet: []interface{}{value}}
+}
+
+// applySetDiff returns the symmetric difference of the old set and the diff
+func applySetDiff(old, diff ovsdb.OvsSet) ovsdb.OvsSet {
+	result := ovsdb.OvsSet{GoSet: []interface{}{}}
+	inDiff := map[interface{}]bool{}
+	for _, elem := range diff.GoSet {
+		inDiff[elem] = true
+	}
+	inOld := map[interface{}]bool{}
+	for _, elem := range old.GoSet {
+		inOld[elem] = true
+		if !inDiff[elem] {
+			result.GoSet = append(result.GoSet, elem)
+		}
+	}
+	for _, elem := range diff.GoSet {
+		if !inOld[elem] {
+			result.GoSet = append(result.GoSet, elem)
+		}
+	}
+	return result
+}
+
+// applyMapDiff adds the keys of the diff missing from the old map, removes the keys with the same value
+// and updates the keys with a different value
+func applyMapDiff(old, diff ovsdb.OvsMap) ovsdb.OvsMap {
+	result := ovsdb.OvsMap{GoMap: map[interface{}]interface{}{}}
+	for key, value := range old.GoMap {
+		result.GoMap[key] = value
+	}
+	for key, value := range diff.GoMap {
+		if oldValue, ok := old.GoMap[key]; ok && oldValue == value {
+			delete(result.GoMap, key)
+		} else {
+			result.GoMap[key] = value
+		}
+	}
+	return result
+}
+
+func (db *dbFile) snapshot() (*Snapshot, error) {
+	clientDBModel, err := nbdb.FullDatabaseModel()
+	if err != nil {
+		return nil, err
+	}
+	dbModel, errs := model.NewDatabaseModel(*db.schema, clientDBModel)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to build NB database model: %v", errs)
+	}
+	var acls []*nbdb.ACL
+	var portGroups []*nbdb.PortGroup
+	var addressSets []*nbdb.AddressSet
+	var switches []*nbdb.LogicalSwitch
+	var ports []*nbdb.LogicalSwitchPort
+	for table, rows := range db.tables {
+		for uuid, row := range rows {
+			m, err := model.CreateModel(dbModel, table, &row, uuid)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s row %s: %w", table, uuid, err)
+			}
+			switch obj := m.(type) {
+			case *nbdb.ACL:
+				acls = append(acls, obj)
+			case *nbdb.PortGroup:
+				portGroups = append(portGroups, obj)
+			case *nbdb.AddressSet:
+				addressSets = append(addressSets, obj)
+			case *nbdb.LogicalSwitch:
+				switches = append(switches, obj)
+			case *nbdb.LogicalSwitchPort:
+				ports = append(ports, obj)
+			}
+		}
+	}
+	return newSnapshot(acls, portGroups, addressSets, switches, ports), nil
+}
+
+func (s *Snapshot) portGroupHasPort(portGroup, port string) bool {
+	return port != "" && s.portGroupPorts[portGroup].Has(port)
+}
+
+func (s *Snapshot) addressSetHasIP(addressSet string, ip net.IP) bool {
+	as := s.addressSets[addressSet]
+	if as == nil {
+		return false
+	}
+	for _, address := range as.Addresses {
+		if match, err := ipMatches(address, ip); err == nil && match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package policysim
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dbRecord returns a database file record of the given JSON content
+func dbRecord(magic, content string) string {
+	return fmt.Sprintf("OVSDB %s %d %x\n%s\n", magic, len(content), sha1.Sum([]byte(content)), content)
+}
+
+const (
+	testPGInsert = `{"Port_Group":{"pg-uuid":{"name":"pg1","ports":["set",[["uuid","port1-uuid"]]],` +
+		`"acls":["uuid","acl-uuid"],"external_ids":["map",[["owner","a"]]]}},` +
+		`"Logical_Switch_Port":{"port1-uuid":{"name":"ns_a","addresses":"0a:58:0a:80:01:03 10.128.1.3"},` +
+		`"port2-uuid":{"name":"ns_b","addresses":"0a:58:0a:80:01:04 10.128.1.4"}},` +
+		`"ACL":{"acl-uuid":{"direction":"to-lport","priority":1000,"action":"drop","match":"outport == @pg1","tier":2}},` +
+		`"Address_Set":{"as-uuid":{"name":"as1","addresses":["set",["10.0.0.1","10.0.0.2"]]}},` +
+		`"Logical_Switch":{"ls-uuid":{"name":"node1","ports":["set",[["uuid","port1-uuid"],["uuid","port2-uuid"]]]}},` +
+		`"NB_Global":{"global-uuid":{}},"_date":1700000000000}`
+	// the diff adds port2 and removes port1 from the port group, and updates its external IDs
+	testPGDiff = `{"Port_Group":{"pg-uuid":{"ports":["set",[["uuid","port1-uuid"],["uuid","port2-uuid"]]],` +
+		`"external_ids":["map",[["owner","a"],["new","b"]]]}},` +
+		`"Address_Set":{"as-uuid":null},"_date":1700000001000,"_is_diff":true}`
+)
+
+func checkTestSnapshot(t *testing.T, s *Snapshot) {
+	if assert.Len(t, s.portGroups, 1) {
+		pg := s.portGroups[0]
+		assert.Equal(t, "pg1", pg.Name)
+		assert.Equal(t, []string{"port2-uuid"}, pg.Ports)
+		assert.Equal(t, map[string]string{"new": "b"}, pg.ExternalIDs)
+		assert.Equal(t, []string{"acl-uuid"}, pg.ACLs)
+	}
+	assert.True(t, s.portGroupHasPort("pg1", "ns_b"))
+	assert.False(t, s.portGroupHasPort("pg1", "ns_a"))
+	assert.Empty(t, s.addressSets)
+	if assert.Contains(t, s.acls, "acl-uuid") {
+		acl := s.acls["acl-uuid"]
+		assert.Equal(t, "outport == @pg1", acl.Match)
+		assert.Equal(t, 2, acl.Tier)
+		assert.Equal(t, 1000, acl.Priority)
+	}
+	assert.Len(t, s.ports, 2)
+	if assert.NotNil(t, s.portSwitches["port1-uuid"]) {
+		assert.Equal(t, "node1", s.portSwitches["port1-uuid"].Name)
+	}
+	assert.Equal(t, []net.IP{net.ParseIP("10.128.1.4")}, getPortIPs(s.ports["port2-uuid"]))
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	schema := `{"name":"OVN_Northbound","version":"7.3.0","tables":{}}`
+	testcases := []struct {
+		desc        string
+		content     string
+		expectedErr string
+	}{
+		{
+			desc:    "standalone database",
+			content: dbRecord("JSON", schema) + dbRecord("JSON", testPGInsert) + dbRecord("JSON", testPGDiff),
+		},
+		{
+			desc: "clustered database",
+			content: dbRecord("CLUSTER", `{"name":"OVN_Northbound","cluster_id":"c","server_id":"s","local_address":"tcp:1.1.1.1:6643",`+
+				`"prev_term":1,"prev_index":1,"prev_servers":{},"prev_data":[`+schema+`,`+testPGInsert+`]}`) +
+				dbRecord("CLUSTER", `{"term":1,"index":2,"data":[null,`+testPGDiff+`],"eid":"e"}`) +
+				dbRecord("CLUSTER", `{"term":1,"vote":"s"}`),
+		},
+		{
+			desc:        "other database",
+			content:     dbRecord("JSON", `{"name":"OVN_Southbound"}`),
+			expectedErr: `database "OVN_Southbound" is not a OVN_Northbound database`,
+		},
+		{
+			desc:        "invalid header",
+			content:     "OVSDB JSON\n{}\n",
+			expectedErr: "invalid record header",
+		},
+		{
+			desc:        "truncated record",
+			content:     dbRecord("JSON", schema)[:30],
+			expectedErr: "failed to read record",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := LoadSnapshot(strings.NewReader(tc.content))
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				checkTestSnapshot(t, s)
+			}
+		})
+	}
+}
+
+func TestLoadSnapshotFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ovnnb_db.db")
+	content := dbRecord("JSON", `{"name":"OVN_Northbound"}`) + dbRecord("JSON", testPGInsert) + dbRecord("JSON", testPGDiff)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write database file: %v", err)
+	}
+	s, err := LoadSnapshotFile(path)
+	if assert.NoError(t, err) {
+		checkTestSnapshot(t, s)
+	}
+	_, err = LoadSnapshotFile(filepath.Join(t.TempDir(), "missing.db"))
+	assert.Error(t, err)
+}
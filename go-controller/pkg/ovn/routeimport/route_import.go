@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"maps"
 	"net"
+	"reflect"
+	"slices"
 	"sync"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 
 	controllerutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
 	nbdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -145,6 +148,7 @@ func (c *controller) ForgetNetwork(name string) {
 	delete(c.networkIDs, network.GetNetworkID())
 	delete(c.networks, name)
 	c.setTableForNetworkUnlocked(network.GetNetworkID(), noTable)
+	metrics.DeleteRouteImportPrefixes(name)
 
 	c.log.V(5).Info("Stopped tracking network", "name", name)
 }
@@ -158,7 +162,7 @@ func (c *controller) NeedsReconciliation(network util.NetInfo) bool {
 	}
 
 	// TODO check if overlay mode changed
-	return false
+	return !reflect.DeepEqual(c.networks[network.GetNetworkName()].GetRouteImportPolicy(), network.GetRouteImportPolicy())
 }
 
 func (c *controller) ReconcileNetwork(name string) error {
@@ -363,6 +367,12 @@ func (c *controller) syncNetwork(network string) error {
 		return fmt.Errorf("failed to get routes from OVN: %w", err)
 	}
 
+	expected, rejected := filterRoutes(info.GetRouteImportPolicy(), expected, actual)
+	if len(rejected) > 0 {
+		c.log.Info("Rejected BGP routes by import policy", "network", network, "prefixes", rejected)
+	}
+	metrics.UpdateRouteImportPrefixes(network, len(prefixesOf(expected)), len(rejected))
+
 	deletes := actual.Difference(expected)
 	adds := expected.Difference(actual)
 	if len(deletes)+len(adds) == 0 {
@@ -440,6 +450,75 @@ func (c *controller) getBGPRoutes(table int, ignoreSubnets []*net.IPNet) (sets.S
 	return routes, nil
 }
 
+// filterRoutes applies the import policy to the provided routes, returning
+// those accepted and the destination prefixes rejected. When the number of
+// prefixes exceeds the maximum allowed, prefixes already imported are
+// preferred over new ones so that the imported routes don't change needlessly.
+func filterRoutes(policy *util.RouteImportPolicy, routes, imported sets.Set[route]) (sets.Set[route], []string) {
+	if policy == nil {
+		return routes, nil
+	}
+
+	rejected := sets.New[string]()
+	accepted := sets.New[string]()
+	for prefix := range prefixesOf(routes) {
+		if !isPrefixAllowed(policy, prefix) {
+			rejected.Insert(prefix)
+			continue
+		}
+		accepted.Insert(prefix)
+	}
+
+	if policy.MaxPrefixes > 0 && accepted.Len() > policy.MaxPrefixes {
+		alreadyImported := prefixesOf(imported)
+		candidates := sets.List(accepted)
+		slices.SortStableFunc(candidates, func(a, b string) int {
+			switch {
+			case alreadyImported.Has(a) == alreadyImported.Has(b):
+				return 0
+			case alreadyImported.Has(a):
+				return -1
+			default:
+				return 1
+			}
+		})
+		for _, prefix := range candidates[policy.MaxPrefixes:] {
+			accepted.Delete(prefix)
+			rejected.Insert(prefix)
+		}
+	}
+
+	filtered := sets.New[route]()
+	for r := range routes {
+		if accepted.Has(r.dst) {
+			filtered.Insert(r)
+		}
+	}
+	return filtered, sets.List(rejected)
+}
+
+func isPrefixAllowed(policy *util.RouteImportPolicy, prefix string) bool {
+	_, dst, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return false
+	}
+	if ones, _ := dst.Mask.Size(); ones == 0 && policy.IgnoreDefaultRoute {
+		return false
+	}
+	if util.IsContainedInAnyCIDR(dst, policy.DeniedPrefixes...) {
+		return false
+	}
+	return len(policy.AllowedPrefixes) == 0 || util.IsContainedInAnyCIDR(dst, policy.AllowedPrefixes...)
+}
+
+func prefixesOf(routes sets.Set[route]) sets.Set[string] {
+	prefixes := sets.New[string]()
+	for r := range routes {
+		prefixes.Insert(r.dst)
+	}
+	return prefixes
+}
+
 func (c *controller) getOVNRoutes(router string) (sets.Set[route], map[route]string, error) {
 	start := time.Now()
 	lr := &nbdb.LogicalRouter{
@@ -2,6 +2,7 @@ package routeimport
 
 import (
 	"errors"
+	"net"
 	"sync"
 	"testing"
 
@@ -36,6 +37,7 @@ func Test_controller_syncNetwork(t *testing.T) {
 	udn.On("GetNetworkID").Return(1)
 	udn.On("Subnets").Return(nil)
 	udn.On("GetNetworkScopedGWRouterName", node).Return("router")
+	udn.On("GetRouteImportPolicy").Return(nil)
 
 	cudn := &multinetworkmocks.NetInfo{}
 	cudn.On("IsDefault").Return(false)
@@ -43,6 +45,21 @@ func Test_controller_syncNetwork(t *testing.T) {
 	cudn.On("GetNetworkID").Return(2)
 	cudn.On("Subnets").Return(nil)
 	cudn.On("GetNetworkScopedGWRouterName", node).Return("router")
+	cudn.On("GetRouteImportPolicy").Return(nil)
+
+	filtered := &multinetworkmocks.NetInfo{}
+	filtered.On("IsDefault").Return(false)
+	filtered.On("GetNetworkName").Return("filtered")
+	filtered.On("GetNetworkID").Return(3)
+	filtered.On("Subnets").Return(nil)
+	filtered.On("GetNetworkScopedGWRouterName", node).Return("router")
+	filtered.On("GetRouteImportPolicy").Return(&util.RouteImportPolicy{
+		AllowedPrefixes:    []*net.IPNet{ovntesting.MustParseIPNet("10.0.0.0/8"), ovntesting.MustParseIPNet("0.0.0.0/0")},
+		DeniedPrefixes:     []*net.IPNet{ovntesting.MustParseIPNet("10.2.0.0/16")},
+		MaxPrefixes:        2,
+		IgnoreDefaultRoute: true,
+	})
+	filteredRouterPort := types.GWRouterToExtSwitchPrefix + "router"
 
 	type fields struct {
 		networkIDs map[int]string
@@ -168,6 +185,39 @@ func Test_controller_syncNetwork(t *testing.T) {
 				&nbdb.LogicalRouterStaticRoute{UUID: "untouched-1", IPPrefix: "3.3.3.0/24", Nexthop: "3.3.3.2", ExternalIDs: map[string]string{controllerExternalIDKey: controllerName}},
 			},
 		},
+		{
+			name: "imports routes according to the route import policy",
+			args: args{"filtered"},
+			link: &netlink.Vrf{Table: 1002},
+			fields: fields{
+				networkIDs: map[int]string{3: "filtered"},
+				networks:   map[string]util.NetInfo{"filtered": filtered},
+			},
+			initial: []libovsdb.TestData{
+				&nbdb.LogicalRouter{Name: "router", StaticRoutes: []string{"keep"}},
+				&nbdb.LogicalRouterStaticRoute{UUID: "keep", IPPrefix: "10.4.0.0/24", Nexthop: "1.1.1.1", OutputPort: &filteredRouterPort, ExternalIDs: map[string]string{controllerExternalIDKey: controllerName}},
+			},
+			routes: []netlink.Route{
+				// rejected as default route
+				{Dst: ovntesting.MustParseIPNet("0.0.0.0/0"), Gw: ovntesting.MustParseIP("1.1.1.1")},
+				// rejected as not allowed
+				{Dst: ovntesting.MustParseIPNet("20.0.0.0/24"), Gw: ovntesting.MustParseIP("1.1.1.1")},
+				// rejected as denied
+				{Dst: ovntesting.MustParseIPNet("10.2.1.0/24"), Gw: ovntesting.MustParseIP("1.1.1.1")},
+				// rejected as exceeding the maximum number of prefixes
+				{Dst: ovntesting.MustParseIPNet("10.3.0.0/24"), Gw: ovntesting.MustParseIP("1.1.1.1")},
+				// imported, preferred as already imported
+				{Dst: ovntesting.MustParseIPNet("10.4.0.0/24"), Gw: ovntesting.MustParseIP("1.1.1.1")},
+				// imported
+				{Dst: ovntesting.MustParseIPNet("10.1.0.0/24"), MultiPath: []*netlink.NexthopInfo{{Gw: ovntesting.MustParseIP("1.1.1.1")}, {Gw: ovntesting.MustParseIP("1.1.1.2")}}},
+			},
+			expected: []libovsdb.TestData{
+				&nbdb.LogicalRouter{UUID: "router", Name: "router", StaticRoutes: []string{"keep", "add-1", "add-2"}},
+				&nbdb.LogicalRouterStaticRoute{UUID: "keep", IPPrefix: "10.4.0.0/24", Nexthop: "1.1.1.1", OutputPort: &filteredRouterPort, ExternalIDs: map[string]string{controllerExternalIDKey: controllerName}},
+				&nbdb.LogicalRouterStaticRoute{UUID: "add-1", IPPrefix: "10.1.0.0/24", Nexthop: "1.1.1.1", OutputPort: &filteredRouterPort, ExternalIDs: map[string]string{controllerExternalIDKey: controllerName}},
+				&nbdb.LogicalRouterStaticRoute{UUID: "add-2", IPPrefix: "10.1.0.0/24", Nexthop: "1.1.1.2", OutputPort: &filteredRouterPort, ExternalIDs: map[string]string{controllerExternalIDKey: controllerName}},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -223,6 +273,63 @@ func Test_controller_syncNetwork(t *testing.T) {
 	}
 }
 
+func Test_controller_NeedsReconciliation(t *testing.T) {
+	policy := &util.RouteImportPolicy{MaxPrefixes: 10}
+	tests := []struct {
+		name    string
+		known   bool
+		current *util.RouteImportPolicy
+		updated *util.RouteImportPolicy
+		want    bool
+	}{
+		{
+			name:    "does not need reconciliation if network not known",
+			updated: policy,
+		},
+		{
+			name:  "does not need reconciliation if nothing changed",
+			known: true,
+		},
+		{
+			name:    "does not need reconciliation if route import policy did not change",
+			known:   true,
+			current: policy,
+			updated: policy.Copy(),
+		},
+		{
+			name:    "needs reconciliation if route import policy changed",
+			known:   true,
+			current: policy,
+			updated: &util.RouteImportPolicy{MaxPrefixes: 5},
+			want:    true,
+		},
+		{
+			name:    "needs reconciliation if route import policy removed",
+			known:   true,
+			current: policy,
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			current := &multinetworkmocks.NetInfo{}
+			current.On("GetRouteImportPolicy").Return(tt.current)
+			updated := &multinetworkmocks.NetInfo{}
+			updated.On("GetNetworkName").Return("udn")
+			updated.On("GetRouteImportPolicy").Return(tt.updated)
+
+			c := &controller{networks: map[string]util.NetInfo{}}
+			if tt.known {
+				c.networks["udn"] = current
+			}
+
+			g.Expect(c.NeedsReconciliation(updated)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func Test_controller_syncRouteUpdate(t *testing.T) {
 	defaultNetwork := &util.DefaultNetInfo{}
 	type fields struct {
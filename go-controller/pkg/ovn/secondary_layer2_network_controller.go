@@ -11,6 +11,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/pod"
@@ -329,9 +330,11 @@ func NewSecondaryLayer2NetworkController(
 					logicalPortCache:            portCache,
 					namespaces:                  make(map[string]*namespaceInfo),
 					namespacesMutex:             sync.Mutex{},
+					multicastPeerNamespaces:     make(map[string]sets.Set[string]),
 					addressSetFactory:           addressSetFactory,
 					networkPolicies:             syncmap.NewSyncMap[*networkPolicy](),
 					sharedNetpolPortGroups:      syncmap.NewSyncMap[*defaultDenyPortGroups](),
+					sharedNetpolGressRules:      syncmap.NewSyncMap[*sharedGressRule](),
 					podSelectorAddressSets:      syncmap.NewSyncMap[*PodSelectorAddressSet](),
 					stopChan:                    stopChan,
 					wg:                          &sync.WaitGroup{},
@@ -383,6 +386,7 @@ func NewSecondaryLayer2NetworkController(
 		oc.podAnnotationAllocator = pod.NewPodAnnotationAllocator(
 			oc.GetNetInfo(),
 			cnci.watchFactory.PodCoreInformer().Lister(),
+			cnci.watchFactory.PodCoreInformer().Informer().GetIndexer(),
 			cnci.kube,
 			claimsReconciler)
 	}
@@ -454,6 +458,10 @@ func (oc *SecondaryLayer2NetworkController) Cleanup() error {
 		return true
 	})
 
+	if err := oc.deleteLayer2GatewayBFDs(); err != nil {
+		klog.Errorf("Failed to delete gateway BFDs on network %q: %v", networkName, err)
+	}
+
 	// remove load balancer groups
 	lbGroups := make([]*nbdb.LoadBalancerGroup, 0, 3)
 	for _, lbGroupUUID := range []string{oc.switchLoadBalancerGroupUUID, oc.clusterLoadBalancerGroupUUID, oc.routerLoadBalancerGroupUUID} {
@@ -597,6 +605,9 @@ func (oc *SecondaryLayer2NetworkController) addUpdateLocalNodeEvent(node *corev1
 					} else {
 						err = oc.deleteUDNClusterSubnetEgressSNAT(gwConfig.hostSubnets, gwManager.gwRouterName)
 					}
+					if err == nil {
+						err = oc.syncLayer2GatewayECMP(node)
+					}
 					if err != nil {
 						errs = append(errs, err)
 						oc.gatewaysFailed.Store(node.Name, true)
@@ -661,6 +672,10 @@ func (oc *SecondaryLayer2NetworkController) addUpdateRemoteNodeEvent(node *corev
 				err = fmt.Errorf("failed to add the remote zone node %s's remote LRP, %w", node.Name, err)
 				errs = append(errs, err)
 				oc.syncZoneICFailed.Store(node.Name, true)
+			} else if err := oc.syncLayer2GatewayECMPForLocalNodes(); err != nil {
+				err = fmt.Errorf("failed to sync the ECMP gateway routes towards remote zone node %s: %w", node.Name, err)
+				errs = append(errs, err)
+				oc.syncZoneICFailed.Store(node.Name, true)
 			} else {
 				oc.syncZoneICFailed.Delete(node.Name)
 			}
@@ -730,6 +745,11 @@ func (oc *SecondaryLayer2NetworkController) addPortForRemoteNodeGR(node *corev1.
 }
 
 func (oc *SecondaryLayer2NetworkController) deleteNodeEvent(node *corev1.Node) error {
+	if _, local := oc.localZoneNodes.Load(node.Name); local && oc.IsPrimaryNetwork() {
+		if err := oc.deleteLayer2GatewayECMP(oc.GetNetworkScopedGWRouterName(node.Name)); err != nil {
+			return fmt.Errorf("failed to cleanup ECMP gateway routes on node %q: %w", node.Name, err)
+		}
+	}
 	if err := oc.gatewayManagerForNode(node.Name).Cleanup(); err != nil {
 		return fmt.Errorf("failed to cleanup gateway on node %q: %w", node.Name, err)
 	}
@@ -737,6 +757,11 @@ func (oc *SecondaryLayer2NetworkController) deleteNodeEvent(node *corev1.Node) e
 	oc.localZoneNodes.Delete(node.Name)
 	oc.mgmtPortFailed.Delete(node.Name)
 	oc.syncEIPNodeRerouteFailed.Delete(node.Name)
+	// the gateway routers of the local nodes no longer use the gateway router
+	// of the deleted node
+	if err := oc.syncLayer2GatewayECMPForLocalNodes(); err != nil {
+		return fmt.Errorf("failed to sync ECMP gateway routes after deleting node %q: %w", node.Name, err)
+	}
 	return nil
 }
 
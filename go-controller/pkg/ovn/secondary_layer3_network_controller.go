@@ -347,9 +347,11 @@ func NewSecondaryLayer3NetworkController(
 				logicalPortCache:            portCache,
 				namespaces:                  make(map[string]*namespaceInfo),
 				namespacesMutex:             sync.Mutex{},
+				multicastPeerNamespaces:     make(map[string]sets.Set[string]),
 				addressSetFactory:           addressSetFactory,
 				networkPolicies:             syncmap.NewSyncMap[*networkPolicy](),
 				sharedNetpolPortGroups:      syncmap.NewSyncMap[*defaultDenyPortGroups](),
+				sharedNetpolGressRules:      syncmap.NewSyncMap[*sharedGressRule](),
 				podSelectorAddressSets:      syncmap.NewSyncMap[*PodSelectorAddressSet](),
 				stopChan:                    stopChan,
 				wg:                          &sync.WaitGroup{},
@@ -393,6 +395,7 @@ func NewSecondaryLayer3NetworkController(
 		podAnnotationAllocator := pod.NewPodAnnotationAllocator(
 			oc.GetNetInfo(),
 			cnci.watchFactory.PodCoreInformer().Lister(),
+			cnci.watchFactory.PodCoreInformer().Informer().GetIndexer(),
 			cnci.kube,
 			nil)
 		oc.podAnnotationAllocator = podAnnotationAllocator
@@ -539,6 +542,7 @@ func (oc *SecondaryLayer3NetworkController) Cleanup() error {
 	if err != nil {
 		return fmt.Errorf("failed to deleting routers/switches of network %s: %v", netName, err)
 	}
+	oc.cleanupANPStatus()
 
 	if config.OVNKubernetesFeature.EnableInterconnect {
 		if err = oc.zoneICHandler.Cleanup(); err != nil {
@@ -602,6 +606,12 @@ func (oc *SecondaryLayer3NetworkController) run() error {
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableAdminNetworkPolicy && oc.IsPrimaryNetwork() {
+		if err := oc.runANPController(); err != nil {
+			return err
+		}
+	}
+
 	// Add ourselves to the route import manager
 	if oc.routeImportManager != nil {
 		err := oc.routeImportManager.AddNetwork(oc.GetNetInfo())
@@ -10,6 +10,7 @@ import (
 	mnpapi "github.com/k8snetworkplumbingwg/multi-networkpolicy/pkg/apis/k8s.cni.cncf.io/v1beta1"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/pod"
@@ -210,9 +211,11 @@ func NewSecondaryLocalnetNetworkController(
 					logicalPortCache:            NewPortCache(stopChan),
 					namespaces:                  make(map[string]*namespaceInfo),
 					namespacesMutex:             sync.Mutex{},
+					multicastPeerNamespaces:     make(map[string]sets.Set[string]),
 					addressSetFactory:           addressSetFactory,
 					networkPolicies:             syncmap.NewSyncMap[*networkPolicy](),
 					sharedNetpolPortGroups:      syncmap.NewSyncMap[*defaultDenyPortGroups](),
+					sharedNetpolGressRules:      syncmap.NewSyncMap[*sharedGressRule](),
 					podSelectorAddressSets:      syncmap.NewSyncMap[*PodSelectorAddressSet](),
 					stopChan:                    stopChan,
 					wg:                          &sync.WaitGroup{},
@@ -238,6 +241,7 @@ func NewSecondaryLocalnetNetworkController(
 		oc.podAnnotationAllocator = pod.NewPodAnnotationAllocator(
 			netInfo,
 			cnci.watchFactory.PodCoreInformer().Lister(),
+			cnci.watchFactory.PodCoreInformer().Informer().GetIndexer(),
 			cnci.kube,
 			claimsReconciler)
 	}
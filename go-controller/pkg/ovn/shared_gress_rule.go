@@ -0,0 +1,415 @@
+package ovn
+
+import (
+	"fmt"
+	"strings"
+
+	knet "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// sharedGressRule implements a gress rule that is identical for multiple network policies.
+// It owns a port group with the local pods of all these policies, and the ACLs of the rule applied to that
+// port group, instead of every policy having its own copy of the ACLs.
+// sharedGressRule is a shared object and should only be used with bnc.sharedNetpolGressRules key lock.
+type sharedGressRule struct {
+	pgName string
+	// portToPolicies: map[portName]sets.Set[policyKey]
+	// store policies that are using every port in the map
+	// this map should be atomically updated with db operations
+	// if adding a port to db for a policy fails, map shouldn't be changed
+	portToPolicies map[string]sets.Set[string]
+	// policies is a set of policies that use this rule
+	// policy keys must be unique, and it can be retrieved with (np *networkPolicy) getKey()
+	policies sets.Set[string]
+}
+
+// addPortsForPolicy adds port-policy association for the shared rule port group and
+// returns a list of new port UUIDs to add to the port group.
+func (rule *sharedGressRule) addPortsForPolicy(npKey string, portNamesToUUIDs map[string]string) []string {
+	ports := []string{}
+	for portName, portUUID := range portNamesToUUIDs {
+		// if this is the first policy referencing this pod, then we
+		// need to add it to the port group.
+		if rule.portToPolicies[portName].Len() == 0 {
+			ports = append(ports, portUUID)
+			rule.portToPolicies[portName] = sets.Set[string]{}
+		}
+		rule.portToPolicies[portName].Insert(npKey)
+	}
+	return ports
+}
+
+// deletePortsForPolicy deletes port-policy association for the shared rule port group,
+// and returns a list of port UUIDs to delete from the port group.
+func (rule *sharedGressRule) deletePortsForPolicy(npKey string, portNamesToUUIDs map[string]string) []string {
+	ports := []string{}
+	for portName, portUUID := range portNamesToUUIDs {
+		// Delete and Len can be used for zero-value nil set
+		rule.portToPolicies[portName].Delete(npKey)
+		if rule.portToPolicies[portName].Len() == 0 {
+			ports = append(ports, portUUID)
+			delete(rule.portToPolicies, portName)
+		}
+	}
+	return ports
+}
+
+// isNetworkPolicyStateless returns true if the ACLs of the given policy should be created as stateless ACLs.
+// network policy will be annotated with this
+// annotation -- [ "k8s.ovn.org/acl-stateless": "true"] for the ingress/egress
+// policies to be added as stateless OVN ACL's.
+// if the above annotation is not present or set to false in network policy,
+// then corresponding egress/ingress policies will be added as stateful OVN ACL's.
+func isNetworkPolicyStateless(policy *knet.NetworkPolicy) bool {
+	if !config.OVNKubernetesFeature.EnableStatelessNetPol {
+		return false
+	}
+	// look for stateless annotation if the statelessNetPol feature flag is enabled
+	return policy.Annotations[ovnStatelessNetPolAnnotationName] == "true"
+}
+
+// isNetworkPolicyStatelessReply returns true if the given stateless policy opted in to allow its reply traffic
+// with stateless ACLs in the other direction, with the annotation [ "k8s.ovn.org/acl-stateless-reply": "true"].
+// Without it, the reply traffic has to be allowed by other policies, since it is not related to a connection
+// committed to conntrack.
+func isNetworkPolicyStatelessReply(policy *knet.NetworkPolicy) bool {
+	return isNetworkPolicyStateless(policy) && policy.Annotations[ovnStatelessReplyNetPolAnnotationName] == "true"
+}
+
+// getSharedGressRuleSpec returns a normalized description of the traffic allowed by a gress rule of the given
+// policy. Identical rules of different network policies have the same spec.
+// Ports and ipBlocks keep their order, since ACL matches and IDs depend on it, while selector-based peers are
+// sorted, since they are all merged into the same address set match.
+func getSharedGressRuleSpec(policy *knet.NetworkPolicy, policyType knet.PolicyType, ports []knet.NetworkPolicyPort,
+	peers []knet.NetworkPolicyPeer) string {
+	// use gressPolicy to normalize ports the same way as ACLs do
+	gp := &gressPolicy{}
+	for i := range ports {
+		gp.addPortPolicy(&ports[i])
+	}
+	portSpecs := make([]string, 0, len(gp.portPolicies))
+	for _, pp := range gp.portPolicies {
+		portSpecs = append(portSpecs, fmt.Sprintf("%s/%d-%d", pp.Protocol, pp.Port, pp.EndPort))
+	}
+	ipBlockSpecs := []string{}
+	selectorSpecs := sets.New[string]()
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			ipBlockSpecs = append(ipBlockSpecs, peer.IPBlock.CIDR+"-"+strings.Join(peer.IPBlock.Except, ","))
+			continue
+		}
+		if peer.PodSelector == nil && peer.NamespaceSelector == nil {
+			// ignored by setupGressPolicy
+			continue
+		}
+		if useNamespaceAddrSet(peer) {
+			selectorSpecs.Insert("ns:" + shortLabelSelectorString(peer.NamespaceSelector))
+			continue
+		}
+		podSelector := peer.PodSelector
+		if podSelector == nil {
+			podSelector = &metav1.LabelSelector{}
+		}
+		// pod selector address set key includes policy namespace when namespace selector is nil
+		selectorSpecs.Insert("pod:" + getPodSelectorKey(podSelector, peer.NamespaceSelector, policy.Namespace))
+	}
+	return fmt.Sprintf("%s|stateless=%t|statelessReply=%t|ports=%s|ipBlocks=%s|peers=%s", policyType,
+		isNetworkPolicyStateless(policy), isNetworkPolicyStatelessReply(policy), strings.Join(portSpecs, ";"), strings.Join(ipBlockSpecs, ";"), strings.Join(sets.List(selectorSpecs), ";"))
+}
+
+// getSharedGressRuleKey returns the key of the shared rule for a rule spec and the allow ACL log level of
+// the policy namespace, since every shared ACL can only have one log level.
+func getSharedGressRuleKey(spec string, aclLogging *libovsdbutil.ACLLoggingLevels) string {
+	return util.HashForOVN(spec + "|log=" + aclLogging.Allow)
+}
+
+func (bnc *BaseNetworkController) getSharedGressRulePortGroupDbIDs(key string) *libovsdbops.DbObjectIDs {
+	return libovsdbops.NewDbObjectIDs(libovsdbops.PortGroupNetpolSharedRule, bnc.controllerName,
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.ObjectNameKey: key,
+		})
+}
+
+// getExpectedSharedGressRules returns the keys of the shared rules required by the given policies.
+func (bnc *BaseNetworkController) getExpectedSharedGressRules(policies []*knet.NetworkPolicy) sets.Set[string] {
+	expectedRules := sets.New[string]()
+	if !config.OVNKubernetesFeature.EnableNetworkPolicyRuleSharing {
+		return expectedRules
+	}
+	for _, policy := range policies {
+		aclLogging := &libovsdbutil.ACLLoggingLevels{}
+		nsInfo, nsUnlock := bnc.getNamespaceLocked(policy.Namespace, true)
+		if nsInfo != nil {
+			aclLogging.Allow = nsInfo.aclLogging.Allow
+			nsUnlock()
+		}
+		for _, ingress := range policy.Spec.Ingress {
+			spec := getSharedGressRuleSpec(policy, knet.PolicyTypeIngress, ingress.Ports, ingress.From)
+			expectedRules.Insert(getSharedGressRuleKey(spec, aclLogging))
+		}
+		for _, egress := range policy.Spec.Egress {
+			spec := getSharedGressRuleSpec(policy, knet.PolicyTypeEgress, egress.Ports, egress.To)
+			expectedRules.Insert(getSharedGressRuleKey(spec, aclLogging))
+		}
+	}
+	return expectedRules
+}
+
+// getGressPolicies returns both ingress and egress policies of the network policy.
+func (np *networkPolicy) getGressPolicies() []*gressPolicy {
+	gressPolicies := make([]*gressPolicy, 0, len(np.ingressPolicies)+len(np.egressPolicies))
+	gressPolicies = append(gressPolicies, np.ingressPolicies...)
+	return append(gressPolicies, np.egressPolicies...)
+}
+
+// getUsedSharedGressRuleKeys returns the keys of the shared rules currently set for the gress policies
+// of the network policy. Multiple gress rules of the same policy may use the same shared rule.
+func (np *networkPolicy) getUsedSharedGressRuleKeys() sets.Set[string] {
+	keys := sets.New[string]()
+	for _, gp := range np.getGressPolicies() {
+		if gp.sharedRuleKey != "" {
+			keys.Insert(gp.sharedRuleKey)
+		}
+	}
+	return keys
+}
+
+// addPolicyToSharedGressRule sets the shared rule for the given gress policy according to its spec and
+// the log level, and adds the policy with the given ports to that rule.
+// The shared rule port group and ACLs are created if the rule doesn't exist yet.
+// Must be called with networkPolicy Lock.
+func (bnc *BaseNetworkController) addPolicyToSharedGressRule(np *networkPolicy, gp *gressPolicy,
+	aclLogging *libovsdbutil.ACLLoggingLevels, portNamesToUUIDs map[string]string) error {
+	key := getSharedGressRuleKey(gp.sharedRuleSpec, aclLogging)
+	pgDbIDs := bnc.getSharedGressRulePortGroupDbIDs(key)
+	pgName := libovsdbutil.GetPortGroupName(pgDbIDs)
+	prevKey, prevPGName := gp.sharedRuleKey, gp.sharedPortGroupName
+	gp.sharedRuleKey, gp.sharedPortGroupName = key, pgName
+
+	err := bnc.sharedNetpolGressRules.DoWithLock(key, func(key string) error {
+		rule, loaded := bnc.sharedNetpolGressRules.Load(key)
+		if !loaded {
+			rule = &sharedGressRule{
+				pgName:         pgName,
+				portToPolicies: map[string]sets.Set[string]{},
+				policies:       sets.New[string](),
+			}
+		}
+		ports := rule.addPortsForPolicy(np.getKey(), portNamesToUUIDs)
+		var err error
+		defer func() {
+			if err != nil {
+				rule.deletePortsForPolicy(np.getKey(), portNamesToUUIDs)
+			}
+		}()
+		var ops []ovsdb.Operation
+		if !loaded {
+			// buildLocalPodACLs uses gp.sharedRuleKey for ACL IDs
+			acls, _ := gp.buildLocalPodACLs(pgName, aclLogging)
+			ops, err = libovsdbops.CreateOrUpdateACLsOps(bnc.nbClient, nil, bnc.GetSamplingConfig(), acls...)
+			if err != nil {
+				return fmt.Errorf("failed to create shared rule %s ACL ops: %v", key, err)
+			}
+			lsps := make([]*nbdb.LogicalSwitchPort, 0, len(ports))
+			for _, portUUID := range ports {
+				lsps = append(lsps, &nbdb.LogicalSwitchPort{UUID: portUUID})
+			}
+			pg := libovsdbutil.BuildPortGroup(pgDbIDs, lsps, acls)
+			ops, err = libovsdbops.CreateOrUpdatePortGroupsOps(bnc.nbClient, ops, pg)
+			if err != nil {
+				return fmt.Errorf("failed to create shared rule %s port group ops: %v", key, err)
+			}
+		} else if len(ports) > 0 {
+			ops, err = libovsdbops.AddPortsToPortGroupOps(bnc.nbClient, nil, pgName, ports...)
+			if err != nil {
+				return fmt.Errorf("failed to add ports to shared rule %s port group ops: %v", key, err)
+			}
+		}
+		if _, err = libovsdbops.TransactAndCheck(bnc.nbClient, ops); err != nil {
+			return fmt.Errorf("failed to add policy to shared rule %s: %v", key, err)
+		}
+		rule.policies.Insert(np.getKey())
+		np.sharedRuleKeys.Insert(key)
+		if !loaded {
+			bnc.sharedNetpolGressRules.LoadOrStore(key, rule)
+		}
+		return nil
+	})
+	if err != nil {
+		gp.sharedRuleKey, gp.sharedPortGroupName = prevKey, prevPGName
+	}
+	return err
+}
+
+// delPolicyFromSharedGressRule deletes the given ports of the policy from the shared rule, and the policy itself.
+// The shared rule port group, together with its ACLs, is deleted when the last policy stops using it.
+// Must be called with networkPolicy Lock.
+func (bnc *BaseNetworkController) delPolicyFromSharedGressRule(np *networkPolicy, key string,
+	portNamesToUUIDs map[string]string) error {
+	return bnc.sharedNetpolGressRules.DoWithLock(key, func(key string) error {
+		rule, loaded := bnc.sharedNetpolGressRules.Load(key)
+		if !loaded || !rule.policies.Has(np.getKey()) {
+			// policy was already deleted from the rule
+			np.sharedRuleKeys.Delete(key)
+			return nil
+		}
+		ports := rule.deletePortsForPolicy(np.getKey(), portNamesToUUIDs)
+		rule.policies.Delete(np.getKey())
+		var err error
+		defer func() {
+			if err != nil {
+				rule.addPortsForPolicy(np.getKey(), portNamesToUUIDs)
+				rule.policies.Insert(np.getKey())
+			}
+		}()
+		var ops []ovsdb.Operation
+		if rule.policies.Len() == 0 {
+			// ACLs are garbage-collected with the port group
+			ops, err = libovsdbops.DeletePortGroupsOps(bnc.nbClient, nil, rule.pgName)
+		} else {
+			ops, err = libovsdbops.DeletePortsFromPortGroupOps(bnc.nbClient, nil, rule.pgName, ports...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get ops to delete policy from shared rule %s: %v", key, err)
+		}
+		if _, err = libovsdbops.TransactAndCheck(bnc.nbClient, ops); err != nil {
+			return fmt.Errorf("failed to delete policy from shared rule %s: %v", key, err)
+		}
+		np.sharedRuleKeys.Delete(key)
+		if rule.policies.Len() == 0 {
+			bnc.sharedNetpolGressRules.Delete(key)
+		}
+		return nil
+	})
+}
+
+// sharedGressRulesAddPorts adds ports to the port groups of the shared rules used by the network policy.
+// It only adds new ports that do not already exist in the shared rules port groups.
+// Must be called with networkPolicy RLock.
+func (bnc *BaseNetworkController) sharedGressRulesAddPorts(np *networkPolicy, portNamesToUUIDs map[string]string) error {
+	for _, key := range sets.List(np.sharedRuleKeys) {
+		err := bnc.sharedNetpolGressRules.DoWithLock(key, func(key string) error {
+			rule, loaded := bnc.sharedNetpolGressRules.Load(key)
+			if !loaded {
+				return fmt.Errorf("shared rule %s doesn't exist", key)
+			}
+			ports := rule.addPortsForPolicy(np.getKey(), portNamesToUUIDs)
+			if len(ports) == 0 {
+				return nil
+			}
+			ops, err := libovsdbops.AddPortsToPortGroupOps(bnc.nbClient, nil, rule.pgName, ports...)
+			if err == nil {
+				_, err = libovsdbops.TransactAndCheck(bnc.nbClient, ops)
+			}
+			if err != nil {
+				rule.deletePortsForPolicy(np.getKey(), portNamesToUUIDs)
+				return fmt.Errorf("unable to add ports to shared rule %s port group: %v", key, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sharedGressRulesDeletePorts deletes ports from the port groups of the shared rules used by the network policy.
+// Ports are only deleted from the port groups when no other policy using the shared rule selects them.
+// Must be called with networkPolicy RLock.
+func (bnc *BaseNetworkController) sharedGressRulesDeletePorts(np *networkPolicy, portNamesToUUIDs map[string]string) error {
+	for _, key := range sets.List(np.sharedRuleKeys) {
+		err := bnc.sharedNetpolGressRules.DoWithLock(key, func(key string) error {
+			rule, loaded := bnc.sharedNetpolGressRules.Load(key)
+			if !loaded {
+				klog.Infof("Skip delete ports from shared rule %s: rule doesn't exist", key)
+				return nil
+			}
+			ports := rule.deletePortsForPolicy(np.getKey(), portNamesToUUIDs)
+			if len(ports) == 0 {
+				return nil
+			}
+			ops, err := libovsdbops.DeletePortsFromPortGroupOps(bnc.nbClient, nil, rule.pgName, ports...)
+			if err == nil {
+				_, err = libovsdbops.TransactAndCheck(bnc.nbClient, ops)
+			}
+			if err != nil {
+				rule.addPortsForPolicy(np.getKey(), portNamesToUUIDs)
+				return fmt.Errorf("unable to delete ports from shared rule %s port group: %v", key, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delPolicyFromSharedGressRules deletes the network policy with all its local pods from the shared rules it uses.
+// Must be called with networkPolicy Lock.
+func (bnc *BaseNetworkController) delPolicyFromSharedGressRules(np *networkPolicy) error {
+	portNamesToUUIDs := map[string]string{}
+	np.localPods.Range(func(key, value interface{}) bool {
+		portNamesToUUIDs[key.(string)] = value.(string)
+		return true
+	})
+	for _, key := range sets.List(np.sharedRuleKeys) {
+		if err := bnc.delPolicyFromSharedGressRule(np, key, portNamesToUUIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateSharedGressRulesLogging moves the gress policies of the network policy to the shared rules
+// with the new log level, since ACLs of a shared rule have a single log level for all policies.
+// Policy is added to the new rules before it is deleted from the old ones, to keep allowing the traffic.
+func (bnc *BaseNetworkController) updateSharedGressRulesLogging(np *networkPolicy, aclLogging *libovsdbutil.ACLLoggingLevels) error {
+	// gress policies shared rules are changed, take the write lock to sync with local pod handlers
+	np.Lock()
+	defer np.Unlock()
+	if np.deleted {
+		return nil
+	}
+	portNamesToUUIDs := map[string]string{}
+	np.localPods.Range(func(key, value interface{}) bool {
+		portNamesToUUIDs[key.(string)] = value.(string)
+		return true
+	})
+	for _, gp := range np.getGressPolicies() {
+		if gp.sharedRuleKey == "" || gp.sharedRuleKey == getSharedGressRuleKey(gp.sharedRuleSpec, aclLogging) {
+			continue
+		}
+		if err := bnc.addPolicyToSharedGressRule(np, gp, aclLogging, portNamesToUUIDs); err != nil {
+			return err
+		}
+	}
+	// delete policy from the rules that are not used by any gress policy anymore,
+	// including the ones left by the previous failed attempts
+	for _, key := range sets.List(np.sharedRuleKeys.Difference(np.getUsedSharedGressRuleKeys())) {
+		if err := bnc.delPolicyFromSharedGressRule(np, key, portNamesToUUIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getGressPolicyPortGroupName returns the name of the port group the gress policy ACLs are applied to.
+func (np *networkPolicy) getGressPolicyPortGroupName(gp *gressPolicy) string {
+	if gp.sharedRuleKey != "" {
+		return gp.sharedPortGroupName
+	}
+	return np.portGroupName
+}
@@ -108,6 +108,10 @@ func (zic *ZoneChassisHandler) SyncNodes(kNodes []interface{}) error {
 	}
 
 	for _, ch := range chassis {
+		if IsPeerClusterChassis(ch) {
+			// peer cluster chassis are handled by the PeerClusterController
+			continue
+		}
 		if ch.OtherConfig != nil && strings.ToLower(ch.OtherConfig["is-remote"]) == "true" {
 			if !foundNodes.Has(ch.Hostname) {
 				// Its a stale remote chassis, delete it.
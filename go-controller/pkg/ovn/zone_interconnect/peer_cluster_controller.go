@@ -0,0 +1,764 @@
+package zoneinterconnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	metav1apply "k8s.io/client-go/applyconfigurations/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	libovsdbcache "github.com/ovn-org/libovsdb/cache"
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/model"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
+	peerclusterapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1"
+	peerclusterapply "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/applyconfiguration/peercluster/v1"
+	peerclusterclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/clientset/versioned"
+	peerclusterlisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/listers/peercluster/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	ovsops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops/ovs"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/vswitchd"
+)
+
+const (
+	// peerClusterExternalID and peerNodeExternalID identify the peer cluster
+	// node of remote chassis (other_config) and transit switch ports
+	// (external_ids)
+	peerClusterExternalID = "peer-cluster"
+	peerNodeExternalID    = "peer-node"
+	// icPeerClusterExternalID and icPeerNodeExternalID identify the peer
+	// cluster node of static routes, like "ic-node" does for remote zone nodes
+	icPeerClusterExternalID = "ic-peer-cluster"
+	icPeerNodeExternalID    = "ic-peer-node"
+
+	// peerNodesReachableConditionPrefix is the prefix of the type of the
+	// condition each zone reports on the reachability of the peer nodes
+	peerNodesReachableConditionPrefix = "PeerNodesReachable-"
+	// maxReportedUnreachablePeerNodes is the number of unreachable peer nodes
+	// listed in the status
+	maxReportedUnreachablePeerNodes = 10
+)
+
+/*
+ * PeerClusterController connects the pods of the default network with the pods
+ * of other ovn-kubernetes clusters described by PeerCluster resources. Pods of
+ * peered clusters talk directly over Geneve, as pods of different zones of the
+ * same cluster do, without going through a gateway router.
+ *
+ * For each node of a peer cluster, in the same way it is done for the nodes of
+ * remote zones:
+ *    - A remote chassis with a geneve encap towards the tunnel endpoint of the
+ *      node is created in the OVN Southbound DB. The chassis hostname is
+ *      <peer_cluster>_<peer_node>.
+ *    - A logical switch port of type "remote" named
+ *      tstor-<peer_cluster>_<peer_node> is created in the transit switch with
+ *      the tunnel key and addresses of the node transit switch port, and bound
+ *      to the remote chassis.
+ *    - Static routes {IPPrefix: "peer_node_pod_subnet", Nexthop: "peer_node_transit_switch_port_ip"}
+ *      are added in the ovn_cluster_router.
+ *
+ * The peer cluster is expected to have a PeerCluster describing the nodes of
+ * this cluster, so that traffic can flow back.
+ *
+ * PeerClusters are validated before being configured: the peer pod subnets must
+ * not overlap with the cluster, service, join, masquerade or transit subnets
+ * nor with the pod subnets of other peer clusters, and the tunnel keys, chassis
+ * IDs and transit switch port IPs must not be in use by the local nodes nor by
+ * other peer clusters. On conflicts among peer clusters, the one created first
+ * wins. The local nodes are watched as well, since they claim node IDs, chassis
+ * IDs and transit switch port IPs.
+ *
+ * Each zone reports the outcome in the PeerCluster status, with a
+ * PeerNodesReachable-<zone> condition telling whether the tunnels towards the
+ * peer nodes are up, as reported by the local OVS database: the BFD state of
+ * the tunnel interface when ovn-controller runs BFD on it, its link state
+ * otherwise. The reachability is unknown when the OVS database is not
+ * available, e.g. in multi node zones.
+ */
+
+// PeerClusterController configures the interconnect resources for the nodes of
+// peer clusters
+type PeerClusterController struct {
+	// zic is the default network zone interconnect handler
+	zic    *ZoneInterconnectHandler
+	zone   string
+	client peerclusterclientset.Interface
+	// ovsClient is the client of the local OVS database, nil if not available
+	ovsClient libovsdbclient.Client
+
+	peerClusterLister peerclusterlisters.PeerClusterLister
+	nodeLister        corev1listers.NodeLister
+
+	controller     controller.Controller
+	nodeController controller.Controller
+}
+
+// peerNode is the parsed representation of a peer cluster node
+type peerNode struct {
+	name           string
+	chassisID      string
+	tunnelEndpoint string
+	tunnelKey      int
+	// transitIPs are sorted with the IPv4 address first, matching the order
+	// used to derive the MAC address of the peer transit router port
+	transitIPs []*net.IPNet
+	podSubnets []*net.IPNet
+}
+
+// peerNodesHealth is the reachability of the nodes of a peer cluster from the
+// local zone
+type peerNodesHealth struct {
+	// known is false when the tunnel status is not available
+	known bool
+	// unreachable maps the names of the unreachable peer nodes to the reason
+	unreachable map[string]string
+}
+
+// NewPeerClusterController returns a new PeerClusterController for the given
+// default network zone interconnect handler
+func NewPeerClusterController(zic *ZoneInterconnectHandler, client peerclusterclientset.Interface,
+	ovsClient libovsdbclient.Client, wf *factory.WatchFactory, zone string) *PeerClusterController {
+	c := &PeerClusterController{
+		zic:               zic,
+		zone:              zone,
+		client:            client,
+		ovsClient:         ovsClient,
+		peerClusterLister: wf.PeerClusterInformer().Lister(),
+		nodeLister:        wf.NodeCoreInformer().Lister(),
+	}
+
+	controllerConfig := &controller.ControllerConfig[peerclusterapi.PeerCluster]{
+		RateLimiter:    workqueue.DefaultTypedControllerRateLimiter[string](),
+		Informer:       wf.PeerClusterInformer().Informer(),
+		Lister:         wf.PeerClusterInformer().Lister().List,
+		ObjNeedsUpdate: peerClusterNeedsUpdate,
+		// PeerClusters might conflict with each other, so all of them are
+		// synced together whatever changed
+		Reconcile:   func(string) error { return c.sync() },
+		Threadiness: 1,
+	}
+	c.controller = controller.NewController[peerclusterapi.PeerCluster]("peer-cluster-controller", controllerConfig)
+
+	nodeControllerConfig := &controller.ControllerConfig[corev1.Node]{
+		RateLimiter:    workqueue.DefaultTypedControllerRateLimiter[string](),
+		Informer:       wf.NodeCoreInformer().Informer(),
+		Lister:         wf.NodeCoreInformer().Lister().List,
+		ObjNeedsUpdate: nodeNeedsUpdate,
+		// local nodes might conflict with the nodes of any peer cluster
+		Reconcile: func(string) error {
+			c.controller.ReconcileAll()
+			return nil
+		},
+		Threadiness: 1,
+	}
+	c.nodeController = controller.NewController[corev1.Node]("peer-cluster-node-controller", nodeControllerConfig)
+
+	return c
+}
+
+// Start starts the controller
+func (c *PeerClusterController) Start() error {
+	klog.Infof("Starting peer cluster controller for zone %s", c.zone)
+	if c.ovsClient != nil {
+		c.ovsClient.Cache().AddEventHandler(&libovsdbcache.EventHandlerFuncs{
+			AddFunc: func(table string, model model.Model) {
+				c.onTunnelInterfaceUpdate(table, nil, model)
+			},
+			UpdateFunc: func(table string, old, new model.Model) {
+				c.onTunnelInterfaceUpdate(table, old, new)
+			},
+			DeleteFunc: func(table string, model model.Model) {
+				c.onTunnelInterfaceUpdate(table, nil, model)
+			},
+		})
+	}
+	return controller.Start(c.controller, c.nodeController)
+}
+
+// Stop stops the controller
+func (c *PeerClusterController) Stop() {
+	controller.Stop(c.controller, c.nodeController)
+}
+
+// onTunnelInterfaceUpdate reconciles the peer clusters when the status of a
+// tunnel interface changes, to update their reachability
+func (c *PeerClusterController) onTunnelInterfaceUpdate(table string, oldModel, newModel model.Model) {
+	if table != vswitchd.InterfaceTable {
+		return
+	}
+	newIface := newModel.(*vswitchd.Interface)
+	if newIface.Type != "geneve" {
+		return
+	}
+	if oldModel != nil {
+		oldIface := oldModel.(*vswitchd.Interface)
+		if reflect.DeepEqual(oldIface.BFDStatus, newIface.BFDStatus) &&
+			reflect.DeepEqual(oldIface.LinkState, newIface.LinkState) &&
+			oldIface.Options["remote_ip"] == newIface.Options["remote_ip"] {
+			return
+		}
+	}
+	c.controller.ReconcileAll()
+}
+
+func nodeNeedsUpdate(oldObj, newObj *corev1.Node) bool {
+	if oldObj == nil || newObj == nil {
+		return true
+	}
+	return util.NodeIDAnnotationChanged(oldObj, newObj) ||
+		util.NodeChassisIDAnnotationChanged(oldObj, newObj) ||
+		util.NodeTransitSwitchPortAddrAnnotationChanged(oldObj, newObj)
+}
+
+// IsPeerClusterChassis returns true if the chassis was created for a peer
+// cluster node
+func IsPeerClusterChassis(chassis *sbdb.Chassis) bool {
+	return chassis.OtherConfig[peerClusterExternalID] != ""
+}
+
+func peerClusterNeedsUpdate(oldObj, newObj *peerclusterapi.PeerCluster) bool {
+	if oldObj == nil || newObj == nil {
+		return true
+	}
+	// status updates, including our own, don't need to be reconciled
+	return !reflect.DeepEqual(oldObj.Spec, newObj.Spec)
+}
+
+// sync configures the interconnect resources for all the valid peer clusters
+// and removes the stale ones
+func (c *PeerClusterController) sync() error {
+	peerClusters, err := c.peerClusterLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list peer clusters: %w", err)
+	}
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	// process the peer clusters in creation order so that on conflicts the
+	// one created first keeps working
+	sort.Slice(peerClusters, func(i, j int) bool {
+		if peerClusters[i].CreationTimestamp.Equal(&peerClusters[j].CreationTimestamp) {
+			return peerClusters[i].Name < peerClusters[j].Name
+		}
+		return peerClusters[i].CreationTimestamp.Before(&peerClusters[j].CreationTimestamp)
+	})
+
+	claims := newLocalClusterClaims(nodes)
+	desired := map[string][]*peerNode{}
+	var errs []error
+	for _, peerCluster := range peerClusters {
+		peerNodes, err := parsePeerCluster(peerCluster)
+		if err == nil {
+			err = claims.claim(peerCluster.Name, peerNodes)
+		}
+		if err != nil {
+			// invalid configuration, no point in retrying until it changes
+			klog.Warningf("Invalid peer cluster %s: %v", peerCluster.Name, err)
+			if statusErr := c.updateStatus(peerCluster, nil, err); statusErr != nil {
+				errs = append(errs, statusErr)
+			}
+			continue
+		}
+
+		desired[peerCluster.Name] = peerNodes
+		err = c.ensurePeerCluster(peerCluster.Name, peerNodes)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if statusErr := c.updateStatus(peerCluster, peerNodes, err); statusErr != nil {
+			errs = append(errs, statusErr)
+		}
+	}
+
+	if err := c.deleteStalePeerResources(desired); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// ensurePeerCluster creates or updates the remote chassis, transit switch
+// ports and static routes for the nodes of a peer cluster
+func (c *PeerClusterController) ensurePeerCluster(peerCluster string, peerNodes []*peerNode) error {
+	for _, node := range peerNodes {
+		if err := c.ensurePeerNodeChassis(peerCluster, node); err != nil {
+			return fmt.Errorf("failed to create chassis for peer node %s: %w", node.name, err)
+		}
+		if err := c.ensurePeerNodeTransitSwitchPort(peerCluster, node); err != nil {
+			return fmt.Errorf("failed to create transit switch port for peer node %s: %w", node.name, err)
+		}
+		if err := c.ensurePeerNodeStaticRoutes(peerCluster, node); err != nil {
+			return fmt.Errorf("failed to create static routes for peer node %s: %w", node.name, err)
+		}
+	}
+	return nil
+}
+
+func (c *PeerClusterController) ensurePeerNodeChassis(peerCluster string, node *peerNode) error {
+	chassis := sbdb.Chassis{
+		Name:     node.chassisID,
+		Hostname: getPeerNodeName(peerCluster, node.name),
+		OtherConfig: map[string]string{
+			"is-remote":           "true",
+			peerClusterExternalID: peerCluster,
+			peerNodeExternalID:    node.name,
+		},
+	}
+
+	encap := sbdb.Encap{
+		ChassisName: node.chassisID,
+		IP:          node.tunnelEndpoint,
+		Type:        "geneve",
+		Options:     map[string]string{"csum": "true"},
+	}
+
+	// peer clusters are expected to use the same geneve port
+	if config.Default.EncapPort != config.DefaultEncapPort {
+		encap.Options["dst_port"] = strconv.FormatUint(uint64(config.Default.EncapPort), 10)
+	}
+
+	return libovsdbops.CreateOrUpdateChassis(c.zic.sbClient, &chassis, &encap)
+}
+
+func (c *PeerClusterController) ensurePeerNodeTransitSwitchPort(peerCluster string, node *peerNode) error {
+	remotePortAddr := util.IPAddrToHWAddr(node.transitIPs[0].IP).String()
+	for _, ip := range node.transitIPs {
+		remotePortAddr = remotePortAddr + " " + ip.String()
+	}
+
+	lspOptions := map[string]string{
+		"requested-tnl-key": strconv.Itoa(node.tunnelKey),
+		"requested-chassis": node.chassisID,
+	}
+	// Store the peer cluster and node names in the external_ids column for
+	// book keeping. Note that there is no "node" external ID as the port
+	// doesn't belong to a node of this cluster.
+	externalIDs := map[string]string{
+		peerClusterExternalID: peerCluster,
+		peerNodeExternalID:    node.name,
+	}
+
+	return c.zic.addNodeLogicalSwitchPort(c.zic.networkTransitSwitchName, getPeerNodeTransitSwitchPortName(peerCluster, node.name),
+		lportTypeRemote, []string{remotePortAddr}, lspOptions, externalIDs)
+}
+
+func (c *PeerClusterController) ensurePeerNodeStaticRoutes(peerCluster string, node *peerNode) error {
+	for _, staticRoute := range c.zic.getStaticRoutes(node.podSubnets, node.transitIPs, false) {
+		logicalRouterStaticRoute := nbdb.LogicalRouterStaticRoute{
+			ExternalIDs: map[string]string{
+				icPeerClusterExternalID: peerCluster,
+				icPeerNodeExternalID:    node.name,
+			},
+			Nexthop:  staticRoute.nexthop,
+			IPPrefix: staticRoute.prefix,
+		}
+		p := func(lrsr *nbdb.LogicalRouterStaticRoute) bool {
+			return lrsr.IPPrefix == staticRoute.prefix &&
+				lrsr.ExternalIDs[icPeerClusterExternalID] == peerCluster &&
+				lrsr.ExternalIDs[icPeerNodeExternalID] == node.name
+		}
+		if err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(c.zic.nbClient, c.zic.networkClusterRouterName,
+			&logicalRouterStaticRoute, p); err != nil {
+			return fmt.Errorf("error adding static route %s - %s to the router %s : %w", staticRoute.prefix, staticRoute.nexthop,
+				c.zic.networkClusterRouterName, err)
+		}
+	}
+	return nil
+}
+
+// deleteStalePeerResources deletes the chassis, transit switch ports and
+// static routes of peer cluster nodes that are not desired anymore
+func (c *PeerClusterController) deleteStalePeerResources(desired map[string][]*peerNode) error {
+	chassisIDs := sets.New[string]()
+	portNames := sets.New[string]()
+	routes := sets.New[string]()
+	for peerCluster, peerNodes := range desired {
+		for _, node := range peerNodes {
+			chassisIDs.Insert(node.chassisID)
+			portNames.Insert(getPeerNodeTransitSwitchPortName(peerCluster, node.name))
+			for _, staticRoute := range c.zic.getStaticRoutes(node.podSubnets, node.transitIPs, false) {
+				routes.Insert(getPeerNodeStaticRouteKey(peerCluster, node.name, staticRoute.prefix, staticRoute.nexthop))
+			}
+		}
+	}
+
+	routePredicate := func(lrsr *nbdb.LogicalRouterStaticRoute) bool {
+		peerCluster := lrsr.ExternalIDs[icPeerClusterExternalID]
+		return peerCluster != "" &&
+			!routes.Has(getPeerNodeStaticRouteKey(peerCluster, lrsr.ExternalIDs[icPeerNodeExternalID], lrsr.IPPrefix, lrsr.Nexthop))
+	}
+	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(c.zic.nbClient, c.zic.networkClusterRouterName, routePredicate); err != nil {
+		return fmt.Errorf("failed to delete stale peer cluster static routes: %w", err)
+	}
+
+	portPredicate := func(lsp *nbdb.LogicalSwitchPort) bool {
+		return lsp.ExternalIDs[peerClusterExternalID] != "" && !portNames.Has(lsp.Name)
+	}
+	ops, err := libovsdbops.DeleteLogicalSwitchPortsWithPredicateOps(c.zic.nbClient, nil,
+		&nbdb.LogicalSwitch{Name: c.zic.networkTransitSwitchName}, portPredicate)
+	if err != nil {
+		return fmt.Errorf("failed to find stale peer cluster transit switch ports: %w", err)
+	}
+	if _, err := libovsdbops.TransactAndCheck(c.zic.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to delete stale peer cluster transit switch ports: %w", err)
+	}
+
+	chassisPredicate := func(chassis *sbdb.Chassis) bool {
+		return IsPeerClusterChassis(chassis) && !chassisIDs.Has(chassis.Name)
+	}
+	if err := libovsdbops.DeleteChassisWithPredicate(c.zic.sbClient, chassisPredicate); err != nil {
+		return fmt.Errorf("failed to delete stale peer cluster chassis: %w", err)
+	}
+
+	return nil
+}
+
+// getPeerNodesHealth returns the reachability of the peer nodes from the
+// status of the tunnel interfaces towards their tunnel endpoints
+func (c *PeerClusterController) getPeerNodesHealth(peerNodes []*peerNode) (*peerNodesHealth, error) {
+	health := &peerNodesHealth{unreachable: map[string]string{}}
+	if c.ovsClient == nil {
+		return health, nil
+	}
+	endpoints := sets.New[string]()
+	for _, node := range peerNodes {
+		endpoints.Insert(node.tunnelEndpoint)
+	}
+	ifaces, err := ovsops.FindInterfacesWithPredicate(c.ovsClient, func(iface *vswitchd.Interface) bool {
+		return iface.Type == "geneve" && endpoints.Has(iface.Options["remote_ip"])
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the tunnel interfaces: %w", err)
+	}
+	tunnels := map[string]*vswitchd.Interface{}
+	for _, iface := range ifaces {
+		tunnels[iface.Options["remote_ip"]] = iface
+	}
+
+	health.known = true
+	for _, node := range peerNodes {
+		iface := tunnels[node.tunnelEndpoint]
+		switch {
+		case iface == nil:
+			health.unreachable[node.name] = "no tunnel"
+		case iface.BFD["enable"] == "true":
+			if state := iface.BFDStatus["state"]; state != "up" {
+				health.unreachable[node.name] = "BFD state " + state
+			}
+		case iface.LinkState == nil || *iface.LinkState != vswitchd.InterfaceLinkStateUp:
+			health.unreachable[node.name] = "tunnel down"
+		}
+	}
+	return health, nil
+}
+
+// updateStatus reports the outcome of the peer cluster configuration and the
+// reachability of the peer nodes for this zone in the peer cluster status
+func (c *PeerClusterController) updateStatus(peerCluster *peerclusterapi.PeerCluster, peerNodes []*peerNode, syncErr error) error {
+	var health *peerNodesHealth
+	if syncErr == nil {
+		var err error
+		health, err = c.getPeerNodesHealth(peerNodes)
+		if err != nil {
+			klog.Warningf("Failed to get the reachability of peer cluster %s nodes: %v", peerCluster.Name, err)
+			health = &peerNodesHealth{}
+		}
+	}
+	newMsg, condition := c.getStatus(len(peerNodes), health, syncErr)
+	newMsg = types.GetZoneStatus(c.zone, newMsg)
+	oldCondition := meta.FindStatusCondition(peerCluster.Status.Conditions, *condition.Type)
+	if slices.Contains(peerCluster.Status.Messages, newMsg) && oldCondition != nil &&
+		oldCondition.Status == *condition.Status && oldCondition.Reason == *condition.Reason &&
+		oldCondition.Message == *condition.Message {
+		// found previous status
+		return nil
+	}
+	now := metav1.Now()
+	if oldCondition != nil && oldCondition.Status == *condition.Status {
+		now = oldCondition.LastTransitionTime
+	}
+	condition.WithLastTransitionTime(now)
+
+	applyOptions := metav1.ApplyOptions{
+		Force:        true,
+		FieldManager: c.zone,
+	}
+	applyObj := peerclusterapply.PeerCluster(peerCluster.Name).
+		WithStatus(peerclusterapply.PeerClusterStatus().
+			WithMessages(newMsg).
+			WithConditions(condition).
+			WithLastTransitionTime(metav1.Now()))
+	_, err := c.client.K8sV1().PeerClusters().ApplyStatus(context.TODO(), applyObj, applyOptions)
+	return err
+}
+
+// getStatus returns the status message and the reachability condition of the
+// zone. The health is nil if the peer cluster could not be configured.
+func (c *PeerClusterController) getStatus(peerNodes int, health *peerNodesHealth,
+	syncErr error) (string, *metav1apply.ConditionApplyConfiguration) {
+	condition := metav1apply.Condition().WithType(getPeerNodesReachableConditionType(c.zone))
+	switch {
+	case syncErr != nil:
+		condition.WithStatus(metav1.ConditionFalse).
+			WithReason("ConfigurationFailed").
+			WithMessage(syncErr.Error())
+		return fmt.Sprintf("%s: %v", types.PeerClusterErrorMsg, syncErr), condition
+	case !health.known:
+		condition.WithStatus(metav1.ConditionUnknown).
+			WithReason("TunnelStatusUnknown").
+			WithMessage("the tunnel status is not available in this zone")
+		return fmt.Sprintf("configured %d peer nodes", peerNodes), condition
+	case len(health.unreachable) > 0:
+		names := sets.List(sets.KeySet(health.unreachable))
+		unreachable := make([]string, 0, maxReportedUnreachablePeerNodes)
+		for _, name := range names {
+			if len(unreachable) == maxReportedUnreachablePeerNodes {
+				unreachable = append(unreachable, fmt.Sprintf("and %d more", len(names)-maxReportedUnreachablePeerNodes))
+				break
+			}
+			unreachable = append(unreachable, fmt.Sprintf("%s (%s)", name, health.unreachable[name]))
+		}
+		msg := fmt.Sprintf("%d of %d peer nodes: %s", len(names), peerNodes, strings.Join(unreachable, ", "))
+		condition.WithStatus(metav1.ConditionFalse).
+			WithReason("PeerNodesUnreachable").
+			WithMessage(msg)
+		return fmt.Sprintf("%s: %s", types.PeerClusterUnreachableMsg, msg), condition
+	default:
+		condition.WithStatus(metav1.ConditionTrue).
+			WithReason("PeerNodesReachable").
+			WithMessage(fmt.Sprintf("all %d peer nodes are reachable", peerNodes))
+		return fmt.Sprintf("configured %d peer nodes, all reachable", peerNodes), condition
+	}
+}
+
+// parsePeerCluster parses and validates the nodes of a peer cluster on their
+// own. Conflicts with other nodes are checked by peerClusterClaims.
+func parsePeerCluster(peerCluster *peerclusterapi.PeerCluster) ([]*peerNode, error) {
+	transitSubnets, err := util.ParseIPNets([]string{config.ClusterManager.V4TransitSwitchSubnet, config.ClusterManager.V6TransitSwitchSubnet})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transit switch subnets: %w", err)
+	}
+
+	peerNodes := make([]*peerNode, 0, len(peerCluster.Spec.Nodes))
+	for _, n := range peerCluster.Spec.Nodes {
+		node := &peerNode{
+			name:           n.Name,
+			chassisID:      n.ChassisID,
+			tunnelEndpoint: n.TunnelEndpoint,
+			tunnelKey:      int(n.TunnelKey),
+		}
+		if net.ParseIP(n.TunnelEndpoint) == nil {
+			return nil, fmt.Errorf("node %s has an invalid tunnel endpoint %q", n.Name, n.TunnelEndpoint)
+		}
+
+		for _, cidr := range n.TransitSwitchIPs {
+			ip, ipNet, err := net.ParseCIDR(string(cidr))
+			if err != nil {
+				return nil, fmt.Errorf("node %s has an invalid transit switch IP: %w", n.Name, err)
+			}
+			if err := checkIPFamilyEnabled(ipNet); err != nil {
+				return nil, fmt.Errorf("node %s transit switch IP %s: %w", n.Name, cidr, err)
+			}
+			if !util.IsContainedInAnyCIDR(ipNet, transitSubnets...) {
+				return nil, fmt.Errorf("node %s transit switch IP %s is not within the transit switch subnets", n.Name, cidr)
+			}
+			ipNet.IP = ip
+			node.transitIPs = append(node.transitIPs, ipNet)
+		}
+		sort.SliceStable(node.transitIPs, func(i, j int) bool {
+			return !utilnet.IsIPv6(node.transitIPs[i].IP) && utilnet.IsIPv6(node.transitIPs[j].IP)
+		})
+
+		for _, cidr := range n.PodSubnets {
+			_, ipNet, err := net.ParseCIDR(string(cidr))
+			if err != nil {
+				return nil, fmt.Errorf("node %s has an invalid pod subnet: %w", n.Name, err)
+			}
+			if err := checkIPFamilyEnabled(ipNet); err != nil {
+				return nil, fmt.Errorf("node %s pod subnet %s: %w", n.Name, cidr, err)
+			}
+			if !slices.ContainsFunc(node.transitIPs, func(ip *net.IPNet) bool {
+				return utilnet.IPFamilyOfCIDR(ip) == utilnet.IPFamilyOfCIDR(ipNet)
+			}) {
+				return nil, fmt.Errorf("node %s has no transit switch IP for the family of pod subnet %s", n.Name, cidr)
+			}
+			node.podSubnets = append(node.podSubnets, ipNet)
+		}
+
+		peerNodes = append(peerNodes, node)
+	}
+
+	return peerNodes, nil
+}
+
+func checkIPFamilyEnabled(ipNet *net.IPNet) error {
+	if utilnet.IsIPv6CIDR(ipNet) && !config.IPv6Mode {
+		return fmt.Errorf("IPv6 is not enabled")
+	}
+	if !utilnet.IsIPv6CIDR(ipNet) && !config.IPv4Mode {
+		return fmt.Errorf("IPv4 is not enabled")
+	}
+	return nil
+}
+
+// peerClusterClaims tracks the addresses and identifiers that are already in
+// use either by the local cluster or by the peer clusters
+type peerClusterClaims struct {
+	subnets    []config.ConfigSubnet
+	transitIPs map[string]string
+	tunnelKeys map[int]string
+	chassisIDs map[string]string
+}
+
+func newPeerClusterClaims() *peerClusterClaims {
+	return &peerClusterClaims{
+		transitIPs: map[string]string{},
+		tunnelKeys: map[int]string{},
+		chassisIDs: map[string]string{},
+	}
+}
+
+// newLocalClusterClaims returns the claims of the local cluster: its
+// configured subnets and the node IDs, chassis IDs and transit switch port IPs
+// of its nodes
+func newLocalClusterClaims(nodes []*corev1.Node) *peerClusterClaims {
+	claims := newPeerClusterClaims()
+
+	for _, subnet := range config.Default.ClusterSubnets {
+		claims.subnets = append(claims.subnets, config.ConfigSubnet{SubnetType: config.ConfigSubnetCluster, Subnet: subnet.CIDR})
+	}
+	for _, subnet := range config.Kubernetes.ServiceCIDRs {
+		claims.subnets = append(claims.subnets, config.ConfigSubnet{SubnetType: config.ConfigSubnetService, Subnet: subnet})
+	}
+	for subnetType, subnets := range map[config.ConfigSubnetType][]string{
+		config.ConfigSubnetJoin:       {config.Gateway.V4JoinSubnet, config.Gateway.V6JoinSubnet},
+		config.ConfigSubnetMasquerade: {config.Gateway.V4MasqueradeSubnet, config.Gateway.V6MasqueradeSubnet},
+		config.ConfigSubnetTransit:    {config.ClusterManager.V4TransitSwitchSubnet, config.ClusterManager.V6TransitSwitchSubnet},
+	} {
+		for _, subnet := range subnets {
+			if _, ipNet, err := net.ParseCIDR(subnet); err == nil {
+				claims.subnets = append(claims.subnets, config.ConfigSubnet{SubnetType: subnetType, Subnet: ipNet})
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		owner := "node " + node.Name
+		if nodeID := util.GetNodeID(node); nodeID != -1 {
+			claims.tunnelKeys[nodeID] = owner
+		}
+		if chassisID, err := util.ParseNodeChassisIDAnnotation(node); err == nil {
+			claims.chassisIDs[chassisID] = owner
+		}
+		if transitIPs, err := util.ParseNodeTransitSwitchPortAddrs(node); err == nil {
+			for _, ip := range transitIPs {
+				claims.transitIPs[ip.IP.String()] = owner
+			}
+		}
+	}
+
+	return claims
+}
+
+// claim checks that the nodes of a peer cluster don't conflict with the
+// existing claims nor among themselves, and adds them to the claims
+func (c *peerClusterClaims) claim(peerCluster string, peerNodes []*peerNode) error {
+	pending := newPeerClusterClaims()
+	for _, node := range peerNodes {
+		owner := fmt.Sprintf("peer cluster %s node %s", peerCluster, node.name)
+		if err := c.check(owner, node); err != nil {
+			return err
+		}
+		if err := pending.check(owner, node); err != nil {
+			return err
+		}
+		pending.add(owner, node)
+	}
+
+	c.subnets = append(c.subnets, pending.subnets...)
+	for ip, owner := range pending.transitIPs {
+		c.transitIPs[ip] = owner
+	}
+	for key, owner := range pending.tunnelKeys {
+		c.tunnelKeys[key] = owner
+	}
+	for chassisID, owner := range pending.chassisIDs {
+		c.chassisIDs[chassisID] = owner
+	}
+	return nil
+}
+
+func (c *peerClusterClaims) check(owner string, node *peerNode) error {
+	if other, ok := c.tunnelKeys[node.tunnelKey]; ok {
+		return fmt.Errorf("%s tunnel key %d is already in use by %s", owner, node.tunnelKey, other)
+	}
+	if other, ok := c.chassisIDs[node.chassisID]; ok {
+		return fmt.Errorf("%s chassis ID %s is already in use by %s", owner, node.chassisID, other)
+	}
+	for _, ip := range node.transitIPs {
+		if other, ok := c.transitIPs[ip.IP.String()]; ok {
+			return fmt.Errorf("%s transit switch IP %s is already in use by %s", owner, ip.IP, other)
+		}
+	}
+	for _, subnet := range node.podSubnets {
+		for _, claimed := range c.subnets {
+			if subnet.Contains(claimed.Subnet.IP) || claimed.Subnet.Contains(subnet.IP) {
+				return fmt.Errorf("%s pod subnet %s overlaps %s %s", owner, subnet, claimed.SubnetType, claimed.Subnet)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *peerClusterClaims) add(owner string, node *peerNode) {
+	c.tunnelKeys[node.tunnelKey] = owner
+	c.chassisIDs[node.chassisID] = owner
+	for _, ip := range node.transitIPs {
+		c.transitIPs[ip.IP.String()] = owner
+	}
+	for _, subnet := range node.podSubnets {
+		c.subnets = append(c.subnets, config.ConfigSubnet{SubnetType: config.ConfigSubnetType(owner + " pod subnet"), Subnet: subnet})
+	}
+}
+
+// getPeerNodeName returns a name for a peer cluster node that can't clash
+// with the name of a local node, as '_' is not valid in node names
+func getPeerNodeName(peerCluster, node string) string {
+	return peerCluster + "_" + node
+}
+
+func getPeerNodeTransitSwitchPortName(peerCluster, node string) string {
+	return types.TransitSwitchToRouterPrefix + getPeerNodeName(peerCluster, node)
+}
+
+func getPeerNodesReachableConditionType(zone string) string {
+	return peerNodesReachableConditionPrefix + zone
+}
+
+func getPeerNodeStaticRouteKey(peerCluster, node, prefix, nexthop string) string {
+	return peerCluster + "/" + node + "/" + prefix + "/" + nexthop
+}
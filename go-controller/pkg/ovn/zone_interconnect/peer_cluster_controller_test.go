@@ -0,0 +1,392 @@
+package zoneinterconnect
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/urfave/cli/v2"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	peerclusterapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1"
+	peerclusterfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/clientset/versioned/fake"
+	peerclusterlisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/listers/peercluster/v1"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/vswitchd"
+)
+
+func newPeerCluster(name string, created time.Time, nodes ...peerclusterapi.PeerClusterNode) *peerclusterapi.PeerCluster {
+	return &peerclusterapi.PeerCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: peerclusterapi.PeerClusterSpec{Nodes: nodes},
+	}
+}
+
+func newPeerClusterNode(name, chassisID string, tunnelKey int32, transitIP, podSubnet string) peerclusterapi.PeerClusterNode {
+	return peerclusterapi.PeerClusterNode{
+		Name:             name,
+		ChassisID:        chassisID,
+		TunnelEndpoint:   "172.18.0.100",
+		TunnelKey:        tunnelKey,
+		TransitSwitchIPs: []peerclusterapi.CIDR{peerclusterapi.CIDR(transitIP)},
+		PodSubnets:       []peerclusterapi.CIDR{peerclusterapi.CIDR(podSubnet)},
+	}
+}
+
+var _ = ginkgo.Describe("Zone Interconnect Peer Cluster Operations", func() {
+	var (
+		app             *cli.App
+		libovsdbCleanup *libovsdbtest.Context
+		testNode1       corev1.Node
+		initialNBDB     []libovsdbtest.TestData
+	)
+
+	const (
+		clusterCIDR string = "10.1.0.0/16"
+	)
+
+	// newTestPeerClusterController returns a controller that syncs the given
+	// peer clusters against the local node testNode1, the statuses it applies
+	// by peer cluster name, and the indexers of the peer clusters and nodes.
+	// The fake client doesn't support ApplyStatus patches, so they are
+	// intercepted.
+	newTestPeerClusterController := func(nbClient, sbClient, ovsClient libovsdbclient.Client,
+		peerClusters ...*peerclusterapi.PeerCluster) (*PeerClusterController, map[string]*peerclusterapi.PeerClusterStatus,
+		cache.Indexer, cache.Indexer) {
+		zoneICHandler := NewZoneInterconnectHandler(&util.DefaultNetInfo{}, nbClient, sbClient, nil)
+		gomega.Expect(zoneICHandler.createOrUpdateTransitSwitch(0)).To(gomega.Succeed())
+
+		nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		gomega.Expect(nodeIndexer.Add(&testNode1)).To(gomega.Succeed())
+		peerClusterIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for _, peerCluster := range peerClusters {
+			gomega.Expect(peerClusterIndexer.Add(peerCluster)).To(gomega.Succeed())
+		}
+
+		statuses := map[string]*peerclusterapi.PeerClusterStatus{}
+		client := peerclusterfake.NewSimpleClientset()
+		client.PrependReactor("patch", "peerclusters", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			patch := action.(clienttesting.PatchAction)
+			gomega.Expect(patch.GetSubresource()).To(gomega.Equal("status"))
+			peerCluster := &peerclusterapi.PeerCluster{}
+			if err := json.Unmarshal(patch.GetPatch(), peerCluster); err != nil {
+				return true, nil, err
+			}
+			statuses[patch.GetName()] = &peerCluster.Status
+			return true, peerCluster, nil
+		})
+
+		return &PeerClusterController{
+			zic:               zoneICHandler,
+			zone:              "global",
+			client:            client,
+			ovsClient:         ovsClient,
+			peerClusterLister: peerclusterlisters.NewPeerClusterLister(peerClusterIndexer),
+			nodeLister:        corev1listers.NewNodeLister(nodeIndexer),
+		}, statuses, peerClusterIndexer, nodeIndexer
+	}
+
+	ginkgo.BeforeEach(func() {
+		// Restore global default values before each testcase
+		gomega.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+
+		app = cli.NewApp()
+		app.Name = "test"
+		app.Flags = config.Flags
+		libovsdbCleanup = nil
+
+		testNode1 = corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node1",
+				Annotations: map[string]string{
+					ovnNodeChassisIDAnnotatin:          "cb9ec8fa-b409-4ef3-9f42-d9283c47aac6",
+					ovnNodeZoneNameAnnotation:          "global",
+					ovnNodeIDAnnotaton:                 "2",
+					ovnNodeSubnetsAnnotation:           "{\"default\":[\"10.1.2.0/24\"]}",
+					ovnTransitSwitchPortAddrAnnotation: "{\"ipv4\":\"100.88.0.2/16\"}",
+				},
+			},
+		}
+
+		initialNBDB = []libovsdbtest.TestData{
+			newOVNClusterRouter(types.DefaultNetworkName),
+		}
+	})
+
+	ginkgo.AfterEach(func() {
+		if libovsdbCleanup != nil {
+			libovsdbCleanup.Cleanup()
+		}
+	})
+
+	runTest := func(test func(nbClient, sbClient libovsdbclient.Client)) {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := config.InitConfig(ctx, nil, nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			var libovsdbOvnNBClient, libovsdbOvnSBClient libovsdbclient.Client
+			libovsdbOvnNBClient, libovsdbOvnSBClient, libovsdbCleanup, err = libovsdbtest.NewNBSBTestHarness(libovsdbtest.TestSetup{NBData: initialNBDB})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			test(libovsdbOvnNBClient, libovsdbOvnSBClient)
+			return nil
+		}
+
+		err := app.Run([]string{
+			app.Name,
+			"-cluster-subnets=" + clusterCIDR,
+			"-enable-interconnect",
+			"-enable-peer-clusters",
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+
+	ginkgo.It("creates and deletes the resources of peer cluster nodes", func() {
+		runTest(func(nbClient, sbClient libovsdbclient.Client) {
+			peerCluster := newPeerCluster("east", time.Now(),
+				newPeerClusterNode("node1", "peer-chassis-1", 10, "100.88.0.10/16", "10.128.1.0/24"))
+			c, statuses, peerClusterIndexer, _ := newTestPeerClusterController(nbClient, sbClient, nil, peerCluster)
+
+			gomega.Expect(c.sync()).To(gomega.Succeed())
+
+			chassis, err := libovsdbops.GetChassis(sbClient, &sbdb.Chassis{Name: "peer-chassis-1"})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(chassis.Hostname).To(gomega.Equal("east_node1"))
+			gomega.Expect(chassis.OtherConfig).To(gomega.HaveKeyWithValue("is-remote", "true"))
+			gomega.Expect(IsPeerClusterChassis(chassis)).To(gomega.BeTrue())
+			var encaps []*sbdb.Encap
+			gomega.Expect(sbClient.List(context.TODO(), &encaps)).To(gomega.Succeed())
+			gomega.Expect(encaps).To(gomega.HaveLen(1))
+			gomega.Expect(encaps[0].ChassisName).To(gomega.Equal("peer-chassis-1"))
+			gomega.Expect(encaps[0].IP).To(gomega.Equal("172.18.0.100"))
+
+			lsp, err := libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: "tstor-east_node1"})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(lsp.Type).To(gomega.Equal(lportTypeRemote))
+			gomega.Expect(lsp.Addresses).To(gomega.Equal([]string{"0a:58:64:58:00:0a 100.88.0.10/16"}))
+			gomega.Expect(lsp.Options).To(gomega.HaveKeyWithValue("requested-tnl-key", "10"))
+			gomega.Expect(lsp.Options).To(gomega.HaveKeyWithValue("requested-chassis", "peer-chassis-1"))
+			gomega.Expect(lsp.ExternalIDs).NotTo(gomega.HaveKey("node"))
+
+			routes, err := libovsdbops.GetRouterLogicalRouterStaticRoutesWithPredicate(nbClient, &nbdb.LogicalRouter{Name: types.OVNClusterRouter},
+				func(*nbdb.LogicalRouterStaticRoute) bool { return true })
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(routes).To(gomega.HaveLen(1))
+			gomega.Expect(routes[0].IPPrefix).To(gomega.Equal("10.128.1.0/24"))
+			gomega.Expect(routes[0].Nexthop).To(gomega.Equal("100.88.0.10"))
+
+			gomega.Expect(statuses["east"].Messages).To(gomega.ConsistOf("global: configured 1 peer nodes"))
+			condition := meta.FindStatusCondition(statuses["east"].Conditions, "PeerNodesReachable-global")
+			gomega.Expect(condition).NotTo(gomega.BeNil())
+			gomega.Expect(condition.Status).To(gomega.Equal(metav1.ConditionUnknown))
+
+			// deleting the peer cluster removes all its resources
+			gomega.Expect(peerClusterIndexer.Delete(peerCluster)).To(gomega.Succeed())
+			gomega.Expect(c.sync()).To(gomega.Succeed())
+
+			_, err = libovsdbops.GetChassis(sbClient, &sbdb.Chassis{Name: "peer-chassis-1"})
+			gomega.Expect(err).To(gomega.MatchError(libovsdbclient.ErrNotFound))
+			_, err = libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: "tstor-east_node1"})
+			gomega.Expect(err).To(gomega.MatchError(libovsdbclient.ErrNotFound))
+			routes, err = libovsdbops.GetRouterLogicalRouterStaticRoutesWithPredicate(nbClient, &nbdb.LogicalRouter{Name: types.OVNClusterRouter},
+				func(*nbdb.LogicalRouterStaticRoute) bool { return true })
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(routes).To(gomega.BeEmpty())
+		})
+	})
+
+	ginkgo.It("keeps the peer cluster created first on conflicts", func() {
+		runTest(func(nbClient, sbClient libovsdbclient.Client) {
+			now := time.Now()
+			east := newPeerCluster("east", now,
+				newPeerClusterNode("node1", "peer-chassis-1", 10, "100.88.0.10/16", "10.128.1.0/24"))
+			west := newPeerCluster("west", now.Add(time.Minute),
+				newPeerClusterNode("node1", "peer-chassis-2", 11, "100.88.0.11/16", "10.128.0.0/16"))
+			c, statuses, _, _ := newTestPeerClusterController(nbClient, sbClient, nil, west, east)
+
+			gomega.Expect(c.sync()).To(gomega.Succeed())
+
+			_, err := libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: "tstor-east_node1"})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: "tstor-west_node1"})
+			gomega.Expect(err).To(gomega.MatchError(libovsdbclient.ErrNotFound))
+
+			gomega.Expect(statuses["east"].Messages).To(gomega.ConsistOf("global: configured 1 peer nodes"))
+			messages := statuses["west"].Messages
+			gomega.Expect(messages).To(gomega.HaveLen(1))
+			gomega.Expect(messages[0]).To(gomega.ContainSubstring(types.PeerClusterErrorMsg))
+			gomega.Expect(messages[0]).To(gomega.ContainSubstring("overlaps peer cluster east node node1"))
+		})
+	})
+
+	ginkgo.It("reports the reachability of the peer nodes from the tunnel status", func() {
+		runTest(func(nbClient, sbClient libovsdbclient.Client) {
+			tunnelUp := newPeerClusterNode("node1", "peer-chassis-1", 10, "100.88.0.10/16", "10.128.1.0/24")
+			tunnelUp.TunnelEndpoint = "172.18.0.101"
+			bfdDown := newPeerClusterNode("node2", "peer-chassis-2", 11, "100.88.0.11/16", "10.128.2.0/24")
+			bfdDown.TunnelEndpoint = "172.18.0.102"
+			noTunnel := newPeerClusterNode("node3", "peer-chassis-3", 12, "100.88.0.12/16", "10.128.3.0/24")
+			noTunnel.TunnelEndpoint = "172.18.0.103"
+			peerCluster := newPeerCluster("east", time.Now(), tunnelUp, bfdDown, noTunnel)
+
+			ovsClient, ovsCleanup, err := libovsdbtest.NewOVSTestHarness(libovsdbtest.TestSetup{
+				OVSData: []libovsdbtest.TestData{
+					&vswitchd.OpenvSwitch{UUID: "root-uuid", Bridges: []string{"br-int-uuid"}},
+					&vswitchd.Bridge{UUID: "br-int-uuid", Name: "br-int", Ports: []string{"tunnel1-port-uuid", "tunnel2-port-uuid"}},
+					&vswitchd.Port{UUID: "tunnel1-port-uuid", Name: "ovn-peer-c-0", Interfaces: []string{"tunnel1-uuid"}},
+					&vswitchd.Port{UUID: "tunnel2-port-uuid", Name: "ovn-peer-c-1", Interfaces: []string{"tunnel2-uuid"}},
+					&vswitchd.Interface{
+						UUID:      "tunnel1-uuid",
+						Name:      "ovn-peer-c-0",
+						Type:      "geneve",
+						Options:   map[string]string{"remote_ip": "172.18.0.101"},
+						LinkState: ptr.To(vswitchd.InterfaceLinkStateUp),
+					},
+					&vswitchd.Interface{
+						UUID:      "tunnel2-uuid",
+						Name:      "ovn-peer-c-1",
+						Type:      "geneve",
+						Options:   map[string]string{"remote_ip": "172.18.0.102"},
+						LinkState: ptr.To(vswitchd.InterfaceLinkStateUp),
+						BFD:       map[string]string{"enable": "true"},
+						BFDStatus: map[string]string{"state": "down"},
+					},
+				},
+			})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer ovsCleanup.Cleanup()
+
+			c, statuses, _, _ := newTestPeerClusterController(nbClient, sbClient, ovsClient, peerCluster)
+			gomega.Expect(c.sync()).To(gomega.Succeed())
+
+			// unreachable peer nodes don't prevent configuring the others
+			_, err = libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: "tstor-east_node3"})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			gomega.Expect(statuses["east"].Messages).To(gomega.ConsistOf("global: " + types.PeerClusterUnreachableMsg +
+				": 2 of 3 peer nodes: node2 (BFD state down), node3 (no tunnel)"))
+			condition := meta.FindStatusCondition(statuses["east"].Conditions, "PeerNodesReachable-global")
+			gomega.Expect(condition).NotTo(gomega.BeNil())
+			gomega.Expect(condition.Status).To(gomega.Equal(metav1.ConditionFalse))
+			gomega.Expect(condition.Reason).To(gomega.Equal("PeerNodesUnreachable"))
+		})
+	})
+
+	ginkgo.It("revalidates the peer clusters when local nodes change", func() {
+		runTest(func(nbClient, sbClient libovsdbclient.Client) {
+			peerCluster := newPeerCluster("east", time.Now(),
+				newPeerClusterNode("node1", "peer-chassis-1", 10, "100.88.0.10/16", "10.128.1.0/24"))
+			c, statuses, _, nodeIndexer := newTestPeerClusterController(nbClient, sbClient, nil, peerCluster)
+
+			gomega.Expect(c.sync()).To(gomega.Succeed())
+			_, err := libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: "tstor-east_node1"})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			// a new local node gets the tunnel key of the peer node
+			testNode2 := testNode1.DeepCopy()
+			testNode2.Name = "node2"
+			testNode2.Annotations[ovnNodeChassisIDAnnotatin] = "0b4b9a06-a4b6-4b40-8b62-6ac4d6b3c8a9"
+			testNode2.Annotations[ovnNodeIDAnnotaton] = "10"
+			testNode2.Annotations[ovnTransitSwitchPortAddrAnnotation] = "{\"ipv4\":\"100.88.0.3/16\"}"
+			gomega.Expect(nodeNeedsUpdate(nil, testNode2)).To(gomega.BeTrue())
+			gomega.Expect(nodeIndexer.Add(testNode2)).To(gomega.Succeed())
+			gomega.Expect(c.sync()).To(gomega.Succeed())
+
+			_, err = libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: "tstor-east_node1"})
+			gomega.Expect(err).To(gomega.MatchError(libovsdbclient.ErrNotFound))
+			messages := statuses["east"].Messages
+			gomega.Expect(messages).To(gomega.HaveLen(1))
+			gomega.Expect(messages[0]).To(gomega.ContainSubstring("tunnel key 10 is already in use by node node2"))
+		})
+	})
+
+	ginkgo.DescribeTable("reconciles peer clusters on local node changes",
+		func(update func(node *corev1.Node), expected bool) {
+			newNode := testNode1.DeepCopy()
+			update(newNode)
+			gomega.Expect(nodeNeedsUpdate(&testNode1, newNode)).To(gomega.Equal(expected))
+		},
+		ginkgo.Entry("node ID change", func(node *corev1.Node) {
+			node.Annotations[ovnNodeIDAnnotaton] = "3"
+		}, true),
+		ginkgo.Entry("chassis ID change", func(node *corev1.Node) {
+			node.Annotations[ovnNodeChassisIDAnnotatin] = "0b4b9a06-a4b6-4b40-8b62-6ac4d6b3c8a9"
+		}, true),
+		ginkgo.Entry("transit switch IP change", func(node *corev1.Node) {
+			node.Annotations[ovnTransitSwitchPortAddrAnnotation] = "{\"ipv4\":\"100.88.0.3/16\"}"
+		}, true),
+		ginkgo.Entry("other change", func(node *corev1.Node) {
+			node.Labels = map[string]string{"foo": "bar"}
+		}, false),
+	)
+
+	ginkgo.DescribeTable("rejects invalid peer clusters",
+		func(node peerclusterapi.PeerClusterNode, expectedErr string) {
+			runTest(func(nbClient, sbClient libovsdbclient.Client) {
+				c, statuses, _, _ := newTestPeerClusterController(nbClient, sbClient, nil, newPeerCluster("east", time.Now(), node))
+
+				gomega.Expect(c.sync()).To(gomega.Succeed())
+
+				_, err := libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: "tstor-east_node1"})
+				gomega.Expect(err).To(gomega.MatchError(libovsdbclient.ErrNotFound))
+				messages := statuses["east"].Messages
+				gomega.Expect(messages).To(gomega.HaveLen(1))
+				gomega.Expect(messages[0]).To(gomega.ContainSubstring(types.PeerClusterErrorMsg))
+				gomega.Expect(messages[0]).To(gomega.ContainSubstring(expectedErr))
+				condition := meta.FindStatusCondition(statuses["east"].Conditions, "PeerNodesReachable-global")
+				gomega.Expect(condition).NotTo(gomega.BeNil())
+				gomega.Expect(condition.Status).To(gomega.Equal(metav1.ConditionFalse))
+				gomega.Expect(condition.Reason).To(gomega.Equal("ConfigurationFailed"))
+			})
+		},
+		ginkgo.Entry("pod subnet overlapping the cluster subnets",
+			newPeerClusterNode("node1", "peer-chassis-1", 10, "100.88.0.10/16", "10.1.128.0/24"),
+			"overlaps cluster subnet 10.1.0.0/16",
+		),
+		ginkgo.Entry("pod subnet overlapping the join subnet",
+			newPeerClusterNode("node1", "peer-chassis-1", 10, "100.88.0.10/16", "100.64.0.0/24"),
+			"overlaps built-in join subnet",
+		),
+		ginkgo.Entry("tunnel key of a local node",
+			newPeerClusterNode("node1", "peer-chassis-1", 2, "100.88.0.10/16", "10.128.1.0/24"),
+			"tunnel key 2 is already in use by node node1",
+		),
+		ginkgo.Entry("chassis ID of a local node",
+			newPeerClusterNode("node1", "cb9ec8fa-b409-4ef3-9f42-d9283c47aac6", 10, "100.88.0.10/16", "10.128.1.0/24"),
+			"is already in use by node node1",
+		),
+		ginkgo.Entry("transit switch IP of a local node",
+			newPeerClusterNode("node1", "peer-chassis-1", 10, "100.88.0.2/16", "10.128.1.0/24"),
+			"transit switch IP 100.88.0.2 is already in use by node node1",
+		),
+		ginkgo.Entry("transit switch IP outside of the transit switch subnet",
+			newPeerClusterNode("node1", "peer-chassis-1", 10, "100.89.0.10/16", "10.128.1.0/24"),
+			"is not within the transit switch subnets",
+		),
+		ginkgo.Entry("IPv6 pod subnet in an IPv4 cluster",
+			newPeerClusterNode("node1", "peer-chassis-1", 10, "100.88.0.10/16", "fd00:10:128::/64"),
+			"IPv6 is not enabled",
+		),
+	)
+})
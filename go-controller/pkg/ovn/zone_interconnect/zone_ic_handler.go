@@ -270,11 +270,12 @@ func (zic *ZoneInterconnectHandler) SyncNodes(objs []interface{}) error {
 			continue
 		}
 
-		if lp.ExternalIDs == nil {
+		lportNode, ok := lp.ExternalIDs["node"]
+		if !ok {
+			// not a node port, like the ports of peer cluster nodes
 			continue
 		}
 
-		lportNode := lp.ExternalIDs["node"]
 		if !foundNodeNames.Has(lportNode) {
 			staleNodeNames = append(staleNodeNames, lportNode)
 		}
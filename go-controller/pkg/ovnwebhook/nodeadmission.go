@@ -187,6 +187,9 @@ func (p NodeAdmission) ValidateUpdate(ctx context.Context, oldObj, newObj runtim
 			oldNodeShallowCopy.Status.Conditions = conditionsDeepCopy
 		}
 	}
+	// ovnkube-node tracks the roll out of network MTU changes with a node condition
+	oldNodeShallowCopy.Status.Conditions = withNodeCondition(oldNodeShallowCopy.Status.Conditions,
+		newNodeShallowCopy.Status.Conditions, types.NodeNetworkMTUCondition)
 	if !apiequality.Semantic.DeepEqual(oldNodeShallowCopy.ObjectMeta, newNodeShallowCopy.ObjectMeta) ||
 		!apiequality.Semantic.DeepEqual(oldNodeShallowCopy.Status, newNodeShallowCopy.Status) {
 		return nil, fmt.Errorf("ovnkube-node on node: %q is not allowed to modify anything other than annotations", nodeName)
@@ -194,3 +197,21 @@ func (p NodeAdmission) ValidateUpdate(ctx context.Context, oldObj, newObj runtim
 
 	return nil, nil
 }
+
+// withNodeCondition returns a copy of the old conditions where the condition
+// of the given type is added or replaced with the one found in the new
+// conditions, if any.
+func withNodeCondition(oldConditions, newConditions []corev1.NodeCondition, conditionType corev1.NodeConditionType) []corev1.NodeCondition {
+	_, newCondition := nodeutil.GetNodeCondition(&corev1.NodeStatus{Conditions: newConditions}, conditionType)
+	if newCondition == nil {
+		return oldConditions
+	}
+	conditions := make([]corev1.NodeCondition, len(oldConditions))
+	copy(conditions, oldConditions)
+	oldID, _ := nodeutil.GetNodeCondition(&corev1.NodeStatus{Conditions: conditions}, conditionType)
+	if oldID < 0 {
+		return append(conditions, *newCondition)
+	}
+	conditions[oldID] = *newCondition
+	return conditions
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"golang.org/x/exp/maps"
 
 	corev1 "k8s.io/api/core/v1"
@@ -59,6 +60,12 @@ var interconnectPodAnnotations = map[string]checkPodAnnot{
 	util.DPUConnectionStatusAnnot:  nil,
 }
 
+// podInterfaceHotplugPodAnnotations holds annotations allowed for ovnkube-node:<nodeName> users when the
+// secondary network interfaces of running pods are hot-plugged
+var podInterfaceHotplugPodAnnotations = map[string]checkPodAnnot{
+	nadapi.NetworkStatusAnnot: nil,
+}
+
 // PodAdmissionConditionOptions specifies additional validate admission for pod.
 type PodAdmissionConditionOption struct {
 	// CommonNamePrefix specifies common name in Usename
@@ -100,11 +107,16 @@ type PodAdmission struct {
 	podAdmissions     []PodAdmissionConditionOption
 }
 
-func NewPodAdmissionWebhook(nodeLister listers.NodeLister, podAdmissions []PodAdmissionConditionOption, extraAllowedUsers ...string) *PodAdmission {
+func NewPodAdmissionWebhook(nodeLister listers.NodeLister, podAdmissions []PodAdmissionConditionOption, enablePodInterfaceHotplug bool, extraAllowedUsers ...string) *PodAdmission {
+	annotations := make(map[string]checkPodAnnot)
+	maps.Copy(annotations, interconnectPodAnnotations)
+	if enablePodInterfaceHotplug {
+		maps.Copy(annotations, podInterfaceHotplugPodAnnotations)
+	}
 	return &PodAdmission{
 		nodeLister:        nodeLister,
-		annotations:       interconnectPodAnnotations,
-		annotationKeys:    sets.New[string](maps.Keys(interconnectPodAnnotations)...),
+		annotations:       annotations,
+		annotationKeys:    sets.New[string](maps.Keys(annotations)...),
 		extraAllowedUsers: sets.New[string](extraAllowedUsers...),
 		podAdmissions:     podAdmissions,
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	kubevirtv1 "kubevirt.io/api/core/v1"
 
 	admv1 "k8s.io/api/admission/v1"
@@ -433,7 +434,7 @@ func TestPodAdmission_ValidateUpdate(t *testing.T) {
 				nodes: map[string]*corev1.Node{tt.node.Name: tt.node},
 			}, []PodAdmissionConditionOption{
 				additionalPodAdmissions,
-			})
+			}, false)
 			_, err := padm.ValidateUpdate(tt.ctx, tt.oldObj, tt.newObj)
 			if err != tt.expectedErr && err.Error() != tt.expectedErr.Error() {
 				t.Errorf("ValidateUpdate() error = %v, expectedErr %v", err, tt.expectedErr)
@@ -523,7 +524,7 @@ func TestPodAdmission_ValidateUpdateExtraUsers(t *testing.T) {
 				nodes: map[string]*corev1.Node{tt.node.Name: tt.node},
 			}, []PodAdmissionConditionOption{
 				additionalPodAdmissions,
-			}, extraUser)
+			}, false, extraUser)
 			_, err := padm.ValidateUpdate(tt.ctx, tt.oldObj, tt.newObj)
 			if err != tt.expectedErr && err.Error() != tt.expectedErr.Error() {
 				t.Errorf("ValidateUpdate() error = %v, expectedErr %v", err, tt.expectedErr)
@@ -532,3 +533,56 @@ func TestPodAdmission_ValidateUpdateExtraUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestPodAdmission_ValidateUpdatePodInterfaceHotplug(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+		},
+	}
+	ctx := admission.NewContextWithRequest(context.TODO(), admission.Request{
+		AdmissionRequest: admv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+			Username: userName,
+		}},
+	})
+	tests := []struct {
+		name                      string
+		enablePodInterfaceHotplug bool
+		expectedErr               error
+	}{
+		{
+			name:                      "ovnkube-node can modify NetworkStatusAnnot annotation on a pod when hot-plug is enabled",
+			enablePodInterfaceHotplug: true,
+		},
+		{
+			name:        "ovnkube-node cannot modify NetworkStatusAnnot annotation on a pod when hot-plug is disabled",
+			expectedErr: fmt.Errorf("ovnkube-node on node: %q is not allowed to set the following annotations on pod: %q: %v", nodeName, podName, []string{nadapi.NetworkStatusAnnot}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			padm := NewPodAdmissionWebhook(&fakeNodeLister{
+				nodes: map[string]*corev1.Node{node.Name: node},
+			}, nil, tt.enablePodInterfaceHotplug)
+			// the pods are built for every test as the validation clears their annotations
+			oldPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        podName,
+					Annotations: map[string]string{nadapi.NetworkStatusAnnot: "old"},
+				},
+				Spec: corev1.PodSpec{NodeName: nodeName},
+			}
+			newPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        podName,
+					Annotations: map[string]string{nadapi.NetworkStatusAnnot: "new"},
+				},
+				Spec: corev1.PodSpec{NodeName: nodeName},
+			}
+			_, err := padm.ValidateUpdate(ctx, oldPod, newPod)
+			if fmt.Sprint(err) != fmt.Sprint(tt.expectedErr) {
+				t.Errorf("ValidateUpdate() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
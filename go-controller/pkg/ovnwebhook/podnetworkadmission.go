@@ -0,0 +1,195 @@
+package ovnwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// anyNamespace matches every namespace that has no allowance of its own
+const anyNamespace = "*"
+
+// podNetworkAnnotations are the pod annotations validated against the namespace allowances
+var podNetworkAnnotations = []string{
+	nadapi.NetworkAttachmentAnnot,
+	util.DefNetworkAnnotation,
+	util.PodIngressBandwidthAnnotation,
+	util.PodEgressBandwidthAnnotation,
+}
+
+// PodNetworkAllowance specifies the network settings pods of a set of namespaces may request
+// through their annotations.
+type PodNetworkAllowance struct {
+	// Namespaces the allowance applies to, "*" applies it to any namespace not listed elsewhere
+	Namespaces []string `json:"namespaces"`
+	// AllowedNetworks contains the network attachment definitions, in <namespace>/<name> form,
+	// pods are allowed to attach to. "<namespace>/*" allows any NAD of that namespace.
+	AllowedNetworks []string `json:"allowedNetworks"`
+	// MaxIngressBandwidth is the ceiling for the kubernetes.io/ingress-bandwidth annotation
+	MaxIngressBandwidth *resource.Quantity `json:"maxIngressBandwidth,omitempty"`
+	// MaxEgressBandwidth is the ceiling for the kubernetes.io/egress-bandwidth annotation
+	MaxEgressBandwidth *resource.Quantity `json:"maxEgressBandwidth,omitempty"`
+	// AllowStaticAddresses allows pods to request static IP or MAC addresses
+	AllowStaticAddresses bool `json:"allowStaticAddresses"`
+	// AllowedNetworkKeys contains AllowedNetworks value as sets.Set[]
+	AllowedNetworkKeys sets.Set[string] `json:"-"`
+}
+
+// InitPodNetworkAllowances loads the PodNetworkAllowance list from fileName
+func InitPodNetworkAllowances(fileName string) (allowances []PodNetworkAllowance, err error) {
+	if fileName == "" {
+		return nil, nil
+	}
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(file, &allowances); err != nil {
+		return nil, err
+	}
+
+	namespaces := sets.New[string]()
+	for i, v := range allowances {
+		for _, namespace := range v.Namespaces {
+			if namespaces.Has(namespace) {
+				return nil, fmt.Errorf("namespace %q is listed in more than one pod network allowance", namespace)
+			}
+			namespaces.Insert(namespace)
+		}
+		// initialize Sets from slices
+		allowances[i].AllowedNetworkKeys = sets.New[string](v.AllowedNetworks...)
+	}
+
+	return allowances, nil
+}
+
+// PodNetworkAdmission validates the network annotations of pods against the
+// allowances of their namespace. Namespaces without an allowance may not
+// request any secondary network, bandwidth limit or static address.
+type PodNetworkAdmission struct {
+	allowances map[string]*PodNetworkAllowance
+}
+
+func NewPodNetworkAdmissionWebhook(allowances []PodNetworkAllowance) *PodNetworkAdmission {
+	p := &PodNetworkAdmission{
+		allowances: map[string]*PodNetworkAllowance{},
+	}
+	for i := range allowances {
+		for _, namespace := range allowances[i].Namespaces {
+			p.allowances[namespace] = &allowances[i]
+		}
+	}
+	return p
+}
+
+var _ admission.CustomValidator = &PodNetworkAdmission{}
+
+func (p PodNetworkAdmission) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	pod := obj.(*corev1.Pod)
+	return nil, p.validatePodNetworks(pod)
+}
+
+func (p PodNetworkAdmission) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	oldPod := oldObj.(*corev1.Pod)
+	newPod := newObj.(*corev1.Pod)
+
+	// Only validate pods changing their network annotations so that updates
+	// of pods admitted before the allowances changed are not blocked.
+	changed := false
+	for _, annotation := range podNetworkAnnotations {
+		if oldPod.Annotations[annotation] != newPod.Annotations[annotation] {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	return nil, p.validatePodNetworks(newPod)
+}
+
+func (p PodNetworkAdmission) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+func (p PodNetworkAdmission) allowanceForNamespace(namespace string) *PodNetworkAllowance {
+	if allowance, ok := p.allowances[namespace]; ok {
+		return allowance
+	}
+	if allowance, ok := p.allowances[anyNamespace]; ok {
+		return allowance
+	}
+	return &PodNetworkAllowance{}
+}
+
+func (p PodNetworkAdmission) validatePodNetworks(pod *corev1.Pod) error {
+	allowance := p.allowanceForNamespace(pod.Namespace)
+
+	networks, err := util.GetK8sPodAllNetworkSelections(pod)
+	if err != nil {
+		return err
+	}
+	defaultNetwork, err := util.GetK8sPodDefaultNetworkSelection(pod)
+	if err != nil {
+		return err
+	}
+	if defaultNetwork != nil {
+		networks = append(networks, defaultNetwork)
+	}
+	for _, network := range networks {
+		if !allowance.allowsNetwork(network.Namespace, network.Name) {
+			return fmt.Errorf("pod %s/%s is not allowed to attach to network %s/%s",
+				pod.Namespace, pod.Name, network.Namespace, network.Name)
+		}
+	}
+
+	if !allowance.AllowStaticAddresses {
+		for _, network := range networks {
+			if len(network.IPRequest) > 0 || network.MacRequest != "" {
+				return fmt.Errorf("pod %s/%s is not allowed to request static addresses on network %s/%s",
+					pod.Namespace, pod.Name, network.Namespace, network.Name)
+			}
+		}
+	}
+
+	if err := checkPodBandwidth(pod, util.PodIngressBandwidthAnnotation, allowance.MaxIngressBandwidth); err != nil {
+		return err
+	}
+	return checkPodBandwidth(pod, util.PodEgressBandwidthAnnotation, allowance.MaxEgressBandwidth)
+}
+
+func (a *PodNetworkAllowance) allowsNetwork(namespace, name string) bool {
+	return a.AllowedNetworkKeys.Has(util.GetNADName(namespace, name)) ||
+		a.AllowedNetworkKeys.Has(util.GetNADName(namespace, "*"))
+}
+
+// checkPodBandwidth fails if the pod requests more bandwidth through annotation than allowed by ceiling.
+// Requesting any bandwidth is rejected when no ceiling is configured.
+func checkPodBandwidth(pod *corev1.Pod, annotation string, ceiling *resource.Quantity) error {
+	bandwidth, found, err := util.ParsePodBandwidthAnnotation(pod.Annotations, annotation)
+	if !found {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse %s on pod %s/%s: %w", annotation, pod.Namespace, pod.Name, err)
+	}
+	if ceiling == nil {
+		return fmt.Errorf("pod %s/%s is not allowed to set %s", pod.Namespace, pod.Name, annotation)
+	}
+	if bandwidth > ceiling.Value() {
+		return fmt.Errorf("pod %s/%s %s %d exceeds the namespace ceiling %s",
+			pod.Namespace, pod.Name, annotation, bandwidth, ceiling.String())
+	}
+	return nil
+}
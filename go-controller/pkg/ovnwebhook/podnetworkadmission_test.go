@@ -0,0 +1,173 @@
+package ovnwebhook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestPodNetworkAdmission_ValidateCreate(t *testing.T) {
+	maxBandwidth := resource.MustParse("10M")
+	allowances := []PodNetworkAllowance{
+		{
+			Namespaces:           []string{"tenant"},
+			AllowedNetworks:      []string{"tenant/blue", "shared/*"},
+			MaxIngressBandwidth:  &maxBandwidth,
+			AllowStaticAddresses: false,
+			AllowedNetworkKeys:   sets.New[string]("tenant/blue", "shared/*"),
+		},
+		{
+			Namespaces:           []string{"infra"},
+			AllowedNetworks:      []string{"infra/localnet"},
+			AllowStaticAddresses: true,
+			AllowedNetworkKeys:   sets.New[string]("infra/localnet"),
+		},
+	}
+	tests := []struct {
+		name        string
+		namespace   string
+		annotations map[string]string
+		expectErr   bool
+	}{
+		{
+			name:      "allow pods without network annotations",
+			namespace: "other",
+		},
+		{
+			name:        "allow an allowed network attachment",
+			namespace:   "tenant",
+			annotations: map[string]string{"k8s.v1.cni.cncf.io/networks": "blue"},
+		},
+		{
+			name:        "allow any network attachment of a wildcard namespace",
+			namespace:   "tenant",
+			annotations: map[string]string{"k8s.v1.cni.cncf.io/networks": "shared/red"},
+		},
+		{
+			name:        "deny a network attachment not allowed for the namespace",
+			namespace:   "tenant",
+			annotations: map[string]string{"k8s.v1.cni.cncf.io/networks": "infra/localnet"},
+			expectErr:   true,
+		},
+		{
+			name:        "deny network attachments in namespaces without allowances",
+			namespace:   "other",
+			annotations: map[string]string{"k8s.v1.cni.cncf.io/networks": "other/blue"},
+			expectErr:   true,
+		},
+		{
+			name:        "deny static IP requests when not allowed",
+			namespace:   "tenant",
+			annotations: map[string]string{"k8s.v1.cni.cncf.io/networks": `[{"name":"blue","ips":["10.0.0.5/24"]}]`},
+			expectErr:   true,
+		},
+		{
+			name:        "deny static MAC requests on the default network when not allowed",
+			namespace:   "tenant",
+			annotations: map[string]string{"v1.multus-cni.io/default-network": `[{"name":"default","namespace":"shared","mac":"0a:58:0a:00:00:05"}]`},
+			expectErr:   true,
+		},
+		{
+			name:        "deny a default network selection not allowed for the namespace",
+			namespace:   "tenant",
+			annotations: map[string]string{"v1.multus-cni.io/default-network": "infra/localnet"},
+			expectErr:   true,
+		},
+		{
+			name:        "allow an allowed default network selection",
+			namespace:   "tenant",
+			annotations: map[string]string{"v1.multus-cni.io/default-network": "shared/default"},
+		},
+		{
+			name:        "allow static IP requests when allowed",
+			namespace:   "infra",
+			annotations: map[string]string{"k8s.v1.cni.cncf.io/networks": `[{"name":"localnet","ips":["10.0.0.5/24"]}]`},
+		},
+		{
+			name:        "allow bandwidth below the ceiling",
+			namespace:   "tenant",
+			annotations: map[string]string{"kubernetes.io/ingress-bandwidth": "1M"},
+		},
+		{
+			name:        "deny bandwidth above the ceiling",
+			namespace:   "tenant",
+			annotations: map[string]string{"kubernetes.io/ingress-bandwidth": "100M"},
+			expectErr:   true,
+		},
+		{
+			name:        "deny bandwidth without a ceiling",
+			namespace:   "tenant",
+			annotations: map[string]string{"kubernetes.io/egress-bandwidth": "1M"},
+			expectErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPodNetworkAdmissionWebhook(allowances)
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        podName,
+					Namespace:   tt.namespace,
+					Annotations: tt.annotations,
+				},
+			}
+			_, err := p.ValidateCreate(context.TODO(), pod)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ValidateCreate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestPodNetworkAdmission_ValidateUpdate(t *testing.T) {
+	p := NewPodNetworkAdmissionWebhook(nil)
+	oldPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        podName,
+			Namespace:   "tenant",
+			Annotations: map[string]string{"k8s.v1.cni.cncf.io/networks": "blue"},
+		},
+	}
+
+	// unrelated changes of pods admitted before are allowed
+	newPod := oldPod.DeepCopy()
+	newPod.Labels = map[string]string{"key": "new"}
+	if _, err := p.ValidateUpdate(context.TODO(), oldPod, newPod); err != nil {
+		t.Errorf("ValidateUpdate() unexpected error = %v", err)
+	}
+
+	newPod = oldPod.DeepCopy()
+	newPod.Annotations["kubernetes.io/ingress-bandwidth"] = "1M"
+	if _, err := p.ValidateUpdate(context.TODO(), oldPod, newPod); err == nil {
+		t.Errorf("ValidateUpdate() expected an error changing the network annotations")
+	}
+}
+
+func TestInitPodNetworkAllowances(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "allowances.json")
+	if err := os.WriteFile(fileName, []byte(`[{"namespaces":["tenant"],"allowedNetworks":["tenant/blue"],"maxIngressBandwidth":"10M"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	allowances, err := InitPodNetworkAllowances(fileName)
+	if err != nil {
+		t.Fatalf("InitPodNetworkAllowances() unexpected error = %v", err)
+	}
+	if len(allowances) != 1 || !allowances[0].AllowedNetworkKeys.Has("tenant/blue") ||
+		allowances[0].MaxIngressBandwidth.Value() != 10000000 {
+		t.Errorf("InitPodNetworkAllowances() unexpected allowances %+v", allowances)
+	}
+
+	if err := os.WriteFile(fileName, []byte(`[{"namespaces":["tenant"]},{"namespaces":["tenant"]}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InitPodNetworkAllowances(fileName); err == nil {
+		t.Errorf("InitPodNetworkAllowances() expected an error for duplicated namespaces")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	ipamclaimsapi "github.com/k8snetworkplumbingwg/ipamclaims/pkg/crd/ipamclaims/v1alpha1"
 	ipamclaimslister "github.com/k8snetworkplumbingwg/ipamclaims/pkg/crd/ipamclaims/v1alpha1/apis/listers/ipamclaims/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	ipam "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
@@ -35,6 +36,10 @@ type PersistentAllocations interface {
 	FindIPAMClaim(claimName string, namespace string) (*ipamclaimsapi.IPAMClaim, error)
 
 	Reconcile(oldIPAMClaim *ipamclaimsapi.IPAMClaim, newIPAMClaim *ipamclaimsapi.IPAMClaim, ipReleaser IPReleaser) error
+
+	EnsureStatefulSetIPAMClaim(pod *corev1.Pod, claimName string) error
+
+	ReleaseStatefulSetIPAMClaim(pod *corev1.Pod, claimName string) error
 }
 
 // IPAMClaimReconciler acts on IPAMClaim events handed off by the cluster network
@@ -0,0 +1,92 @@
+package persistentips
+
+import (
+	"fmt"
+
+	ipamclaimsapi "github.com/k8snetworkplumbingwg/ipamclaims/pkg/crd/ipamclaims/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/udn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// EnsureStatefulSetIPAMClaim creates the IPAMClaim persisting the primary UDN
+// IPs of the given StatefulSet pod if it does not exist yet. The claim is owned
+// by the StatefulSet so that it is garbage collected along with it.
+func (icr *IPAMClaimReconciler) EnsureStatefulSetIPAMClaim(pod *corev1.Pod, claimName string) error {
+	if _, err := icr.FindIPAMClaim(claimName, pod.Namespace); err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	stsName, _, ok := util.GetStatefulSetPodOrdinal(pod)
+	if !ok {
+		return fmt.Errorf("pod %s/%s is not part of a StatefulSet", pod.Namespace, pod.Name)
+	}
+	sts, err := icr.kube.GetStatefulSet(pod.Namespace, stsName)
+	if err != nil {
+		return fmt.Errorf("failed to get StatefulSet %s/%s: %w", pod.Namespace, stsName, err)
+	}
+
+	ipamClaim := &ipamclaimsapi.IPAMClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: pod.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         appsv1.SchemeGroupVersion.String(),
+					Kind:               "StatefulSet",
+					Name:               sts.Name,
+					UID:                sts.UID,
+					BlockOwnerDeletion: ptr.To(true),
+				},
+			},
+		},
+		Spec: ipamclaimsapi.IPAMClaimSpec{
+			Network:   icr.netInfo.GetNetworkName(),
+			Interface: udn.PrimaryNetworkInterfaceName,
+		},
+	}
+	if _, err := icr.kube.CreateIPAMClaim(ipamClaim); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create IPAMClaim %s/%s: %w", pod.Namespace, claimName, err)
+	}
+	klog.V(5).Infof("Created IPAMClaim %s/%s for StatefulSet pod %s", pod.Namespace, claimName, pod.Name)
+	return nil
+}
+
+// ReleaseStatefulSetIPAMClaim deletes the IPAMClaim of a deleted StatefulSet
+// pod if its ordinal is no longer part of the StatefulSet, i.e. on scale down.
+// The IPs are then released when the IPAMClaim deletion is reconciled.
+func (icr *IPAMClaimReconciler) ReleaseStatefulSetIPAMClaim(pod *corev1.Pod, claimName string) error {
+	stsName, ordinal, ok := util.GetStatefulSetPodOrdinal(pod)
+	if !ok {
+		return nil
+	}
+	sts, err := icr.kube.GetStatefulSet(pod.Namespace, stsName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get StatefulSet %s/%s: %w", pod.Namespace, stsName, err)
+	}
+	if err == nil && sts.DeletionTimestamp == nil {
+		start := 0
+		if sts.Spec.Ordinals != nil {
+			start = int(sts.Spec.Ordinals.Start)
+		}
+		if ordinal >= start && ordinal < start+int(ptr.Deref(sts.Spec.Replicas, 1)) {
+			// the ordinal is going to be recreated and reuse the IPAMClaim
+			return nil
+		}
+	}
+
+	if err := icr.kube.DeleteIPAMClaim(pod.Namespace, claimName); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete IPAMClaim %s/%s: %w", pod.Namespace, claimName, err)
+	}
+	klog.V(5).Infof("Deleted IPAMClaim %s/%s of scaled down StatefulSet pod %s", pod.Namespace, claimName, pod.Name)
+	return nil
+}
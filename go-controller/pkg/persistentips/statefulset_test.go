@@ -0,0 +1,137 @@
+package persistentips
+
+import (
+	"context"
+
+	ipamclaimsapi "github.com/k8snetworkplumbingwg/ipamclaims/pkg/crd/ipamclaims/v1alpha1"
+	fakeipamclaimclient "github.com/k8snetworkplumbingwg/ipamclaims/pkg/crd/ipamclaims/v1alpha1/apis/clientset/versioned/fake"
+	ipamclaimsfactory "github.com/k8snetworkplumbingwg/ipamclaims/pkg/crd/ipamclaims/v1alpha1/apis/informers/externalversions"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/udn"
+	ovnkclient "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Persistent IPs for StatefulSets", func() {
+	const (
+		namespace   = "ns1"
+		networkName = "tenantblue"
+		stsName     = "db"
+		podName     = "db-1"
+	)
+
+	var (
+		ipamClaimClient *fakeipamclaimclient.Clientset
+		sts             *appsv1.StatefulSet
+		pod             *corev1.Pod
+		claimName       string
+	)
+
+	newReconciler := func(objects ...runtime.Object) *IPAMClaimReconciler {
+		stopCh := make(chan struct{})
+		informerFactory := ipamclaimsfactory.NewSharedInformerFactory(ipamClaimClient, 0)
+		lister := informerFactory.K8s().V1alpha1().IPAMClaims().Lister()
+		informerFactory.Start(stopCh)
+		informerFactory.WaitForCacheSync(stopCh)
+		DeferCleanup(func() {
+			close(stopCh)
+			informerFactory.Shutdown()
+		})
+
+		netInfo, err := util.NewNetInfo(dummyNetconf(networkName))
+		Expect(err).NotTo(HaveOccurred())
+
+		kube := &ovnkclient.KubeOVN{
+			Kube:             ovnkclient.Kube{KClient: fake.NewSimpleClientset(objects...)},
+			IPAMClaimsClient: ipamClaimClient,
+		}
+		return NewIPAMClaimReconciler(kube, netInfo, lister)
+	}
+
+	BeforeEach(func() {
+		ipamClaimClient = fakeipamclaimclient.NewSimpleClientset()
+
+		sts = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: stsName, Namespace: namespace, UID: "sts-uid"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](2)},
+		}
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					util.OvnUDNPersistentIPsAnnotationName: "true",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(sts, appsv1.SchemeGroupVersion.WithKind("StatefulSet")),
+				},
+			},
+		}
+		claimName = util.GetStatefulSetPodIPAMClaimName(pod)
+		Expect(claimName).NotTo(BeEmpty())
+	})
+
+	getClaim := func() (*ipamclaimsapi.IPAMClaim, error) {
+		return ipamClaimClient.K8sV1alpha1().IPAMClaims(namespace).Get(context.Background(), claimName, metav1.GetOptions{})
+	}
+
+	It("creates an IPAMClaim owned by the StatefulSet", func() {
+		Expect(newReconciler(sts).EnsureStatefulSetIPAMClaim(pod, claimName)).To(Succeed())
+
+		claim, err := getClaim()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claim.Spec.Network).To(Equal(networkName))
+		Expect(claim.Spec.Interface).To(Equal(udn.PrimaryNetworkInterfaceName))
+		Expect(claim.OwnerReferences).To(HaveLen(1))
+		Expect(claim.OwnerReferences[0].Kind).To(Equal("StatefulSet"))
+		Expect(claim.OwnerReferences[0].UID).To(Equal(sts.UID))
+	})
+
+	It("reuses an existing IPAMClaim", func() {
+		existing := ipamClaimWithIPs(namespace, claimName, networkName, "192.10.10.10/24")
+		_, err := ipamClaimClient.K8sV1alpha1().IPAMClaims(namespace).Create(context.Background(), existing, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		// the StatefulSet is not needed when the claim is already there
+		Expect(newReconciler().EnsureStatefulSetIPAMClaim(pod, claimName)).To(Succeed())
+
+		claim, err := getClaim()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claim.Status.IPs).To(ConsistOf("192.10.10.10/24"))
+	})
+
+	DescribeTable("releasing the IPAMClaim of a deleted pod", func(replicas int32, stsExists, expectDeleted bool) {
+		sts.Spec.Replicas = &replicas
+		existing := ipamClaimWithIPs(namespace, claimName, networkName, "192.10.10.10/24")
+		_, err := ipamClaimClient.K8sV1alpha1().IPAMClaims(namespace).Create(context.Background(), existing, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var objects []runtime.Object
+		if stsExists {
+			objects = append(objects, sts)
+		}
+		Expect(newReconciler(objects...).ReleaseStatefulSetIPAMClaim(pod, claimName)).To(Succeed())
+
+		_, err = getClaim()
+		if expectDeleted {
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+		Entry("keeps it while the ordinal is part of the StatefulSet", int32(2), true, false),
+		Entry("deletes it when the StatefulSet is scaled down", int32(1), true, true),
+		Entry("deletes it when the StatefulSet is gone", int32(2), false, true),
+	)
+})
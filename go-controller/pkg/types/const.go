@@ -121,6 +121,12 @@ const (
 	// priority of logical router policies on a nodes gateway router
 	EgressIPSNATMarkPriority           = 95
 	EgressLiveMigrationReroutePriority = 10
+	// priority of the logical router policy on a layer2 network gateway router
+	// that sends the traffic of other gateway routers out of the local uplink
+	Layer2GatewayPeerReroutePriority = 105
+	// priority of the QoS rules limiting the bandwidth of a pod's logical
+	// switch port, above the EgressQoS rules
+	PodBandwidthQoSRulePriority = 1001
 
 	// EndpointSliceMirrorControllerName mirror EndpointSlice controller name (used as a value for the "endpointslice.kubernetes.io/managed-by" label)
 	EndpointSliceMirrorControllerName = "endpointslice-mirror-controller.k8s.ovn.org"
@@ -216,8 +222,14 @@ const (
 	LoadBalancerOwnerExternalID = OvnK8sPrefix + "/" + "owner"
 	// key for UDN enabled services routes
 	UDNEnabledServiceExternalID = OvnK8sPrefix + "/" + "udn-enabled-default-service"
+	// key for the audit mode external-id of ACLs, set to "would-drop" on the ACLs of policies in audit mode
+	// that let through the traffic they would otherwise drop
+	ACLAuditExternalID = OvnK8sPrefix + "/" + "audit"
 	// RequiredUDNNamespaceLabel is the required namespace label for enabling primary UDNs
 	RequiredUDNNamespaceLabel = "k8s.ovn.org/primary-user-defined-network"
+	// HostNetworkPolicyNodeLabel is the node label enabling network policies for the host network pods of the node,
+	// when set to "true"
+	HostNetworkPolicyNodeLabel = "k8s.ovn.org/host-network-policy"
 
 	// different secondary network topology type defined in CNI netconf
 	Layer3Topology   = "layer3"
@@ -301,4 +313,8 @@ const (
 
 	// CUDNPrefix of all CUDN network names
 	CUDNPrefix = "cluster_udn_"
+
+	// NodeNetworkMTUCondition is the type of the node condition that tracks
+	// the roll out of network MTU changes to the pods running on the node
+	NodeNetworkMTUCondition = "NetworkMTUUpdated"
 )
@@ -10,6 +10,10 @@ const (
 	APBRouteErrorMsg       = "failed to apply policy"
 	EgressFirewallErrorMsg = "EgressFirewall Rules not correctly applied"
 	EgressQoSErrorMsg      = "EgressQoS Rules not correctly applied"
+	PeerClusterErrorMsg    = "failed to connect to peer cluster"
+	// PeerClusterUnreachableMsg is reported when the peer cluster is configured
+	// but some peer nodes are unreachable
+	PeerClusterUnreachableMsg = "peer cluster nodes unreachable"
 )
 
 func GetZoneStatus(zoneID, message string) string {
@@ -31,6 +31,8 @@ import (
 	egressqosfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned/fake"
 	egressservice "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1"
 	egressservicefake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned/fake"
+	peercluster "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1"
+	peerclusterfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/clientset/versioned/fake"
 	routeadvertisements "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1"
 	routeadvertisementsfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1/apis/clientset/versioned/fake"
 	udnv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/userdefinednetwork/v1"
@@ -51,6 +53,7 @@ func GetOVNClientset(objects ...runtime.Object) *OVNClientset {
 	dnsNameResolverObjects := []runtime.Object{}
 	udnObjects := []runtime.Object{}
 	raObjects := []runtime.Object{}
+	peerClusterObjects := []runtime.Object{}
 	frrObjects := []runtime.Object{}
 	for _, object := range objects {
 		switch object.(type) {
@@ -78,6 +81,8 @@ func GetOVNClientset(objects ...runtime.Object) *OVNClientset {
 			udnObjects = append(udnObjects, object)
 		case *routeadvertisements.RouteAdvertisements:
 			raObjects = append(raObjects, object)
+		case *peercluster.PeerCluster:
+			peerClusterObjects = append(peerClusterObjects, object)
 		case *frrapi.FRRConfiguration:
 			frrObjects = append(frrObjects, object)
 		default:
@@ -106,6 +111,7 @@ func GetOVNClientset(objects ...runtime.Object) *OVNClientset {
 		OCPNetworkClient:          ocpnetworkclientfake.NewSimpleClientset(dnsNameResolverObjects...),
 		UserDefinedNetworkClient:  udnfake.NewSimpleClientset(udnObjects...),
 		RouteAdvertisementsClient: routeadvertisementsfake.NewSimpleClientset(raObjects...),
+		PeerClusterClient:         peerclusterfake.NewSimpleClientset(peerClusterObjects...),
 		FRRClient:                 frrfake.NewSimpleClientset(frrObjects...),
 	}
 }
@@ -50,6 +50,7 @@ import (
 	egressipclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned"
 	egressqosclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned"
 	egressserviceclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned"
+	peerclusterclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/peercluster/v1/apis/clientset/versioned"
 	routeadvertisementsclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1/apis/clientset/versioned"
 	userdefinednetworkclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/userdefinednetwork/v1/apis/clientset/versioned"
 )
@@ -70,6 +71,7 @@ type OVNClientset struct {
 	IPAMClaimsClient          ipamclaimssclientset.Interface
 	UserDefinedNetworkClient  userdefinednetworkclientset.Interface
 	RouteAdvertisementsClient routeadvertisementsclientset.Interface
+	PeerClusterClient         peerclusterclientset.Interface
 	FRRClient                 frrclientset.Interface
 }
 
@@ -89,6 +91,7 @@ type OVNMasterClientset struct {
 	NetworkAttchDefClient     networkattchmentdefclientset.Interface
 	UserDefinedNetworkClient  userdefinednetworkclientset.Interface
 	RouteAdvertisementsClient routeadvertisementsclientset.Interface
+	PeerClusterClient         peerclusterclientset.Interface
 	FRRClient                 frrclientset.Interface
 }
 
@@ -107,6 +110,7 @@ type OVNKubeControllerClientset struct {
 	NetworkAttchDefClient     networkattchmentdefclientset.Interface
 	UserDefinedNetworkClient  userdefinednetworkclientset.Interface
 	RouteAdvertisementsClient routeadvertisementsclientset.Interface
+	PeerClusterClient         peerclusterclientset.Interface
 }
 
 type OVNNodeClientset struct {
@@ -133,6 +137,7 @@ type OVNClusterManagerClientset struct {
 	OCPNetworkClient          ocpnetworkclientset.Interface
 	UserDefinedNetworkClient  userdefinednetworkclientset.Interface
 	RouteAdvertisementsClient routeadvertisementsclientset.Interface
+	PeerClusterClient         peerclusterclientset.Interface
 	FRRClient                 frrclientset.Interface
 }
 
@@ -162,6 +167,7 @@ func (cs *OVNClientset) GetMasterClientset() *OVNMasterClientset {
 		NetworkAttchDefClient:     cs.NetworkAttchDefClient,
 		UserDefinedNetworkClient:  cs.UserDefinedNetworkClient,
 		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
+		PeerClusterClient:         cs.PeerClusterClient,
 		FRRClient:                 cs.FRRClient,
 	}
 }
@@ -181,6 +187,7 @@ func (cs *OVNMasterClientset) GetOVNKubeControllerClientset() *OVNKubeController
 		NetworkAttchDefClient:     cs.NetworkAttchDefClient,
 		UserDefinedNetworkClient:  cs.UserDefinedNetworkClient,
 		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
+		PeerClusterClient:         cs.PeerClusterClient,
 	}
 }
 
@@ -199,6 +206,7 @@ func (cs *OVNClientset) GetOVNKubeControllerClientset() *OVNKubeControllerClient
 		NetworkAttchDefClient:     cs.NetworkAttchDefClient,
 		UserDefinedNetworkClient:  cs.UserDefinedNetworkClient,
 		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
+		PeerClusterClient:         cs.PeerClusterClient,
 	}
 }
 
@@ -217,6 +225,7 @@ func (cs *OVNClientset) GetClusterManagerClientset() *OVNClusterManagerClientset
 		OCPNetworkClient:          cs.OCPNetworkClient,
 		UserDefinedNetworkClient:  cs.UserDefinedNetworkClient,
 		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
+		PeerClusterClient:         cs.PeerClusterClient,
 		FRRClient:                 cs.FRRClient,
 	}
 }
@@ -517,6 +526,11 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 		return nil, err
 	}
 
+	peerClusterClientset, err := peerclusterclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
 	frrClientset, err := frrclientset.NewForConfig(kconfig)
 	if err != nil {
 		return nil, err
@@ -537,6 +551,7 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 		IPAMClaimsClient:          ipamClaimsClientset,
 		UserDefinedNetworkClient:  userDefinedNetworkClientSet,
 		RouteAdvertisementsClient: routeAdvertisementsClientset,
+		PeerClusterClient:         peerClusterClientset,
 		FRRClient:                 frrClientset,
 	}, nil
 }
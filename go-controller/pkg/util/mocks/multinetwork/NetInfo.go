@@ -490,6 +490,26 @@ func (_m *NetInfo) GetPodNetworkAdvertisedVRFs() map[string][]string {
 	return r0
 }
 
+// GetRouteImportPolicy provides a mock function with given fields:
+func (_m *NetInfo) GetRouteImportPolicy() *util.RouteImportPolicy {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRouteImportPolicy")
+	}
+
+	var r0 *util.RouteImportPolicy
+	if rf, ok := ret.Get(0).(func() *util.RouteImportPolicy); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*util.RouteImportPolicy)
+		}
+	}
+
+	return r0
+}
+
 // HasNAD provides a mock function with given fields: nadName
 func (_m *NetInfo) HasNAD(nadName string) bool {
 	ret := _m.Called(nadName)
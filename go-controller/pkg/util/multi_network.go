@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -67,6 +68,10 @@ type NetInfo interface {
 	// GetEgressIPAdvertisedNodes return the nodes where egress IP are
 	// advertised.
 	GetEgressIPAdvertisedNodes() []string
+	// GetRouteImportPolicy returns the policy restricting which of the routes
+	// learned over BGP are imported into the network, or nil if all of them
+	// are imported.
+	GetRouteImportPolicy() *RouteImportPolicy
 
 	// derived information.
 	GetNADNamespaces() []string
@@ -114,6 +119,39 @@ type MutableNetInfo interface {
 
 	// Nodes advertising Egress IP
 	SetEgressIPAdvertisedVRFs(eipAdvertisements map[string][]string)
+
+	// Policy for routes imported into the network
+	SetRouteImportPolicy(policy *RouteImportPolicy)
+}
+
+// RouteImportPolicy restricts which of the routes learned over BGP are
+// imported into a network.
+type RouteImportPolicy struct {
+	// AllowedPrefixes, if not empty, limits imported routes to destinations
+	// contained in any of these prefixes
+	AllowedPrefixes []*net.IPNet
+	// DeniedPrefixes prevents importing routes to destinations contained in
+	// any of these prefixes
+	DeniedPrefixes []*net.IPNet
+	// MaxPrefixes limits the number of imported destinations, no limit if 0
+	MaxPrefixes int
+	// IgnoreDefaultRoute prevents importing default routes
+	IgnoreDefaultRoute bool
+}
+
+// Copy returns a deep copy of the policy
+func (p *RouteImportPolicy) Copy() *RouteImportPolicy {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	if p.AllowedPrefixes != nil {
+		c.AllowedPrefixes = CopyIPNets(p.AllowedPrefixes)
+	}
+	if p.DeniedPrefixes != nil {
+		c.DeniedPrefixes = CopyIPNets(p.DeniedPrefixes)
+	}
+	return &c
 }
 
 // NewMutableNetInfo builds a copy of netInfo as a MutableNetInfo
@@ -219,9 +257,14 @@ type mutableNetInfo struct {
 	// changed or reconciled on day-2
 	id int
 
+	// mtu of the network. It can be changed on day-2, in which case it is
+	// rolled out to the network infrastructure and to existing pods.
+	mtu int
+
 	nads                     sets.Set[string]
 	podNetworkAdvertisements map[string][]string
 	eipAdvertisements        map[string][]string
+	routeImportPolicy        *RouteImportPolicy
 
 	// information generated from previous fields, not used in comparisons
 
@@ -260,9 +303,11 @@ func (l *mutableNetInfo) equals(r *mutableNetInfo) bool {
 	r.RLock()
 	defer r.RUnlock()
 	return reflect.DeepEqual(l.id, r.id) &&
+		l.mtu == r.mtu &&
 		reflect.DeepEqual(l.nads, r.nads) &&
 		reflect.DeepEqual(l.podNetworkAdvertisements, r.podNetworkAdvertisements) &&
-		reflect.DeepEqual(l.eipAdvertisements, r.eipAdvertisements)
+		reflect.DeepEqual(l.eipAdvertisements, r.eipAdvertisements) &&
+		reflect.DeepEqual(l.routeImportPolicy, r.routeImportPolicy)
 }
 
 func (l *mutableNetInfo) copyFrom(r *mutableNetInfo) {
@@ -272,17 +317,21 @@ func (l *mutableNetInfo) copyFrom(r *mutableNetInfo) {
 	aux := mutableNetInfo{}
 	r.RLock()
 	aux.id = r.id
+	aux.mtu = r.mtu
 	aux.nads = r.nads.Clone()
 	aux.setPodNetworkAdvertisedOnVRFs(r.podNetworkAdvertisements)
 	aux.setEgressIPAdvertisedAtNodes(r.eipAdvertisements)
+	aux.routeImportPolicy = r.routeImportPolicy.Copy()
 	aux.namespaces = r.namespaces.Clone()
 	r.RUnlock()
 	l.Lock()
 	defer l.Unlock()
 	l.id = aux.id
+	l.mtu = aux.mtu
 	l.nads = aux.nads
 	l.podNetworkAdvertisements = aux.podNetworkAdvertisements
 	l.eipAdvertisements = aux.eipAdvertisements
+	l.routeImportPolicy = aux.routeImportPolicy
 	l.namespaces = aux.namespaces
 }
 
@@ -368,6 +417,18 @@ func (nInfo *mutableNetInfo) GetEgressIPAdvertisedNodes() []string {
 	return maps.Keys(nInfo.eipAdvertisements)
 }
 
+func (nInfo *mutableNetInfo) SetRouteImportPolicy(policy *RouteImportPolicy) {
+	nInfo.Lock()
+	defer nInfo.Unlock()
+	nInfo.routeImportPolicy = policy.Copy()
+}
+
+func (nInfo *mutableNetInfo) GetRouteImportPolicy() *RouteImportPolicy {
+	nInfo.RLock()
+	defer nInfo.RUnlock()
+	return nInfo.routeImportPolicy
+}
+
 // GetNADs returns all the NADs associated with this network
 func (nInfo *mutableNetInfo) GetNADs() []string {
 	nInfo.RLock()
@@ -643,7 +704,6 @@ type secondaryNetInfo struct {
 	// as the pod's primary network?
 	primaryNetwork     bool
 	topology           string
-	mtu                int
 	vlan               uint
 	allowPersistentIPs bool
 
@@ -757,6 +817,8 @@ func (nInfo *secondaryNetInfo) TopologyType() string {
 
 // MTU returns the layer3NetConfInfo's MTU value
 func (nInfo *secondaryNetInfo) MTU() int {
+	nInfo.RLock()
+	defer nInfo.RUnlock()
 	return nInfo.mtu
 }
 
@@ -828,9 +890,6 @@ func (nInfo *secondaryNetInfo) canReconcile(other NetInfo) bool {
 	if nInfo.topology != other.TopologyType() {
 		return false
 	}
-	if nInfo.mtu != other.MTU() {
-		return false
-	}
 	if nInfo.vlan != other.Vlan() {
 		return false
 	}
@@ -862,7 +921,6 @@ func (nInfo *secondaryNetInfo) copy() *secondaryNetInfo {
 		netName:             nInfo.netName,
 		primaryNetwork:      nInfo.primaryNetwork,
 		topology:            nInfo.topology,
-		mtu:                 nInfo.mtu,
 		vlan:                nInfo.vlan,
 		allowPersistentIPs:  nInfo.allowPersistentIPs,
 		ipv4mode:            nInfo.ipv4mode,
@@ -893,9 +951,9 @@ func newLayer3NetConfInfo(netconf *ovncnitypes.NetConf) (MutableNetInfo, error)
 		topology:       types.Layer3Topology,
 		subnets:        subnets,
 		joinSubnets:    joinSubnets,
-		mtu:            netconf.MTU,
 		mutableNetInfo: mutableNetInfo{
 			id:   types.InvalidID,
+			mtu:  netconf.MTU,
 			nads: sets.Set[string]{},
 		},
 	}
@@ -919,10 +977,10 @@ func newLayer2NetConfInfo(netconf *ovncnitypes.NetConf) (MutableNetInfo, error)
 		subnets:            subnets,
 		joinSubnets:        joinSubnets,
 		excludeSubnets:     excludes,
-		mtu:                netconf.MTU,
 		allowPersistentIPs: netconf.AllowPersistentIPs,
 		mutableNetInfo: mutableNetInfo{
 			id:   types.InvalidID,
+			mtu:  netconf.MTU,
 			nads: sets.Set[string]{},
 		},
 	}
@@ -941,12 +999,12 @@ func newLocalnetNetConfInfo(netconf *ovncnitypes.NetConf) (MutableNetInfo, error
 		topology:            types.LocalnetTopology,
 		subnets:             subnets,
 		excludeSubnets:      excludes,
-		mtu:                 netconf.MTU,
 		vlan:                uint(netconf.VLANID),
 		allowPersistentIPs:  netconf.AllowPersistentIPs,
 		physicalNetworkName: netconf.PhysicalNetworkName,
 		mutableNetInfo: mutableNetInfo{
 			id:   types.InvalidID,
+			mtu:  netconf.MTU,
 			nads: sets.Set[string]{},
 		},
 	}
@@ -1151,7 +1209,7 @@ func ParseNADInfo(nad *nettypes.NetworkAttachmentDefinition) (NetInfo, error) {
 func ParseNetConf(netattachdef *nettypes.NetworkAttachmentDefinition) (*ovncnitypes.NetConf, error) {
 	netconf, err := config.ParseNetConf([]byte(netattachdef.Spec.Config))
 	if err != nil {
-		return nil, fmt.Errorf("error parsing Network Attachment Definition %s/%s: %v", netattachdef.Namespace, netattachdef.Name, err)
+		return nil, fmt.Errorf("error parsing Network Attachment Definition %s/%s: %w", netattachdef.Namespace, netattachdef.Name, err)
 	}
 
 	nadName := GetNADName(netattachdef.Namespace, netattachdef.Name)
@@ -1313,6 +1371,36 @@ func GetPodNADToNetworkMapping(pod *corev1.Pod, nInfo NetInfo) (bool, map[string
 	return true, networkSelections, nil
 }
 
+// GetPodHotUnpluggedNADs returns the NADs of the given secondary network the
+// pod is annotated for but that are no longer requested in its
+// k8s.v1.cni.cncf.io/networks annotation, i.e. the NADs the pod was
+// hot-unplugged from while running.
+func GetPodHotUnpluggedNADs(pod *corev1.Pod, nInfo NetInfo) ([]string, error) {
+	if !nInfo.IsSecondary() || nInfo.IsPrimaryNetwork() || pod.Spec.HostNetwork {
+		return nil, nil
+	}
+	podNetworks, err := UnmarshalPodAnnotationAllNetworks(pod.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	allNetworks, err := GetK8sPodAllNetworkSelections(pod)
+	if err != nil {
+		return nil, err
+	}
+	requested := sets.New[string]()
+	for _, network := range allNetworks {
+		requested.Insert(GetNADName(network.Namespace, network.Name))
+	}
+	var nadNames []string
+	for nadName := range podNetworks {
+		if nInfo.HasNAD(nadName) && !requested.Has(nadName) {
+			nadNames = append(nadNames, nadName)
+		}
+	}
+	sort.Strings(nadNames)
+	return nadNames, nil
+}
+
 // GetPodNADToNetworkMappingWithActiveNetwork will call `GetPodNADToNetworkMapping` passing "nInfo" which correspond
 // to the NetInfo representing the NAD, the resulting NetworkSelectingElements will be decorated with the ones
 // from found active network
@@ -1346,8 +1434,21 @@ func GetPodNADToNetworkMappingWithActiveNetwork(pod *corev1.Pod, nInfo NetInfo,
 		Name:      activeNetworkNADKey[1],
 	}
 
+	if IsPreconfiguredUDNAddressesEnabled() {
+		// the addresses requested for the pod's primary interface apply to
+		// the primary UDN rather than the default network
+		defaultNetwork, err := GetK8sPodDefaultNetworkSelection(pod)
+		if err != nil {
+			return false, nil, fmt.Errorf("error getting default-network's network-attachment for pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		if defaultNetwork != nil {
+			networkSelections[activeNetworkNADs[0]].IPRequest = defaultNetwork.IPRequest
+			networkSelections[activeNetworkNADs[0]].MacRequest = defaultNetwork.MacRequest
+		}
+	}
+
 	if nInfo.IsPrimaryNetwork() && AllowsPersistentIPs(nInfo) {
-		ipamClaimName, wasPersistentIPRequested := pod.Annotations[OvnUDNIPAMClaimName]
+		ipamClaimName, wasPersistentIPRequested := GetPrimaryUDNIPAMClaimName(pod)
 		if wasPersistentIPRequested {
 			networkSelections[activeNetworkNADs[0]].IPAMClaimReference = ipamClaimName
 		}
@@ -1364,6 +1465,12 @@ func IsNetworkSegmentationSupportEnabled() bool {
 	return config.OVNKubernetesFeature.EnableMultiNetwork && config.OVNKubernetesFeature.EnableNetworkSegmentation
 }
 
+// IsPreconfiguredUDNAddressesEnabled returns whether pods can request the IPs
+// and MAC of their primary UDN interface
+func IsPreconfiguredUDNAddressesEnabled() bool {
+	return IsNetworkSegmentationSupportEnabled() && config.OVNKubernetesFeature.EnablePreconfiguredUDNAddresses
+}
+
 func IsRouteAdvertisementsEnabled() bool {
 	// for now, we require multi-network to be enabled because we rely on NADs,
 	// even for the default network
@@ -846,6 +846,93 @@ func TestGetPodNADToNetworkMapping(t *testing.T) {
 	}
 }
 
+func TestGetPodHotUnpluggedNADs(t *testing.T) {
+	const (
+		namespaceName = "ns1"
+		networkName   = "l2-network"
+		podAnnotation = `{
+			"ns1/attachment1": {"ip_addresses":["10.1.0.4/24"], "mac_address":"0a:58:0a:01:00:04"},
+			"ns1/attachment2": {"ip_addresses":["10.1.0.5/24"], "mac_address":"0a:58:0a:01:00:05"},
+			"ns1/other": {"ip_addresses":["10.2.0.4/24"], "mac_address":"0a:58:0a:02:00:04"}
+		}`
+	)
+
+	tests := []struct {
+		desc                string
+		inputRole           string
+		inputHostNetwork    bool
+		inputPodAnnotations map[string]string
+		expectedNADs        []string
+	}{
+		{
+			desc: "no NAD is unplugged while all of them are requested",
+			inputPodAnnotations: map[string]string{
+				nadv1.NetworkAttachmentAnnot: "ns1/attachment1,ns1/attachment2",
+				OvnPodAnnotationName:         podAnnotation,
+			},
+		},
+		{
+			desc: "the NADs of the network no longer requested are unplugged",
+			inputPodAnnotations: map[string]string{
+				nadv1.NetworkAttachmentAnnot: "ns1/attachment2",
+				OvnPodAnnotationName:         podAnnotation,
+			},
+			expectedNADs: []string{"ns1/attachment1"},
+		},
+		{
+			desc: "all the NADs of the network are unplugged when none is requested",
+			inputPodAnnotations: map[string]string{
+				OvnPodAnnotationName: podAnnotation,
+			},
+			expectedNADs: []string{"ns1/attachment1", "ns1/attachment2"},
+		},
+		{
+			desc:      "primary networks are never unplugged",
+			inputRole: ovntypes.NetworkRolePrimary,
+			inputPodAnnotations: map[string]string{
+				OvnPodAnnotationName: podAnnotation,
+			},
+		},
+		{
+			desc:             "host networked pods have nothing to unplug",
+			inputHostNetwork: true,
+			inputPodAnnotations: map[string]string{
+				OvnPodAnnotationName: podAnnotation,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+			config.IPv4Mode = true
+			netInfo, err := NewNetInfo(&ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: networkName},
+				Topology: ovntypes.Layer2Topology,
+				Role:     test.inputRole,
+				Subnets:  "10.1.0.0/24",
+			})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			mutableNetInfo := NewMutableNetInfo(netInfo)
+			mutableNetInfo.AddNADs(GetNADName(namespaceName, "attachment1"), GetNADName(namespaceName, "attachment2"))
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-pod",
+					Namespace:   namespaceName,
+					Annotations: test.inputPodAnnotations,
+				},
+				Spec: corev1.PodSpec{HostNetwork: test.inputHostNetwork},
+			}
+
+			nadNames, err := GetPodHotUnpluggedNADs(pod, mutableNetInfo)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(nadNames).To(gomega.Equal(test.expectedNADs))
+		})
+	}
+}
+
 func TestGetPodNADToNetworkMappingWithActiveNetwork(t *testing.T) {
 	const (
 		attachmentName = "attachment1"
@@ -859,6 +946,7 @@ func TestGetPodNADToNetworkMappingWithActiveNetwork(t *testing.T) {
 		inputNetConf                     *ovncnitypes.NetConf
 		inputPrimaryUDNConfig            *ovncnitypes.NetConf
 		inputPodAnnotations              map[string]string
+		enablePreconfiguredUDNAddresses  bool
 		expectedError                    error
 		expectedIsAttachmentRequested    bool
 		expectedNetworkSelectionElements map[string]*nadv1.NetworkSelectionElement
@@ -1011,10 +1099,44 @@ func TestGetPodNADToNetworkMappingWithActiveNetwork(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "the pod requests addresses for its primary layer2 UDN",
+			inputNetConf: &ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: networkName},
+				Topology: ovntypes.Layer2Topology,
+				NADName:  GetNADName(namespaceName, attachmentName),
+				Role:     ovntypes.NetworkRolePrimary,
+			},
+			inputPrimaryUDNConfig: &ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: networkName},
+				Topology: ovntypes.Layer2Topology,
+				NADName:  GetNADName(namespaceName, attachmentName),
+				Role:     ovntypes.NetworkRolePrimary,
+			},
+			inputPodAnnotations: map[string]string{
+				DefNetworkAnnotation: `[{"namespace":"ovn-kubernetes","name":"default","ips":["192.168.0.4/24"],"mac":"0a:58:c0:a8:00:04"}]`,
+			},
+			enablePreconfiguredUDNAddresses: true,
+			expectedIsAttachmentRequested:   true,
+			expectedNetworkSelectionElements: map[string]*nadv1.NetworkSelectionElement{
+				"ns1/attachment1": {
+					Name:       "attachment1",
+					Namespace:  "ns1",
+					IPRequest:  []string{"192.168.0.4/24"},
+					MacRequest: "0a:58:c0:a8:00:04",
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
 			g := gomega.NewWithT(t)
+			g.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+			if test.enablePreconfiguredUDNAddresses {
+				config.OVNKubernetesFeature.EnableMultiNetwork = true
+				config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+				config.OVNKubernetesFeature.EnablePreconfiguredUDNAddresses = true
+			}
 			netInfo, err := NewNetInfo(test.inputNetConf)
 			g.Expect(err).ToNot(gomega.HaveOccurred())
 			if test.inputNetConf.NADName != "" {
@@ -1275,6 +1397,13 @@ func TestAreNetworksCompatible(t *testing.T) {
 			expectedResult:         false,
 			expectationDescription: "we should reconcile on physical network name updates",
 		},
+		{
+			desc:                   "MTU update",
+			aNetwork:               &secondaryNetInfo{mutableNetInfo: mutableNetInfo{mtu: 1400}},
+			anotherNetwork:         &secondaryNetInfo{mutableNetInfo: mutableNetInfo{mtu: 1300}},
+			expectedResult:         true,
+			expectationDescription: "the MTU can be changed without recreating the network",
+		},
 	}
 
 	for _, test := range tests {
@@ -1288,6 +1417,26 @@ func TestAreNetworksCompatible(t *testing.T) {
 	}
 }
 
+func TestReconcileNetInfoMTU(t *testing.T) {
+	g := gomega.NewWithT(t)
+	netconf := &ovncnitypes.NetConf{
+		NetConf:  cnitypes.NetConf{Name: "tenantred"},
+		Topology: ovntypes.Layer3Topology,
+		Subnets:  "192.168.200.0/16/24",
+		MTU:      1400,
+	}
+	network, err := NewNetInfo(netconf)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	netconf.MTU = 1300
+	updated, err := NewNetInfo(netconf)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	reconcilable := NewReconcilableNetInfo(network)
+	g.Expect(DoesNetworkNeedReconciliation(reconcilable, updated)).To(gomega.BeTrue())
+	g.Expect(ReconcileNetInfo(reconcilable, updated)).To(gomega.Succeed())
+	g.Expect(reconcilable.MTU()).To(gomega.Equal(1300))
+}
+
 func applyNADDefaults(nad *nadv1.NetworkAttachmentDefinition) *nadv1.NetworkAttachmentDefinition {
 	const (
 		name      = "nad1"
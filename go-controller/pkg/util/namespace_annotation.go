@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 )
@@ -14,6 +16,12 @@ import (
 const (
 	// Annotation used to enable/disable multicast in the namespace
 	NsMulticastAnnotation = "k8s.ovn.org/multicast-enabled"
+	// Annotations used to restrict multicast in a namespace with multicast enabled: the comma separated
+	// multicast groups (IPs or CIDRs) pods may send to and receive from, the comma separated IGMP/MLD versions
+	// pods may join groups with, and the comma separated namespaces whose pods may send multicast to the namespace
+	NsMulticastAllowedGroupsAnnotation  = "k8s.ovn.org/multicast-allowed-groups"
+	NsMulticastVersionsAnnotation       = "k8s.ovn.org/multicast-versions"
+	NsMulticastPeerNamespacesAnnotation = "k8s.ovn.org/multicast-peer-namespaces"
 	// Annotations used by multiple external gateways feature
 	RoutingExternalGWsAnnotation    = "k8s.ovn.org/routing-external-gws"
 	RoutingNamespaceAnnotation      = "k8s.ovn.org/routing-namespaces"
@@ -22,8 +30,114 @@ const (
 	ExternalGatewayPodIPsAnnotation = "k8s.ovn.org/external-gw-pod-ips"
 	// Annotation for enabling ACL logging to controller's log file
 	AclLoggingAnnotation = "k8s.ovn.org/acl-logging"
+	// Annotation for running the network policies of a namespace, or an (baseline) admin network
+	// policy, in audit mode: the traffic they would drop is let through and logged instead
+	NetworkPolicyAuditAnnotation = "k8s.ovn.org/network-policy-audit"
 )
 
+// Multicast group membership protocol versions accepted by the k8s.ovn.org/multicast-versions annotation.
+// IGMPv1 reports can't be told apart from IGMPv2 reports, and are allowed with igmpv2.
+const (
+	MulticastVersionIGMPv2 = "igmpv2"
+	MulticastVersionIGMPv3 = "igmpv3"
+	MulticastVersionMLDv1  = "mldv1"
+	MulticastVersionMLDv2  = "mldv2"
+)
+
+var validMulticastVersions = sets.New[string](MulticastVersionIGMPv2, MulticastVersionIGMPv3,
+	MulticastVersionMLDv1, MulticastVersionMLDv2)
+
+// NamespaceMulticastPolicy restricts the multicast traffic of a namespace with multicast enabled.
+type NamespaceMulticastPolicy struct {
+	// AllowedGroups are the CIDRs of the multicast groups pods may send to and receive from,
+	// any group is allowed if nil
+	AllowedGroups []string
+	// Versions are the IGMP/MLD versions pods may join groups with, any version is allowed if nil
+	Versions sets.Set[string]
+	// PeerNamespaces are the namespaces, besides the namespace itself, whose pods may send
+	// multicast to the namespace
+	PeerNamespaces sets.Set[string]
+}
+
+// GetAllowedGroups returns the allowed multicast groups of the given IP family.
+// It returns nil if any group is allowed, and an empty slice if no group of the family is allowed.
+func (p *NamespaceMulticastPolicy) GetAllowedGroups(ipv6 bool) []string {
+	if p.AllowedGroups == nil {
+		return nil
+	}
+	groups := []string{}
+	for _, group := range p.AllowedGroups {
+		if utilnet.IsIPv6CIDRString(group) == ipv6 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// Equal returns true if both policies allow the same multicast traffic.
+func (p *NamespaceMulticastPolicy) Equal(other *NamespaceMulticastPolicy) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return (p.AllowedGroups == nil) == (other.AllowedGroups == nil) &&
+		sets.New[string](p.AllowedGroups...).Equal(sets.New[string](other.AllowedGroups...)) &&
+		(p.Versions == nil) == (other.Versions == nil) && p.Versions.Equal(other.Versions) &&
+		p.PeerNamespaces.Equal(other.PeerNamespaces)
+}
+
+// ParseNamespaceMulticastPolicy parses the multicast policy annotations of a namespace.
+// Unset or empty annotations don't restrict multicast.
+func ParseNamespaceMulticastPolicy(annotations map[string]string) (*NamespaceMulticastPolicy, error) {
+	policy := &NamespaceMulticastPolicy{
+		PeerNamespaces: sets.New[string](),
+	}
+	if annotation := annotations[NsMulticastAllowedGroupsAnnotation]; annotation != "" {
+		policy.AllowedGroups = []string{}
+		for _, group := range strings.Split(annotation, ",") {
+			group = strings.TrimSpace(group)
+			if !strings.Contains(group, "/") {
+				ip := net.ParseIP(group)
+				if ip == nil {
+					return nil, fmt.Errorf("invalid multicast group %q in %s annotation", group, NsMulticastAllowedGroupsAnnotation)
+				}
+				group = GetIPNetFullMaskFromIP(ip).String()
+			}
+			_, ipNet, err := net.ParseCIDR(group)
+			if err != nil {
+				return nil, fmt.Errorf("invalid multicast group %q in %s annotation: %v", group, NsMulticastAllowedGroupsAnnotation, err)
+			}
+			// the whole CIDR must be in the multicast range, 224.0.0.0/4 or ff00::/8
+			ones, _ := ipNet.Mask.Size()
+			if !ipNet.IP.IsMulticast() || (ipNet.IP.To4() != nil && ones < 4) || (ipNet.IP.To4() == nil && ones < 8) {
+				return nil, fmt.Errorf("%s in %s annotation is not a multicast group", group, NsMulticastAllowedGroupsAnnotation)
+			}
+			policy.AllowedGroups = append(policy.AllowedGroups, ipNet.String())
+		}
+	}
+	if annotation := annotations[NsMulticastVersionsAnnotation]; annotation != "" {
+		policy.Versions = sets.New[string]()
+		for _, version := range strings.Split(annotation, ",") {
+			version = strings.ToLower(strings.TrimSpace(version))
+			if !validMulticastVersions.Has(version) {
+				return nil, fmt.Errorf("invalid multicast version %q in %s annotation, supported versions are %v",
+					version, NsMulticastVersionsAnnotation, sets.List(validMulticastVersions))
+			}
+			policy.Versions.Insert(version)
+		}
+	}
+	if annotation := annotations[NsMulticastPeerNamespacesAnnotation]; annotation != "" {
+		for _, namespace := range strings.Split(annotation, ",") {
+			namespace = strings.TrimSpace(namespace)
+			if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid namespace %q in %s annotation: %s", namespace,
+					NsMulticastPeerNamespacesAnnotation, strings.Join(errs, ", "))
+			}
+			policy.PeerNamespaces.Insert(namespace)
+		}
+	}
+	return policy, nil
+}
+
 func UpdateExternalGatewayPodIPsAnnotation(k kube.Interface, namespace string, exgwIPs []string) error {
 	exgwPodAnnotation := strings.Join(exgwIPs, ",")
 	err := k.SetAnnotationsOnNamespace(namespace, map[string]interface{}{ExternalGatewayPodIPsAnnotation: exgwPodAnnotation})
@@ -51,3 +165,9 @@ func ParseRoutingExternalGWAnnotation(annotation string) (sets.Set[string], erro
 	}
 	return ipTracker, nil
 }
+
+// IsNetworkPolicyAuditEnabled returns whether the given annotations put the
+// network policies they apply to in audit mode
+func IsNetworkPolicyAuditEnabled(annotations map[string]string) bool {
+	return annotations[NetworkPolicyAuditAnnotation] == "true"
+}
@@ -0,0 +1,97 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestParseNamespaceMulticastPolicy(t *testing.T) {
+	testcases := []struct {
+		name           string
+		annotations    map[string]string
+		expectedErr    bool
+		expectedPolicy *NamespaceMulticastPolicy
+	}{
+		{
+			name:        "no annotations don't restrict multicast",
+			annotations: map[string]string{NsMulticastAnnotation: "true"},
+			expectedPolicy: &NamespaceMulticastPolicy{
+				PeerNamespaces: sets.New[string](),
+			},
+		},
+		{
+			name: "empty annotations don't restrict multicast",
+			annotations: map[string]string{
+				NsMulticastAllowedGroupsAnnotation:  "",
+				NsMulticastVersionsAnnotation:       "",
+				NsMulticastPeerNamespacesAnnotation: "",
+			},
+			expectedPolicy: &NamespaceMulticastPolicy{
+				PeerNamespaces: sets.New[string](),
+			},
+		},
+		{
+			name: "parses groups, versions and peer namespaces",
+			annotations: map[string]string{
+				NsMulticastAllowedGroupsAnnotation:  "239.1.1.1, 239.2.0.0/16,ff3e::1234,ff3e::/16",
+				NsMulticastVersionsAnnotation:       "IGMPv3, mldv2",
+				NsMulticastPeerNamespacesAnnotation: "ns1, ns2",
+			},
+			expectedPolicy: &NamespaceMulticastPolicy{
+				AllowedGroups:  []string{"239.1.1.1/32", "239.2.0.0/16", "ff3e::1234/128", "ff3e::/16"},
+				Versions:       sets.New[string](MulticastVersionIGMPv3, MulticastVersionMLDv2),
+				PeerNamespaces: sets.New[string]("ns1", "ns2"),
+			},
+		},
+		{
+			name:        "fails for a unicast group",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: "10.0.0.1"},
+			expectedErr: true,
+		},
+		{
+			name:        "fails for a CIDR larger than the multicast range",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: "224.0.0.0/3"},
+			expectedErr: true,
+		},
+		{
+			name:        "fails for an invalid group",
+			annotations: map[string]string{NsMulticastAllowedGroupsAnnotation: "239.1.1"},
+			expectedErr: true,
+		},
+		{
+			name:        "fails for an unknown version",
+			annotations: map[string]string{NsMulticastVersionsAnnotation: "igmpv4"},
+			expectedErr: true,
+		},
+		{
+			name:        "fails for an invalid namespace",
+			annotations: map[string]string{NsMulticastPeerNamespacesAnnotation: "ns1,Ns_2"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := ParseNamespaceMulticastPolicy(tc.annotations)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPolicy, policy)
+		})
+	}
+}
+
+func TestNamespaceMulticastPolicyGetAllowedGroups(t *testing.T) {
+	policy := &NamespaceMulticastPolicy{}
+	assert.Nil(t, policy.GetAllowedGroups(false))
+	assert.Nil(t, policy.GetAllowedGroups(true))
+
+	policy.AllowedGroups = []string{"239.1.1.0/24"}
+	assert.Equal(t, []string{"239.1.1.0/24"}, policy.GetAllowedGroups(false))
+	// only IPv4 groups are allowed, no IPv6 group is allowed
+	assert.Equal(t, []string{}, policy.GetAllowedGroups(true))
+}
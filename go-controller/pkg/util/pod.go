@@ -2,9 +2,13 @@ package util
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
@@ -64,3 +68,80 @@ func UpdatePodWithRetryOrRollback(podLister listers.PodLister, kube kube.Interfa
 
 	return nil
 }
+
+const (
+	// PodIngressBandwidthAnnotation limits the bandwidth of the traffic sent to the pod
+	PodIngressBandwidthAnnotation = "kubernetes.io/ingress-bandwidth"
+	// PodEgressBandwidthAnnotation limits the bandwidth of the traffic sent by the pod
+	PodEgressBandwidthAnnotation = "kubernetes.io/egress-bandwidth"
+)
+
+var (
+	minPodBandwidth = resource.MustParse("1k")
+	maxPodBandwidth = resource.MustParse("1P")
+)
+
+// ParsePodBandwidthAnnotation returns the bandwidth in bits per second set
+// by the given bandwidth annotation of a pod, and whether it is set at all.
+func ParsePodBandwidthAnnotation(podAnnotations map[string]string, annotation string) (int64, bool, error) {
+	str, found := podAnnotations[annotation]
+	if !found {
+		return 0, false, nil
+	}
+	bwVal, err := resource.ParseQuantity(str)
+	if err != nil {
+		return 0, true, err
+	}
+	if bwVal.Value() < minPodBandwidth.Value() {
+		return 0, true, fmt.Errorf("resource is unreasonably small (< 1kbit)")
+	}
+	if bwVal.Value() > maxPodBandwidth.Value() {
+		return 0, true, fmt.Errorf("resource is unreasonably large (> 1Pbit)")
+	}
+	return bwVal.Value(), true, nil
+}
+
+// GetStatefulSetPodOrdinal returns the name of the StatefulSet controlling the
+// pod and the ordinal of the pod within it, and whether the pod is controlled
+// by a StatefulSet at all.
+func GetStatefulSetPodOrdinal(pod *corev1.Pod) (string, int, bool) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != "StatefulSet" {
+		return "", 0, false
+	}
+	// StatefulSet pods are named <statefulset name>-<ordinal>
+	ordinal, found := strings.CutPrefix(pod.Name, owner.Name+"-")
+	if !found {
+		return "", 0, false
+	}
+	ord, err := strconv.Atoi(ordinal)
+	if err != nil || ord < 0 {
+		return "", 0, false
+	}
+	return owner.Name, ord, true
+}
+
+// GetStatefulSetPodIPAMClaimName returns the name of the IPAMClaim persisting
+// the primary UDN IPs of a StatefulSet pod that opted in to it, or an empty
+// string otherwise. The claim is named after the pod so that it is the same
+// for a given ordinal across rescheduling.
+func GetStatefulSetPodIPAMClaimName(pod *corev1.Pod) string {
+	if pod.Annotations[OvnUDNPersistentIPsAnnotationName] != "true" {
+		return ""
+	}
+	if _, _, ok := GetStatefulSetPodOrdinal(pod); !ok {
+		return ""
+	}
+	return pod.Name + ".primary-udn"
+}
+
+// GetPrimaryUDNIPAMClaimName returns the name of the IPAMClaim persisting the
+// primary UDN IPs of the pod and whether one was requested, either explicitly
+// or as a StatefulSet pod.
+func GetPrimaryUDNIPAMClaimName(pod *corev1.Pod) (string, bool) {
+	if ipamClaimName, ok := pod.Annotations[OvnUDNIPAMClaimName]; ok {
+		return ipamClaimName, true
+	}
+	ipamClaimName := GetStatefulSetPodIPAMClaimName(pod)
+	return ipamClaimName, ipamClaimName != ""
+}
@@ -58,6 +58,10 @@ const (
 	// OvnUDNIPAMClaimName is used for workload owners to instruct OVN-K which
 	// IPAMClaim will hold the allocation for the workload
 	OvnUDNIPAMClaimName = "k8s.ovn.org/primary-udn-ipamclaim"
+	// OvnUDNPersistentIPsAnnotationName is set to "true" on the pod template
+	// of a StatefulSet to have OVN-K create an IPAMClaim per ordinal holding
+	// the primary UDN IPs of its pods
+	OvnUDNPersistentIPsAnnotationName = "k8s.ovn.org/primary-udn-persistent-ips"
 	// UDNOpenPortsAnnotationName is the pod annotation to open default network pods on UDN pods.
 	UDNOpenPortsAnnotationName = "k8s.ovn.org/open-default-ports"
 )
@@ -213,6 +217,28 @@ func MarshalPodAnnotation(annotations map[string]string, podInfo *PodAnnotation,
 	return annotations, nil
 }
 
+// DeletePodAnnotation removes the pod's network details of the specified network from the corresponding pod annotation.
+func DeletePodAnnotation(annotations map[string]string, nadName string) (map[string]string, error) {
+	podNetworks, err := UnmarshalPodAnnotationAllNetworks(annotations)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := podNetworks[nadName]; !ok {
+		return annotations, nil
+	}
+	delete(podNetworks, nadName)
+	if len(podNetworks) == 0 {
+		delete(annotations, OvnPodAnnotationName)
+		return annotations, nil
+	}
+	bytes, err := json.Marshal(podNetworks)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling podNetworks map %v", podNetworks)
+	}
+	annotations[OvnPodAnnotationName] = string(bytes)
+	return annotations, nil
+}
+
 // UnmarshalPodAnnotation returns the Pod's network info of the given network from pod.Annotations
 func UnmarshalPodAnnotation(annotations map[string]string, nadName string) (*PodAnnotation, error) {
 	var err error
@@ -317,6 +343,38 @@ func UnmarshalPodAnnotationAllNetworks(annotations map[string]string) (map[strin
 	return podNetworks, nil
 }
 
+// PodMACIndex is the name of the pod informer index of the MACs annotated on
+// pods, with keys built by PodMACIndexKey
+const PodMACIndex = "k8s.ovn.org/pod-mac"
+
+// PodMACIndexKey returns the PodMACIndex key of the given MAC annotated on a
+// pod for the given NAD
+func PodMACIndexKey(nadName string, mac net.HardwareAddr) string {
+	return nadName + "/" + mac.String()
+}
+
+// PodMACIndexFunc indexes pods by the MAC annotated on each of their networks
+func PodMACIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+	podNetworks, err := UnmarshalPodAnnotationAllNetworks(pod.Annotations)
+	if err != nil {
+		// nothing to index on a malformed annotation
+		return nil, nil
+	}
+	keys := make([]string, 0, len(podNetworks))
+	for nadName, podNetwork := range podNetworks {
+		mac, err := net.ParseMAC(podNetwork.MAC)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, PodMACIndexKey(nadName, mac))
+	}
+	return keys, nil
+}
+
 // GetPodCIDRsWithFullMask returns the pod's IP addresses in a CIDR with FullMask format
 // Internally it calls GetPodIPsOfNetwork
 func GetPodCIDRsWithFullMask(pod *corev1.Pod, nInfo NetInfo) ([]*net.IPNet, error) {
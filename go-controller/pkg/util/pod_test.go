@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	kubemocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
 	v1mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/k8s.io/client-go/listers/core/v1"
@@ -114,3 +116,118 @@ func TestUpdatePodWithAllocationOrRollback(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePodBandwidthAnnotation(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expectedBW    int64
+		expectedFound bool
+		expectErr     bool
+	}{
+		{
+			name: "annotation not set",
+		},
+		{
+			name:          "valid bandwidth",
+			annotations:   map[string]string{PodIngressBandwidthAnnotation: "10M"},
+			expectedBW:    10000000,
+			expectedFound: true,
+		},
+		{
+			name:          "invalid quantity",
+			annotations:   map[string]string{PodIngressBandwidthAnnotation: "fast"},
+			expectedFound: true,
+			expectErr:     true,
+		},
+		{
+			name:          "bandwidth too small",
+			annotations:   map[string]string{PodIngressBandwidthAnnotation: "10"},
+			expectedFound: true,
+			expectErr:     true,
+		},
+		{
+			name:          "bandwidth too large",
+			annotations:   map[string]string{PodIngressBandwidthAnnotation: "10P"},
+			expectedFound: true,
+			expectErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bw, found, err := ParsePodBandwidthAnnotation(tt.annotations, PodIngressBandwidthAnnotation)
+			if tt.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if found != tt.expectedFound {
+				t.Errorf("expected found %v, got %v", tt.expectedFound, found)
+			}
+			if bw != tt.expectedBW {
+				t.Errorf("expected bandwidth %d, got %d", tt.expectedBW, bw)
+			}
+		})
+	}
+}
+
+func TestGetPrimaryUDNIPAMClaimName(t *testing.T) {
+	stsOwner := []metav1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", Controller: ptr.To(true)},
+	}
+	tests := []struct {
+		name              string
+		podName           string
+		annotations       map[string]string
+		owners            []metav1.OwnerReference
+		expectedClaim     string
+		expectedRequested bool
+	}{
+		{
+			name:    "no persistent IPs requested",
+			podName: "db-0",
+			owners:  stsOwner,
+		},
+		{
+			name:              "explicit IPAMClaim",
+			podName:           "db-0",
+			annotations:       map[string]string{OvnUDNIPAMClaimName: "claim1"},
+			owners:            stsOwner,
+			expectedClaim:     "claim1",
+			expectedRequested: true,
+		},
+		{
+			name:              "StatefulSet pod opted in",
+			podName:           "db-3",
+			annotations:       map[string]string{OvnUDNPersistentIPsAnnotationName: "true"},
+			owners:            stsOwner,
+			expectedClaim:     "db-3.primary-udn",
+			expectedRequested: true,
+		},
+		{
+			name:        "pod opted in without a StatefulSet",
+			podName:     "db-3",
+			annotations: map[string]string{OvnUDNPersistentIPsAnnotationName: "true"},
+		},
+		{
+			name:        "pod opted in with a name not matching the StatefulSet",
+			podName:     "web-3",
+			annotations: map[string]string{OvnUDNPersistentIPsAnnotationName: "true"},
+			owners:      stsOwner,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:            tt.podName,
+				Annotations:     tt.annotations,
+				OwnerReferences: tt.owners,
+			}}
+			claim, requested := GetPrimaryUDNIPAMClaimName(pod)
+			if requested != tt.expectedRequested {
+				t.Errorf("expected requested %v, got %v", tt.expectedRequested, requested)
+			}
+			if claim != tt.expectedClaim {
+				t.Errorf("expected claim %q, got %q", tt.expectedClaim, claim)
+			}
+		})
+	}
+}
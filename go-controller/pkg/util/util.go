@@ -226,6 +226,12 @@ func GetNodeChassisID() (string, error) {
 	return chassisID, nil
 }
 
+// IsPeerClustersEnabled returns true if both interconnect and the PeerCluster
+// feature are enabled.
+func IsPeerClustersEnabled() bool {
+	return config.OVNKubernetesFeature.EnableInterconnect && config.OVNKubernetesFeature.EnablePeerClusters
+}
+
 // GetHybridOverlayPortName returns the name of the hybrid overlay switch port
 // for a given node
 func GetHybridOverlayPortName(nodeName string) string {